@@ -0,0 +1,70 @@
+package main
+
+import (
+	"blockchain-tracking/internal/core/domain/anomaly"
+	"blockchain-tracking/internal/database/postgresql"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+// runAnomaly anomaly list [-chain-id 1] [-kind malformed_log] [-resolved] [-limit 50]
+//
+//	anomaly resolve -id 12 [-note "reseeded"]
+func runAnomaly(args []string, anomalyService *anomaly.Service) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: anomaly list|resolve [flags]")
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("anomaly list", flag.ExitOnError)
+		chainID := fs.Int64("chain-id", 0, "chain id, default all chains")
+		kind := fs.String("kind", "", "anomaly kind, default all kinds")
+		resolved := fs.Bool("resolved", false, "list resolved anomalies instead of open ones")
+		limit := fs.Int("limit", 50, "max rows")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		anomalies, err := anomalyService.List(ctx, *chainID, *kind, *resolved, int32(*limit))
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tCHAIN\tKIND\tBLOCK\tTX\tLOG\tCONTRACT\tADDRESS\tEXPECTED\tACTUAL\tDETAIL")
+		for _, a := range anomalies {
+			logIndex := ""
+			if a.LogIndex.Valid {
+				logIndex = fmt.Sprint(a.LogIndex.Int64)
+			}
+			fmt.Fprintf(w, "%d\t%d\t%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				a.ID, a.ChainID, a.Kind, a.BlockNumber, postgresql.BytesToHex(a.TransactionHash), logIndex,
+				postgresql.BytesToHex(a.Contract), postgresql.BytesToHex(a.Address), a.Expected.String, a.Actual.String, a.Detail.String)
+		}
+		return w.Flush()
+	case "resolve":
+		fs := flag.NewFlagSet("anomaly resolve", flag.ExitOnError)
+		id := fs.Int64("id", 0, "anomaly id")
+		note := fs.String("note", "", "resolution note")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *id <= 0 {
+			return fmt.Errorf("-id is required")
+		}
+
+		if err := anomalyService.Resolve(ctx, *id, *note); err != nil {
+			return err
+		}
+		fmt.Printf("anomaly %d resolved\n", *id)
+		return nil
+	}
+
+	return fmt.Errorf("unknown anomaly command %s", args[0])
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"blockchain-tracking/config"
+	"blockchain-tracking/internal/api"
+	"blockchain-tracking/internal/api/rpc"
+	"blockchain-tracking/internal/core/domain/explorer"
+	"blockchain-tracking/internal/core/domain/stream"
+	"blockchain-tracking/internal/database/postgresql"
+	"blockchain-tracking/internal/logger"
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// runAPI 조회 API 서버 (REST/GraphQL 과 gRPC). 트래커와 별도 프로세스로 띄운다 (SIGINT/SIGTERM 에 종료)
+func runAPI(config *config.Config, explorerService *explorer.Service, db *postgresql.Database, l logger.Logger) error {
+	// 빈 키는 sha256("") 이라 누구나 cursor 를 만들고 읽을 수 있다
+	if config.CursorSecret == "" {
+		return errors.New("CURSOR_SECRET is required for the api server")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// 트래커가 블록을 커밋하면 pg_notify 로 알림이 온다 (000007_block_notify)
+	streamService := stream.NewService(db, explorerService, l)
+	go func() {
+		if err := streamService.Run(ctx); err != nil {
+			l.Error("stream listener stopped", logger.Field{Key: "error", Value: err.Error()})
+		}
+	}()
+
+	// 한쪽이 실패하면 다른 쪽도 내린다
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	grpcErr := make(chan error, 1)
+	go func() {
+		defer cancel()
+		grpcErr <- rpc.NewServer(explorerService, streamService, db.Cursor(), l).Run(ctx, config.GRPCAddr)
+	}()
+
+	server := api.NewServer(explorerService, streamService, db.Cursor(), l)
+	err := server.Run(ctx, config.APIAddr)
+	cancel()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return <-grpcErr
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"blockchain-tracking/internal/database/postgresql"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+// runCompact compact backfill [-batch 10000] | status
+func runCompact(args []string, migrator *postgresql.Migrator) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: compact backfill|status [flags]")
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "backfill":
+		fs := flag.NewFlagSet("compact backfill", flag.ExitOnError)
+		batch := fs.Int64("batch", 10000, "rows copied per transaction")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *batch <= 0 {
+			return fmt.Errorf("-batch must be positive")
+		}
+		return migrator.CompactBackfill(ctx, *batch)
+	case "status":
+		progress, err := migrator.CompactStatus(ctx)
+		if err != nil {
+			return err
+		}
+		if progress == nil {
+			fmt.Println("compact migration is not in progress")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TABLE\tLAST ID\tTARGET ID\tDONE")
+		for _, p := range progress {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%t\n", p.Table, p.LastID, p.TargetID, p.LastID >= p.TargetID)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown compact command %s", args[0])
+	}
+}
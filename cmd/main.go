@@ -2,16 +2,30 @@ package main
 
 import (
 	"blockchain-tracking/config"
+	"blockchain-tracking/internal/admin"
 	"blockchain-tracking/internal/blockchain/evm"
 	"blockchain-tracking/internal/blockchain/jsonRpc"
+	"blockchain-tracking/internal/core/domain/anomaly"
 	"blockchain-tracking/internal/core/domain/blockchain"
+	"blockchain-tracking/internal/core/domain/explorer"
+	"blockchain-tracking/internal/core/domain/partition"
+	"blockchain-tracking/internal/core/domain/reconcile"
+	"blockchain-tracking/internal/core/domain/rule"
+	"blockchain-tracking/internal/core/domain/watchlist"
+	"blockchain-tracking/internal/core/domain/webhook"
+	"blockchain-tracking/internal/database/migrations"
 	"blockchain-tracking/internal/database/postgresql"
+	"blockchain-tracking/internal/health"
 	"blockchain-tracking/internal/logger"
 	"blockchain-tracking/internal/logger/zerolog"
-	"blockchain-tracking/internal/smtp"
+	"blockchain-tracking/internal/notify"
+	"blockchain-tracking/internal/tracing"
 	"context"
 	"fmt"
-	"sync"
+	"net/http"
+	"os"
+	"strings"
+	"time"
 )
 
 func main() {
@@ -39,42 +53,174 @@ func main() {
 		}
 	}()
 
+	migrator, err := postgresql.NewMigrator(db, migrations.FS, l)
+	if err != nil {
+		l.Fatal("failed to load migrations", logger.Field{Key: "error", Value: err.Error()})
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:], migrator); err != nil {
+			l.Fatal("migrate failed", logger.Field{Key: "error", Value: err.Error()})
+		}
+		return
+	}
+
+	// compact backfill 은 스키마 교체 전 (기존 바이너리가 도는 중) 에 실행한다
+	if len(os.Args) > 1 && os.Args[1] == "compact" {
+		if err := runCompact(os.Args[2:], migrator); err != nil {
+			l.Fatal("compact failed", logger.Field{Key: "error", Value: err.Error()})
+		}
+		return
+	}
+
+	// 스키마 버전이 바이너리와 다르면 실행하지 않는다
+	if err = migrator.Check(context.Background()); err != nil {
+		l.Fatal("unexpected schema version", logger.Field{Key: "error", Value: err.Error()})
+	}
+
 	transactionManager := postgresql.NewManager(db)
 
-	blockchainService := blockchain.NewService(db, transactionManager, l)
+	notifier, mailer, err := newNotifier(config, l)
+	if err != nil {
+		l.Fatal("invalid notification config", logger.Field{Key: "error", Value: err.Error()})
+	}
+
+	partitionService := partition.NewService(db, l)
+	watchlistService := watchlist.NewService(db, l)
+	webhookService := webhook.NewService(db, l)
+	blockchainService := blockchain.NewService(db, transactionManager, partitionService, watchlistService, webhookService, l)
+	reconcileService := reconcile.NewService(db, transactionManager, l)
+	anomalyService := anomaly.NewService(db, l)
+	explorerService := explorer.NewService(db, l)
+	ruleService := rule.NewService(db, notifier, config.RulesFile, l)
+
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "reconcile":
+			if err := runReconcile(os.Args[2:], config, reconcileService, l); err != nil {
+				l.Fatal("reconcile failed", logger.Field{Key: "error", Value: err.Error()})
+			}
+		case "anomaly":
+			if err := runAnomaly(os.Args[2:], anomalyService); err != nil {
+				l.Fatal("anomaly failed", logger.Field{Key: "error", Value: err.Error()})
+			}
+		case "api":
+			if err := runAPI(config, explorerService, db, l); err != nil {
+				l.Fatal("api server failed", logger.Field{Key: "error", Value: err.Error()})
+			}
+		case "partition":
+			if err := runPartition(os.Args[2:], partitionService); err != nil {
+				l.Fatal("partition failed", logger.Field{Key: "error", Value: err.Error()})
+			}
+		case "watchlist":
+			if err := runWatchlist(os.Args[2:], watchlistService); err != nil {
+				l.Fatal("watchlist failed", logger.Field{Key: "error", Value: err.Error()})
+			}
+		case "webhook":
+			if err := runWebhook(os.Args[2:], webhookService); err != nil {
+				l.Fatal("webhook failed", logger.Field{Key: "error", Value: err.Error()})
+			}
+		case "rule":
+			if err := runRule(os.Args[2:], ruleService); err != nil {
+				l.Fatal("rule failed", logger.Field{Key: "error", Value: err.Error()})
+			}
+		default:
+			l.Fatal(fmt.Sprintf("unknown command %s", os.Args[1]))
+		}
+		return
+	}
+
+	// 블록 스캔, RPC, 디코딩, 저장 단계를 span 으로 남긴다
+	shutdownTracing, err := tracing.Setup(context.Background(), config.TracingExporter, config.TracingServiceName)
+	if err != nil {
+		l.Fatal("tracing setup", logger.Field{Key: "error", Value: err.Error()})
+	}
+	defer func() {
+		_ = shutdownTracing(context.Background())
+	}()
 
 	jsonRpcAdapter := jsonRpc.NewJsonRpc(l)
-	smtpAdapter := smtp.NewSmtp(config.SMTPID, config.SMTPPassword)
+	alert := evm.AlertOptions{
+		AnomalyThreshold: config.AnomalyAlertThreshold,
+	}
+
+	// 체인 레지스트리 (CHAINS_FILE). RPC 의 eth_chainId 가 설정과 다르면 시작하지 않는다
+	chains, err := config.Chains()
+	if err != nil {
+		l.Fatal("invalid chain registry", logger.Field{Key: "error", Value: err.Error()})
+	}
+	verifyChains(chains, jsonRpcAdapter, l)
+
+	// 체인별 추적 상황 (/healthz, /readyz, /status). 최신 높이가 멈춘 체인은 알림을 보낸다
+	registry := health.NewRegistry(time.Duration(config.ChainStallSeconds)*time.Second, notifier, l)
+	go registry.Monitor(context.Background(), 15*time.Second)
 
-	chainList := []string{"Ethereum"}
-	// chainList := []string{"Ethereum", "Biance", "GiantMammoth"}
+	// 보관 정책이 있는 체인의 오래된 원본 로그/input 파티션 정리
+	go partitionService.RunRetention(context.Background(), time.Hour)
 
-	var wg sync.WaitGroup
+	// 감시 주소 알림을 watchlist 별로 묶어 메일로 보낸다
+	if mailer != nil {
+		go watchlistService.RunDelivery(context.Background(), mailer, time.Minute)
+	} else {
+		l.Warn("smtp is not configured, watchlist alerts are only recorded")
+	}
 
-	for _, chain := range chainList {
-		wg.Add(1)
-		go func(chainName, rpc string) {
-			defer wg.Done()
-			defer func() {
-				if r := recover(); r != nil {
-					l.Error(fmt.Sprintf("go routine recover %s chain", chain), logger.Field{Key: "error", Value: r.(error).Error()})
-				}
-			}()
+	// 블록과 같이 커밋된 웹훅 outbox 를 보낸다
+	go webhookService.RunDispatcher(context.Background(), 5*time.Second)
 
-			ctx := context.Background()
+	// 알림 규칙은 1분마다 다시 읽는다
+	go ruleService.Run(context.Background(), time.Minute)
 
-			l.Info(fmt.Sprintf("go routine start %s chain", chainName), logger.Field{Key: "chain", Value: chainName})
+	// 체인 트래커가 실패나 panic 으로 끝나면 backoff 후 다시 띄운다
+	supervisor := evm.NewSupervisor(registry, notifier, evm.SupervisorOptions{
+		RestartBudget: config.TrackerRestartBudget,
+		BudgetWindow:  time.Duration(config.TrackerRestartWindowMinutes) * time.Minute,
+	}, l)
 
-			err := evm.StartTrack(ctx, chainName, rpc, jsonRpcAdapter, smtpAdapter, blockchainService, l)
-			if err != nil {
-				l.Error(fmt.Sprintf("%s chain error tracking", chainName), logger.Field{Key: "error", Value: err.Error()})
-			}
-		}(chain, config.RPC[chain])
+	// 운영 API 로 체인을 추가하고 멈추고 재인덱싱한다
+	track := tracker(jsonRpcAdapter, notifier, blockchainService, anomalyService, ruleService, alert, l)
+	manager := evm.NewManager(supervisor, registry, jsonRpcAdapter, blockchainService, track, l)
+
+	for _, chain := range chains {
+		if err = manager.Start(chain); err != nil {
+			l.Fatal("start chain tracker", logger.Field{Key: "error", Value: err.Error()})
+		}
+	}
+
+	var adminHandler http.Handler
+	if config.AdminToken != "" {
+		adminHandler = admin.NewHandler(manager, config.Chain, config.AdminToken, l)
+	} else {
+		l.Warn("ADMIN_TOKEN is not set, admin api is disabled")
 	}
+	go serveOps(config.MetricsAddr, registry, adminHandler, l)
 
 	l.Info("blockchain tracking starting...")
 
-	wg.Wait()
+	// 트래커는 Supervisor 가 계속 다시 띄운다
+	select {}
+}
+
+// verifyChains 체인마다 모든 RPC 주소의 eth_chainId 를 확인한다. 연결이 안 되는 주소는 추적 중에 BlockScanner 가 다시 확인한다
+func verifyChains(chains []config.Chain, jrAdapter *jsonRpc.JsonRpc, l logger.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
-	l.Error("server is dead")
+	for _, chain := range chains {
+		unreachable, err := evm.VerifyChainID(ctx, chain, jrAdapter)
+		if err != nil {
+			l.Fatal("chain id check", logger.Field{Key: "error", Value: err.Error()})
+		}
+		if len(unreachable) > 0 {
+			l.Warn(fmt.Sprintf("%s rpc is unreachable, skipped chain id check", chain.Name), logger.Field{Key: "endpoints", Value: strings.Join(unreachable, ",")})
+		}
+		l.Info(fmt.Sprintf("%s chain registered", chain.Name), logger.Field{Key: "chain_id", Value: chain.ChainID}, logger.Field{Key: "endpoints", Value: len(chain.RPC)})
+	}
+}
+
+// tracker 체인 하나를 추적하는 StartTrack (Manager 가 체인마다 부른다)
+func tracker(jrAdapter *jsonRpc.JsonRpc, notifier notify.Notifier, blockchainService *blockchain.Service, anomalyService *anomaly.Service, ruleService *rule.Service, alert evm.AlertOptions, l logger.Logger) evm.TrackFunc {
+	return func(ctx context.Context, chain config.Chain, status *health.Chain) error {
+		return evm.StartTrack(ctx, chain, jrAdapter, notifier, blockchainService, anomalyService, ruleService, status, alert, l)
+	}
 }
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"blockchain-tracking/internal/database/postgresql"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+// runMigrate migrate up [-steps 0] | down [-steps 1] | status | force -version 1
+func runMigrate(args []string, migrator *postgresql.Migrator) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: migrate up|down|status|force [flags]")
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		fs := flag.NewFlagSet("migrate up", flag.ExitOnError)
+		steps := fs.Int("steps", 0, "number of migrations to apply, 0 applies all")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		return migrator.Up(ctx, *steps)
+	case "down":
+		fs := flag.NewFlagSet("migrate down", flag.ExitOnError)
+		steps := fs.Int("steps", 1, "number of migrations to roll back")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		return migrator.Down(ctx, *steps)
+	case "force":
+		fs := flag.NewFlagSet("migrate force", flag.ExitOnError)
+		version := fs.Int64("version", -1, "schema version to record without running migrations")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *version < 0 {
+			return fmt.Errorf("-version is required")
+		}
+		return migrator.Force(ctx, *version)
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, s := range status {
+			appliedAt := "pending"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown migrate command %s", args[0])
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"blockchain-tracking/config"
+	"blockchain-tracking/internal/logger"
+	"blockchain-tracking/internal/notify"
+	"strings"
+	"time"
+)
+
+// newNotifier 설정된 채널로 운영 알림 Router 를 만든다.
+// 메일 채널은 watchlist 알림에도 쓰므로 따로 돌려준다 (SMTP 계정이 없으면 nil)
+func newNotifier(config *config.Config, l logger.Logger) (*notify.Router, *notify.SMTP, error) {
+	router := notify.NewRouter(notify.RouterOptions{
+		Dedup:      time.Duration(config.AlertDedupMinutes) * time.Minute,
+		RateLimit:  config.AlertRateLimitPerHour,
+		RateWindow: time.Hour,
+	}, l)
+
+	var mailer *notify.SMTP
+	if config.SMTPID != "" || config.SMTPFrom != "" {
+		var recipients []string
+		for _, address := range strings.Split(config.AlertEmail, ",") {
+			if address = strings.TrimSpace(address); address != "" {
+				recipients = append(recipients, address)
+			}
+		}
+
+		var err error
+		mailer, err = notify.NewSMTP(notify.SMTPOptions{
+			Host:     config.SMTPHost,
+			Port:     config.SMTPPort,
+			TLS:      config.SMTPTLS,
+			Username: config.SMTPID,
+			Password: config.SMTPPassword,
+			From:     config.SMTPFrom,
+			To:       recipients,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(recipients) > 0 {
+			if err = addRoute(router, mailer, config.AlertEmailSeverity); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	for _, channel := range []struct{ name, url, format, severity string }{
+		{"slack", config.SlackWebhookURL, notify.FormatSlack, config.SlackSeverity},
+		{"discord", config.DiscordWebhookURL, notify.FormatDiscord, config.DiscordSeverity},
+		{"http", config.AlertHTTPURL, notify.FormatJSON, config.AlertHTTPSeverity},
+	} {
+		if channel.url == "" {
+			continue
+		}
+		http, err := notify.NewHTTP(channel.name, channel.url, channel.format)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err = addRoute(router, http, channel.severity); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return router, mailer, nil
+}
+
+func addRoute(router *notify.Router, channel notify.Channel, severity string) error {
+	minSeverity, err := notify.ParseSeverity(severity)
+	if err != nil {
+		return err
+	}
+	router.Add(channel, minSeverity)
+	return nil
+}
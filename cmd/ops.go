@@ -0,0 +1,32 @@
+package main
+
+import (
+	"blockchain-tracking/internal/health"
+	"blockchain-tracking/internal/logger"
+	"blockchain-tracking/internal/metrics"
+	"net/http"
+	"time"
+)
+
+// serveOps 트래커의 /metrics (Prometheus) 와 /healthz, /readyz, /status. admin 이 있으면 /admin/ 도 연다. 실패해도 추적은 계속한다
+func serveOps(addr string, registry *health.Registry, admin http.Handler, l logger.Logger) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	mux.HandleFunc("/healthz", registry.Healthz)
+	mux.HandleFunc("/readyz", registry.Readyz)
+	mux.HandleFunc("/status", registry.Status)
+	if admin != nil {
+		mux.Handle("/admin/", admin)
+	}
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	l.Info("ops server listening", logger.Field{Key: "addr", Value: addr})
+	if err := server.ListenAndServe(); err != nil {
+		l.Error("ops server stopped", logger.Field{Key: "error", Value: err.Error()})
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"blockchain-tracking/internal/core/domain/partition"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+// runPartition partition policy -chain-id 1 [-block-range 1000000] [-retain 0]
+//
+//	partition status [-chain-id 1]
+//	partition prune -chain-id 1
+func runPartition(args []string, partitionService *partition.Service) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: partition policy|status|prune [flags]")
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "policy":
+		fs := flag.NewFlagSet("partition policy", flag.ExitOnError)
+		chainID := fs.Int64("chain-id", 0, "chain id")
+		blockRange := fs.Int64("block-range", 1000000, "blocks per transaction/log partition created from now on")
+		retain := fs.Int64("retain", 0, "keep raw log/input data for the last N blocks, 0 keeps everything")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *chainID <= 0 {
+			return fmt.Errorf("-chain-id is required")
+		}
+
+		if err := partitionService.SetPolicy(ctx, *chainID, *blockRange, *retain); err != nil {
+			return err
+		}
+		fmt.Printf("chain %d partition policy updated\n", *chainID)
+		return nil
+	case "status":
+		fs := flag.NewFlagSet("partition status", flag.ExitOnError)
+		chainID := fs.Int64("chain-id", 0, "list partitions of this chain")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		if *chainID == 0 {
+			policies, err := partitionService.Policies(ctx)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(w, "CHAIN\tBLOCK RANGE\tRETAIN\tPRUNED BELOW")
+			for _, p := range policies {
+				retain := "-"
+				if p.RetainBlocks.Valid {
+					retain = fmt.Sprint(p.RetainBlocks.Int64)
+				}
+				fmt.Fprintf(w, "%d\t%d\t%s\t%d\n", p.ChainID, p.BlockRange, retain, p.PrunedBelow)
+			}
+			return w.Flush()
+		}
+
+		ranges, err := partitionService.Ranges(ctx, *chainID)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(w, "TABLE\tPARTITION\tFROM\tTO\tPRUNED")
+		for _, r := range ranges {
+			from, to := fmt.Sprint(r.LowerBound), fmt.Sprint(r.UpperBound)
+			if !isBlockTable(r.Parent) {
+				from = time.Unix(r.LowerBound, 0).UTC().Format("2006-01")
+				to = time.Unix(r.UpperBound, 0).UTC().Format("2006-01")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\n", r.Parent, r.Name, from, to, r.Pruned)
+		}
+		return w.Flush()
+	case "prune":
+		fs := flag.NewFlagSet("partition prune", flag.ExitOnError)
+		chainID := fs.Int64("chain-id", 0, "chain id")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *chainID <= 0 {
+			return fmt.Errorf("-chain-id is required")
+		}
+
+		pruned, err := partitionService.Prune(ctx, *chainID)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("chain %d: %d partitions dropped\n", *chainID, pruned)
+		return nil
+	}
+
+	return fmt.Errorf("unknown partition command %s", args[0])
+}
+
+func isBlockTable(table string) bool {
+	for _, t := range partition.BlockTables {
+		if t == table {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"blockchain-tracking/config"
+	"blockchain-tracking/internal/blockchain/evm"
+	"blockchain-tracking/internal/core/domain/reconcile"
+	"blockchain-tracking/internal/logger"
+	"context"
+	"flag"
+	"strings"
+)
+
+// runReconcile reconcile -chain Ethereum [-kind coin,erc20] [-sample 100] [-repair]
+func runReconcile(args []string, config *config.Config, reconcileService *reconcile.Service, l logger.Logger) error {
+	fs := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	chain := fs.String("chain", "Ethereum", "chain name")
+	kinds := fs.String("kind", "", "comma separated balance kinds (coin,erc20,erc721,erc1155), default all")
+	sample := fs.Int("sample", 0, "number of random rows per kind, 0 scans everything")
+	repair := fs.Bool("repair", false, "overwrite stored balances with on-chain values")
+	pageSize := fs.Int("page", evm.DefaultReconcilePageSize, "rows per page in full scan")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	c, err := config.Chain(*chain)
+	if err != nil {
+		return err
+	}
+	rpc := c.RPC[0]
+
+	opts := evm.ReconcileOptions{
+		Sample:   *sample,
+		Repair:   *repair,
+		PageSize: *pageSize,
+	}
+	if *kinds != "" {
+		for _, kind := range strings.Split(*kinds, ",") {
+			opts.Kinds = append(opts.Kinds, reconcile.Kind(strings.TrimSpace(kind)))
+		}
+	}
+
+	runID, err := evm.Reconcile(context.Background(), *chain, rpc, opts, reconcileService, l)
+	if err != nil {
+		return err
+	}
+
+	l.Info("reconcile done", logger.Field{Key: "run", Value: runID})
+	return nil
+}
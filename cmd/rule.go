@@ -0,0 +1,124 @@
+package main
+
+import (
+	"blockchain-tracking/internal/core/domain/rule"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+)
+
+// runRule rule add -name big-usdc -type large_transfer [-chain-id 1] [-severity warning] [-cooldown 300] -params '{"kind":"erc20","contract":"0x...","minAmount":"1000000","decimals":6}'
+//
+//	rule list
+//	rule enable -name big-usdc
+//	rule disable -name big-usdc
+//	rule delete -name big-usdc
+//	rule events [-name big-usdc] [-limit 50]
+func runRule(args []string, ruleService *rule.Service) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: rule add|list|enable|disable|delete|events [flags]")
+	}
+
+	ctx := context.Background()
+
+	fs := flag.NewFlagSet("rule "+args[0], flag.ExitOnError)
+	name := fs.String("name", "", "rule name")
+
+	switch args[0] {
+	case "add":
+		ruleType := fs.String("type", "", "large_transfer, large_mint, large_burn, contract_deployed, transfer_rate or gas_price_spike")
+		chainID := fs.Int64("chain-id", 0, "chain id, 0 for every chain")
+		severity := fs.String("severity", "warning", "info, warning or critical")
+		cooldown := fs.Int("cooldown", 300, "seconds before the same rule fires again for the same subject")
+		params := fs.String("params", "{}", "rule params as JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *name == "" || *ruleType == "" {
+			return fmt.Errorf("-name and -type are required")
+		}
+
+		r := &rule.Rule{
+			Name:            *name,
+			ChainID:         *chainID,
+			Type:            *ruleType,
+			Severity:        *severity,
+			CooldownSeconds: *cooldown,
+		}
+		if err := json.Unmarshal([]byte(*params), &r.Params); err != nil {
+			return fmt.Errorf("invalid -params: %w", err)
+		}
+
+		id, err := ruleService.Create(ctx, r)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("rule %s created (id %d)\n", *name, id)
+		return nil
+	case "list":
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		rules, err := ruleService.List(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tSOURCE\tCHAIN\tTYPE\tSEVERITY\tCOOLDOWN\tENABLED\tPARAMS")
+		for _, r := range rules {
+			chain := "all"
+			if r.ChainID != 0 {
+				chain = strconv.FormatInt(r.ChainID, 10)
+			}
+			params, _ := json.Marshal(r.Params)
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%t\t%s\n", r.Name, r.Source, chain, r.Type, r.Severity, r.CooldownSeconds, r.Enabled == nil || *r.Enabled, params)
+		}
+		return w.Flush()
+	case "enable", "disable", "delete":
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *name == "" {
+			return fmt.Errorf("-name is required")
+		}
+
+		if args[0] == "delete" {
+			if err := ruleService.Delete(ctx, *name); err != nil {
+				return err
+			}
+			fmt.Printf("rule %s deleted\n", *name)
+			return nil
+		}
+
+		if err := ruleService.SetEnabled(ctx, *name, args[0] == "enable"); err != nil {
+			return err
+		}
+		fmt.Printf("rule %s %sd\n", *name, args[0])
+		return nil
+	case "events":
+		limit := fs.Int("limit", 50, "max rows")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		events, err := ruleService.Events(ctx, *name, int32(*limit))
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tRULE\tCHAIN\tBLOCK\tSUBJECT\tTITLE\tCREATED")
+		for _, e := range events {
+			fmt.Fprintf(w, "%d\t%s\t%d\t%d\t%s\t%s\t%s\n", e.ID, e.RuleName, e.ChainID, e.BlockNumber, e.Subject, e.Title, e.CreatedAt.Format("2006-01-02 15:04:05"))
+		}
+		return w.Flush()
+	}
+
+	return fmt.Errorf("unknown rule command %s", args[0])
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"blockchain-tracking/internal/core/domain/watchlist"
+	"blockchain-tracking/internal/database/postgresql"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+// runWatchlist watchlist create -name treasury [-email ops@example.com]
+//
+//	watchlist list
+//	watchlist email -name treasury [-email ops@example.com]
+//	watchlist delete -name treasury
+//	watchlist add -name treasury -chain-id 1 -address 0x... [-label "cold wallet"]
+//	watchlist remove -name treasury -chain-id 1 -address 0x...
+//	watchlist show -name treasury
+//	watchlist alerts [-name treasury] [-limit 50]
+func runWatchlist(args []string, watchlistService *watchlist.Service) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: watchlist create|list|email|delete|add|remove|show|alerts [flags]")
+	}
+
+	ctx := context.Background()
+
+	fs := flag.NewFlagSet("watchlist "+args[0], flag.ExitOnError)
+	name := fs.String("name", "", "watchlist name")
+
+	switch args[0] {
+	case "create", "email":
+		email := fs.String("email", "", "alert email, empty to only record alerts")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *name == "" {
+			return fmt.Errorf("-name is required")
+		}
+
+		if args[0] == "email" {
+			if err := watchlistService.SetEmail(ctx, *name, *email); err != nil {
+				return err
+			}
+			fmt.Printf("watchlist %s email updated\n", *name)
+			return nil
+		}
+
+		id, err := watchlistService.Create(ctx, *name, *email)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("watchlist %s created (id %d)\n", *name, id)
+		return nil
+	case "list":
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		watchlists, err := watchlistService.List(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tEMAIL\tADDRESSES\tCREATED")
+		for _, wl := range watchlists {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\n", wl.ID, wl.Name, wl.Email.String, wl.Addresses, wl.CreatedAt.Format("2006-01-02 15:04:05"))
+		}
+		return w.Flush()
+	case "delete":
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *name == "" {
+			return fmt.Errorf("-name is required")
+		}
+
+		if err := watchlistService.Delete(ctx, *name); err != nil {
+			return err
+		}
+		fmt.Printf("watchlist %s deleted\n", *name)
+		return nil
+	case "add", "remove":
+		chainID := fs.Int64("chain-id", 0, "chain id")
+		address := fs.String("address", "", "address to watch")
+		label := fs.String("label", "", "address label (add only)")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *name == "" || *chainID <= 0 || *address == "" {
+			return fmt.Errorf("-name, -chain-id and -address are required")
+		}
+
+		if args[0] == "remove" {
+			if err := watchlistService.RemoveAddress(ctx, *name, *chainID, *address); err != nil {
+				return err
+			}
+			fmt.Printf("%s removed from watchlist %s\n", *address, *name)
+			return nil
+		}
+
+		if err := watchlistService.AddAddress(ctx, *name, *chainID, *address, *label); err != nil {
+			return err
+		}
+		fmt.Printf("%s added to watchlist %s\n", *address, *name)
+		return nil
+	case "show":
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *name == "" {
+			return fmt.Errorf("-name is required")
+		}
+
+		addresses, err := watchlistService.Addresses(ctx, *name)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "CHAIN\tADDRESS\tLABEL\tADDED")
+		for _, a := range addresses {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", a.ChainID, postgresql.BytesToHex(a.Address), a.Label.String, a.CreatedAt.Format("2006-01-02 15:04:05"))
+		}
+		return w.Flush()
+	case "alerts":
+		limit := fs.Int("limit", 50, "max rows")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		alerts, err := watchlistService.Alerts(ctx, *name, int32(*limit))
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tWATCHLIST\tCHAIN\tBLOCK\tTX\tKIND\tADDRESS\tDIR\tCOUNTERPARTY\tCONTRACT\tTOKEN ID\tAMOUNT\tSYMBOL\tDELIVERED")
+		for _, a := range alerts {
+			delivered := ""
+			if a.DeliveredAt.Valid {
+				delivered = a.DeliveredAt.Time.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%d\t%d\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				a.ID, a.WatchlistID, a.ChainID, a.BlockNumber, postgresql.BytesToHex(a.TransactionHash), a.Kind,
+				postgresql.BytesToHex(a.Address), a.Direction, postgresql.BytesToHex(a.Counterparty),
+				postgresql.BytesToHex(a.Contract), a.TokenID.String, a.Value, a.Symbol.String, delivered)
+		}
+		return w.Flush()
+	}
+
+	return fmt.Errorf("unknown watchlist command %s", args[0])
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"blockchain-tracking/internal/core/domain/webhook"
+	"blockchain-tracking/internal/database/postgresql"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// runWebhook webhook create -url https://example.com/hook [-secret s] [-chain-id 1] [-kinds erc20,erc721] [-addresses 0x..,0x..] [-contracts 0x..]
+//
+//	webhook list
+//	webhook pause|resume|delete -id 3
+//	webhook deliveries [-id 3] [-status dead] [-limit 50]
+//	webhook replay -delivery 120
+//	webhook replay -id 3   (구독의 dead 건 전체)
+func runWebhook(args []string, webhookService *webhook.Service) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: webhook create|list|pause|resume|delete|deliveries|replay [flags]")
+	}
+
+	ctx := context.Background()
+
+	fs := flag.NewFlagSet("webhook "+args[0], flag.ExitOnError)
+
+	switch args[0] {
+	case "create":
+		endpoint := fs.String("url", "", "callback url")
+		secret := fs.String("secret", "", "signing secret, generated when empty")
+		chainID := fs.Int64("chain-id", 0, "chain id, default all chains")
+		kinds := fs.String("kinds", "", "comma separated coin,erc20,erc721,erc1155, default all")
+		addresses := fs.String("addresses", "", "comma separated from/to addresses, default all")
+		contracts := fs.String("contracts", "", "comma separated token contracts, default all")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *endpoint == "" {
+			return fmt.Errorf("-url is required")
+		}
+
+		id, signingSecret, err := webhookService.Subscribe(ctx, *endpoint, *secret, webhook.Filter{
+			ChainID:   *chainID,
+			Kinds:     splitList(*kinds),
+			Addresses: splitList(*addresses),
+			Contracts: splitList(*contracts),
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("webhook %d created, secret %s\n", id, signingSecret)
+		return nil
+	case "list":
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		subscriptions, err := webhookService.List(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tURL\tCHAIN\tKINDS\tADDRESSES\tCONTRACTS\tACTIVE\tCREATED")
+		for _, sub := range subscriptions {
+			chain := "all"
+			if sub.ChainID.Valid {
+				chain = fmt.Sprint(sub.ChainID.Int64)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%t\t%s\n",
+				sub.ID, sub.Url, chain, strings.Join(sub.Kinds, ","), hexList(sub.Addresses), hexList(sub.Contracts),
+				sub.Active, sub.CreatedAt.Format("2006-01-02 15:04:05"))
+		}
+		return w.Flush()
+	case "pause", "resume", "delete":
+		id := fs.Int64("id", 0, "subscription id")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *id <= 0 {
+			return fmt.Errorf("-id is required")
+		}
+
+		var err error
+		switch args[0] {
+		case "pause":
+			err = webhookService.SetActive(ctx, *id, false)
+		case "resume":
+			err = webhookService.SetActive(ctx, *id, true)
+		case "delete":
+			err = webhookService.Delete(ctx, *id)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("webhook %d %sd\n", *id, strings.TrimSuffix(args[0], "e"))
+		return nil
+	case "deliveries":
+		id := fs.Int64("id", 0, "subscription id, default all subscriptions")
+		status := fs.String("status", "", "pending, delivered or dead, default all")
+		limit := fs.Int("limit", 50, "max rows")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		deliveries, err := webhookService.Deliveries(ctx, *id, *status, int32(*limit))
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tWEBHOOK\tEVENT\tSTATUS\tATTEMPTS\tNEXT ATTEMPT\tLAST STATUS\tLAST ERROR\tCREATED")
+		for _, d := range deliveries {
+			lastStatus := ""
+			if d.LastStatus.Valid {
+				lastStatus = fmt.Sprint(d.LastStatus.Int32)
+			}
+			fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%d\t%s\t%s\t%s\t%s\n",
+				d.ID, d.SubscriptionID, d.EventKey, d.Status, d.Attempts, d.NextAttemptAt.Format("2006-01-02 15:04:05"),
+				lastStatus, d.LastError.String, d.CreatedAt.Format("2006-01-02 15:04:05"))
+		}
+		return w.Flush()
+	case "replay":
+		id := fs.Int64("id", 0, "subscription id, replays all dead deliveries")
+		delivery := fs.Int64("delivery", 0, "delivery id")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		switch {
+		case *delivery > 0:
+			if err := webhookService.Replay(ctx, *delivery); err != nil {
+				return err
+			}
+			fmt.Printf("delivery %d queued\n", *delivery)
+		case *id > 0:
+			replayed, err := webhookService.ReplayDead(ctx, *id)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%d dead deliveries of webhook %d queued\n", replayed, *id)
+		default:
+			return fmt.Errorf("-delivery or -id is required")
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown webhook command %s", args[0])
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func hexList(values [][]byte) string {
+	items := make([]string, len(values))
+	for i, v := range values {
+		items[i] = postgresql.BytesToHex(v)
+	}
+	return strings.Join(items, ",")
+}
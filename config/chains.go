@@ -0,0 +1,155 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 체인 항목에서 비워 둔 값의 기본값
+const (
+	defaultPollInterval   = 3 * time.Second
+	defaultBatchSize      = 10
+	defaultNativeDecimals = 18
+
+	minPollInterval = 500 * time.Millisecond
+	maxBatchSize    = 100
+)
+
+// Chain 체인 레지스트리(CHAINS_FILE) 한 항목
+type Chain struct {
+	Name    string   `yaml:"name"`
+	ChainID int64    `yaml:"chainId"` // RPC 의 eth_chainId 와 같아야 한다
+	RPC     []string `yaml:"rpc"`     // 앞에서부터 쓰고, 실패하면 다음 주소로 넘어간다
+
+	StartBlock    uint64        `yaml:"startBlock"`    // 저장된 블록이 없을 때 여기서부터 받는다
+	Confirmations uint64        `yaml:"confirmations"` // 최신 높이에서 이만큼 뒤까지만 저장한다
+	PollInterval  time.Duration `yaml:"pollInterval"`
+
+	BatchSize         int `yaml:"batchSize"`         // 한 번에 받아서 저장하는 블록 수
+	MaxBlocksPerRound int `yaml:"maxBlocksPerRound"` // 추적 한 번에 받는 최대 블록 수 (0 이면 제한 없음)
+
+	Features       ChainFeatures `yaml:"features"`
+	NativeDecimals int           `yaml:"nativeDecimals"` // 기본 코인 소수점 자리수
+}
+
+type ChainFeatures struct {
+	Traces      bool `yaml:"traces"`
+	NFTMetadata bool `yaml:"nftMetadata"` // erc721/erc1155 컨트랙트의 name, symbol 조회
+}
+
+func (c *Config) Chains() ([]Chain, error) {
+	return LoadChains(c.ChainsFile)
+}
+
+// LoadChains 체인 레지스트리를 읽고 검사한다. ${ETH_RPC} 같은 값은 환경 변수로 바꾼다 (API 키는 .env 에 둔다)
+func LoadChains(path string) ([]Chain, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read chain registry: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader([]byte(os.ExpandEnv(string(raw)))))
+	decoder.KnownFields(true)
+
+	var file struct {
+		Chains []Chain `yaml:"chains"`
+	}
+	if err = decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if len(file.Chains) == 0 {
+		return nil, fmt.Errorf("%s: no chains configured", path)
+	}
+
+	var errs []error
+	names := make(map[string]bool)
+	ids := make(map[int64]string)
+	for i := range file.Chains {
+		c := &file.Chains[i]
+		c.setDefaults()
+
+		if err = c.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: chains[%d]: %w", path, i, err))
+			continue
+		}
+		if names[c.Name] {
+			errs = append(errs, fmt.Errorf("%s: chains[%d]: duplicate name %s", path, i, c.Name))
+		}
+		if other, ok := ids[c.ChainID]; ok {
+			errs = append(errs, fmt.Errorf("%s: chains[%d]: chain id %d is also used by %s", path, i, c.ChainID, other))
+		}
+		names[c.Name] = true
+		ids[c.ChainID] = c.Name
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return file.Chains, nil
+}
+
+func (c *Chain) setDefaults() {
+	if c.PollInterval == 0 {
+		c.PollInterval = defaultPollInterval
+	}
+	if c.BatchSize == 0 {
+		c.BatchSize = defaultBatchSize
+	}
+	if c.NativeDecimals == 0 {
+		c.NativeDecimals = defaultNativeDecimals
+	}
+}
+
+func (c *Chain) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if c.ChainID <= 0 {
+		return fmt.Errorf("%s: chainId is required", c.Name)
+	}
+	if len(c.RPC) == 0 {
+		return fmt.Errorf("%s: at least one rpc endpoint is required", c.Name)
+	}
+	for i, rpc := range c.RPC {
+		if rpc == "" {
+			return fmt.Errorf("%s: rpc[%d] is empty (is the environment variable set?)", c.Name, i)
+		}
+		u, err := url.Parse(rpc)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%s: rpc[%d] must be an http(s) url", c.Name, i)
+		}
+	}
+	if c.PollInterval < minPollInterval {
+		return fmt.Errorf("%s: pollInterval must be at least %s", c.Name, minPollInterval)
+	}
+	if c.BatchSize < 1 || c.BatchSize > maxBatchSize {
+		return fmt.Errorf("%s: batchSize must be between 1 and %d", c.Name, maxBatchSize)
+	}
+	if c.MaxBlocksPerRound < 0 {
+		return fmt.Errorf("%s: maxBlocksPerRound must not be negative", c.Name)
+	}
+	if c.NativeDecimals < 0 || c.NativeDecimals > 36 {
+		return fmt.Errorf("%s: nativeDecimals must be between 0 and 36", c.Name)
+	}
+	return nil
+}
+
+// Chain 이름으로 체인 레지스트리에서 찾는다
+func (c *Config) Chain(name string) (Chain, error) {
+	chains, err := c.Chains()
+	if err != nil {
+		return Chain{}, err
+	}
+	for _, chain := range chains {
+		if chain.Name == name {
+			return chain, nil
+		}
+	}
+	return Chain{}, fmt.Errorf("chain %s is not in %s", name, c.ChainsFile)
+}
@@ -3,20 +3,67 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
 	STAGE        string
-	RPC          map[string]string
+	ChainsFile   string // 체인 레지스트리 (chains.yaml)
 	SMTPID       string
 	SMTPPassword string
+	AlertEmail   string // 쉼표로 여러 명
 	DBUser       string
 	DBPassword   string
 	DBName       string
 	DBHost       string
 	DBPort       string
+
+	// REST API / gRPC 리슨 주소와 목록 cursor 암호화 키
+	APIAddr      string
+	GRPCAddr     string
+	CursorSecret string
+
+	// 트래커 /metrics, /healthz, /readyz 리슨 주소
+	MetricsAddr string
+
+	// 같은 주소의 /admin (체인 추가, 멈춤, 재인덱싱) Bearer 토큰. 비어 있으면 /admin 을 열지 않는다
+	AdminToken string
+
+	// RPC 최신 높이가 이 시간 동안 그대로면 체인이 멈춘 것으로 본다
+	ChainStallSeconds int
+
+	// 체인 트래커가 이 시간(분) 동안 이 수보다 많이 재시작하면 critical 알림
+	TrackerRestartBudget        int
+	TrackerRestartWindowMinutes int
+
+	// span 을 내보낼 곳 (none / otlp / stdout)
+	TracingExporter    string
+	TracingServiceName string
+
+	// 최근 10분 동안 이 수 이상 이상 징후가 쌓이면 알림
+	AnomalyAlertThreshold int
+
+	// 알림 채널. URL/수신자가 비어 있는 채널은 쓰지 않고, 각 채널은 *Severity 이상만 받는다
+	SMTPHost           string
+	SMTPPort           int
+	SMTPTLS            string // starttls / tls / none
+	SMTPFrom           string
+	AlertEmailSeverity string
+	SlackWebhookURL    string
+	SlackSeverity      string
+	DiscordWebhookURL  string
+	DiscordSeverity    string
+	AlertHTTPURL       string
+	AlertHTTPSeverity  string
+
+	// 같은 알림은 이 시간 동안 한 번만, 채널마다 한 시간에 이 수까지만 보낸다
+	AlertDedupMinutes     int
+	AlertRateLimitPerHour int
+
+	// 알림 규칙 JSON 파일 (DB 규칙과 같이 쓴다)
+	RulesFile string
 }
 
 func LoadConfig() *Config {
@@ -26,19 +73,56 @@ func LoadConfig() *Config {
 	}
 
 	return &Config{
-		STAGE: os.Getenv("STAGE"),
-		RPC: map[string]string{
-			"Ethereum":         os.Getenv("ETH_RPC"),
-			"Biance":           os.Getenv("BSC_RPC"),
-			"GiantMammoth":     os.Getenv("GMMT_RPC"),
-			"TestGiantMammoth": os.Getenv("TEST_GMMT_RPC"),
-		},
-		SMTPID:       os.Getenv("SMTP_ID"),
-		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
-		DBUser:       os.Getenv("DB_USER"),
-		DBPassword:   os.Getenv("DB_PASSWORD"),
-		DBName:       os.Getenv("DB_NAME"),
-		DBHost:       os.Getenv("DB_HOST"),
-		DBPort:       os.Getenv("DB_PORT"),
+		STAGE:                       os.Getenv("STAGE"),
+		ChainsFile:                  envString("CHAINS_FILE", "chains.yaml"),
+		SMTPID:                      os.Getenv("SMTP_ID"),
+		SMTPPassword:                os.Getenv("SMTP_PASSWORD"),
+		AlertEmail:                  os.Getenv("ALERT_EMAIL"),
+		AnomalyAlertThreshold:       envInt("ANOMALY_ALERT_THRESHOLD", 50),
+		SMTPHost:                    envString("SMTP_HOST", "smtp.gmail.com"),
+		SMTPPort:                    envInt("SMTP_PORT", 587),
+		SMTPTLS:                     envString("SMTP_TLS", "starttls"),
+		SMTPFrom:                    os.Getenv("SMTP_FROM"),
+		AlertEmailSeverity:          envString("ALERT_EMAIL_SEVERITY", "warning"),
+		SlackWebhookURL:             os.Getenv("SLACK_WEBHOOK_URL"),
+		SlackSeverity:               envString("SLACK_SEVERITY", "warning"),
+		DiscordWebhookURL:           os.Getenv("DISCORD_WEBHOOK_URL"),
+		DiscordSeverity:             envString("DISCORD_SEVERITY", "warning"),
+		AlertHTTPURL:                os.Getenv("ALERT_HTTP_URL"),
+		AlertHTTPSeverity:           envString("ALERT_HTTP_SEVERITY", "info"),
+		AlertDedupMinutes:           envInt("ALERT_DEDUP_MINUTES", 10),
+		AlertRateLimitPerHour:       envInt("ALERT_RATE_LIMIT_PER_HOUR", 10),
+		RulesFile:                   os.Getenv("RULES_FILE"),
+		DBUser:                      os.Getenv("DB_USER"),
+		DBPassword:                  os.Getenv("DB_PASSWORD"),
+		DBName:                      os.Getenv("DB_NAME"),
+		DBHost:                      os.Getenv("DB_HOST"),
+		DBPort:                      os.Getenv("DB_PORT"),
+		APIAddr:                     envString("API_ADDR", ":8080"),
+		GRPCAddr:                    envString("GRPC_ADDR", ":9090"),
+		CursorSecret:                os.Getenv("CURSOR_SECRET"),
+		MetricsAddr:                 envString("METRICS_ADDR", ":2112"),
+		AdminToken:                  os.Getenv("ADMIN_TOKEN"),
+		ChainStallSeconds:           envInt("CHAIN_STALL_SECONDS", 300),
+		TrackerRestartBudget:        envInt("TRACKER_RESTART_BUDGET", 5),
+		TrackerRestartWindowMinutes: envInt("TRACKER_RESTART_WINDOW_MINUTES", 60),
+		TracingExporter:             envString("TRACING_EXPORTER", "none"),
+		TracingServiceName:          envString("OTEL_SERVICE_NAME", "blockchain-tracking"),
+	}
+}
+
+func envInt(key string, fallback int) int {
+	v, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return v
+}
+
+func envString(key string, fallback string) string {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
 	}
+	return v
 }
@@ -0,0 +1,188 @@
+package admin
+
+import (
+	"blockchain-tracking/config"
+	"blockchain-tracking/internal/blockchain/evm"
+	"blockchain-tracking/internal/logger"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// errBadRequest 요청 본문 오류 (400)
+var errBadRequest = errors.New("bad request")
+
+// Lookup 체인 레지스트리(CHAINS_FILE)를 다시 읽어서 이름으로 찾는다
+type Lookup func(name string) (config.Chain, error)
+
+// Handler 체인 트래커 운영 API. Authorization: Bearer <ADMIN_TOKEN> 이 맞아야 한다
+type Handler struct {
+	manager *evm.Manager
+	lookup  Lookup
+	token   []byte
+	l       logger.Logger
+	mux     *http.ServeMux
+}
+
+func NewHandler(manager *evm.Manager, lookup Lookup, token string, l logger.Logger) *Handler {
+	h := &Handler{
+		manager: manager,
+		lookup:  lookup,
+		token:   []byte(token),
+		l:       l,
+		mux:     http.NewServeMux(),
+	}
+
+	h.mux.HandleFunc("GET /admin/chains", h.list)
+	h.mux.HandleFunc("POST /admin/chains", h.add)
+	h.mux.HandleFunc("GET /admin/chains/{name}", h.chain)
+	h.mux.HandleFunc("POST /admin/chains/{name}/pause", h.pause)
+	h.mux.HandleFunc("POST /admin/chains/{name}/resume", h.resume)
+	h.mux.HandleFunc("POST /admin/chains/{name}/reindex", h.reindex)
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), h.token) != 1 {
+		h.writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	h.writeJSON(w, http.StatusOK, h.manager.Chains())
+}
+
+func (h *Handler) chain(w http.ResponseWriter, r *http.Request) {
+	info, err := h.manager.Chain(r.PathValue("name"))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, info)
+}
+
+// add 체인 레지스트리에 추가한 체인을 띄운다 {"name": "..."}
+func (h *Handler) add(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name string `json:"name"`
+	}
+	if err := decode(w, r, &body); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	if body.Name == "" {
+		h.writeError(w, r, fmt.Errorf("%w: name is required", errBadRequest))
+		return
+	}
+
+	chain, err := h.lookup(body.Name)
+	if err != nil {
+		h.writeError(w, r, fmt.Errorf("%w: %s", errBadRequest, err.Error()))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+	if err = h.manager.Add(ctx, chain); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	h.writeChain(w, r, http.StatusCreated, chain.Name)
+}
+
+func (h *Handler) pause(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if err := h.manager.Pause(name); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.writeChain(w, r, http.StatusOK, name)
+}
+
+func (h *Handler) resume(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if err := h.manager.Resume(name); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.writeChain(w, r, http.StatusOK, name)
+}
+
+// reindex from~to 를 다시 받는다 {"from": 100, "to": 200}. 끝날 때까지 기다리지 않고 202, 진행은 GET /admin/chains/{name}
+func (h *Handler) reindex(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		From *uint64 `json:"from"`
+		To   *uint64 `json:"to"`
+	}
+	if err := decode(w, r, &body); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	if body.From == nil || body.To == nil {
+		h.writeError(w, r, fmt.Errorf("%w: from and to are required", errBadRequest))
+		return
+	}
+
+	name := r.PathValue("name")
+	job, err := h.manager.Reindex(r.Context(), name, *body.From, *body.To)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	h.l.Info(fmt.Sprintf("%s reindex requested", name), logger.Field{Key: "from", Value: job.From}, logger.Field{Key: "to", Value: job.To}, logger.Field{Key: "remote", Value: r.RemoteAddr})
+	h.writeJSON(w, http.StatusAccepted, job)
+}
+
+func (h *Handler) writeChain(w http.ResponseWriter, r *http.Request, status int, name string) {
+	info, err := h.manager.Chain(name)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.writeJSON(w, status, info)
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("%w: invalid body: %s", errBadRequest, err.Error())
+	}
+	return nil
+}
+
+func (h *Handler) writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		h.l.Warn("write response", logger.Field{Key: "error", Value: err.Error()})
+	}
+}
+
+// writeError 400/404/409/502 는 메시지를 그대로, 나머지는 로그만 남기고 500
+func (h *Handler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, errBadRequest), errors.Is(err, evm.ErrInvalidRange), errors.Is(err, evm.ErrChainIDMismatch):
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, evm.ErrChainNotFound):
+		h.writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, evm.ErrChainExists), errors.Is(err, evm.ErrReindexRunning):
+		h.writeJSON(w, http.StatusConflict, map[string]string{"error": err.Error()})
+	case errors.Is(err, evm.ErrRPCUnreachable):
+		h.writeJSON(w, http.StatusBadGateway, map[string]string{"error": err.Error()})
+	default:
+		h.l.Error("admin request", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "path", Value: r.URL.Path})
+		h.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+}
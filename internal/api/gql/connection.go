@@ -0,0 +1,59 @@
+package gql
+
+import "blockchain-tracking/internal/core/domain/explorer"
+
+// connection 목록 필드의 edges/pageInfo. edge cursor 는 REST 의 cursor 와 같은 암호화 문자열이다
+type connection[N any] struct {
+	edges   []*edge[N]
+	hasNext bool
+}
+
+type edge[N any] struct {
+	cursor string
+	node   N
+}
+
+type pageInfo struct {
+	hasNextPage bool
+	endCursor   *string
+}
+
+func newConnection[T any, N any](r *Resolver, items []T, next *explorer.Cursor, cursorOf func(T) explorer.Cursor, node func(T) N) (*connection[N], error) {
+	c := &connection[N]{edges: make([]*edge[N], len(items)), hasNext: next != nil}
+	for i, item := range items {
+		cursor, err := cursorOf(item).Encode(r.cursor)
+		if err != nil {
+			return nil, errInternal
+		}
+		c.edges[i] = &edge[N]{cursor: cursor, node: node(item)}
+	}
+	return c, nil
+}
+
+func (c *connection[N]) Edges() []*edge[N] {
+	return c.edges
+}
+
+func (c *connection[N]) PageInfo() *pageInfo {
+	info := &pageInfo{hasNextPage: c.hasNext}
+	if len(c.edges) > 0 {
+		info.endCursor = &c.edges[len(c.edges)-1].cursor
+	}
+	return info
+}
+
+func (e *edge[N]) Cursor() string {
+	return e.cursor
+}
+
+func (e *edge[N]) Node() N {
+	return e.node
+}
+
+func (p *pageInfo) HasNextPage() bool {
+	return p.hasNextPage
+}
+
+func (p *pageInfo) EndCursor() *string {
+	return p.endCursor
+}
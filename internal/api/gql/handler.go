@@ -0,0 +1,30 @@
+package gql
+
+import (
+	"blockchain-tracking/internal/core/domain/explorer"
+	"blockchain-tracking/internal/database/postgresql"
+	_ "embed"
+	"net/http"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+)
+
+//go:embed schema.graphql
+var schema string
+
+const (
+	// 같은 단계의 목록 resolver 가 동시에 돌아야 loader 가 키를 한 번에 모은다
+	maxParallelism = 64
+	maxDepth       = 12
+)
+
+// NewHandler POST /graphql. 요청마다 새 loader 를 ctx 에 넣는다
+func NewHandler(explorerService *explorer.Service, cursor *postgresql.Cursor) http.Handler {
+	root := &Resolver{explorer: explorerService, cursor: cursor}
+	h := &relay.Handler{Schema: graphql.MustParseSchema(schema, root, graphql.MaxParallelism(maxParallelism), graphql.MaxDepth(maxDepth))}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r.WithContext(withLoaders(r.Context(), explorerService)))
+	})
+}
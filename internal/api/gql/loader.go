@@ -0,0 +1,156 @@
+package gql
+
+import (
+	"blockchain-tracking/internal/core/domain/explorer"
+	"context"
+
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+// 요청마다 새로 만드는 loader. 같은 요청 안에서 중첩 resolver 가 부르는 키를 모아 체인별로 쿼리 한 번에 읽는다
+
+const loaderBatchCapacity = 500
+
+type loadersKey struct{}
+
+type contractKey struct {
+	chainID int64
+	hash    string
+}
+
+type blockKey struct {
+	chainID int64
+	number  int64
+}
+
+type txKey struct {
+	chainID int64
+	ref     explorer.TxRef
+}
+
+type nftKey struct {
+	chainID int64
+	ref     explorer.NFTRef
+}
+
+type loaders struct {
+	contracts *dataloader.Loader[contractKey, *explorer.Token]
+	blocks    *dataloader.Loader[blockKey, *explorer.Block]
+	inputs    *dataloader.Loader[txKey, string]
+	logs      *dataloader.Loader[txKey, []*explorer.Log]
+	transfers *dataloader.Loader[txKey, []*explorer.TokenTransfer]
+	nfts      *dataloader.Loader[nftKey, *explorer.NFT]
+}
+
+func newLoaders(s *explorer.Service) *loaders {
+	return &loaders{
+		contracts: newLoader(func(k contractKey) int64 { return k.chainID }, func(ctx context.Context, chainID int64, keys []contractKey) ([]*explorer.Token, error) {
+			hashes := make([]string, len(keys))
+			for i, key := range keys {
+				hashes[i] = key.hash
+			}
+			tokens, err := s.TokensByHash(ctx, chainID, hashes)
+			if err != nil {
+				return nil, err
+			}
+			values := make([]*explorer.Token, len(keys))
+			for i, key := range keys {
+				values[i] = tokens[key.hash]
+			}
+			return values, nil
+		}),
+		blocks: newLoader(func(k blockKey) int64 { return k.chainID }, func(ctx context.Context, chainID int64, keys []blockKey) ([]*explorer.Block, error) {
+			numbers := make([]int64, len(keys))
+			for i, key := range keys {
+				numbers[i] = key.number
+			}
+			blocks, err := s.BlocksByNumber(ctx, chainID, numbers)
+			if err != nil {
+				return nil, err
+			}
+			values := make([]*explorer.Block, len(keys))
+			for i, key := range keys {
+				values[i] = blocks[key.number]
+			}
+			return values, nil
+		}),
+		inputs:    newLoader(txChain, byTransaction(s.InputsByTransaction)),
+		logs:      newLoader(txChain, byTransaction(s.LogsByTransaction)),
+		transfers: newLoader(txChain, byTransaction(s.TransfersByTransaction)),
+		nfts: newLoader(func(k nftKey) int64 { return k.chainID }, func(ctx context.Context, chainID int64, keys []nftKey) ([]*explorer.NFT, error) {
+			refs := make([]explorer.NFTRef, len(keys))
+			for i, key := range keys {
+				refs[i] = key.ref
+			}
+			nfts, err := s.NFTsByRef(ctx, chainID, refs)
+			if err != nil {
+				return nil, err
+			}
+			values := make([]*explorer.NFT, len(keys))
+			for i, key := range keys {
+				values[i] = nfts[key.ref]
+			}
+			return values, nil
+		}),
+	}
+}
+
+func withLoaders(ctx context.Context, s *explorer.Service) context.Context {
+	return context.WithValue(ctx, loadersKey{}, newLoaders(s))
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// newLoader 배치로 모인 키를 체인별로 나눠 load 를 한 번씩 부른다. load 는 받은 키 순서대로 값을 돌려준다
+func newLoader[K comparable, V any](chainOf func(K) int64, load func(ctx context.Context, chainID int64, keys []K) ([]V, error)) *dataloader.Loader[K, V] {
+	batch := func(ctx context.Context, keys []K) []*dataloader.Result[V] {
+		results := make([]*dataloader.Result[V], len(keys))
+		groups := make(map[int64][]int)
+		for i, key := range keys {
+			groups[chainOf(key)] = append(groups[chainOf(key)], i)
+		}
+
+		for chainID, indexes := range groups {
+			group := make([]K, len(indexes))
+			for j, i := range indexes {
+				group[j] = keys[i]
+			}
+			values, err := load(ctx, chainID, group)
+			for j, i := range indexes {
+				if err != nil {
+					results[i] = &dataloader.Result[V]{Error: err}
+					continue
+				}
+				results[i] = &dataloader.Result[V]{Data: values[j]}
+			}
+		}
+		return results
+	}
+
+	return dataloader.NewBatchedLoader(batch, dataloader.WithBatchCapacity[K, V](loaderBatchCapacity))
+}
+
+func txChain(k txKey) int64 {
+	return k.chainID
+}
+
+// byTransaction 트랜잭션 해시로 묶인 결과를 키 순서로 편다
+func byTransaction[V any](fetch func(ctx context.Context, chainID int64, refs []explorer.TxRef) (map[string]V, error)) func(context.Context, int64, []txKey) ([]V, error) {
+	return func(ctx context.Context, chainID int64, keys []txKey) ([]V, error) {
+		refs := make([]explorer.TxRef, len(keys))
+		for i, key := range keys {
+			refs[i] = key.ref
+		}
+		byHash, err := fetch(ctx, chainID, refs)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]V, len(keys))
+		for i, key := range keys {
+			values[i] = byHash[key.ref.Hash]
+		}
+		return values, nil
+	}
+}
@@ -0,0 +1,188 @@
+package gql
+
+import (
+	"blockchain-tracking/internal/core/domain/explorer"
+	"blockchain-tracking/internal/database/postgresql"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	addressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+	hashPattern    = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+	tokenIDPattern = regexp.MustCompile(`^[0-9]{1,78}$`)
+)
+
+// errInternal DB 오류는 explorer 에서 로그를 남기고 응답에는 내용을 숨긴다
+var errInternal = errors.New("internal error")
+
+// Resolver 루트 Query
+type Resolver struct {
+	explorer *explorer.Service
+	cursor   *postgresql.Cursor
+}
+
+func (r *Resolver) Block(ctx context.Context, args struct {
+	ChainID Long
+	Number  *Long
+	Hash    *string
+}) (*blockResolver, error) {
+	var number int64
+	var hash string
+	switch {
+	case args.Hash != nil:
+		var err error
+		if hash, err = hexArg("hash", *args.Hash, hashPattern); err != nil {
+			return nil, err
+		}
+	case args.Number != nil && *args.Number >= 0:
+		number = int64(*args.Number)
+	default:
+		return nil, errors.New("number or hash is required")
+	}
+
+	block, err := r.explorer.Block(ctx, int64(args.ChainID), number, hash)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &blockResolver{r: r, chainID: int64(args.ChainID), block: block}, nil
+}
+
+func (r *Resolver) Transaction(ctx context.Context, args struct {
+	ChainID Long
+	Hash    string
+}) (*transactionResolver, error) {
+	hash, err := hexArg("hash", args.Hash, hashPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.explorer.Transaction(ctx, int64(args.ChainID), hash)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &transactionResolver{r: r, chainID: int64(args.ChainID), tx: tx}, nil
+}
+
+func (r *Resolver) Address(args struct {
+	ChainID Long
+	Address string
+}) (*addressResolver, error) {
+	address, err := hexArg("address", args.Address, addressPattern)
+	if err != nil {
+		return nil, err
+	}
+	return &addressResolver{r: r, chainID: int64(args.ChainID), address: address}, nil
+}
+
+func (r *Resolver) Contract(ctx context.Context, args struct {
+	ChainID Long
+	Address string
+}) (*contractResolver, error) {
+	address, err := hexArg("address", args.Address, addressPattern)
+	if err != nil {
+		return nil, err
+	}
+	return r.contract(ctx, int64(args.ChainID), address)
+}
+
+func (r *Resolver) Nft(ctx context.Context, args struct {
+	ChainID  Long
+	Contract string
+	TokenID  string
+	Kind     *string
+}) (*nftResolver, error) {
+	contract, err := hexArg("contract", args.Contract, addressPattern)
+	if err != nil {
+		return nil, err
+	}
+	if !tokenIDPattern.MatchString(args.TokenID) {
+		return nil, errors.New("invalid tokenId")
+	}
+
+	var kind explorer.Kind
+	if args.Kind != nil {
+		kind = toKind(*args.Kind)
+	} else {
+		token, err := r.contract(ctx, int64(args.ChainID), contract)
+		if err != nil {
+			return nil, err
+		}
+		if token == nil || token.token.Kind == "" {
+			return nil, errors.New("token type is unknown, pass kind")
+		}
+		kind = token.token.Kind
+	}
+	if kind == explorer.KindErc20 {
+		return nil, fmt.Errorf("%s is not an nft contract", contract)
+	}
+
+	return &nftResolver{r: r, chainID: int64(args.ChainID), ref: explorer.NFTRef{Kind: kind, Hash: contract, TokenID: args.TokenID}}, nil
+}
+
+// contract 인덱싱되지 않은 컨트랙트는 nil
+func (r *Resolver) contract(ctx context.Context, chainID int64, hash string) (*contractResolver, error) {
+	token, err := loadersFrom(ctx).contracts.Load(ctx, contractKey{chainID: chainID, hash: hash})()
+	if err != nil {
+		return nil, errInternal
+	}
+	if token == nil {
+		return nil, nil
+	}
+	return &contractResolver{r: r, chainID: chainID, token: token}, nil
+}
+
+// page first/after 를 explorer 페이지 인자로 바꾼다
+func (r *Resolver) page(first *int32, after *string) (explorer.Cursor, int32, error) {
+	var limit int32
+	if first != nil {
+		if *first <= 0 {
+			return explorer.Cursor{}, 0, errors.New("first must be positive")
+		}
+		limit = *first
+	}
+
+	var encoded string
+	if after != nil {
+		encoded = *after
+	}
+	cursor, err := explorer.DecodeCursor(r.cursor, encoded)
+	if err != nil {
+		return cursor, 0, errors.New("invalid cursor")
+	}
+	return cursor, limit, nil
+}
+
+// notFound 없는 항목은 오류 없이 null
+func notFound(err error) error {
+	if errors.Is(err, explorer.ErrNotFound) {
+		return nil
+	}
+	return errInternal
+}
+
+// hexArg 주소/해시를 DB 와 같은 소문자 0x hex 로 맞춘다
+func hexArg(name, value string, pattern *regexp.Regexp) (string, error) {
+	if !pattern.MatchString(value) {
+		return "", fmt.Errorf("invalid %s", name)
+	}
+	return strings.ToLower(value), nil
+}
+
+func toKind(value string) explorer.Kind {
+	return explorer.Kind(strings.ToLower(value))
+}
+
+func fromKind(kind explorer.Kind) string {
+	return strings.ToUpper(string(kind))
+}
+
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,47 @@
+package gql
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Long 64비트 정수 스칼라. GraphQL Int 는 32비트라 블록 번호/체인 id 에 쓴다
+type Long int64
+
+func (Long) ImplementsGraphQLType(name string) bool {
+	return name == "Long"
+}
+
+func (l *Long) UnmarshalGraphQL(input interface{}) error {
+	switch v := input.(type) {
+	case int32:
+		*l = Long(v)
+	case int64:
+		*l = Long(v)
+	case float64:
+		if v != float64(int64(v)) {
+			return fmt.Errorf("long must be an integer, got %v", v)
+		}
+		*l = Long(v)
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return fmt.Errorf("invalid long %q", v)
+		}
+		*l = Long(n)
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid long %q", v)
+		}
+		*l = Long(n)
+	default:
+		return fmt.Errorf("wrong type for long: %T", input)
+	}
+	return nil
+}
+
+func (l Long) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(l), 10), nil
+}
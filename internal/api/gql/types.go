@@ -0,0 +1,442 @@
+package gql
+
+import (
+	"blockchain-tracking/internal/core/domain/explorer"
+	"context"
+	"errors"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+type blockResolver struct {
+	r       *Resolver
+	chainID int64
+	block   *explorer.Block
+}
+
+func (b *blockResolver) ChainID() Long            { return Long(b.chainID) }
+func (b *blockResolver) Number() Long             { return Long(b.block.Number) }
+func (b *blockResolver) Hash() string             { return b.block.Hash }
+func (b *blockResolver) ParentHash() string       { return b.block.ParentHash }
+func (b *blockResolver) Miner() string            { return b.block.Miner }
+func (b *blockResolver) GasLimit() Long           { return Long(b.block.GasLimit) }
+func (b *blockResolver) GasUsed() Long            { return Long(b.block.GasUsed) }
+func (b *blockResolver) Difficulty() string       { return b.block.Difficulty }
+func (b *blockResolver) TotalDifficulty() string  { return b.block.TotalDifficulty }
+func (b *blockResolver) TransactionsRoot() string { return b.block.TransactionsRoot }
+func (b *blockResolver) Timestamp() graphql.Time  { return graphql.Time{Time: b.block.Timestamp} }
+
+// Transactions loader 로 읽은 블록은 헤더만 있어서 트랜잭션을 따로 읽는다
+func (b *blockResolver) Transactions(ctx context.Context) ([]*transactionResolver, error) {
+	transactions := b.block.Transactions
+	if transactions == nil {
+		block, err := b.r.explorer.Block(ctx, b.chainID, b.block.Number, "")
+		if err != nil {
+			return nil, errInternal
+		}
+		transactions = block.Transactions
+	}
+
+	resolvers := make([]*transactionResolver, len(transactions))
+	for i, tx := range transactions {
+		resolvers[i] = &transactionResolver{r: b.r, chainID: b.chainID, tx: tx}
+	}
+	return resolvers, nil
+}
+
+type transactionResolver struct {
+	r       *Resolver
+	chainID int64
+	tx      *explorer.Transaction
+}
+
+func (t *transactionResolver) ChainID() Long            { return Long(t.chainID) }
+func (t *transactionResolver) Hash() string             { return t.tx.Hash }
+func (t *transactionResolver) BlockHash() string        { return t.tx.BlockHash }
+func (t *transactionResolver) BlockNumber() Long        { return Long(t.tx.BlockNumber) }
+func (t *transactionResolver) TransactionIndex() Long   { return Long(t.tx.TransactionIndex) }
+func (t *transactionResolver) From() string             { return t.tx.From }
+func (t *transactionResolver) To() *string              { return optional(t.tx.To) }
+func (t *transactionResolver) ContractAddress() *string { return optional(t.tx.ContractAddress) }
+func (t *transactionResolver) Value() string            { return t.tx.Value }
+func (t *transactionResolver) Gas() Long                { return Long(t.tx.Gas) }
+func (t *transactionResolver) GasPrice() string         { return t.tx.GasPrice }
+func (t *transactionResolver) GasUsed() Long            { return Long(t.tx.GasUsed) }
+func (t *transactionResolver) Nonce() Long              { return Long(t.tx.Nonce) }
+func (t *transactionResolver) Status() int32            { return int32(t.tx.Status) }
+func (t *transactionResolver) Type() int32              { return int32(t.tx.Type) }
+func (t *transactionResolver) Timestamp() graphql.Time  { return graphql.Time{Time: t.tx.Timestamp} }
+
+func (t *transactionResolver) key() txKey {
+	return txKey{chainID: t.chainID, ref: explorer.TxRef{BlockNumber: t.tx.BlockNumber, Timestamp: t.tx.Timestamp, Hash: t.tx.Hash}}
+}
+
+func (t *transactionResolver) Block(ctx context.Context) (*blockResolver, error) {
+	block, err := loadersFrom(ctx).blocks.Load(ctx, blockKey{chainID: t.chainID, number: t.tx.BlockNumber})()
+	if err != nil {
+		return nil, errInternal
+	}
+	if block == nil {
+		return nil, nil
+	}
+	return &blockResolver{r: t.r, chainID: t.chainID, block: block}, nil
+}
+
+func (t *transactionResolver) CreatedContract(ctx context.Context) (*contractResolver, error) {
+	if t.tx.ContractAddress == "" {
+		return nil, nil
+	}
+	return t.r.contract(ctx, t.chainID, t.tx.ContractAddress)
+}
+
+func (t *transactionResolver) Input(ctx context.Context) (string, error) {
+	input, err := loadersFrom(ctx).inputs.Load(ctx, t.key())()
+	if err != nil {
+		return "", errInternal
+	}
+	return input, nil
+}
+
+func (t *transactionResolver) Logs(ctx context.Context) ([]*logResolver, error) {
+	logs, err := loadersFrom(ctx).logs.Load(ctx, t.key())()
+	if err != nil {
+		return nil, errInternal
+	}
+
+	resolvers := make([]*logResolver, len(logs))
+	for i, log := range logs {
+		resolvers[i] = &logResolver{r: t.r, chainID: t.chainID, log: log}
+	}
+	return resolvers, nil
+}
+
+func (t *transactionResolver) TokenTransfers(ctx context.Context, args struct{ Kind *string }) ([]*transferResolver, error) {
+	transfers, err := loadersFrom(ctx).transfers.Load(ctx, t.key())()
+	if err != nil {
+		return nil, errInternal
+	}
+
+	resolvers := make([]*transferResolver, 0, len(transfers))
+	for _, transfer := range transfers {
+		if args.Kind != nil && transfer.Kind != toKind(*args.Kind) {
+			continue
+		}
+		resolvers = append(resolvers, &transferResolver{r: t.r, chainID: t.chainID, transfer: transfer})
+	}
+	return resolvers, nil
+}
+
+type logResolver struct {
+	r       *Resolver
+	chainID int64
+	log     *explorer.Log
+}
+
+func (l *logResolver) Address() string         { return l.log.Address }
+func (l *logResolver) BlockHash() string       { return l.log.BlockHash }
+func (l *logResolver) BlockNumber() Long       { return Long(l.log.BlockNumber) }
+func (l *logResolver) TransactionHash() string { return l.log.TransactionHash }
+func (l *logResolver) TransactionIndex() Long  { return Long(l.log.TransactionIndex) }
+func (l *logResolver) LogIndex() Long          { return Long(l.log.LogIndex) }
+func (l *logResolver) Topics() []string        { return l.log.Topics }
+func (l *logResolver) Data() string            { return l.log.Data }
+func (l *logResolver) Removed() bool           { return l.log.Removed }
+func (l *logResolver) Timestamp() graphql.Time { return graphql.Time{Time: l.log.Timestamp} }
+
+func (l *logResolver) Contract(ctx context.Context) (*contractResolver, error) {
+	return l.r.contract(ctx, l.chainID, l.log.Address)
+}
+
+type contractResolver struct {
+	r       *Resolver
+	chainID int64
+	token   *explorer.Token
+}
+
+func (c *contractResolver) ChainID() Long        { return Long(c.chainID) }
+func (c *contractResolver) Address() string      { return c.token.Hash }
+func (c *contractResolver) Name() *string        { return optional(c.token.Name) }
+func (c *contractResolver) Symbol() *string      { return optional(c.token.Symbol) }
+func (c *contractResolver) Decimals() *int32     { return c.token.Decimals }
+func (c *contractResolver) TotalSupply() *string { return optional(c.token.TotalSupply) }
+func (c *contractResolver) Creator() *string     { return optional(c.token.Creator) }
+
+func (c *contractResolver) Type() *string {
+	if c.token.Kind == "" {
+		return nil
+	}
+	kind := fromKind(c.token.Kind)
+	return &kind
+}
+
+func (c *contractResolver) Transfers(ctx context.Context, args struct {
+	First *int32
+	After *string
+}) (*connection[*transferResolver], error) {
+	if c.token.Kind == "" {
+		return nil, errors.New("token type is unknown")
+	}
+	cursor, limit, err := c.r.page(args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers, next, err := c.r.explorer.TokenTransfers(ctx, c.chainID, c.token.Kind, "", c.token.Hash, cursor, limit)
+	if err != nil {
+		return nil, errInternal
+	}
+	return newConnection(c.r, transfers, next, transferCursor, func(transfer *explorer.TokenTransfer) *transferResolver {
+		return &transferResolver{r: c.r, chainID: c.chainID, transfer: transfer}
+	})
+}
+
+func (c *contractResolver) Holders(ctx context.Context, args struct {
+	First *int32
+	After *string
+}) (*connection[*holderResolver], error) {
+	if c.token.Kind == "" {
+		return nil, errors.New("token type is unknown")
+	}
+	cursor, limit, err := c.r.page(args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	holders, next, err := c.r.explorer.Holders(ctx, c.chainID, c.token.Kind, c.token.Hash, cursor, limit)
+	if err != nil {
+		return nil, errInternal
+	}
+	return newConnection(c.r, holders, next, holderCursor, newHolderResolver)
+}
+
+type transferResolver struct {
+	r        *Resolver
+	chainID  int64
+	transfer *explorer.TokenTransfer
+}
+
+func (t *transferResolver) Kind() string            { return fromKind(t.transfer.Kind) }
+func (t *transferResolver) ContractAddress() string { return t.transfer.ContractAddress }
+func (t *transferResolver) TransactionHash() string { return t.transfer.TransactionHash }
+func (t *transferResolver) LogIndex() Long          { return Long(t.transfer.LogIndex) }
+func (t *transferResolver) BatchIndex() int32       { return t.transfer.BatchIndex }
+func (t *transferResolver) From() string            { return t.transfer.From }
+func (t *transferResolver) To() string              { return t.transfer.To }
+func (t *transferResolver) TokenID() *string        { return optional(t.transfer.TokenID) }
+func (t *transferResolver) Amount() *string         { return optional(t.transfer.Amount) }
+func (t *transferResolver) Function() *string       { return optional(t.transfer.Function) }
+func (t *transferResolver) Timestamp() graphql.Time { return graphql.Time{Time: t.transfer.Timestamp} }
+
+func (t *transferResolver) Contract(ctx context.Context) (*contractResolver, error) {
+	return t.r.contract(ctx, t.chainID, t.transfer.ContractAddress)
+}
+
+func (t *transferResolver) Nft() *nftResolver {
+	if t.transfer.Kind == explorer.KindErc20 || t.transfer.TokenID == "" {
+		return nil
+	}
+	return &nftResolver{r: t.r, chainID: t.chainID, ref: explorer.NFTRef{Kind: t.transfer.Kind, Hash: t.transfer.ContractAddress, TokenID: t.transfer.TokenID}}
+}
+
+// nftResolver 메타데이터가 기록되지 않은 토큰도 소유자는 조회할 수 있어서 ref 만으로 만든다
+type nftResolver struct {
+	r       *Resolver
+	chainID int64
+	ref     explorer.NFTRef
+}
+
+func (n *nftResolver) Kind() string            { return fromKind(n.ref.Kind) }
+func (n *nftResolver) ContractAddress() string { return n.ref.Hash }
+func (n *nftResolver) TokenID() string         { return n.ref.TokenID }
+
+func (n *nftResolver) Contract(ctx context.Context) (*contractResolver, error) {
+	return n.r.contract(ctx, n.chainID, n.ref.Hash)
+}
+
+func (n *nftResolver) Url(ctx context.Context) (*string, error) {
+	nft, err := n.metadata(ctx)
+	if err != nil || nft == nil {
+		return nil, err
+	}
+	return optional(nft.URL), nil
+}
+
+func (n *nftResolver) ImageUrl(ctx context.Context) (*string, error) {
+	nft, err := n.metadata(ctx)
+	if err != nil || nft == nil {
+		return nil, err
+	}
+	return optional(nft.ImageURL), nil
+}
+
+func (n *nftResolver) metadata(ctx context.Context) (*explorer.NFT, error) {
+	nft, err := loadersFrom(ctx).nfts.Load(ctx, nftKey{chainID: n.chainID, ref: n.ref})()
+	if err != nil {
+		return nil, errInternal
+	}
+	return nft, nil
+}
+
+func (n *nftResolver) Owners(ctx context.Context, args struct {
+	First *int32
+	After *string
+}) (*connection[*holderResolver], error) {
+	cursor, limit, err := n.r.page(args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	owners, next, err := n.r.explorer.Owners(ctx, n.chainID, n.ref.Kind, n.ref.Hash, n.ref.TokenID, cursor, limit)
+	if err != nil && !errors.Is(err, explorer.ErrNotFound) {
+		return nil, errInternal
+	}
+	return newConnection(n.r, owners, next, holderCursor, newHolderResolver)
+}
+
+type addressResolver struct {
+	r       *Resolver
+	chainID int64
+	address string
+}
+
+func (a *addressResolver) ChainID() Long   { return Long(a.chainID) }
+func (a *addressResolver) Address() string { return a.address }
+
+func (a *addressResolver) Balance(ctx context.Context) (string, error) {
+	balance, err := a.r.explorer.NativeBalance(ctx, a.chainID, a.address)
+	if err != nil {
+		return "", errInternal
+	}
+	return balance, nil
+}
+
+func (a *addressResolver) Transactions(ctx context.Context, args struct {
+	First *int32
+	After *string
+}) (*connection[*transactionResolver], error) {
+	cursor, limit, err := a.r.page(args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, next, err := a.r.explorer.Transactions(ctx, a.chainID, a.address, cursor, limit)
+	if err != nil {
+		return nil, errInternal
+	}
+	return newConnection(a.r, transactions, next, func(tx *explorer.Transaction) explorer.Cursor {
+		return explorer.Cursor{ID: tx.ID}
+	}, func(tx *explorer.Transaction) *transactionResolver {
+		return &transactionResolver{r: a.r, chainID: a.chainID, tx: tx}
+	})
+}
+
+func (a *addressResolver) TokenTransfers(ctx context.Context, args struct {
+	Kind  string
+	First *int32
+	After *string
+}) (*connection[*transferResolver], error) {
+	cursor, limit, err := a.r.page(args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers, next, err := a.r.explorer.TokenTransfers(ctx, a.chainID, toKind(args.Kind), a.address, "", cursor, limit)
+	if err != nil {
+		return nil, errInternal
+	}
+	return newConnection(a.r, transfers, next, transferCursor, func(transfer *explorer.TokenTransfer) *transferResolver {
+		return &transferResolver{r: a.r, chainID: a.chainID, transfer: transfer}
+	})
+}
+
+func (a *addressResolver) Logs(ctx context.Context, args struct {
+	Topic0 *string
+	First  *int32
+	After  *string
+}) (*connection[*logResolver], error) {
+	var topic0 string
+	if args.Topic0 != nil {
+		var err error
+		if topic0, err = hexArg("topic0", *args.Topic0, hashPattern); err != nil {
+			return nil, err
+		}
+	}
+	cursor, limit, err := a.r.page(args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, next, err := a.r.explorer.Logs(ctx, a.chainID, a.address, topic0, cursor, limit)
+	if err != nil {
+		return nil, errInternal
+	}
+	return newConnection(a.r, logs, next, func(log *explorer.Log) explorer.Cursor {
+		return explorer.Cursor{ID: log.ID}
+	}, func(log *explorer.Log) *logResolver {
+		return &logResolver{r: a.r, chainID: a.chainID, log: log}
+	})
+}
+
+func (a *addressResolver) Holdings(ctx context.Context, args struct {
+	Kind  string
+	First *int32
+	After *string
+}) (*connection[*holdingResolver], error) {
+	cursor, limit, err := a.r.page(args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	holdings, next, err := a.r.explorer.Holdings(ctx, a.chainID, toKind(args.Kind), a.address, cursor, limit)
+	if err != nil {
+		return nil, errInternal
+	}
+	return newConnection(a.r, holdings, next, func(holding *explorer.Holding) explorer.Cursor {
+		return explorer.Cursor{ID: holding.ID}
+	}, func(holding *explorer.Holding) *holdingResolver {
+		return &holdingResolver{r: a.r, chainID: a.chainID, holding: holding}
+	})
+}
+
+type holdingResolver struct {
+	r       *Resolver
+	chainID int64
+	holding *explorer.Holding
+}
+
+func (h *holdingResolver) Kind() string            { return fromKind(h.holding.Kind) }
+func (h *holdingResolver) ContractAddress() string { return h.holding.Hash }
+func (h *holdingResolver) TokenID() *string        { return optional(h.holding.TokenID) }
+func (h *holdingResolver) Balance() string         { return h.holding.Balance }
+
+func (h *holdingResolver) Contract(ctx context.Context) (*contractResolver, error) {
+	return h.r.contract(ctx, h.chainID, h.holding.Hash)
+}
+
+func (h *holdingResolver) Nft() *nftResolver {
+	if h.holding.Kind == explorer.KindErc20 {
+		return nil
+	}
+	return &nftResolver{r: h.r, chainID: h.chainID, ref: explorer.NFTRef{Kind: h.holding.Kind, Hash: h.holding.Hash, TokenID: h.holding.TokenID}}
+}
+
+type holderResolver struct {
+	holder *explorer.Holder
+}
+
+func newHolderResolver(holder *explorer.Holder) *holderResolver {
+	return &holderResolver{holder: holder}
+}
+
+func (h *holderResolver) Address() string  { return h.holder.Address }
+func (h *holderResolver) TokenID() *string { return optional(h.holder.TokenID) }
+func (h *holderResolver) Balance() string  { return h.holder.Balance }
+
+func transferCursor(transfer *explorer.TokenTransfer) explorer.Cursor {
+	return explorer.Cursor{ID: transfer.ID}
+}
+
+// holderCursor erc20 보유자는 잔액 순이라 잔액도 cursor 에 넣는다
+func holderCursor(holder *explorer.Holder) explorer.Cursor {
+	return explorer.Cursor{ID: holder.ID, Balance: holder.Balance}
+}
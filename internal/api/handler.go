@@ -0,0 +1,338 @@
+package api
+
+import (
+	"blockchain-tracking/internal/api/gql"
+	"blockchain-tracking/internal/core/domain/explorer"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+func (s *Server) routes() {
+	s.mux.HandleFunc("GET /openapi.yaml", s.spec)
+	s.mux.Handle("POST /graphql", gql.NewHandler(s.explorer, s.cursor))
+
+	s.mux.HandleFunc("GET /v1/chains/{chainId}/blocks/{block}", s.block)
+	s.mux.HandleFunc("GET /v1/chains/{chainId}/transactions/{hash}", s.transaction)
+
+	s.mux.HandleFunc("GET /v1/chains/{chainId}/addresses/{address}", s.address)
+	s.mux.HandleFunc("GET /v1/chains/{chainId}/addresses/{address}/transactions", s.addressTransactions)
+	s.mux.HandleFunc("GET /v1/chains/{chainId}/addresses/{address}/transfers", s.addressTransfers)
+	s.mux.HandleFunc("GET /v1/chains/{chainId}/addresses/{address}/logs", s.addressLogs)
+	s.mux.HandleFunc("GET /v1/chains/{chainId}/addresses/{address}/holdings", s.addressHoldings)
+
+	s.mux.HandleFunc("GET /v1/chains/{chainId}/tokens/{token}", s.token)
+	s.mux.HandleFunc("GET /v1/chains/{chainId}/tokens/{token}/transfers", s.tokenTransfers)
+	s.mux.HandleFunc("GET /v1/chains/{chainId}/tokens/{token}/holders", s.tokenHolders)
+	s.mux.HandleFunc("GET /v1/chains/{chainId}/tokens/{token}/nfts/{tokenId}/owners", s.nftOwners)
+
+	s.mux.HandleFunc("GET /v1/chains/{chainId}/stream", s.subscribe)
+}
+
+func (s *Server) spec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(openAPISpec)
+}
+
+// block {block} 은 블록 번호 또는 0x 블록 해시
+func (s *Server) block(w http.ResponseWriter, r *http.Request) {
+	chain, err := chainID(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	var number int64
+	var hash string
+	value := r.PathValue("block")
+	if hashPattern.MatchString(value) {
+		hash, _ = hexValue("block hash", value, hashPattern)
+	} else if number, err = strconv.ParseInt(value, 10, 64); err != nil || number < 0 {
+		s.writeError(w, r, fmt.Errorf("%w: block must be a number or a block hash", errBadRequest))
+		return
+	}
+
+	block, err := s.explorer.Block(r.Context(), chain, number, hash)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	s.writeJSON(w, http.StatusOK, block)
+}
+
+func (s *Server) transaction(w http.ResponseWriter, r *http.Request) {
+	chain, err := chainID(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	hash, err := hexValue("transaction hash", r.PathValue("hash"), hashPattern)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	detail, err := s.explorer.TransactionDetail(r.Context(), chain, hash)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	s.writeJSON(w, http.StatusOK, detail)
+}
+
+// address 코인 잔액
+func (s *Server) address(w http.ResponseWriter, r *http.Request) {
+	chain, address, err := s.addressParams(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	balance, err := s.explorer.NativeBalance(r.Context(), chain, address)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	s.writeJSON(w, http.StatusOK, map[string]string{"address": address, "balance": balance})
+}
+
+func (s *Server) addressTransactions(w http.ResponseWriter, r *http.Request) {
+	chain, address, err := s.addressParams(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	cursor, limit, err := s.page(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	transactions, next, err := s.explorer.Transactions(r.Context(), chain, address, cursor, limit)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writePage(s, w, r, transactions, next)
+}
+
+// addressTransfers ?kind= (기본 erc20)
+func (s *Server) addressTransfers(w http.ResponseWriter, r *http.Request) {
+	chain, address, err := s.addressParams(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	kind, err := s.kindParam(r, explorer.KindErc20)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	cursor, limit, err := s.page(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	transfers, next, err := s.explorer.TokenTransfers(r.Context(), chain, kind, address, "", cursor, limit)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writePage(s, w, r, transfers, next)
+}
+
+// addressLogs ?topic0= 로 이벤트 종류를 거를 수 있다
+func (s *Server) addressLogs(w http.ResponseWriter, r *http.Request) {
+	chain, address, err := s.addressParams(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	var topic0 string
+	if v := r.URL.Query().Get("topic0"); v != "" {
+		if topic0, err = hexValue("topic0", v, topicPattern); err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+	}
+	cursor, limit, err := s.page(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	logs, next, err := s.explorer.Logs(r.Context(), chain, address, topic0, cursor, limit)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writePage(s, w, r, logs, next)
+}
+
+// addressHoldings ?kind= (기본 erc20)
+func (s *Server) addressHoldings(w http.ResponseWriter, r *http.Request) {
+	chain, address, err := s.addressParams(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	kind, err := s.kindParam(r, explorer.KindErc20)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	cursor, limit, err := s.page(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	holdings, next, err := s.explorer.Holdings(r.Context(), chain, kind, address, cursor, limit)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writePage(s, w, r, holdings, next)
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	chain, token, err := s.tokenParams(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	info, err := s.explorer.Token(r.Context(), chain, token)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	s.writeJSON(w, http.StatusOK, info)
+}
+
+func (s *Server) tokenTransfers(w http.ResponseWriter, r *http.Request) {
+	chain, token, err := s.tokenParams(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	kind, err := s.tokenKind(r, chain, token)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	cursor, limit, err := s.page(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	transfers, next, err := s.explorer.TokenTransfers(r.Context(), chain, kind, "", token, cursor, limit)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writePage(s, w, r, transfers, next)
+}
+
+func (s *Server) tokenHolders(w http.ResponseWriter, r *http.Request) {
+	chain, token, err := s.tokenParams(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	kind, err := s.tokenKind(r, chain, token)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	cursor, limit, err := s.page(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	holders, next, err := s.explorer.Holders(r.Context(), chain, kind, token, cursor, limit)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writePage(s, w, r, holders, next)
+}
+
+func (s *Server) nftOwners(w http.ResponseWriter, r *http.Request) {
+	chain, token, err := s.tokenParams(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	tokenID := r.PathValue("tokenId")
+	if !tokenIDPattern.MatchString(tokenID) {
+		s.writeError(w, r, fmt.Errorf("%w: invalid token id", errBadRequest))
+		return
+	}
+	kind, err := s.tokenKind(r, chain, token)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if kind == explorer.KindErc20 {
+		s.writeError(w, r, fmt.Errorf("%w: %s is not an nft contract", errBadRequest, token))
+		return
+	}
+	cursor, limit, err := s.page(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	owners, next, err := s.explorer.Owners(r.Context(), chain, kind, token, tokenID, cursor, limit)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writePage(s, w, r, owners, next)
+}
+
+func (s *Server) addressParams(r *http.Request) (int64, string, error) {
+	chain, err := chainID(r)
+	if err != nil {
+		return 0, "", err
+	}
+	address, err := hexValue("address", r.PathValue("address"), addressPattern)
+	return chain, address, err
+}
+
+func (s *Server) tokenParams(r *http.Request) (int64, string, error) {
+	chain, err := chainID(r)
+	if err != nil {
+		return 0, "", err
+	}
+	token, err := hexValue("token address", r.PathValue("token"), addressPattern)
+	return chain, token, err
+}
+
+func (s *Server) kindParam(r *http.Request, fallback explorer.Kind) (explorer.Kind, error) {
+	v := r.URL.Query().Get("kind")
+	if v == "" {
+		return fallback, nil
+	}
+	return kindValue(v)
+}
+
+// tokenKind ?kind= 가 없으면 컨트랙트 생성 시 판별한 타입을 쓴다
+func (s *Server) tokenKind(r *http.Request, chain int64, token string) (explorer.Kind, error) {
+	if v := r.URL.Query().Get("kind"); v != "" {
+		return kindValue(v)
+	}
+
+	info, err := s.explorer.Token(r.Context(), chain, token)
+	if err != nil {
+		return "", err
+	}
+	if info.Kind == "" {
+		return "", fmt.Errorf("%w: token type is unknown, pass ?kind=", errBadRequest)
+	}
+	return kindValue(string(info.Kind))
+}
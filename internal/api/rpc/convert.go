@@ -0,0 +1,123 @@
+package rpc
+
+import (
+	"blockchain-tracking/internal/api/rpc/trackerpb"
+	"blockchain-tracking/internal/core/domain/explorer"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var tokenKinds = map[explorer.Kind]trackerpb.TokenKind{
+	explorer.KindCoin:    trackerpb.TokenKind_TOKEN_KIND_COIN,
+	explorer.KindErc20:   trackerpb.TokenKind_TOKEN_KIND_ERC20,
+	explorer.KindErc721:  trackerpb.TokenKind_TOKEN_KIND_ERC721,
+	explorer.KindErc1155: trackerpb.TokenKind_TOKEN_KIND_ERC1155,
+}
+
+// fromTokenKind 모르는 값이면 빈 값
+func fromTokenKind(kind trackerpb.TokenKind) explorer.Kind {
+	for k, v := range tokenKinds {
+		if v == kind {
+			return k
+		}
+	}
+	return ""
+}
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func toBlock(chainID int64, block *explorer.Block) *trackerpb.Block {
+	res := &trackerpb.Block{
+		ChainId:          chainID,
+		Number:           block.Number,
+		Hash:             block.Hash,
+		ParentHash:       block.ParentHash,
+		Miner:            block.Miner,
+		GasLimit:         block.GasLimit,
+		GasUsed:          block.GasUsed,
+		Difficulty:       block.Difficulty,
+		TotalDifficulty:  block.TotalDifficulty,
+		TransactionsRoot: block.TransactionsRoot,
+		Timestamp:        timestamp(block.Timestamp),
+		Transactions:     make([]*trackerpb.Transaction, len(block.Transactions)),
+	}
+	for i, tx := range block.Transactions {
+		res.Transactions[i] = toTransaction(tx)
+	}
+	return res
+}
+
+func toTransaction(tx *explorer.Transaction) *trackerpb.Transaction {
+	return &trackerpb.Transaction{
+		Hash:             tx.Hash,
+		BlockHash:        tx.BlockHash,
+		BlockNumber:      tx.BlockNumber,
+		TransactionIndex: tx.TransactionIndex,
+		From:             tx.From,
+		To:               tx.To,
+		ContractAddress:  tx.ContractAddress,
+		Value:            tx.Value,
+		Gas:              tx.Gas,
+		GasPrice:         tx.GasPrice,
+		GasUsed:          tx.GasUsed,
+		Nonce:            tx.Nonce,
+		Status:           int32(tx.Status),
+		Type:             int32(tx.Type),
+		Timestamp:        timestamp(tx.Timestamp),
+	}
+}
+
+func toTransactionDetail(detail *explorer.TransactionDetail) *trackerpb.TransactionDetail {
+	res := &trackerpb.TransactionDetail{
+		Transaction: toTransaction(detail.Transaction),
+		Input:       detail.Input,
+		Transfers:   make([]*trackerpb.Transfer, len(detail.Transfers)),
+		Logs:        make([]*trackerpb.Log, len(detail.Logs)),
+	}
+	for i, transfer := range detail.Transfers {
+		res.Transfers[i] = toTransfer(transfer)
+	}
+	for i, log := range detail.Logs {
+		res.Logs[i] = toLog(log)
+	}
+	return res
+}
+
+func toLog(log *explorer.Log) *trackerpb.Log {
+	return &trackerpb.Log{
+		Address:          log.Address,
+		BlockHash:        log.BlockHash,
+		BlockNumber:      log.BlockNumber,
+		TransactionHash:  log.TransactionHash,
+		TransactionIndex: log.TransactionIndex,
+		LogIndex:         log.LogIndex,
+		Topics:           log.Topics,
+		Data:             log.Data,
+		Removed:          log.Removed,
+		Timestamp:        timestamp(log.Timestamp),
+	}
+}
+
+func toTransfer(transfer *explorer.TokenTransfer) *trackerpb.Transfer {
+	return &trackerpb.Transfer{
+		Kind:            tokenKinds[transfer.Kind],
+		ContractAddress: transfer.ContractAddress,
+		TransactionHash: transfer.TransactionHash,
+		LogIndex:        transfer.LogIndex,
+		BatchIndex:      transfer.BatchIndex,
+		From:            transfer.From,
+		To:              transfer.To,
+		TokenId:         transfer.TokenID,
+		Amount:          transfer.Amount,
+		Function:        transfer.Function,
+		Name:            transfer.Name,
+		Symbol:          transfer.Symbol,
+		Timestamp:       timestamp(transfer.Timestamp),
+	}
+}
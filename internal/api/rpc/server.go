@@ -0,0 +1,317 @@
+package rpc
+
+import (
+	"blockchain-tracking/internal/api/rpc/trackerpb"
+	"blockchain-tracking/internal/core/domain/explorer"
+	"blockchain-tracking/internal/core/domain/stream"
+	"blockchain-tracking/internal/database/postgresql"
+	"blockchain-tracking/internal/logger"
+	"context"
+	"errors"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	addressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+	hashPattern    = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+)
+
+// Server REST API 와 같은 explorer/stream 서비스를 쓰는 gRPC 서버
+type Server struct {
+	trackerpb.UnimplementedTrackerServer
+
+	explorer *explorer.Service
+	stream   *stream.Service
+	cursor   *postgresql.Cursor
+	l        logger.Logger
+}
+
+func NewServer(explorerService *explorer.Service, streamService *stream.Service, cursor *postgresql.Cursor, l logger.Logger) *Server {
+	return &Server{
+		explorer: explorerService,
+		stream:   streamService,
+		cursor:   cursor,
+		l:        l,
+	}
+}
+
+// Run ctx 가 끝나면 처리 중인 호출을 기다렸다가 종료한다. 구독 스트림은 끝나지 않으므로 10초 뒤 끊는다
+func (s *Server) Run(ctx context.Context, addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	server := grpc.NewServer()
+	trackerpb.RegisterTrackerServer(server, s)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(listener)
+	}()
+	s.l.Info("grpc server listening", logger.Field{Key: "addr", Value: addr})
+
+	select {
+	case err = <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(10 * time.Second):
+		server.Stop()
+	}
+	return nil
+}
+
+func (s *Server) GetBlock(ctx context.Context, req *trackerpb.GetBlockRequest) (*trackerpb.Block, error) {
+	if req.ChainId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid chain_id")
+	}
+
+	var number int64
+	var hash string
+	switch block := req.Block.(type) {
+	case *trackerpb.GetBlockRequest_Number:
+		if block.Number < 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid number")
+		}
+		number = block.Number
+	case *trackerpb.GetBlockRequest_Hash:
+		var err error
+		if hash, err = hexValue("hash", block.Hash, hashPattern); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "number or hash is required")
+	}
+
+	block, err := s.explorer.Block(ctx, req.ChainId, number, hash)
+	if err != nil {
+		return nil, s.statusError(err)
+	}
+	return toBlock(req.ChainId, block), nil
+}
+
+func (s *Server) GetTransaction(ctx context.Context, req *trackerpb.GetTransactionRequest) (*trackerpb.TransactionDetail, error) {
+	if req.ChainId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid chain_id")
+	}
+	hash, err := hexValue("hash", req.Hash, hashPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	detail, err := s.explorer.TransactionDetail(ctx, req.ChainId, hash)
+	if err != nil {
+		return nil, s.statusError(err)
+	}
+	return toTransactionDetail(detail), nil
+}
+
+func (s *Server) GetAddressTransfers(ctx context.Context, req *trackerpb.GetAddressTransfersRequest) (*trackerpb.GetAddressTransfersResponse, error) {
+	if req.ChainId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid chain_id")
+	}
+	address, err := hexValue("address", req.Address, addressPattern)
+	if err != nil {
+		return nil, err
+	}
+	kind := explorer.KindErc20
+	if req.Kind != trackerpb.TokenKind_TOKEN_KIND_UNSPECIFIED {
+		if kind = fromTokenKind(req.Kind); kind == "" || kind == explorer.KindCoin {
+			return nil, status.Error(codes.InvalidArgument, "kind must be ERC20, ERC721 or ERC1155")
+		}
+	}
+	if req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid limit")
+	}
+	cursor, err := explorer.DecodeCursor(s.cursor, req.Cursor)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+
+	transfers, next, err := s.explorer.TokenTransfers(ctx, req.ChainId, kind, address, "", cursor, req.Limit)
+	if err != nil {
+		return nil, s.statusError(err)
+	}
+
+	res := &trackerpb.GetAddressTransfersResponse{Transfers: make([]*trackerpb.Transfer, len(transfers))}
+	for i, transfer := range transfers {
+		res.Transfers[i] = toTransfer(transfer)
+	}
+	if next != nil {
+		if res.NextCursor, err = next.Encode(s.cursor); err != nil {
+			return nil, s.statusError(err)
+		}
+	}
+	return res, nil
+}
+
+// SubscribeTransfers 재개 위치가 있으면 DB 에서 재전송한 뒤 새 블록을 보낸다. 블록마다 TYPE_BLOCK 으로 끝난다
+func (s *Server) SubscribeTransfers(req *trackerpb.SubscribeTransfersRequest, srv grpc.ServerStreamingServer[trackerpb.TransferEvent]) error {
+	filter, from, err := s.subscribeParams(req)
+	if err != nil {
+		return err
+	}
+	ctx := srv.Context()
+
+	// 재전송 중에 저장된 블록을 놓치지 않게 먼저 구독한다
+	sub := s.stream.Subscribe(filter)
+	defer s.stream.Unsubscribe(sub)
+
+	sent := int64(-1)
+	send := func(batch *stream.Batch) error {
+		return s.sendBatch(srv, batch, &sent)
+	}
+	if from >= 0 {
+		if _, err = s.stream.Replay(ctx, filter, from, send); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return s.statusError(err)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sub.Dropped():
+			return status.Error(codes.Unavailable, stream.ErrDropped.Error())
+		case batch := <-sub.Batches():
+			// 재전송에서 이미 보낸 블록
+			if !batch.Removed && batch.BlockNumber <= sent {
+				continue
+			}
+			if err = send(batch); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sendBatch 지워진 블록이면 cursor 를 그 앞 블록으로 되돌린다
+func (s *Server) sendBatch(srv grpc.ServerStreamingServer[trackerpb.TransferEvent], batch *stream.Batch, sent *int64) error {
+	for _, event := range batch.Events {
+		if event.Transfer == nil {
+			continue
+		}
+		err := srv.Send(&trackerpb.TransferEvent{
+			Type:        trackerpb.TransferEvent_TYPE_TRANSFER,
+			ChainId:     event.ChainID,
+			BlockNumber: event.BlockNumber,
+			BlockHash:   event.BlockHash,
+			Timestamp:   timestamp(event.Timestamp),
+			Transfer:    toTransfer(event.Transfer),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	checkpoint := batch.BlockNumber
+	eventType := trackerpb.TransferEvent_TYPE_BLOCK
+	if batch.Removed {
+		eventType = trackerpb.TransferEvent_TYPE_REMOVED
+		if batch.BlockNumber <= *sent {
+			checkpoint = batch.BlockNumber - 1
+		} else {
+			checkpoint = *sent
+		}
+	}
+	cursor, err := explorer.Cursor{ID: checkpoint}.Encode(s.cursor)
+	if err != nil {
+		return s.statusError(err)
+	}
+	err = srv.Send(&trackerpb.TransferEvent{
+		Type:        eventType,
+		ChainId:     batch.ChainID,
+		BlockNumber: batch.BlockNumber,
+		BlockHash:   batch.BlockHash,
+		Timestamp:   timestamp(batch.Timestamp),
+		Cursor:      cursor,
+	})
+	if err != nil {
+		return err
+	}
+	*sent = checkpoint
+	return nil
+}
+
+// subscribeParams 재전송 시작 블록. 재개 위치가 없으면 -1 (새 블록만)
+func (s *Server) subscribeParams(req *trackerpb.SubscribeTransfersRequest) (stream.Filter, int64, error) {
+	if req.ChainId <= 0 {
+		return stream.Filter{}, 0, status.Error(codes.InvalidArgument, "invalid chain_id")
+	}
+	filter := stream.Filter{ChainID: req.ChainId}
+
+	var err error
+	if req.Address != "" {
+		if filter.Address, err = hexValue("address", req.Address, addressPattern); err != nil {
+			return filter, 0, err
+		}
+	}
+	if req.Contract != "" {
+		if filter.Contract, err = hexValue("contract", req.Contract, addressPattern); err != nil {
+			return filter, 0, err
+		}
+	}
+	// 블록 이벤트는 구독자마다 직접 만들어 보내므로 전송 종류만 고른다
+	filter.Types = map[stream.Type]bool{stream.TypeCoin: true, stream.TypeErc20: true, stream.TypeErc721: true, stream.TypeErc1155: true}
+	if len(req.Kinds) > 0 {
+		filter.Types = make(map[stream.Type]bool)
+		for _, k := range req.Kinds {
+			kind := fromTokenKind(k)
+			if kind == "" {
+				return filter, 0, status.Error(codes.InvalidArgument, "invalid kind")
+			}
+			filter.Types[stream.Type(kind)] = true
+		}
+	}
+
+	switch resume := req.Resume.(type) {
+	case *trackerpb.SubscribeTransfersRequest_FromBlock:
+		if resume.FromBlock < 0 {
+			return filter, 0, status.Error(codes.InvalidArgument, "invalid from_block")
+		}
+		return filter, resume.FromBlock, nil
+	case *trackerpb.SubscribeTransfersRequest_Cursor:
+		cursor, err := explorer.DecodeCursor(s.cursor, resume.Cursor)
+		if err != nil || resume.Cursor == "" {
+			return filter, 0, status.Error(codes.InvalidArgument, "invalid cursor")
+		}
+		return filter, cursor.ID + 1, nil
+	}
+	return filter, -1, nil
+}
+
+// statusError 없는 항목은 NOT_FOUND, 나머지는 로그만 남기고 INTERNAL
+func (s *Server) statusError(err error) error {
+	if errors.Is(err, explorer.ErrNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	s.l.Error("grpc request", logger.Field{Key: "error", Value: err.Error()})
+	return status.Error(codes.Internal, "internal error")
+}
+
+// hexValue 주소/해시를 DB 와 같은 소문자 0x hex 로 맞춘다
+func hexValue(name, value string, pattern *regexp.Regexp) (string, error) {
+	if !pattern.MatchString(value) {
+		return "", status.Errorf(codes.InvalidArgument, "invalid %s", name)
+	}
+	return strings.ToLower(value), nil
+}
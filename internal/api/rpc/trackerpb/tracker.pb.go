@@ -0,0 +1,1457 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: tracker/v1/tracker.proto
+
+package trackerpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TokenKind int32
+
+const (
+	TokenKind_TOKEN_KIND_UNSPECIFIED TokenKind = 0
+	TokenKind_TOKEN_KIND_COIN        TokenKind = 1
+	TokenKind_TOKEN_KIND_ERC20       TokenKind = 2
+	TokenKind_TOKEN_KIND_ERC721      TokenKind = 3
+	TokenKind_TOKEN_KIND_ERC1155     TokenKind = 4
+)
+
+// Enum value maps for TokenKind.
+var (
+	TokenKind_name = map[int32]string{
+		0: "TOKEN_KIND_UNSPECIFIED",
+		1: "TOKEN_KIND_COIN",
+		2: "TOKEN_KIND_ERC20",
+		3: "TOKEN_KIND_ERC721",
+		4: "TOKEN_KIND_ERC1155",
+	}
+	TokenKind_value = map[string]int32{
+		"TOKEN_KIND_UNSPECIFIED": 0,
+		"TOKEN_KIND_COIN":        1,
+		"TOKEN_KIND_ERC20":       2,
+		"TOKEN_KIND_ERC721":      3,
+		"TOKEN_KIND_ERC1155":     4,
+	}
+)
+
+func (x TokenKind) Enum() *TokenKind {
+	p := new(TokenKind)
+	*p = x
+	return p
+}
+
+func (x TokenKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TokenKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_tracker_v1_tracker_proto_enumTypes[0].Descriptor()
+}
+
+func (TokenKind) Type() protoreflect.EnumType {
+	return &file_tracker_v1_tracker_proto_enumTypes[0]
+}
+
+func (x TokenKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TokenKind.Descriptor instead.
+func (TokenKind) EnumDescriptor() ([]byte, []int) {
+	return file_tracker_v1_tracker_proto_rawDescGZIP(), []int{0}
+}
+
+type TransferEvent_Type int32
+
+const (
+	TransferEvent_TYPE_UNSPECIFIED TransferEvent_Type = 0
+	TransferEvent_TYPE_TRANSFER    TransferEvent_Type = 1
+	// All transfers of the block have been sent.
+	TransferEvent_TYPE_BLOCK TransferEvent_Type = 2
+	// A stored block was deleted (reindex or rollback). Its transfers are no longer valid.
+	TransferEvent_TYPE_REMOVED TransferEvent_Type = 3
+)
+
+// Enum value maps for TransferEvent_Type.
+var (
+	TransferEvent_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_TRANSFER",
+		2: "TYPE_BLOCK",
+		3: "TYPE_REMOVED",
+	}
+	TransferEvent_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_TRANSFER":    1,
+		"TYPE_BLOCK":       2,
+		"TYPE_REMOVED":     3,
+	}
+)
+
+func (x TransferEvent_Type) Enum() *TransferEvent_Type {
+	p := new(TransferEvent_Type)
+	*p = x
+	return p
+}
+
+func (x TransferEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransferEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_tracker_v1_tracker_proto_enumTypes[1].Descriptor()
+}
+
+func (TransferEvent_Type) Type() protoreflect.EnumType {
+	return &file_tracker_v1_tracker_proto_enumTypes[1]
+}
+
+func (x TransferEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransferEvent_Type.Descriptor instead.
+func (TransferEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_tracker_v1_tracker_proto_rawDescGZIP(), []int{10, 0}
+}
+
+type Block struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ChainId          int64                  `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Number           int64                  `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	Hash             string                 `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	ParentHash       string                 `protobuf:"bytes,4,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
+	Miner            string                 `protobuf:"bytes,5,opt,name=miner,proto3" json:"miner,omitempty"`
+	GasLimit         int64                  `protobuf:"varint,6,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	GasUsed          int64                  `protobuf:"varint,7,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	Difficulty       string                 `protobuf:"bytes,8,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	TotalDifficulty  string                 `protobuf:"bytes,9,opt,name=total_difficulty,json=totalDifficulty,proto3" json:"total_difficulty,omitempty"`
+	TransactionsRoot string                 `protobuf:"bytes,10,opt,name=transactions_root,json=transactionsRoot,proto3" json:"transactions_root,omitempty"`
+	Timestamp        *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Transactions     []*Transaction         `protobuf:"bytes,12,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Block) Reset() {
+	*x = Block{}
+	mi := &file_tracker_v1_tracker_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Block) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Block) ProtoMessage() {}
+
+func (x *Block) ProtoReflect() protoreflect.Message {
+	mi := &file_tracker_v1_tracker_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Block.ProtoReflect.Descriptor instead.
+func (*Block) Descriptor() ([]byte, []int) {
+	return file_tracker_v1_tracker_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Block) GetChainId() int64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *Block) GetNumber() int64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Block) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *Block) GetParentHash() string {
+	if x != nil {
+		return x.ParentHash
+	}
+	return ""
+}
+
+func (x *Block) GetMiner() string {
+	if x != nil {
+		return x.Miner
+	}
+	return ""
+}
+
+func (x *Block) GetGasLimit() int64 {
+	if x != nil {
+		return x.GasLimit
+	}
+	return 0
+}
+
+func (x *Block) GetGasUsed() int64 {
+	if x != nil {
+		return x.GasUsed
+	}
+	return 0
+}
+
+func (x *Block) GetDifficulty() string {
+	if x != nil {
+		return x.Difficulty
+	}
+	return ""
+}
+
+func (x *Block) GetTotalDifficulty() string {
+	if x != nil {
+		return x.TotalDifficulty
+	}
+	return ""
+}
+
+func (x *Block) GetTransactionsRoot() string {
+	if x != nil {
+		return x.TransactionsRoot
+	}
+	return ""
+}
+
+func (x *Block) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Block) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type Transaction struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Hash             string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	BlockHash        string                 `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockNumber      int64                  `protobuf:"varint,3,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	TransactionIndex int64                  `protobuf:"varint,4,opt,name=transaction_index,json=transactionIndex,proto3" json:"transaction_index,omitempty"`
+	From             string                 `protobuf:"bytes,5,opt,name=from,proto3" json:"from,omitempty"`
+	To               string                 `protobuf:"bytes,6,opt,name=to,proto3" json:"to,omitempty"`
+	ContractAddress  string                 `protobuf:"bytes,7,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	Value            string                 `protobuf:"bytes,8,opt,name=value,proto3" json:"value,omitempty"`
+	Gas              int64                  `protobuf:"varint,9,opt,name=gas,proto3" json:"gas,omitempty"`
+	GasPrice         string                 `protobuf:"bytes,10,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+	GasUsed          int64                  `protobuf:"varint,11,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	Nonce            int64                  `protobuf:"varint,12,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Status           int32                  `protobuf:"varint,13,opt,name=status,proto3" json:"status,omitempty"`
+	Type             int32                  `protobuf:"varint,14,opt,name=type,proto3" json:"type,omitempty"`
+	Timestamp        *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_tracker_v1_tracker_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_tracker_v1_tracker_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_tracker_v1_tracker_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Transaction) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *Transaction) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *Transaction) GetBlockNumber() int64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *Transaction) GetTransactionIndex() int64 {
+	if x != nil {
+		return x.TransactionIndex
+	}
+	return 0
+}
+
+func (x *Transaction) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Transaction) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *Transaction) GetContractAddress() string {
+	if x != nil {
+		return x.ContractAddress
+	}
+	return ""
+}
+
+func (x *Transaction) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Transaction) GetGas() int64 {
+	if x != nil {
+		return x.Gas
+	}
+	return 0
+}
+
+func (x *Transaction) GetGasPrice() string {
+	if x != nil {
+		return x.GasPrice
+	}
+	return ""
+}
+
+func (x *Transaction) GetGasUsed() int64 {
+	if x != nil {
+		return x.GasUsed
+	}
+	return 0
+}
+
+func (x *Transaction) GetNonce() int64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *Transaction) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *Transaction) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *Transaction) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type Log struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Address          string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	BlockHash        string                 `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockNumber      int64                  `protobuf:"varint,3,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	TransactionHash  string                 `protobuf:"bytes,4,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	TransactionIndex int64                  `protobuf:"varint,5,opt,name=transaction_index,json=transactionIndex,proto3" json:"transaction_index,omitempty"`
+	LogIndex         int64                  `protobuf:"varint,6,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	Topics           []string               `protobuf:"bytes,7,rep,name=topics,proto3" json:"topics,omitempty"`
+	Data             string                 `protobuf:"bytes,8,opt,name=data,proto3" json:"data,omitempty"`
+	Removed          bool                   `protobuf:"varint,9,opt,name=removed,proto3" json:"removed,omitempty"`
+	Timestamp        *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Log) Reset() {
+	*x = Log{}
+	mi := &file_tracker_v1_tracker_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Log) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Log) ProtoMessage() {}
+
+func (x *Log) ProtoReflect() protoreflect.Message {
+	mi := &file_tracker_v1_tracker_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Log.ProtoReflect.Descriptor instead.
+func (*Log) Descriptor() ([]byte, []int) {
+	return file_tracker_v1_tracker_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Log) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Log) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *Log) GetBlockNumber() int64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *Log) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+func (x *Log) GetTransactionIndex() int64 {
+	if x != nil {
+		return x.TransactionIndex
+	}
+	return 0
+}
+
+func (x *Log) GetLogIndex() int64 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
+func (x *Log) GetTopics() []string {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *Log) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *Log) GetRemoved() bool {
+	if x != nil {
+		return x.Removed
+	}
+	return false
+}
+
+func (x *Log) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type Transfer struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Kind  TokenKind              `protobuf:"varint,1,opt,name=kind,proto3,enum=tracker.v1.TokenKind" json:"kind,omitempty"`
+	// Empty for coin transfers.
+	ContractAddress string                 `protobuf:"bytes,2,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	TransactionHash string                 `protobuf:"bytes,3,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	LogIndex        int64                  `protobuf:"varint,4,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	BatchIndex      int32                  `protobuf:"varint,5,opt,name=batch_index,json=batchIndex,proto3" json:"batch_index,omitempty"`
+	From            string                 `protobuf:"bytes,6,opt,name=from,proto3" json:"from,omitempty"`
+	To              string                 `protobuf:"bytes,7,opt,name=to,proto3" json:"to,omitempty"`
+	TokenId         string                 `protobuf:"bytes,8,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	Amount          string                 `protobuf:"bytes,9,opt,name=amount,proto3" json:"amount,omitempty"`
+	Function        string                 `protobuf:"bytes,10,opt,name=function,proto3" json:"function,omitempty"`
+	Name            string                 `protobuf:"bytes,11,opt,name=name,proto3" json:"name,omitempty"`
+	Symbol          string                 `protobuf:"bytes,12,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Timestamp       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Transfer) Reset() {
+	*x = Transfer{}
+	mi := &file_tracker_v1_tracker_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transfer) ProtoMessage() {}
+
+func (x *Transfer) ProtoReflect() protoreflect.Message {
+	mi := &file_tracker_v1_tracker_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transfer.ProtoReflect.Descriptor instead.
+func (*Transfer) Descriptor() ([]byte, []int) {
+	return file_tracker_v1_tracker_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Transfer) GetKind() TokenKind {
+	if x != nil {
+		return x.Kind
+	}
+	return TokenKind_TOKEN_KIND_UNSPECIFIED
+}
+
+func (x *Transfer) GetContractAddress() string {
+	if x != nil {
+		return x.ContractAddress
+	}
+	return ""
+}
+
+func (x *Transfer) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+func (x *Transfer) GetLogIndex() int64 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
+func (x *Transfer) GetBatchIndex() int32 {
+	if x != nil {
+		return x.BatchIndex
+	}
+	return 0
+}
+
+func (x *Transfer) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Transfer) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *Transfer) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+func (x *Transfer) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Transfer) GetFunction() string {
+	if x != nil {
+		return x.Function
+	}
+	return ""
+}
+
+func (x *Transfer) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Transfer) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Transfer) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type GetBlockRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ChainId int64                  `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// Types that are valid to be assigned to Block:
+	//
+	//	*GetBlockRequest_Number
+	//	*GetBlockRequest_Hash
+	Block         isGetBlockRequest_Block `protobuf_oneof:"block"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockRequest) Reset() {
+	*x = GetBlockRequest{}
+	mi := &file_tracker_v1_tracker_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockRequest) ProtoMessage() {}
+
+func (x *GetBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tracker_v1_tracker_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockRequest.ProtoReflect.Descriptor instead.
+func (*GetBlockRequest) Descriptor() ([]byte, []int) {
+	return file_tracker_v1_tracker_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetBlockRequest) GetChainId() int64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *GetBlockRequest) GetBlock() isGetBlockRequest_Block {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *GetBlockRequest) GetNumber() int64 {
+	if x != nil {
+		if x, ok := x.Block.(*GetBlockRequest_Number); ok {
+			return x.Number
+		}
+	}
+	return 0
+}
+
+func (x *GetBlockRequest) GetHash() string {
+	if x != nil {
+		if x, ok := x.Block.(*GetBlockRequest_Hash); ok {
+			return x.Hash
+		}
+	}
+	return ""
+}
+
+type isGetBlockRequest_Block interface {
+	isGetBlockRequest_Block()
+}
+
+type GetBlockRequest_Number struct {
+	Number int64 `protobuf:"varint,2,opt,name=number,proto3,oneof"`
+}
+
+type GetBlockRequest_Hash struct {
+	Hash string `protobuf:"bytes,3,opt,name=hash,proto3,oneof"`
+}
+
+func (*GetBlockRequest_Number) isGetBlockRequest_Block() {}
+
+func (*GetBlockRequest_Hash) isGetBlockRequest_Block() {}
+
+type GetTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChainId       int64                  `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Hash          string                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionRequest) Reset() {
+	*x = GetTransactionRequest{}
+	mi := &file_tracker_v1_tracker_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionRequest) ProtoMessage() {}
+
+func (x *GetTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tracker_v1_tracker_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_tracker_v1_tracker_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetTransactionRequest) GetChainId() int64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *GetTransactionRequest) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type TransactionDetail struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Transaction *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	// Empty when pruned by the retention policy.
+	Input         string      `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	Transfers     []*Transfer `protobuf:"bytes,3,rep,name=transfers,proto3" json:"transfers,omitempty"`
+	Logs          []*Log      `protobuf:"bytes,4,rep,name=logs,proto3" json:"logs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionDetail) Reset() {
+	*x = TransactionDetail{}
+	mi := &file_tracker_v1_tracker_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionDetail) ProtoMessage() {}
+
+func (x *TransactionDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_tracker_v1_tracker_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionDetail.ProtoReflect.Descriptor instead.
+func (*TransactionDetail) Descriptor() ([]byte, []int) {
+	return file_tracker_v1_tracker_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TransactionDetail) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *TransactionDetail) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *TransactionDetail) GetTransfers() []*Transfer {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+func (x *TransactionDetail) GetLogs() []*Log {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+type GetAddressTransfersRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ChainId int64                  `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Address string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// ERC20, ERC721 or ERC1155. Defaults to ERC20.
+	Kind TokenKind `protobuf:"varint,3,opt,name=kind,proto3,enum=tracker.v1.TokenKind" json:"kind,omitempty"`
+	// Page size, default 20, at most 100.
+	Limit int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_cursor of the previous page. Empty for the first page.
+	Cursor        string `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAddressTransfersRequest) Reset() {
+	*x = GetAddressTransfersRequest{}
+	mi := &file_tracker_v1_tracker_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAddressTransfersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAddressTransfersRequest) ProtoMessage() {}
+
+func (x *GetAddressTransfersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tracker_v1_tracker_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAddressTransfersRequest.ProtoReflect.Descriptor instead.
+func (*GetAddressTransfersRequest) Descriptor() ([]byte, []int) {
+	return file_tracker_v1_tracker_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetAddressTransfersRequest) GetChainId() int64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *GetAddressTransfersRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *GetAddressTransfersRequest) GetKind() TokenKind {
+	if x != nil {
+		return x.Kind
+	}
+	return TokenKind_TOKEN_KIND_UNSPECIFIED
+}
+
+func (x *GetAddressTransfersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetAddressTransfersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type GetAddressTransfersResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Transfers []*Transfer            `protobuf:"bytes,1,rep,name=transfers,proto3" json:"transfers,omitempty"`
+	// Empty on the last page.
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAddressTransfersResponse) Reset() {
+	*x = GetAddressTransfersResponse{}
+	mi := &file_tracker_v1_tracker_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAddressTransfersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAddressTransfersResponse) ProtoMessage() {}
+
+func (x *GetAddressTransfersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tracker_v1_tracker_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAddressTransfersResponse.ProtoReflect.Descriptor instead.
+func (*GetAddressTransfersResponse) Descriptor() ([]byte, []int) {
+	return file_tracker_v1_tracker_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetAddressTransfersResponse) GetTransfers() []*Transfer {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+func (x *GetAddressTransfersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type SubscribeTransfersRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ChainId int64                  `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// Sender or recipient.
+	Address  string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Contract string `protobuf:"bytes,3,opt,name=contract,proto3" json:"contract,omitempty"`
+	// Transfer kinds to receive. Empty means all.
+	Kinds []TokenKind `protobuf:"varint,4,rep,packed,name=kinds,proto3,enum=tracker.v1.TokenKind" json:"kinds,omitempty"`
+	// Without a resume point only new blocks are streamed.
+	//
+	// Types that are valid to be assigned to Resume:
+	//
+	//	*SubscribeTransfersRequest_FromBlock
+	//	*SubscribeTransfersRequest_Cursor
+	Resume        isSubscribeTransfersRequest_Resume `protobuf_oneof:"resume"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeTransfersRequest) Reset() {
+	*x = SubscribeTransfersRequest{}
+	mi := &file_tracker_v1_tracker_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeTransfersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeTransfersRequest) ProtoMessage() {}
+
+func (x *SubscribeTransfersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tracker_v1_tracker_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeTransfersRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeTransfersRequest) Descriptor() ([]byte, []int) {
+	return file_tracker_v1_tracker_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SubscribeTransfersRequest) GetChainId() int64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *SubscribeTransfersRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *SubscribeTransfersRequest) GetContract() string {
+	if x != nil {
+		return x.Contract
+	}
+	return ""
+}
+
+func (x *SubscribeTransfersRequest) GetKinds() []TokenKind {
+	if x != nil {
+		return x.Kinds
+	}
+	return nil
+}
+
+func (x *SubscribeTransfersRequest) GetResume() isSubscribeTransfersRequest_Resume {
+	if x != nil {
+		return x.Resume
+	}
+	return nil
+}
+
+func (x *SubscribeTransfersRequest) GetFromBlock() int64 {
+	if x != nil {
+		if x, ok := x.Resume.(*SubscribeTransfersRequest_FromBlock); ok {
+			return x.FromBlock
+		}
+	}
+	return 0
+}
+
+func (x *SubscribeTransfersRequest) GetCursor() string {
+	if x != nil {
+		if x, ok := x.Resume.(*SubscribeTransfersRequest_Cursor); ok {
+			return x.Cursor
+		}
+	}
+	return ""
+}
+
+type isSubscribeTransfersRequest_Resume interface {
+	isSubscribeTransfersRequest_Resume()
+}
+
+type SubscribeTransfersRequest_FromBlock struct {
+	// Replay stored blocks from this number first.
+	FromBlock int64 `protobuf:"varint,5,opt,name=from_block,json=fromBlock,proto3,oneof"`
+}
+
+type SubscribeTransfersRequest_Cursor struct {
+	// Cursor of the last BLOCK event received, resumes with the next block.
+	Cursor string `protobuf:"bytes,6,opt,name=cursor,proto3,oneof"`
+}
+
+func (*SubscribeTransfersRequest_FromBlock) isSubscribeTransfersRequest_Resume() {}
+
+func (*SubscribeTransfersRequest_Cursor) isSubscribeTransfersRequest_Resume() {}
+
+type TransferEvent struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Type        TransferEvent_Type     `protobuf:"varint,1,opt,name=type,proto3,enum=tracker.v1.TransferEvent_Type" json:"type,omitempty"`
+	ChainId     int64                  `protobuf:"varint,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	BlockNumber int64                  `protobuf:"varint,3,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	BlockHash   string                 `protobuf:"bytes,4,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Timestamp   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Set for TYPE_TRANSFER.
+	Transfer *Transfer `protobuf:"bytes,6,opt,name=transfer,proto3" json:"transfer,omitempty"`
+	// Set for TYPE_BLOCK and TYPE_REMOVED: resume position for SubscribeTransfersRequest.cursor.
+	Cursor        string `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferEvent) Reset() {
+	*x = TransferEvent{}
+	mi := &file_tracker_v1_tracker_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferEvent) ProtoMessage() {}
+
+func (x *TransferEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_tracker_v1_tracker_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferEvent.ProtoReflect.Descriptor instead.
+func (*TransferEvent) Descriptor() ([]byte, []int) {
+	return file_tracker_v1_tracker_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TransferEvent) GetType() TransferEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return TransferEvent_TYPE_UNSPECIFIED
+}
+
+func (x *TransferEvent) GetChainId() int64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *TransferEvent) GetBlockNumber() int64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *TransferEvent) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *TransferEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *TransferEvent) GetTransfer() *Transfer {
+	if x != nil {
+		return x.Transfer
+	}
+	return nil
+}
+
+func (x *TransferEvent) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+var File_tracker_v1_tracker_proto protoreflect.FileDescriptor
+
+const file_tracker_v1_tracker_proto_rawDesc = "" +
+	"\n" +
+	"\x18tracker/v1/tracker.proto\x12\n" +
+	"tracker.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xac\x03\n" +
+	"\x05Block\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x03R\achainId\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\x03R\x06number\x12\x12\n" +
+	"\x04hash\x18\x03 \x01(\tR\x04hash\x12\x1f\n" +
+	"\vparent_hash\x18\x04 \x01(\tR\n" +
+	"parentHash\x12\x14\n" +
+	"\x05miner\x18\x05 \x01(\tR\x05miner\x12\x1b\n" +
+	"\tgas_limit\x18\x06 \x01(\x03R\bgasLimit\x12\x19\n" +
+	"\bgas_used\x18\a \x01(\x03R\agasUsed\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\b \x01(\tR\n" +
+	"difficulty\x12)\n" +
+	"\x10total_difficulty\x18\t \x01(\tR\x0ftotalDifficulty\x12+\n" +
+	"\x11transactions_root\x18\n" +
+	" \x01(\tR\x10transactionsRoot\x128\n" +
+	"\ttimestamp\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12;\n" +
+	"\ftransactions\x18\f \x03(\v2\x17.tracker.v1.TransactionR\ftransactions\"\xbb\x03\n" +
+	"\vTransaction\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x02 \x01(\tR\tblockHash\x12!\n" +
+	"\fblock_number\x18\x03 \x01(\x03R\vblockNumber\x12+\n" +
+	"\x11transaction_index\x18\x04 \x01(\x03R\x10transactionIndex\x12\x12\n" +
+	"\x04from\x18\x05 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x06 \x01(\tR\x02to\x12)\n" +
+	"\x10contract_address\x18\a \x01(\tR\x0fcontractAddress\x12\x14\n" +
+	"\x05value\x18\b \x01(\tR\x05value\x12\x10\n" +
+	"\x03gas\x18\t \x01(\x03R\x03gas\x12\x1b\n" +
+	"\tgas_price\x18\n" +
+	" \x01(\tR\bgasPrice\x12\x19\n" +
+	"\bgas_used\x18\v \x01(\x03R\agasUsed\x12\x14\n" +
+	"\x05nonce\x18\f \x01(\x03R\x05nonce\x12\x16\n" +
+	"\x06status\x18\r \x01(\x05R\x06status\x12\x12\n" +
+	"\x04type\x18\x0e \x01(\x05R\x04type\x128\n" +
+	"\ttimestamp\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xd6\x02\n" +
+	"\x03Log\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x02 \x01(\tR\tblockHash\x12!\n" +
+	"\fblock_number\x18\x03 \x01(\x03R\vblockNumber\x12)\n" +
+	"\x10transaction_hash\x18\x04 \x01(\tR\x0ftransactionHash\x12+\n" +
+	"\x11transaction_index\x18\x05 \x01(\x03R\x10transactionIndex\x12\x1b\n" +
+	"\tlog_index\x18\x06 \x01(\x03R\blogIndex\x12\x16\n" +
+	"\x06topics\x18\a \x03(\tR\x06topics\x12\x12\n" +
+	"\x04data\x18\b \x01(\tR\x04data\x12\x18\n" +
+	"\aremoved\x18\t \x01(\bR\aremoved\x128\n" +
+	"\ttimestamp\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xa2\x03\n" +
+	"\bTransfer\x12)\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x15.tracker.v1.TokenKindR\x04kind\x12)\n" +
+	"\x10contract_address\x18\x02 \x01(\tR\x0fcontractAddress\x12)\n" +
+	"\x10transaction_hash\x18\x03 \x01(\tR\x0ftransactionHash\x12\x1b\n" +
+	"\tlog_index\x18\x04 \x01(\x03R\blogIndex\x12\x1f\n" +
+	"\vbatch_index\x18\x05 \x01(\x05R\n" +
+	"batchIndex\x12\x12\n" +
+	"\x04from\x18\x06 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\a \x01(\tR\x02to\x12\x19\n" +
+	"\btoken_id\x18\b \x01(\tR\atokenId\x12\x16\n" +
+	"\x06amount\x18\t \x01(\tR\x06amount\x12\x1a\n" +
+	"\bfunction\x18\n" +
+	" \x01(\tR\bfunction\x12\x12\n" +
+	"\x04name\x18\v \x01(\tR\x04name\x12\x16\n" +
+	"\x06symbol\x18\f \x01(\tR\x06symbol\x128\n" +
+	"\ttimestamp\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"e\n" +
+	"\x0fGetBlockRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x03R\achainId\x12\x18\n" +
+	"\x06number\x18\x02 \x01(\x03H\x00R\x06number\x12\x14\n" +
+	"\x04hash\x18\x03 \x01(\tH\x00R\x04hashB\a\n" +
+	"\x05block\"F\n" +
+	"\x15GetTransactionRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x03R\achainId\x12\x12\n" +
+	"\x04hash\x18\x02 \x01(\tR\x04hash\"\xbd\x01\n" +
+	"\x11TransactionDetail\x129\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x17.tracker.v1.TransactionR\vtransaction\x12\x14\n" +
+	"\x05input\x18\x02 \x01(\tR\x05input\x122\n" +
+	"\ttransfers\x18\x03 \x03(\v2\x14.tracker.v1.TransferR\ttransfers\x12#\n" +
+	"\x04logs\x18\x04 \x03(\v2\x0f.tracker.v1.LogR\x04logs\"\xaa\x01\n" +
+	"\x1aGetAddressTransfersRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x03R\achainId\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12)\n" +
+	"\x04kind\x18\x03 \x01(\x0e2\x15.tracker.v1.TokenKindR\x04kind\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursor\"r\n" +
+	"\x1bGetAddressTransfersResponse\x122\n" +
+	"\ttransfers\x18\x01 \x03(\v2\x14.tracker.v1.TransferR\ttransfers\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\xde\x01\n" +
+	"\x19SubscribeTransfersRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x03R\achainId\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x1a\n" +
+	"\bcontract\x18\x03 \x01(\tR\bcontract\x12+\n" +
+	"\x05kinds\x18\x04 \x03(\x0e2\x15.tracker.v1.TokenKindR\x05kinds\x12\x1f\n" +
+	"\n" +
+	"from_block\x18\x05 \x01(\x03H\x00R\tfromBlock\x12\x18\n" +
+	"\x06cursor\x18\x06 \x01(\tH\x00R\x06cursorB\b\n" +
+	"\x06resume\"\xf7\x02\n" +
+	"\rTransferEvent\x122\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1e.tracker.v1.TransferEvent.TypeR\x04type\x12\x19\n" +
+	"\bchain_id\x18\x02 \x01(\x03R\achainId\x12!\n" +
+	"\fblock_number\x18\x03 \x01(\x03R\vblockNumber\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x04 \x01(\tR\tblockHash\x128\n" +
+	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x120\n" +
+	"\btransfer\x18\x06 \x01(\v2\x14.tracker.v1.TransferR\btransfer\x12\x16\n" +
+	"\x06cursor\x18\a \x01(\tR\x06cursor\"Q\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rTYPE_TRANSFER\x10\x01\x12\x0e\n" +
+	"\n" +
+	"TYPE_BLOCK\x10\x02\x12\x10\n" +
+	"\fTYPE_REMOVED\x10\x03*\x81\x01\n" +
+	"\tTokenKind\x12\x1a\n" +
+	"\x16TOKEN_KIND_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fTOKEN_KIND_COIN\x10\x01\x12\x14\n" +
+	"\x10TOKEN_KIND_ERC20\x10\x02\x12\x15\n" +
+	"\x11TOKEN_KIND_ERC721\x10\x03\x12\x16\n" +
+	"\x12TOKEN_KIND_ERC1155\x10\x042\xdb\x02\n" +
+	"\aTracker\x12:\n" +
+	"\bGetBlock\x12\x1b.tracker.v1.GetBlockRequest\x1a\x11.tracker.v1.Block\x12R\n" +
+	"\x0eGetTransaction\x12!.tracker.v1.GetTransactionRequest\x1a\x1d.tracker.v1.TransactionDetail\x12f\n" +
+	"\x13GetAddressTransfers\x12&.tracker.v1.GetAddressTransfersRequest\x1a'.tracker.v1.GetAddressTransfersResponse\x12X\n" +
+	"\x12SubscribeTransfers\x12%.tracker.v1.SubscribeTransfersRequest\x1a\x19.tracker.v1.TransferEvent0\x01B0Z.blockchain-tracking/internal/api/rpc/trackerpbb\x06proto3"
+
+var (
+	file_tracker_v1_tracker_proto_rawDescOnce sync.Once
+	file_tracker_v1_tracker_proto_rawDescData []byte
+)
+
+func file_tracker_v1_tracker_proto_rawDescGZIP() []byte {
+	file_tracker_v1_tracker_proto_rawDescOnce.Do(func() {
+		file_tracker_v1_tracker_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_tracker_v1_tracker_proto_rawDesc), len(file_tracker_v1_tracker_proto_rawDesc)))
+	})
+	return file_tracker_v1_tracker_proto_rawDescData
+}
+
+var file_tracker_v1_tracker_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_tracker_v1_tracker_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_tracker_v1_tracker_proto_goTypes = []any{
+	(TokenKind)(0),                      // 0: tracker.v1.TokenKind
+	(TransferEvent_Type)(0),             // 1: tracker.v1.TransferEvent.Type
+	(*Block)(nil),                       // 2: tracker.v1.Block
+	(*Transaction)(nil),                 // 3: tracker.v1.Transaction
+	(*Log)(nil),                         // 4: tracker.v1.Log
+	(*Transfer)(nil),                    // 5: tracker.v1.Transfer
+	(*GetBlockRequest)(nil),             // 6: tracker.v1.GetBlockRequest
+	(*GetTransactionRequest)(nil),       // 7: tracker.v1.GetTransactionRequest
+	(*TransactionDetail)(nil),           // 8: tracker.v1.TransactionDetail
+	(*GetAddressTransfersRequest)(nil),  // 9: tracker.v1.GetAddressTransfersRequest
+	(*GetAddressTransfersResponse)(nil), // 10: tracker.v1.GetAddressTransfersResponse
+	(*SubscribeTransfersRequest)(nil),   // 11: tracker.v1.SubscribeTransfersRequest
+	(*TransferEvent)(nil),               // 12: tracker.v1.TransferEvent
+	(*timestamppb.Timestamp)(nil),       // 13: google.protobuf.Timestamp
+}
+var file_tracker_v1_tracker_proto_depIdxs = []int32{
+	13, // 0: tracker.v1.Block.timestamp:type_name -> google.protobuf.Timestamp
+	3,  // 1: tracker.v1.Block.transactions:type_name -> tracker.v1.Transaction
+	13, // 2: tracker.v1.Transaction.timestamp:type_name -> google.protobuf.Timestamp
+	13, // 3: tracker.v1.Log.timestamp:type_name -> google.protobuf.Timestamp
+	0,  // 4: tracker.v1.Transfer.kind:type_name -> tracker.v1.TokenKind
+	13, // 5: tracker.v1.Transfer.timestamp:type_name -> google.protobuf.Timestamp
+	3,  // 6: tracker.v1.TransactionDetail.transaction:type_name -> tracker.v1.Transaction
+	5,  // 7: tracker.v1.TransactionDetail.transfers:type_name -> tracker.v1.Transfer
+	4,  // 8: tracker.v1.TransactionDetail.logs:type_name -> tracker.v1.Log
+	0,  // 9: tracker.v1.GetAddressTransfersRequest.kind:type_name -> tracker.v1.TokenKind
+	5,  // 10: tracker.v1.GetAddressTransfersResponse.transfers:type_name -> tracker.v1.Transfer
+	0,  // 11: tracker.v1.SubscribeTransfersRequest.kinds:type_name -> tracker.v1.TokenKind
+	1,  // 12: tracker.v1.TransferEvent.type:type_name -> tracker.v1.TransferEvent.Type
+	13, // 13: tracker.v1.TransferEvent.timestamp:type_name -> google.protobuf.Timestamp
+	5,  // 14: tracker.v1.TransferEvent.transfer:type_name -> tracker.v1.Transfer
+	6,  // 15: tracker.v1.Tracker.GetBlock:input_type -> tracker.v1.GetBlockRequest
+	7,  // 16: tracker.v1.Tracker.GetTransaction:input_type -> tracker.v1.GetTransactionRequest
+	9,  // 17: tracker.v1.Tracker.GetAddressTransfers:input_type -> tracker.v1.GetAddressTransfersRequest
+	11, // 18: tracker.v1.Tracker.SubscribeTransfers:input_type -> tracker.v1.SubscribeTransfersRequest
+	2,  // 19: tracker.v1.Tracker.GetBlock:output_type -> tracker.v1.Block
+	8,  // 20: tracker.v1.Tracker.GetTransaction:output_type -> tracker.v1.TransactionDetail
+	10, // 21: tracker.v1.Tracker.GetAddressTransfers:output_type -> tracker.v1.GetAddressTransfersResponse
+	12, // 22: tracker.v1.Tracker.SubscribeTransfers:output_type -> tracker.v1.TransferEvent
+	19, // [19:23] is the sub-list for method output_type
+	15, // [15:19] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_tracker_v1_tracker_proto_init() }
+func file_tracker_v1_tracker_proto_init() {
+	if File_tracker_v1_tracker_proto != nil {
+		return
+	}
+	file_tracker_v1_tracker_proto_msgTypes[4].OneofWrappers = []any{
+		(*GetBlockRequest_Number)(nil),
+		(*GetBlockRequest_Hash)(nil),
+	}
+	file_tracker_v1_tracker_proto_msgTypes[9].OneofWrappers = []any{
+		(*SubscribeTransfersRequest_FromBlock)(nil),
+		(*SubscribeTransfersRequest_Cursor)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tracker_v1_tracker_proto_rawDesc), len(file_tracker_v1_tracker_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_tracker_v1_tracker_proto_goTypes,
+		DependencyIndexes: file_tracker_v1_tracker_proto_depIdxs,
+		EnumInfos:         file_tracker_v1_tracker_proto_enumTypes,
+		MessageInfos:      file_tracker_v1_tracker_proto_msgTypes,
+	}.Build()
+	File_tracker_v1_tracker_proto = out.File
+	file_tracker_v1_tracker_proto_goTypes = nil
+	file_tracker_v1_tracker_proto_depIdxs = nil
+}
@@ -0,0 +1,255 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: tracker/v1/tracker.proto
+
+package trackerpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Tracker_GetBlock_FullMethodName            = "/tracker.v1.Tracker/GetBlock"
+	Tracker_GetTransaction_FullMethodName      = "/tracker.v1.Tracker/GetTransaction"
+	Tracker_GetAddressTransfers_FullMethodName = "/tracker.v1.Tracker/GetAddressTransfers"
+	Tracker_SubscribeTransfers_FullMethodName  = "/tracker.v1.Tracker/SubscribeTransfers"
+)
+
+// TrackerClient is the client API for Tracker service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Tracker read API over indexed chain data. Hashes and addresses are lowercase 0x hex,
+// token amounts and wei values are base-10 strings.
+type TrackerClient interface {
+	// GetBlock returns a block with its transactions. NOT_FOUND if the block is not indexed.
+	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error)
+	// GetTransaction returns a transaction with its calldata, decoded transfers and raw logs.
+	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*TransactionDetail, error)
+	// GetAddressTransfers lists transfers sent or received by an address, newest first.
+	GetAddressTransfers(ctx context.Context, in *GetAddressTransfersRequest, opts ...grpc.CallOption) (*GetAddressTransfersResponse, error)
+	// SubscribeTransfers streams transfers as blocks are committed. Every block ends with a
+	// BLOCK event whose cursor can be passed back to resume after a disconnect.
+	SubscribeTransfers(ctx context.Context, in *SubscribeTransfersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TransferEvent], error)
+}
+
+type trackerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTrackerClient(cc grpc.ClientConnInterface) TrackerClient {
+	return &trackerClient{cc}
+}
+
+func (c *trackerClient) GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Block)
+	err := c.cc.Invoke(ctx, Tracker_GetBlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trackerClient) GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*TransactionDetail, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionDetail)
+	err := c.cc.Invoke(ctx, Tracker_GetTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trackerClient) GetAddressTransfers(ctx context.Context, in *GetAddressTransfersRequest, opts ...grpc.CallOption) (*GetAddressTransfersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAddressTransfersResponse)
+	err := c.cc.Invoke(ctx, Tracker_GetAddressTransfers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trackerClient) SubscribeTransfers(ctx context.Context, in *SubscribeTransfersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TransferEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Tracker_ServiceDesc.Streams[0], Tracker_SubscribeTransfers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeTransfersRequest, TransferEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tracker_SubscribeTransfersClient = grpc.ServerStreamingClient[TransferEvent]
+
+// TrackerServer is the server API for Tracker service.
+// All implementations must embed UnimplementedTrackerServer
+// for forward compatibility.
+//
+// Tracker read API over indexed chain data. Hashes and addresses are lowercase 0x hex,
+// token amounts and wei values are base-10 strings.
+type TrackerServer interface {
+	// GetBlock returns a block with its transactions. NOT_FOUND if the block is not indexed.
+	GetBlock(context.Context, *GetBlockRequest) (*Block, error)
+	// GetTransaction returns a transaction with its calldata, decoded transfers and raw logs.
+	GetTransaction(context.Context, *GetTransactionRequest) (*TransactionDetail, error)
+	// GetAddressTransfers lists transfers sent or received by an address, newest first.
+	GetAddressTransfers(context.Context, *GetAddressTransfersRequest) (*GetAddressTransfersResponse, error)
+	// SubscribeTransfers streams transfers as blocks are committed. Every block ends with a
+	// BLOCK event whose cursor can be passed back to resume after a disconnect.
+	SubscribeTransfers(*SubscribeTransfersRequest, grpc.ServerStreamingServer[TransferEvent]) error
+	mustEmbedUnimplementedTrackerServer()
+}
+
+// UnimplementedTrackerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTrackerServer struct{}
+
+func (UnimplementedTrackerServer) GetBlock(context.Context, *GetBlockRequest) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (UnimplementedTrackerServer) GetTransaction(context.Context, *GetTransactionRequest) (*TransactionDetail, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (UnimplementedTrackerServer) GetAddressTransfers(context.Context, *GetAddressTransfersRequest) (*GetAddressTransfersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAddressTransfers not implemented")
+}
+func (UnimplementedTrackerServer) SubscribeTransfers(*SubscribeTransfersRequest, grpc.ServerStreamingServer[TransferEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeTransfers not implemented")
+}
+func (UnimplementedTrackerServer) mustEmbedUnimplementedTrackerServer() {}
+func (UnimplementedTrackerServer) testEmbeddedByValue()                 {}
+
+// UnsafeTrackerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TrackerServer will
+// result in compilation errors.
+type UnsafeTrackerServer interface {
+	mustEmbedUnimplementedTrackerServer()
+}
+
+func RegisterTrackerServer(s grpc.ServiceRegistrar, srv TrackerServer) {
+	// If the following call pancis, it indicates UnimplementedTrackerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Tracker_ServiceDesc, srv)
+}
+
+func _Tracker_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackerServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tracker_GetBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackerServer).GetBlock(ctx, req.(*GetBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tracker_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackerServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tracker_GetTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackerServer).GetTransaction(ctx, req.(*GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tracker_GetAddressTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAddressTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackerServer).GetAddressTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tracker_GetAddressTransfers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackerServer).GetAddressTransfers(ctx, req.(*GetAddressTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tracker_SubscribeTransfers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeTransfersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TrackerServer).SubscribeTransfers(m, &grpc.GenericServerStream[SubscribeTransfersRequest, TransferEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tracker_SubscribeTransfersServer = grpc.ServerStreamingServer[TransferEvent]
+
+// Tracker_ServiceDesc is the grpc.ServiceDesc for Tracker service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Tracker_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tracker.v1.Tracker",
+	HandlerType: (*TrackerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBlock",
+			Handler:    _Tracker_GetBlock_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _Tracker_GetTransaction_Handler,
+		},
+		{
+			MethodName: "GetAddressTransfers",
+			Handler:    _Tracker_GetAddressTransfers_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeTransfers",
+			Handler:       _Tracker_SubscribeTransfers_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "tracker/v1/tracker.proto",
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"blockchain-tracking/internal/core/domain/explorer"
+	"blockchain-tracking/internal/core/domain/stream"
+	"blockchain-tracking/internal/database/postgresql"
+	"blockchain-tracking/internal/logger"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+var (
+	addressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+	hashPattern    = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+	topicPattern   = hashPattern
+	tokenIDPattern = regexp.MustCompile(`^[0-9]{1,78}$`)
+)
+
+// errBadRequest 요청 파라미터 오류 (400)
+var errBadRequest = errors.New("bad request")
+
+// Page 목록 응답. NextCursor 가 비어 있으면 마지막 페이지
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+type Server struct {
+	explorer *explorer.Service
+	stream   *stream.Service
+	cursor   *postgresql.Cursor
+	l        logger.Logger
+	mux      *http.ServeMux
+
+	// 종료할 때 닫는다. 스트림 연결은 끝나지 않으므로 이걸 보고 끊는다
+	closing chan struct{}
+}
+
+func NewServer(explorerService *explorer.Service, streamService *stream.Service, cursor *postgresql.Cursor, l logger.Logger) *Server {
+	s := &Server{
+		explorer: explorerService,
+		stream:   streamService,
+		cursor:   cursor,
+		l:        l,
+		mux:      http.NewServeMux(),
+		closing:  make(chan struct{}),
+	}
+	s.routes()
+	return s
+}
+
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// Run ctx 가 끝나면 처리 중인 요청을 기다렸다가 종료한다
+func (s *Server) Run(ctx context.Context, addr string) error {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           s.mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	server.RegisterOnShutdown(func() { close(s.closing) })
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+	s.l.Info("api server listening", logger.Field{Key: "addr", Value: addr})
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		s.l.Warn("write response", logger.Field{Key: "error", Value: err.Error()})
+	}
+}
+
+// writeError 400/404 는 메시지를 그대로, 나머지는 로그만 남기고 500
+func (s *Server) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, errBadRequest):
+		s.writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, explorer.ErrNotFound):
+		s.writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+	default:
+		s.l.Error("api request", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "path", Value: r.URL.Path})
+		s.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+	}
+}
+
+// writePage 다음 페이지 위치를 암호화한 cursor 로 내려준다
+func writePage[T any](s *Server, w http.ResponseWriter, r *http.Request, items []T, next *explorer.Cursor) {
+	page := Page[T]{Items: items}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+	if next != nil {
+		var err error
+		if page.NextCursor, err = next.Encode(s.cursor); err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+	}
+
+	s.writeJSON(w, http.StatusOK, page)
+}
+
+// page ?cursor= &limit= 를 읽는다. cursor 가 없으면 첫 페이지
+func (s *Server) page(r *http.Request) (explorer.Cursor, int32, error) {
+	query := r.URL.Query()
+
+	cursor, err := explorer.DecodeCursor(s.cursor, query.Get("cursor"))
+	if err != nil {
+		return cursor, 0, fmt.Errorf("%w: invalid cursor", errBadRequest)
+	}
+
+	var limit int64
+	if v := query.Get("limit"); v != "" {
+		limit, err = strconv.ParseInt(v, 10, 32)
+		if err != nil || limit <= 0 {
+			return cursor, 0, fmt.Errorf("%w: invalid limit", errBadRequest)
+		}
+	}
+
+	return cursor, int32(limit), nil
+}
+
+func chainID(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(r.PathValue("chainId"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%w: invalid chain id", errBadRequest)
+	}
+	return id, nil
+}
+
+// hexValue 주소/해시를 DB 와 같은 소문자 0x hex 로 맞춘다
+func hexValue(name, value string, pattern *regexp.Regexp) (string, error) {
+	if !pattern.MatchString(value) {
+		return "", fmt.Errorf("%w: invalid %s", errBadRequest, name)
+	}
+	return strings.ToLower(value), nil
+}
+
+func kindValue(value string) (explorer.Kind, error) {
+	switch kind := explorer.Kind(strings.ToLower(value)); kind {
+	case explorer.KindErc20, explorer.KindErc721, explorer.KindErc1155:
+		return kind, nil
+	}
+	return "", fmt.Errorf("%w: kind must be erc20, erc721 or erc1155", errBadRequest)
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"blockchain-tracking/internal/core/domain/explorer"
+	"blockchain-tracking/internal/core/domain/stream"
+	"blockchain-tracking/internal/logger"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const streamHeartbeat = 15 * time.Second
+
+var streamTypes = map[stream.Type]bool{
+	stream.TypeBlock:   true,
+	stream.TypeCoin:    true,
+	stream.TypeErc20:   true,
+	stream.TypeErc721:  true,
+	stream.TypeErc1155: true,
+}
+
+// subscribe Server-Sent Events. ?address= &contract= &types=coin,erc20 로 거르고,
+// Last-Event-ID (또는 ?cursor=) 다음 블록이나 ?fromBlock= 부터 DB 에서 재전송한 뒤 새 블록을 이어서 보낸다.
+// 블록을 다 보내면 id 만 있는 메시지로 재접속 위치를 알려준다
+func (s *Server) subscribe(w http.ResponseWriter, r *http.Request) {
+	filter, from, err := s.streamParams(r)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err = rc.Flush(); err != nil {
+		return
+	}
+
+	// 재전송 중에 저장된 블록을 놓치지 않게 먼저 구독한다
+	sub := s.stream.Subscribe(filter)
+	defer s.stream.Unsubscribe(sub)
+
+	sent := int64(-1)
+	write := func(batch *stream.Batch) error {
+		return s.writeBatch(w, rc, batch, &sent)
+	}
+	if from >= 0 {
+		if _, err = s.stream.Replay(r.Context(), filter, from, write); err != nil {
+			if r.Context().Err() == nil {
+				s.writeStreamError(w, rc, r, err)
+			}
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.closing:
+			return
+		case <-sub.Dropped():
+			s.writeStreamError(w, rc, r, stream.ErrDropped)
+			return
+		case <-heartbeat.C:
+			if _, err = fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			if err = rc.Flush(); err != nil {
+				return
+			}
+		case batch := <-sub.Batches():
+			// 재전송에서 이미 보낸 블록
+			if !batch.Removed && batch.BlockNumber <= sent {
+				continue
+			}
+			if err = write(batch); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// writeBatch 블록 하나를 보내고 id 를 그 블록의 cursor 로 옮긴다. 지워진 블록이면 그 앞 블록으로 되돌린다
+func (s *Server) writeBatch(w http.ResponseWriter, rc *http.ResponseController, batch *stream.Batch, sent *int64) error {
+	for _, event := range batch.Events {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if _, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+			return err
+		}
+	}
+
+	checkpoint := batch.BlockNumber
+	if batch.Removed {
+		if batch.BlockNumber > *sent {
+			return rc.Flush()
+		}
+		checkpoint = batch.BlockNumber - 1
+	}
+	id, err := explorer.Cursor{ID: checkpoint}.Encode(s.cursor)
+	if err != nil {
+		return err
+	}
+	if _, err = fmt.Fprintf(w, "id: %s\n\n", id); err != nil {
+		return err
+	}
+	*sent = checkpoint
+	return rc.Flush()
+}
+
+func (s *Server) writeStreamError(w http.ResponseWriter, rc *http.ResponseController, r *http.Request, err error) {
+	message := err.Error()
+	if err != stream.ErrDropped {
+		s.l.Error("api stream", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "path", Value: r.URL.Path})
+		message = "internal error"
+	}
+	data, _ := json.Marshal(map[string]string{"error": message})
+	_, _ = fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+	_ = rc.Flush()
+}
+
+// streamParams 재전송 시작 블록. 재개 위치가 없으면 -1 (새 블록만)
+func (s *Server) streamParams(r *http.Request) (stream.Filter, int64, error) {
+	chain, err := chainID(r)
+	if err != nil {
+		return stream.Filter{}, 0, err
+	}
+	filter := stream.Filter{ChainID: chain}
+
+	query := r.URL.Query()
+	if v := query.Get("address"); v != "" {
+		if filter.Address, err = hexValue("address", v, addressPattern); err != nil {
+			return filter, 0, err
+		}
+	}
+	if v := query.Get("contract"); v != "" {
+		if filter.Contract, err = hexValue("contract", v, addressPattern); err != nil {
+			return filter, 0, err
+		}
+	}
+	if v := query.Get("types"); v != "" {
+		filter.Types = make(map[stream.Type]bool)
+		for _, name := range strings.Split(v, ",") {
+			t := stream.Type(strings.ToLower(strings.TrimSpace(name)))
+			if !streamTypes[t] {
+				return filter, 0, fmt.Errorf("%w: types must be block, coin, erc20, erc721 or erc1155", errBadRequest)
+			}
+			filter.Types[t] = true
+		}
+	}
+
+	// 브라우저 EventSource 는 재접속할 때 Last-Event-ID 를 보낸다
+	resume := r.Header.Get("Last-Event-ID")
+	if resume == "" {
+		resume = query.Get("cursor")
+	}
+	if resume != "" {
+		cursor, err := explorer.DecodeCursor(s.cursor, resume)
+		if err != nil {
+			return filter, 0, fmt.Errorf("%w: invalid cursor", errBadRequest)
+		}
+		return filter, cursor.ID + 1, nil
+	}
+	if v := query.Get("fromBlock"); v != "" {
+		from, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || from < 0 {
+			return filter, 0, fmt.Errorf("%w: invalid fromBlock", errBadRequest)
+		}
+		return filter, from, nil
+	}
+	return filter, -1, nil
+}
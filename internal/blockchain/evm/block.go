@@ -3,12 +3,13 @@ package evm
 import (
 	"blockchain-tracking/internal/blockchain/jsonRpc"
 	"blockchain-tracking/internal/logger"
+	"context"
 	"encoding/json"
 	"fmt"
 )
 
-func FetchBlockData(rpc string, blockHeight string, jrAdapter *jsonRpc.JsonRpc, l logger.Logger) (*jsonRpc.Block, error) {
-	res, err := jrAdapter.CreateRequest(rpc, "eth_getBlockByNumber", []interface{}{blockHeight, true})
+func FetchBlockData(ctx context.Context, rpc string, blockHeight string, jrAdapter *jsonRpc.JsonRpc, l logger.Logger) (*jsonRpc.Block, error) {
+	res, err := jrAdapter.CreateRequest(ctx, rpc, "eth_getBlockByNumber", []interface{}{blockHeight, true})
 	if err != nil {
 		l.Error("eth_getBlockByNumber", logger.Field{Key: "error", Value: err.Error()})
 		return nil, err
@@ -1,10 +1,15 @@
 package evm
 
 import (
+	"blockchain-tracking/config"
 	"blockchain-tracking/internal/blockchain/evm/evmType"
 	"blockchain-tracking/internal/blockchain/jsonRpc"
 	"blockchain-tracking/internal/core/domain/blockchain"
+	"blockchain-tracking/internal/core/domain/rule"
+	"blockchain-tracking/internal/health"
 	"blockchain-tracking/internal/logger"
+	"blockchain-tracking/internal/metrics"
+	"blockchain-tracking/internal/tracing"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -14,9 +19,12 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/ethclient"
+	"go.opentelemetry.io/otel/attribute"
 )
 
-func BlockScanner(ctx context.Context, name, rpc string, jrAdapter *jsonRpc.JsonRpc, blockchainService *blockchain.Service, l logger.Logger) error {
+func BlockScanner(ctx context.Context, chain config.Chain, rpc string, jrAdapter *jsonRpc.JsonRpc, blockchainService *blockchain.Service, rules *rule.Service, status *health.Chain, l logger.Logger) error {
+	name := chain.Name
+
 	// client 연결 확인 및 체인아이디 가져오기
 	client, err := ethclient.DialContext(ctx, rpc)
 	if err != nil {
@@ -30,9 +38,13 @@ func BlockScanner(ctx context.Context, name, rpc string, jrAdapter *jsonRpc.Json
 		l.Error(fmt.Sprintf("%s get chain id", name), logger.Field{Key: "error", Value: err.Error()})
 		return err
 	}
+	// 설정과 다른 체인을 가리키는 RPC 의 블록은 저장하지 않는다
+	if chainID.Int64() != chain.ChainID {
+		return fmt.Errorf("%w: %s rpc returned %s, expected %d", ErrChainIDMismatch, name, chainID, chain.ChainID)
+	}
 
 	// 최신 블록 높이 요청
-	ethBlockNumberRes, err := jrAdapter.CreateRequest(rpc, "eth_blockNumber", []interface{}{})
+	ethBlockNumberRes, err := jrAdapter.CreateRequest(ctx, rpc, "eth_blockNumber", []interface{}{})
 	if err != nil {
 		l.Error("eth_blockNumber", logger.Field{Key: "error", Value: err.Error()})
 		return err
@@ -46,116 +58,247 @@ func BlockScanner(ctx context.Context, name, rpc string, jrAdapter *jsonRpc.Json
 		return err
 	}
 
-	lastScanedBlockHeight, err := blockchainService.GetBlockHeight(ctx, chainID.String())
+	lastScanedBlockHeight, err := blockchainService.GetBlockHeight(ctx, chainID.Int64())
 	if err != nil {
 		l.Error("get block height", logger.Field{Key: "error", Value: err.Error()})
 		return err
 	}
 
-	start := new(big.Int)
-	start.SetString(lastScanedBlockHeight, 10)
-	start.Add(start, big.NewInt(1))
+	// 저장된 블록이 없으면 startBlock 부터
+	start := big.NewInt(max(lastScanedBlockHeight+1, int64(chain.StartBlock)))
 
-	end := HexToBigInt(latestBlockHeight.Result[2:])
+	head := HexToBigInt(latestBlockHeight.Result[2:])
+	metrics.Heights(name, head.Int64(), lastScanedBlockHeight)
+	status.Head(head.Int64())
+
+	// confirmations 만큼 뒤의 블록까지만 받는다
+	end := new(big.Int).Sub(head, new(big.Int).SetUint64(chain.Confirmations))
+	if chain.MaxBlocksPerRound > 0 {
+		limit := new(big.Int).Add(start, big.NewInt(int64(chain.MaxBlocksPerRound-1)))
+		if limit.Cmp(end) < 0 {
+			end = limit
+		}
+	}
 
 	// TODO: 트랜잭션 / 블록 상황에 따라 유동적으로 조절
-	BatchSize := big.NewInt(10)
+	BatchSize := big.NewInt(int64(chain.BatchSize))
 
 	for batchStart := new(big.Int).Set(start); batchStart.Cmp(end) <= 0; batchStart.Add(batchStart, BatchSize) {
-		// TODO: 트랜잭션 / 블록 상황에 따라 유동적으로 조절
-		batchEnd := new(big.Int).Add(batchStart, big.NewInt(9))
+		batchEnd := new(big.Int).Add(batchStart, big.NewInt(int64(chain.BatchSize-1)))
 		if batchEnd.Cmp(end) > 0 {
 			batchEnd.Set(end)
 		}
 
-		batchCount := new(big.Int).Add(new(big.Int).Sub(batchEnd, batchStart), big.NewInt(1))
+		batchStarted := time.Now()
+		stored, err := scanBatch(ctx, chain, rpc, chainID, batchStart, batchEnd, jrAdapter, blockchainService, l)
 
-		var wg sync.WaitGroup
-		errCh := make(chan error, batchCount.Int64())
+		// 저장된 블록에만 알림 규칙을 적용한다
+		for _, data := range stored {
+			indexed(name, status, data)
+			rules.Evaluate(data)
+		}
+		if err != nil {
+			return err
+		}
+		status.Batch()
+		metrics.BatchDuration(name, time.Since(batchStarted))
+	}
 
-		// 성공한 블록 추적 값 데이터 넣는 곳
-		idx := -1
-		blockList := make([]*evmType.Block, batchCount.Int64())
-		var mu sync.Mutex
+	return nil
+}
 
-		for block := new(big.Int).Set(batchStart); block.Cmp(batchEnd) <= 0; block.Add(block, big.NewInt(1)) {
-			wg.Add(1)
+// 재인덱싱 배치가 실패하면 다음 RPC 주소로 이만큼 다시 시도한다
+const (
+	rescanAttempts = 5
+	rescanBackoff  = 5 * time.Second
+)
 
-			idx++
+// ScanRange from~to 블록을 다시 받아서 저장한다 (재인덱싱). 알림 규칙은 적용하지 않는다.
+// progress 는 배치를 저장할 때마다 다음에 받을 블록 번호로 불린다
+func ScanRange(ctx context.Context, chain config.Chain, from, to uint64, jrAdapter *jsonRpc.JsonRpc, blockchainService *blockchain.Service, progress func(next uint64), l logger.Logger) error {
+	chainID := big.NewInt(chain.ChainID)
+	endpoint := 0
+	failCount := 0
 
-			go func(bn *big.Int, list []*evmType.Block, i int) {
-				defer wg.Done()
-				defer func() {
-					if r := recover(); r != nil {
-						errMsg := fmt.Sprintf("panic: %v", r)
-						l.Warn(fmt.Sprintf("block scanner recover %s", name), logger.Field{Key: "error", Value: errMsg})
-					}
-				}()
-				// 복사해서 넘기기
-				blockHex := "0x" + bn.Text(16)
+	for batchStart := from; batchStart <= to; {
+		batchEnd := min(batchStart+uint64(chain.BatchSize-1), to)
+		rpc := chain.RPC[endpoint]
 
-				blockResult, err := FetchBlockData(rpc, blockHex, jrAdapter, l)
-				if err != nil {
+		// 설정과 다른 체인을 가리키는 RPC 의 블록은 저장하지 않는다
+		id, err := FetchChainID(ctx, rpc, jrAdapter)
+		if err == nil && id != chain.ChainID {
+			return fmt.Errorf("%w: %s rpc returned %d, expected %d", ErrChainIDMismatch, chain.Name, id, chain.ChainID)
+		}
+		if err == nil {
+			_, err = scanBatch(ctx, chain, rpc, chainID, new(big.Int).SetUint64(batchStart), new(big.Int).SetUint64(batchEnd), jrAdapter, blockchainService, l)
+		}
+		if err != nil {
+			failCount++
+			if failCount >= rescanAttempts || ctx.Err() != nil {
+				return fmt.Errorf("rescan %d-%d failed %d times: %w", batchStart, batchEnd, failCount, err)
+			}
+			l.Warn(fmt.Sprintf("%s rescan failed, retrying", chain.Name), logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "from", Value: batchStart}, logger.Field{Key: "endpoint", Value: metrics.Endpoint(rpc)})
+
+			endpoint = (endpoint + 1) % len(chain.RPC)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(rescanBackoff):
+			}
+			continue
+		}
+
+		failCount = 0
+		batchStart = batchEnd + 1
+		progress(batchStart)
+	}
+
+	return nil
+}
+
+// scanBatch from~to 블록을 받아서 디코딩하고 저장한다. 에러가 나도 그 전에 저장한 블록은 돌려준다
+func scanBatch(ctx context.Context, chain config.Chain, rpc string, chainID, batchStart, batchEnd *big.Int, jrAdapter *jsonRpc.JsonRpc, blockchainService *blockchain.Service, l logger.Logger) (stored []*evmType.Block, err error) {
+	name := chain.Name
+	ctx, span := tracing.Start(ctx, "evm.ScanBatch",
+		attribute.String("chain.name", name),
+		attribute.Int64("chain.id", chainID.Int64()),
+		attribute.Int64("block.from", batchStart.Int64()),
+		attribute.Int64("block.to", batchEnd.Int64()),
+	)
+	defer func() { tracing.End(span, err) }()
+	l = tracing.Logger(ctx, l)
+
+	batchCount := new(big.Int).Add(new(big.Int).Sub(batchEnd, batchStart), big.NewInt(1))
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, batchCount.Int64())
+
+	// 성공한 블록 추적 값 데이터 넣는 곳
+	idx := -1
+	blockList := make([]*evmType.Block, batchCount.Int64())
+	var mu sync.Mutex
+
+	for block := new(big.Int).Set(batchStart); block.Cmp(batchEnd) <= 0; block.Add(block, big.NewInt(1)) {
+		wg.Add(1)
+
+		idx++
+
+		go func(bn *big.Int, list []*evmType.Block, i int) {
+			defer wg.Done()
+			ctx, span := tracing.Start(ctx, "evm.Block", attribute.Int64("chain.id", chainID.Int64()), attribute.Int64("block.number", bn.Int64()))
+			var err error
+			defer func() { tracing.End(span, err) }()
+			defer func() {
+				if r := recover(); r != nil {
+					metrics.Panic("block_scanner")
+					errMsg := fmt.Sprintf("panic: %v", r)
+					l.Warn(fmt.Sprintf("block scanner recover %s", name), logger.Field{Key: "error", Value: errMsg})
+					err = fmt.Errorf("block %s: %s", bn.String(), errMsg)
 					errCh <- err
-					return
 				}
+			}()
+			// 복사해서 넘기기
+			blockHex := "0x" + bn.Text(16)
 
-				blockTimestampInt := HexToBigInt(blockResult.Timestamp[2:])
-				blockTime := time.Unix(blockTimestampInt.Int64(), 0).UTC()
-				blockNumberInt := HexToBigInt(blockResult.Number[2:])
-
-				input := &evmType.Block{
-					ChainID:          chainID,
-					Difficulty:       blockResult.Difficulty,
-					Hash:             strings.ToLower(blockResult.Hash),
-					GasLimit:         blockResult.GasLimit,
-					GasUsed:          blockResult.GasUsed,
-					Miner:            strings.ToLower(blockResult.Miner),
-					Number:           blockResult.Number,
-					NumberInt:        blockNumberInt,
-					ParentHash:       strings.ToLower(blockResult.ParentHash),
-					Timestamp:        blockResult.Timestamp,
-					TimestampInt:     blockTimestampInt,
-					CreatedAt:        blockTime,
-					TotalDifficulty:  blockResult.TotalDifficulty,
-					TransactionsRoot: strings.ToLower(blockResult.TransactionsRoot),
-					Transaction:      make([]evmType.Transaction, len(blockResult.Transactions)),
-				}
+			blockResult, err := FetchBlockData(ctx, rpc, blockHex, jrAdapter, l)
+			if err != nil {
+				errCh <- err
+				return
+			}
+
+			// 머지 이후 체인은 totalDifficulty 를 주지 않는다
+			var difficulty, totalDifficulty *big.Int
+			if len(blockResult.Difficulty) > 2 {
+				difficulty = HexToBigInt(blockResult.Difficulty[2:])
+			}
+			if len(blockResult.TotalDifficulty) > 2 {
+				totalDifficulty = HexToBigInt(blockResult.TotalDifficulty[2:])
+			}
+
+			input := &evmType.Block{
+				ChainID:          chainID,
+				Difficulty:       difficulty,
+				Hash:             strings.ToLower(blockResult.Hash),
+				GasLimit:         HexToUint64(blockResult.GasLimit),
+				GasUsed:          HexToUint64(blockResult.GasUsed),
+				Miner:            strings.ToLower(blockResult.Miner),
+				Number:           HexToUint64(blockResult.Number),
+				ParentHash:       strings.ToLower(blockResult.ParentHash),
+				Timestamp:        time.Unix(int64(HexToUint64(blockResult.Timestamp)), 0).UTC(),
+				TotalDifficulty:  totalDifficulty,
+				TransactionsRoot: strings.ToLower(blockResult.TransactionsRoot),
+				Transaction:      make([]evmType.Transaction, len(blockResult.Transactions)),
+				NativeDecimals:   chain.NativeDecimals,
+			}
 
-				if len(blockResult.Transactions) > 0 {
-					err = FetchTransactionData(ctx, rpc, blockResult, input, jrAdapter, l)
-					if err != nil {
-						errCh <- err
-						return
-					}
+			if len(blockResult.Transactions) > 0 {
+				err = FetchTransactionData(ctx, rpc, blockResult, input, chain.Features.NFTMetadata, jrAdapter, l)
+				if err != nil {
+					errCh <- err
+					return
 				}
+			}
 
-				mu.Lock()
-				list[i] = input
-				mu.Unlock()
-			}(new(big.Int).Set(block), blockList, idx)
-		}
+			mu.Lock()
+			list[i] = input
+			mu.Unlock()
+		}(new(big.Int).Set(block), blockList, idx)
+	}
 
-		wg.Wait()
-		close(errCh)
+	wg.Wait()
+	close(errCh)
 
-		if len(errCh) > 0 {
-			for errResult := range errCh {
-				if errResult != nil {
-					l.Error("blockchain decode", logger.Field{Key: "error", Value: errResult})
-					return errResult
-				}
+	if len(errCh) > 0 {
+		for errResult := range errCh {
+			if errResult != nil {
+				l.Error("blockchain decode", logger.Field{Key: "error", Value: errResult})
+				return nil, errResult
 			}
 		}
+	}
 
+	// 여러 블록을 한 번에 받아온 경우(백필)는 COPY 로 한 트랜잭션에 저장한다
+	if len(blockList) > 1 {
+		seen := make(map[blockchain.SeedKey]bool)
 		for _, data := range blockList {
-			err = blockchainService.Create(ctx, data)
+			err = SeedBalances(ctx, rpc, data, seen, jrAdapter, blockchainService, l)
 			if err != nil {
-				l.Error("blockchain service create", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: data.ChainID}, logger.Field{Key: "block number", Value: data.NumberInt})
-				return err
+				l.Error("seed balances", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: data.ChainID}, logger.Field{Key: "block number", Value: data.Number})
+				return nil, err
 			}
 		}
+
+		err = blockchainService.CreateBatch(ctx, blockList)
+		if err != nil {
+			l.Error("blockchain service create batch", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "from", Value: blockList[0].Number}, logger.Field{Key: "to", Value: blockList[len(blockList)-1].Number})
+			return nil, err
+		}
+		return blockList, nil
 	}
 
-	return nil
+	for i, data := range blockList {
+		// 처음 보는 주소/토큰은 직전 블록 잔액부터 채운다
+		err = SeedBalances(ctx, rpc, data, nil, jrAdapter, blockchainService, l)
+		if err != nil {
+			l.Error("seed balances", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: data.ChainID}, logger.Field{Key: "block number", Value: data.Number})
+			return blockList[:i], err
+		}
+
+		err = blockchainService.Create(ctx, data)
+		if err != nil {
+			l.Error("blockchain service create", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: data.ChainID}, logger.Field{Key: "block number", Value: data.Number})
+			return blockList[:i], err
+		}
+	}
+	return blockList, nil
+}
+
+// indexed 저장한 블록의 높이와 트랜잭션/로그 수
+func indexed(name string, status *health.Chain, block *evmType.Block) {
+	logs := 0
+	for _, tx := range block.Transaction {
+		logs += len(tx.Logs)
+	}
+	metrics.Indexed(name, block.Number, len(block.Transaction), logs)
+	status.Indexed(int64(block.Number), block.Timestamp)
 }
@@ -0,0 +1,140 @@
+package evm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	standardErc20  = "20"
+	standardErc721 = "721"
+)
+
+// Transfer(address,address,uint256) 는 ERC-20 / ERC-721 이 같은 시그니처를 쓰고
+// indexed 여부에 따라 topic / data 배치가 달라진다.
+//
+//	topics 4, data 0   : from, to, tokenId 모두 indexed (표준 ERC-721)
+//	topics 3, data 32  : from, to indexed, value 는 data (표준 ERC-20)
+//	topics 2, data 64  : from 만 indexed
+//	topics 1, data 96  : 모두 data (CryptoKitties 같은 레거시 NFT, 초기 ERC-20)
+type transferEvent struct {
+	Standard string
+	From     common.Address
+	To       common.Address
+	Value    *big.Int // ERC-20 은 수량, ERC-721 은 tokenId
+}
+
+// decodeTransfer 배치만으로 구분되지 않을 때만 classify 로 컨트랙트 종류를 확인한다 (ctx 는 classify 에 넘긴다)
+func decodeTransfer(ctx context.Context, topics []common.Hash, data string, classify func(ctx context.Context) string) (*transferEvent, error) {
+	if len(topics) == 0 || len(topics) > 4 {
+		return nil, fmt.Errorf("transfer with %d topics", len(topics))
+	}
+
+	raw := common.FromHex(data)
+	if len(data) > 2 && len(raw) == 0 {
+		return nil, fmt.Errorf("transfer data is not hex")
+	}
+
+	// indexed 되지 않은 인자는 data 에 32바이트씩 들어 있다
+	words := make([]common.Hash, 0, 3)
+	words = append(words, topics[1:]...)
+	need := 3 - len(words)
+	if len(raw) < need*32 {
+		return nil, fmt.Errorf("transfer with %d topics has %d bytes of data, need %d", len(topics), len(raw), need*32)
+	}
+	for i := 0; i < need; i++ {
+		words = append(words, common.BytesToHash(raw[i*32:(i+1)*32]))
+	}
+
+	// 주소 자리에 20바이트를 넘는 값이 있으면 Transfer 가 아니다
+	for _, word := range words[:2] {
+		if new(big.Int).SetBytes(word[:12]).Sign() != 0 {
+			return nil, fmt.Errorf("transfer address word %s is not an address", word.Hex())
+		}
+	}
+
+	event := &transferEvent{
+		From:  common.BytesToAddress(words[0][12:]),
+		To:    common.BytesToAddress(words[1][12:]),
+		Value: new(big.Int).SetBytes(words[2][:]),
+	}
+
+	switch {
+	case len(topics) == 4:
+		// 값까지 indexed 한 ERC-20 도 있어서 분류가 20 이면 ERC-20 으로 본다
+		event.Standard = standardErc721
+		if classify(ctx) == standardErc20 {
+			event.Standard = standardErc20
+		}
+	case len(topics) == 3:
+		event.Standard = standardErc20
+		if classify(ctx) == standardErc721 {
+			event.Standard = standardErc721
+		}
+	default:
+		event.Standard = classify(ctx)
+		if event.Standard != standardErc721 {
+			event.Standard = standardErc20
+		}
+	}
+
+	return event, nil
+}
+
+func transferFunction(from, to common.Address) string {
+	if from == (common.Address{}) {
+		return "mint"
+	}
+	if to == (common.Address{}) || to == common.HexToAddress("0x000000000000000000000000000000000000dead") {
+		return "burn"
+	}
+	return "transfer"
+}
+
+// 컨트랙트 종류는 바뀌지 않으므로 프로세스 동안 캐시한다 (chainID:address -> "20" / "721" / "1155" / "")
+var contractTypes sync.Map
+
+// 레거시(draft) ERC-721 interface ID, CryptoKitties 등
+var legacyErc721ID = [4]byte{0x9a, 0x20, 0x48, 0x3d}
+
+func classifyContract(ctx context.Context, client *ethclient.Client, chainID *big.Int, address string) string {
+	key := chainID.String() + ":" + strings.ToLower(address)
+	if cached, ok := contractTypes.Load(key); ok {
+		return cached.(string)
+	}
+
+	contractAddress := common.HexToAddress(address)
+
+	typeStr, _, _, err := ContractType(ctx, contractAddress, client)
+	if err != nil {
+		// 조회 실패는 캐시하지 않고 다음에 다시 확인한다
+		return ""
+	}
+	if typeStr == "" && supportsInterface(ctx, client, contractAddress, legacyErc721ID) {
+		typeStr = standardErc721
+	}
+
+	contractTypes.Store(key, typeStr)
+
+	return typeStr
+}
+
+func supportsInterface(ctx context.Context, client *ethclient.Client, address common.Address, interfaceID [4]byte) bool {
+	out, err := client.CallContract(ctx, ethereum.CallMsg{
+		To:   &address,
+		Data: callData("supportsInterface(bytes4)", common.RightPadBytes(interfaceID[:], 32)),
+	}, nil)
+	if err != nil {
+		return false
+	}
+
+	supports, err := decodeUint(out)
+	return err == nil && supports.Sign() != 0
+}
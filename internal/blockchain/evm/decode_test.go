@@ -0,0 +1,197 @@
+package evm
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+var (
+	testTransferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	testFrom          = common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	testTo            = common.HexToAddress("0x00000000000000000000000000000000000000b2")
+)
+
+func testWord(v interface{}) common.Hash {
+	switch v := v.(type) {
+	case common.Address:
+		return common.BytesToHash(v.Bytes())
+	case int64:
+		return common.BigToHash(big.NewInt(v))
+	}
+	panic("unsupported word")
+}
+
+func testData(words ...common.Hash) string {
+	var raw []byte
+	for _, word := range words {
+		raw = append(raw, word.Bytes()...)
+	}
+	return hexutil.Encode(raw)
+}
+
+func TestDecodeTransfer(t *testing.T) {
+	from, to, value := testWord(testFrom), testWord(testTo), testWord(int64(42))
+	// 주소 자리 상위 12바이트에 값이 있는 word
+	dirty := common.HexToHash("0x00000000000000000000000100000000000000000000000000000000000000a1")
+
+	tests := []struct {
+		name     string
+		topics   []common.Hash
+		data     string
+		classify string
+		want     string // Standard, 비어 있으면 에러
+	}{
+		{"4 topics is erc721", []common.Hash{testTransferTopic, from, to, value}, "0x", "", standardErc721},
+		{"4 topics classified as erc20", []common.Hash{testTransferTopic, from, to, value}, "0x", standardErc20, standardErc20},
+		{"3 topics is erc20", []common.Hash{testTransferTopic, from, to}, testData(value), "", standardErc20},
+		{"3 topics classified as erc721", []common.Hash{testTransferTopic, from, to}, testData(value), standardErc721, standardErc721},
+		{"2 topics defaults to erc20", []common.Hash{testTransferTopic, from}, testData(to, value), "", standardErc20},
+		{"2 topics classified as erc721", []common.Hash{testTransferTopic, from}, testData(to, value), standardErc721, standardErc721},
+		{"1 topic legacy nft", []common.Hash{testTransferTopic}, testData(from, to, value), standardErc721, standardErc721},
+		{"1 topic erc20", []common.Hash{testTransferTopic}, testData(from, to, value), standardErc20, standardErc20},
+		{"1 topic erc1155 falls back to erc20", []common.Hash{testTransferTopic}, testData(from, to, value), "1155", standardErc20},
+		{"no topics", nil, testData(from, to, value), "", ""},
+		{"5 topics", []common.Hash{testTransferTopic, from, to, value, value}, "0x", "", ""},
+		{"3 topics without data", []common.Hash{testTransferTopic, from, to}, "0x", "", ""},
+		{"1 topic with short data", []common.Hash{testTransferTopic}, testData(from, to), "", ""},
+		{"data is not hex", []common.Hash{testTransferTopic, from, to}, "0xzz", "", ""},
+		{"high bytes in indexed from", []common.Hash{testTransferTopic, dirty, to, value}, "0x", "", ""},
+		{"high bytes in data to", []common.Hash{testTransferTopic, from}, testData(dirty, value), "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := decodeTransfer(context.Background(), tt.topics, tt.data, func(ctx context.Context) string {
+				return tt.classify
+			})
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", event)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if event.Standard != tt.want {
+				t.Errorf("standard = %q, want %q", event.Standard, tt.want)
+			}
+			if event.From != testFrom || event.To != testTo || event.Value.Int64() != 42 {
+				t.Errorf("decoded %s -> %s value %s", event.From.Hex(), event.To.Hex(), event.Value)
+			}
+		})
+	}
+}
+
+// testRPCServer eth_call 을 calldata 별로 답하는 노드. 없는 calldata 는 revert 한다
+func testRPCServer(t *testing.T, calls *int64, results map[string]string) *ethclient.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+			return
+		}
+		atomic.AddInt64(calls, 1)
+
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		switch req.Method {
+		case "eth_getCode":
+			resp["result"] = "0x6001"
+		case "eth_call":
+			var msg struct {
+				Data  string `json:"data"`
+				Input string `json:"input"`
+			}
+			_ = json.Unmarshal(req.Params[0], &msg)
+			data := msg.Data
+			if data == "" {
+				data = msg.Input
+			}
+			if result, ok := results[data]; ok {
+				resp["result"] = result
+			} else {
+				resp["error"] = map[string]interface{}{"code": 3, "message": "execution reverted"}
+			}
+		default:
+			resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := ethclient.Dial(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+	return client
+}
+
+func selector(signature string, args ...[]byte) string {
+	return hexutil.Encode(callData(signature, args...))
+}
+
+func TestClassifyContract(t *testing.T) {
+	trueWord := hexutil.Encode(common.LeftPadBytes([]byte{1}, 32))
+	interfaceKey := func(id [4]byte) string {
+		return selector("supportsInterface(bytes4)", common.RightPadBytes(id[:], 32))
+	}
+
+	tests := []struct {
+		name    string
+		address string
+		results map[string]string
+		want    string
+	}{
+		{"erc721", "0x00000000000000000000000000000000000000f1", map[string]string{
+			interfaceKey([4]byte{0x80, 0xac, 0x58, 0xcd}): trueWord,
+		}, standardErc721},
+		{"erc1155", "0x00000000000000000000000000000000000000f5", map[string]string{
+			interfaceKey([4]byte{0xd9, 0xb6, 0x7a, 0x26}): trueWord,
+		}, "1155"},
+		{"legacy erc721", "0x00000000000000000000000000000000000000f2", map[string]string{
+			interfaceKey(legacyErc721ID): trueWord,
+		}, standardErc721},
+		{"erc20", "0x00000000000000000000000000000000000000f3", map[string]string{
+			selector("totalSupply()"): hexutil.Encode(common.LeftPadBytes([]byte{100}, 32)),
+			selector("decimals()"):    hexutil.Encode(common.LeftPadBytes([]byte{18}, 32)),
+		}, standardErc20},
+		{"unknown", "0x00000000000000000000000000000000000000f4", map[string]string{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int64
+			client := testRPCServer(t, &calls, tt.results)
+			chainID := big.NewInt(990001)
+
+			if got := classifyContract(context.Background(), client, chainID, tt.address); got != tt.want {
+				t.Fatalf("classifyContract = %q, want %q", got, tt.want)
+			}
+
+			// 두 번째부터는 캐시에서 읽는다
+			before := atomic.LoadInt64(&calls)
+			if got := classifyContract(context.Background(), client, chainID, strings.ToUpper(tt.address[:2])+tt.address[2:]); got != tt.want {
+				t.Fatalf("cached classifyContract = %q, want %q", got, tt.want)
+			}
+			if after := atomic.LoadInt64(&calls); after != before {
+				t.Errorf("cached lookup made %d rpc calls", after-before)
+			}
+		})
+	}
+}
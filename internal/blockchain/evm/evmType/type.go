@@ -2,87 +2,86 @@ package evmType
 
 import (
 	"math/big"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 )
 
 type Block struct {
-	ChainID          *big.Int      `json:"chainID"` // custom
-	Difficulty       string        `json:"difficulty"`
-	Hash             string        `json:"hash"`
-	GasLimit         string        `json:"gasLimit"`
-	GasUsed          string        `json:"gasUsed"`
-	Miner            string        `json:"miner"`
-	Number           string        `json:"number"`
-	NumberInt        *big.Int      `json:"NumberInt"` // custom
-	ParentHash       string        `json:"parentHash"`
-	Timestamp        string        `json:"timestamp"`
-	TimestampInt     *big.Int      `json:"timestampInt"` // custom
-	CreatedAt        time.Time     `json:"createdAt"`    // custom
-	TotalDifficulty  string        `json:"totalDifficulty"`
-	TransactionsRoot string        `json:"transactionsRoot"`
-	Transaction      []Transaction `json:"transaction"`
+	ChainID          *big.Int       `json:"chainID"` // custom
+	Difficulty       *big.Int       `json:"difficulty"`
+	Hash             string         `json:"hash"`
+	GasLimit         uint64         `json:"gasLimit"`
+	GasUsed          uint64         `json:"gasUsed"`
+	Miner            string         `json:"miner"`
+	Number           uint64         `json:"number"`
+	ParentHash       string         `json:"parentHash"`
+	Timestamp        time.Time      `json:"timestamp"`
+	TotalDifficulty  *big.Int       `json:"totalDifficulty"`
+	TransactionsRoot string         `json:"transactionsRoot"`
+	Transaction      []Transaction  `json:"transaction"`
+	Seeds            []*BalanceSeed `json:"seeds,omitempty"` // custom
+	NativeDecimals   int            `json:"-"`               // custom, 기본 코인 소수점 자리수 (0 이면 18)
+}
+
+// CoinDecimals 체인 기본 코인 (ETH 등) 소수점 자리수
+func (b *Block) CoinDecimals() int {
+	if b.NativeDecimals == 0 {
+		return 18
+	}
+	return b.NativeDecimals
 }
 
 type Transaction struct {
 	ChainID          *big.Int      `json:"chainID"` // custom
 	BlockHash        string        `json:"blockHash"`
-	BlockNumber      string        `json:"blockNumber"`
-	BlockNumberInt   *big.Int      `json:"blockNumberInt"` // custom
+	BlockNumber      uint64        `json:"blockNumber"`
 	From             string        `json:"from"`
 	To               string        `json:"to,omitempty"`
-	Gas              string        `json:"gas"`
-	GasInt           *big.Int      `json:"gasInt"` // custom
-	GasPrice         string        `json:"gasPrice"`
-	GasPriceInt      *big.Int      `json:"gasPriceInt"` // custom
+	Gas              uint64        `json:"gas"`
+	GasPrice         *big.Int      `json:"gasPrice"`
 	Hash             string        `json:"hash"`
 	R                string        `json:"r"`
 	S                string        `json:"s"`
 	V                string        `json:"v"`
-	TransactionIndex string        `json:"transactionIndex"`
-	Value            string        `json:"value"`
-	ValueInt         *big.Int      `json:"valueInt"` // custom
-	Nonce            string        `json:"nonce"`
+	TransactionIndex uint64        `json:"transactionIndex"`
+	Value            *big.Int      `json:"value"`
+	Nonce            uint64        `json:"nonce"`
 	Input            string        `json:"input"`
 	ContractAddress  *string       `json:"contractAddress,omitempty"`
-	GasUsed          string        `json:"gasUsed"`
-	GasUsedInt       *big.Int      `json:"gasUsedInt"` // custom
+	GasUsed          uint64        `json:"gasUsed"`
 	Logs             []*Log        `json:"log"`
-	Status           string        `json:"string"`
-	Type             string        `json:"type"`
-	Timestamp        string        `json:"timestamp"`              // custom
-	TimestampInt     *big.Int      `json:"timestampInt"`           // custom
-	CreatedAt        time.Time     `json:"createdAt"`              // custom
+	Status           uint64        `json:"status"`
+	Type             uint64        `json:"type"`
+	Timestamp        time.Time     `json:"timestamp"`              // custom
 	Contract         *Contract     `json:"contract,omitempty"`     // custom
 	CoinLogs         *CoinLog      `json:"coinLogs,omitempty"`     // custom
 	Erc20Logs        []*Erc20Log   `json:"erc20Logs,omitempty"`    // custom
 	Erc721Logs       []*Erc721Log  `json:"erc721Logs,omitempty"`   // custom
 	Erc1155Logs      []*Erc1155Log `json:"erc1155Logs,omitempty"`  // custom
-	CoinCount        *big.Int      `json:"coinCount,omitempty"`    // custom
-	NftCount         *big.Int      `json:"nftCount,omitempty"`     // custom
-	Erc20Count       *big.Int      `json:"erc20Count,omitempty"`   // custom
-	Erc721Count      *big.Int      `json:"erc721Count,omitempty"`  // custom
-	Erc1155Count     *big.Int      `json:"erc1155Count,omitempty"` // custom
+	CoinCount        int           `json:"coinCount,omitempty"`    // custom
+	NftCount         int           `json:"nftCount,omitempty"`     // custom
+	Erc20Count       int           `json:"erc20Count,omitempty"`   // custom
+	Erc721Count      int           `json:"erc721Count,omitempty"`  // custom
+	Erc1155Count     int           `json:"erc1155Count,omitempty"` // custom
+	Anomalies        []*Anomaly    `json:"anomalies,omitempty"`    // custom
 }
 
 type Log struct {
 	ChainID          *big.Int       `json:"chainID"` // custom
 	Address          string         `json:"address"`
-	BlockHash        string         `json:"blockHash"`      // custom
-	BlockNumber      string         `json:"blockNumber"`    // custom
-	BlockNumberInt   *big.Int       `json:"blockNumberInt"` // custom
+	BlockHash        string         `json:"blockHash"`   // custom
+	BlockNumber      uint64         `json:"blockNumber"` // custom
 	Data             string         `json:"data"`
-	LogIndex         string         `json:"logIndex"`
+	LogIndex         uint64         `json:"logIndex"`
 	Removed          bool           `json:"removed"`
 	Topics           []common.Hash  `json:"topics"`
 	TransactionHash  string         `json:"transactionHash"`
-	TransactionIndex string         `json:"transactionIndex"`
+	TransactionIndex uint64         `json:"transactionIndex"`
 	From             common.Address `json:"from,omitempty"`
 	To               common.Address `json:"to,omitempty"`
-	Timestamp        string         `json:"timestamp"`    // custom
-	TimestampInt     *big.Int       `json:"timestampInt"` // custom
-	CreatedAt        time.Time      `json:"createdAt"`    // custom
+	Timestamp        time.Time      `json:"timestamp"` // custom
 }
 
 type Contract struct {
@@ -98,27 +97,21 @@ type Contract struct {
 
 type CoinLog struct {
 	ChainID         *big.Int  `json:"chainID"`
-	Timestamp       string    `json:"timestamp"`
-	TimestampInt    *big.Int  `json:"timestampInt"`
-	CreatedAt       time.Time `json:"createdAt"`
+	Timestamp       time.Time `json:"timestamp"`
 	TransactionHash string    `json:"transactionHash"`
 	From            string    `json:"from"`
 	To              string    `json:"to,omitempty"`
 	Amount          *big.Int  `json:"amount"`
-	Gas             string    `json:"gas"`
-	GasInt          *big.Int  `json:"gasInt"`
-	GasPrice        string    `json:"gasPrice"`
-	GasPriceInt     *big.Int  `json:"gasPriceInt"`
-	GasUsed         string    `json:"gasUsed"`
-	GasUsedInt      *big.Int  `json:"gasUsedInt"`
+	Gas             uint64    `json:"gas"`
+	GasPrice        *big.Int  `json:"gasPrice"`
+	GasUsed         uint64    `json:"gasUsed"`
 }
 
 type Erc20Log struct {
 	ChainID         *big.Int  `json:"chainID"`
-	Timestamp       string    `json:"timestamp"`
-	TimestampInt    *big.Int  `json:"timestampInt"`
-	CreatedAt       time.Time `json:"createdAt"`
+	Timestamp       time.Time `json:"timestamp"`
 	TransactionHash string    `json:"transactionHash"`
+	LogIndex        uint64    `json:"logIndex"`
 	ContractAddress string    `json:"contractAddress"`
 	From            string    `json:"from"`
 	To              string    `json:"to"`
@@ -130,10 +123,9 @@ type Erc20Log struct {
 
 type Erc721Log struct {
 	ChainID         *big.Int  `json:"chainID"`
-	Timestamp       string    `json:"timestamp"`
-	TimestampInt    *big.Int  `json:"timestampInt"`
-	CreatedAt       time.Time `json:"createdAt"`
+	Timestamp       time.Time `json:"timestamp"`
 	TransactionHash string    `json:"transactionHash"`
+	LogIndex        uint64    `json:"logIndex"`
 	ContractAddress string    `json:"contractAddress"`
 	From            string    `json:"from"`
 	To              string    `json:"to"`
@@ -145,10 +137,10 @@ type Erc721Log struct {
 
 type Erc1155Log struct {
 	ChainID         *big.Int  `json:"chainID"`
-	Timestamp       string    `json:"timestamp"`
-	TimestampInt    *big.Int  `json:"timestampInt"`
-	CreatedAt       time.Time `json:"createdAt"`
+	Timestamp       time.Time `json:"timestamp"`
 	TransactionHash string    `json:"transactionHash"`
+	LogIndex        uint64    `json:"logIndex"`
+	BatchIndex      int       `json:"batchIndex"` // TransferBatch 안에서의 순서
 	ContractAddress string    `json:"contractAddress"`
 	From            string    `json:"from"`
 	To              string    `json:"to"`
@@ -158,3 +150,107 @@ type Erc1155Log struct {
 	Name            string    `json:"name,omitempty"`
 	Symbol          string    `json:"symbol,omitempty"`
 }
+
+// BalanceSeed 처음 등장한 주소/토큰의 직전 블록 온체인 잔액
+type BalanceSeed struct {
+	Kind        string   `json:"kind"` // coin / erc20 / erc721 / erc1155
+	Hash        string   `json:"hash,omitempty"`
+	TokenId     *big.Int `json:"tokenID,omitempty"`
+	Address     string   `json:"address"`
+	Balance     *big.Int `json:"balance"`
+	BlockNumber uint64   `json:"blockNumber"`
+}
+
+const (
+	AnomalyMalformedLog               = "malformed_log"
+	AnomalyErc20NegativeBalance       = "erc20_negative_balance"
+	AnomalyErc1155InsufficientBalance = "erc1155_insufficient_balance"
+)
+
+// Anomaly 반영은 하되 따로 확인이 필요한 데이터
+type Anomaly struct {
+	Kind            string   `json:"kind"`
+	TransactionHash string   `json:"transactionHash"`
+	LogIndex        *uint64  `json:"logIndex,omitempty"`
+	Contract        string   `json:"contract,omitempty"`
+	Address         string   `json:"address,omitempty"`
+	Expected        *big.Int `json:"expected,omitempty"`
+	Actual          *big.Int `json:"actual,omitempty"`
+	Detail          string   `json:"detail,omitempty"`
+}
+
+// Transfer 블록 안의 coin/erc20/erc721/erc1155 전송을 한 모양으로 펼친 것
+type Transfer struct {
+	Kind            string    `json:"kind"` // coin / erc20 / erc721 / erc1155
+	TransactionHash string    `json:"transactionHash"`
+	LogIndex        int64     `json:"logIndex"`   // coin 이면 -1
+	BatchIndex      int       `json:"batchIndex"` // erc1155 TransferBatch 안에서의 순서
+	Contract        string    `json:"contract,omitempty"`
+	TokenId         *big.Int  `json:"tokenID,omitempty"`
+	From            string    `json:"from"`
+	To              string    `json:"to"`
+	Amount          *big.Int  `json:"amount"` // erc721 은 1
+	Function        string    `json:"function,omitempty"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// Transfers 트랜잭션 순서대로, 트랜잭션 안에서는 coin -> erc20 -> erc721 -> erc1155 순. 주소는 소문자
+func (b *Block) Transfers() []*Transfer {
+	var transfers []*Transfer
+	for _, tx := range b.Transaction {
+		if tx.CoinLogs != nil {
+			transfers = append(transfers, &Transfer{
+				Kind:            "coin",
+				TransactionHash: tx.CoinLogs.TransactionHash,
+				LogIndex:        -1,
+				From:            strings.ToLower(tx.CoinLogs.From),
+				To:              strings.ToLower(tx.CoinLogs.To),
+				Amount:          tx.CoinLogs.Amount,
+				Timestamp:       tx.CoinLogs.Timestamp,
+			})
+		}
+		for _, log := range tx.Erc20Logs {
+			transfers = append(transfers, &Transfer{
+				Kind:            "erc20",
+				TransactionHash: log.TransactionHash,
+				LogIndex:        int64(log.LogIndex),
+				Contract:        strings.ToLower(log.ContractAddress),
+				From:            strings.ToLower(log.From),
+				To:              strings.ToLower(log.To),
+				Amount:          log.Amount,
+				Function:        log.Function,
+				Timestamp:       log.Timestamp,
+			})
+		}
+		for _, log := range tx.Erc721Logs {
+			transfers = append(transfers, &Transfer{
+				Kind:            "erc721",
+				TransactionHash: log.TransactionHash,
+				LogIndex:        int64(log.LogIndex),
+				Contract:        strings.ToLower(log.ContractAddress),
+				TokenId:         log.TokenId,
+				From:            strings.ToLower(log.From),
+				To:              strings.ToLower(log.To),
+				Amount:          big.NewInt(1),
+				Function:        log.Function,
+				Timestamp:       log.Timestamp,
+			})
+		}
+		for _, log := range tx.Erc1155Logs {
+			transfers = append(transfers, &Transfer{
+				Kind:            "erc1155",
+				TransactionHash: log.TransactionHash,
+				LogIndex:        int64(log.LogIndex),
+				BatchIndex:      log.BatchIndex,
+				Contract:        strings.ToLower(log.ContractAddress),
+				TokenId:         log.TokenId,
+				From:            strings.ToLower(log.From),
+				To:              strings.ToLower(log.To),
+				Amount:          log.Amount,
+				Function:        log.Function,
+				Timestamp:       log.Timestamp,
+			})
+		}
+	}
+	return transfers
+}
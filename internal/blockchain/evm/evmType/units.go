@@ -0,0 +1,43 @@
+package evmType
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// FormatUnits 1500000000000000000, 18 -> 1.5
+func FormatUnits(amount *big.Int, decimals int) string {
+	if decimals <= 0 {
+		return amount.String()
+	}
+
+	digits := new(big.Int).Abs(amount).String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	whole, fraction := digits[:len(digits)-decimals], strings.TrimRight(digits[len(digits)-decimals:], "0")
+	value := whole
+	if fraction != "" {
+		value += "." + fraction
+	}
+	if amount.Sign() < 0 {
+		value = "-" + value
+	}
+	return value
+}
+
+// ParseUnits "1.5", 18 -> 1500000000000000000
+func ParseUnits(value string, decimals int) (*big.Int, error) {
+	whole, fraction, _ := strings.Cut(value, ".")
+	if len(fraction) > decimals {
+		return nil, fmt.Errorf("%s has more than %d decimals", value, decimals)
+	}
+
+	amount, ok := new(big.Int).SetString(whole+fraction+strings.Repeat("0", decimals-len(fraction)), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %s", value)
+	}
+	return amount, nil
+}
@@ -0,0 +1,333 @@
+package evm
+
+import (
+	"blockchain-tracking/config"
+	"blockchain-tracking/internal/blockchain/jsonRpc"
+	"blockchain-tracking/internal/core/domain/blockchain"
+	"blockchain-tracking/internal/health"
+	"blockchain-tracking/internal/logger"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrChainNotFound  = errors.New("chain not found")
+	ErrChainExists    = errors.New("chain is already tracked")
+	ErrReindexRunning = errors.New("reindex is running")
+	ErrInvalidRange   = errors.New("invalid block range")
+	ErrRPCUnreachable = errors.New("rpc is unreachable")
+)
+
+// TrackFunc 체인 하나를 추적한다 (StartTrack). ctx 가 끝나면 돌아와야 한다
+type TrackFunc func(ctx context.Context, chain config.Chain, status *health.Chain) error
+
+// Manager 실행 중에 체인 트래커를 추가하고 멈추고 다시 띄운다 (운영 API)
+type Manager struct {
+	supervisor        *Supervisor
+	registry          *health.Registry
+	jrAdapter         *jsonRpc.JsonRpc
+	blockchainService *blockchain.Service
+	track             TrackFunc
+	l                 logger.Logger
+
+	mu     sync.Mutex
+	chains []*trackedChain
+}
+
+type trackedChain struct {
+	chain  config.Chain
+	status *health.Chain
+	paused bool
+	job    *ReindexJob
+
+	cancel context.CancelFunc // 트래커가 돌고 있지 않으면 nil
+	done   chan struct{}      // 마지막으로 띄운 트래커가 끝나면 닫힌다
+}
+
+// ReindexJob 재인덱싱 진행 상황. Next 는 다음에 받을 블록
+type ReindexJob struct {
+	From     uint64     `json:"from"`
+	To       uint64     `json:"to"`
+	Next     uint64     `json:"next"`
+	Started  time.Time  `json:"started"`
+	Finished *time.Time `json:"finished,omitempty"`
+	Error    string     `json:"error,omitempty"`
+}
+
+type ChainInfo struct {
+	health.Status
+	ChainID int64       `json:"chainId"`
+	Paused  bool        `json:"paused"`
+	Reindex *ReindexJob `json:"reindex,omitempty"`
+}
+
+func NewManager(supervisor *Supervisor, registry *health.Registry, jrAdapter *jsonRpc.JsonRpc, blockchainService *blockchain.Service, track TrackFunc, l logger.Logger) *Manager {
+	return &Manager{
+		supervisor:        supervisor,
+		registry:          registry,
+		jrAdapter:         jrAdapter,
+		blockchainService: blockchainService,
+		track:             track,
+		l:                 l,
+	}
+}
+
+// Start 체인 트래커를 Supervisor 로 띄운다
+func (m *Manager) Start(chain config.Chain) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.find(chain.Name) != nil {
+		return fmt.Errorf("%w: %s", ErrChainExists, chain.Name)
+	}
+	for _, c := range m.chains {
+		if c.chain.ChainID == chain.ChainID {
+			return fmt.Errorf("%w: chain id %d is tracked by %s", ErrChainExists, chain.ChainID, c.chain.Name)
+		}
+	}
+
+	c := &trackedChain{
+		chain:  chain,
+		status: m.registry.Chain(chain.Name),
+		done:   make(chan struct{}),
+	}
+	close(c.done)
+	m.chains = append(m.chains, c)
+	m.run(c)
+	return nil
+}
+
+// Add 체인 레지스트리에 새로 넣은 체인을 재시작 없이 띄운다. RPC 의 eth_chainId 가 설정과 다르면 띄우지 않는다
+func (m *Manager) Add(ctx context.Context, chain config.Chain) error {
+	m.mu.Lock()
+	exists := m.find(chain.Name) != nil
+	m.mu.Unlock()
+	if exists {
+		return fmt.Errorf("%w: %s", ErrChainExists, chain.Name)
+	}
+
+	unreachable, err := VerifyChainID(ctx, chain, m.jrAdapter)
+	if err != nil {
+		return err
+	}
+	if len(unreachable) == len(chain.RPC) {
+		return fmt.Errorf("%w: %s %s", ErrRPCUnreachable, chain.Name, strings.Join(unreachable, ","))
+	}
+	if len(unreachable) > 0 {
+		m.l.Warn(fmt.Sprintf("%s rpc is unreachable, skipped chain id check", chain.Name), logger.Field{Key: "endpoints", Value: strings.Join(unreachable, ",")})
+	}
+
+	if err = m.Start(chain); err != nil {
+		return err
+	}
+	m.l.Info(fmt.Sprintf("%s chain added", chain.Name), logger.Field{Key: "chain_id", Value: chain.ChainID}, logger.Field{Key: "endpoints", Value: len(chain.RPC)})
+	return nil
+}
+
+// Pause 트래커를 멈춘다. 추적 중인 라운드가 끝날 때까지 기다린다
+func (m *Manager) Pause(name string) error {
+	m.mu.Lock()
+	c := m.find(name)
+	if c == nil {
+		m.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrChainNotFound, name)
+	}
+	if c.running() {
+		m.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrReindexRunning, name)
+	}
+	c.paused = true
+	c.status.Suspend(health.StatePaused)
+	done := m.stop(c)
+	m.mu.Unlock()
+
+	<-done
+	m.l.Info(fmt.Sprintf("%s chain tracker paused", name))
+	return nil
+}
+
+func (m *Manager) Resume(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c := m.find(name)
+	if c == nil {
+		return fmt.Errorf("%w: %s", ErrChainNotFound, name)
+	}
+	if c.running() {
+		return fmt.Errorf("%w: %s", ErrReindexRunning, name)
+	}
+	if !c.paused {
+		return nil
+	}
+
+	c.paused = false
+	c.status.Release()
+	m.run(c)
+	m.l.Info(fmt.Sprintf("%s chain tracker resumed", name))
+	return nil
+}
+
+// Reindex from~to 구간에 저장된 데이터를 지우고 다시 받는다. 트래커는 멈췄다가 끝나면 다시 띄운다 (멈춰 있던 체인은 그대로 둔다).
+// 다시 받다가 실패하면 구간이 비어 있으므로 트래커를 띄우지 않고 paused 로 남긴다. 같은 구간으로 다시 요청하면 된다
+func (m *Manager) Reindex(ctx context.Context, name string, from, to uint64) (ReindexJob, error) {
+	if from > to {
+		return ReindexJob{}, fmt.Errorf("%w: from %d is greater than to %d", ErrInvalidRange, from, to)
+	}
+
+	m.mu.Lock()
+	c := m.find(name)
+	if c == nil {
+		m.mu.Unlock()
+		return ReindexJob{}, fmt.Errorf("%w: %s", ErrChainNotFound, name)
+	}
+	if c.running() {
+		m.mu.Unlock()
+		return ReindexJob{}, fmt.Errorf("%w: %s", ErrReindexRunning, name)
+	}
+	chain := c.chain
+	m.mu.Unlock()
+
+	height, err := m.blockchainService.GetBlockHeight(ctx, chain.ChainID)
+	if err != nil {
+		return ReindexJob{}, err
+	}
+	// 트래커는 저장된 마지막 블록 다음부터 받으므로 그 뒤에서 시작하면 사이가 빈다
+	if next := max(uint64(height+1), chain.StartBlock); from > next {
+		return ReindexJob{}, fmt.Errorf("%w: from must not be after %d (next block to track)", ErrInvalidRange, next)
+	}
+	if s, ok := m.registry.StatusOf(name); ok && s.Head > 0 && to+chain.Confirmations > uint64(s.Head) {
+		return ReindexJob{}, fmt.Errorf("%w: to must not be after %d (head - confirmations)", ErrInvalidRange, uint64(s.Head)-min(chain.Confirmations, uint64(s.Head)))
+	}
+
+	m.mu.Lock()
+	if c.running() {
+		m.mu.Unlock()
+		return ReindexJob{}, fmt.Errorf("%w: %s", ErrReindexRunning, name)
+	}
+	job := &ReindexJob{From: from, To: to, Next: from, Started: time.Now()}
+	c.job = job
+	paused := c.paused
+	c.status.Suspend(health.StateReindexing)
+	done := m.stop(c)
+	snapshot := *job
+	m.mu.Unlock()
+
+	go m.reindex(c, job, paused, done)
+
+	return snapshot, nil
+}
+
+func (m *Manager) reindex(c *trackedChain, job *ReindexJob, paused bool, trackerDone <-chan struct{}) {
+	name := c.chain.Name
+	ctx := context.Background()
+
+	// 추적 중인 라운드가 끝나야 구간을 지울 수 있다
+	<-trackerDone
+	m.l.Info(fmt.Sprintf("%s reindex started", name), logger.Field{Key: "from", Value: job.From}, logger.Field{Key: "to", Value: job.To})
+
+	err := m.blockchainService.Unindex(ctx, c.chain.ChainID, int64(job.From), int64(job.To))
+	if err == nil {
+		err = ScanRange(ctx, c.chain, job.From, job.To, m.jrAdapter, m.blockchainService, func(next uint64) {
+			m.mu.Lock()
+			job.Next = next
+			m.mu.Unlock()
+		}, m.l)
+	}
+	if err == nil {
+		err = m.blockchainService.SyncERC721Owners(ctx, c.chain.ChainID, int64(job.From), int64(job.To))
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	finished := time.Now()
+	job.Finished = &finished
+	if err != nil {
+		job.Error = err.Error()
+		c.paused = true
+		c.status.Suspend(health.StatePaused)
+		m.l.Error(fmt.Sprintf("%s reindex failed, tracker stays paused", name), logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "from", Value: job.From}, logger.Field{Key: "to", Value: job.To}, logger.Field{Key: "next", Value: job.Next})
+		return
+	}
+
+	m.l.Info(fmt.Sprintf("%s reindex finished", name), logger.Field{Key: "from", Value: job.From}, logger.Field{Key: "to", Value: job.To}, logger.Field{Key: "seconds", Value: int64(finished.Sub(job.Started).Seconds())})
+	if paused {
+		c.status.Suspend(health.StatePaused)
+		return
+	}
+	c.status.Release()
+	m.run(c)
+}
+
+// Chains 트래커마다 상태와 마지막 재인덱싱
+func (m *Manager) Chains() []ChainInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	infos := make([]ChainInfo, 0, len(m.chains))
+	for _, c := range m.chains {
+		info := ChainInfo{ChainID: c.chain.ChainID, Paused: c.paused}
+		info.Status, _ = m.registry.StatusOf(c.chain.Name)
+		if c.job != nil {
+			job := *c.job
+			info.Reindex = &job
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// Chain 이름으로 트래커 하나
+func (m *Manager) Chain(name string) (ChainInfo, error) {
+	for _, info := range m.Chains() {
+		if info.Name == name {
+			return info, nil
+		}
+	}
+	return ChainInfo{}, fmt.Errorf("%w: %s", ErrChainNotFound, name)
+}
+
+func (m *Manager) find(name string) *trackedChain {
+	for _, c := range m.chains {
+		if c.chain.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// run 트래커를 띄운다. 이전 트래커가 끝난 뒤에 시작한다 (m.mu 를 잡고 부른다)
+func (m *Manager) run(c *trackedChain) {
+	ctx, cancel := context.WithCancel(context.Background())
+	prev, done := c.done, make(chan struct{})
+	c.cancel, c.done = cancel, done
+
+	chain := c.chain
+	go func() {
+		defer close(done)
+		<-prev
+
+		m.supervisor.Run(ctx, chain.Name, func(ctx context.Context, status *health.Chain) error {
+			return m.track(ctx, chain, status)
+		})
+	}()
+}
+
+// stop 트래커를 끝내고 끝나면 닫히는 채널을 돌려준다 (m.mu 를 잡고 부른다)
+func (m *Manager) stop(c *trackedChain) <-chan struct{} {
+	if c.cancel != nil {
+		c.cancel()
+		c.cancel = nil
+	}
+	return c.done
+}
+
+// running 재인덱싱 중인지
+func (c *trackedChain) running() bool {
+	return c.job != nil && c.job.Finished == nil
+}
@@ -0,0 +1,300 @@
+package evm
+
+import (
+	"blockchain-tracking/internal/blockchain/evm/abi/erc1155"
+	"blockchain-tracking/internal/blockchain/evm/abi/erc20"
+	"blockchain-tracking/internal/blockchain/evm/abi/erc721"
+	"blockchain-tracking/internal/core/domain/reconcile"
+	"blockchain-tracking/internal/database/postgresql"
+	"blockchain-tracking/internal/logger"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const DefaultReconcilePageSize = 200
+
+// errMalformedResult 호출은 성공했지만 돌려준 값이 ABI 와 맞지 않는다
+var errMalformedResult = errors.New("malformed call result")
+
+type ReconcileOptions struct {
+	Kinds    []reconcile.Kind
+	Sample   int // 0 이면 전체 스캔
+	Repair   bool
+	PageSize int
+}
+
+// Reconcile DB 잔액을 인덱싱 높이 기준 온체인 값과 비교해 balance_drift 에 기록한다.
+// 높이는 실행을 시작할 때 한 번 정하고, 실패해도 reconcile_run 은 failed 로 끝낸다.
+func Reconcile(ctx context.Context, name, rpc string, opts ReconcileOptions, reconcileService *reconcile.Service, l logger.Logger) (runID int64, err error) {
+	client, err := ethclient.DialContext(ctx, rpc)
+	if err != nil {
+		l.Error("eth client dial context", logger.Field{Key: "error", Value: err.Error()})
+		return 0, err
+	}
+	defer client.Close()
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		l.Error(fmt.Sprintf("%s get chain id", name), logger.Field{Key: "error", Value: err.Error()})
+		return 0, err
+	}
+
+	if opts.PageSize <= 0 {
+		opts.PageSize = DefaultReconcilePageSize
+	}
+	if len(opts.Kinds) == 0 {
+		opts.Kinds = reconcile.Kinds
+	}
+
+	mode := "full"
+	limit := opts.PageSize
+	if opts.Sample > 0 {
+		mode = "sample"
+		limit = opts.Sample
+	}
+
+	runID, err = reconcileService.StartRun(ctx, chainID.Int64(), mode, opts.Repair)
+	if err != nil {
+		return 0, err
+	}
+
+	checked, mismatched, skipped := 0, 0, 0
+	defer func() {
+		// ctx 가 취소되어 끝난 경우에도 실행 기록은 닫는다
+		finishErr := reconcileService.FinishRun(context.WithoutCancel(ctx), runID, checked, mismatched, skipped, err)
+		if err == nil {
+			err = finishErr
+		}
+	}()
+
+	height, err := reconcileService.Height(ctx, chainID.Int64())
+	if err != nil {
+		return runID, err
+	}
+	if height < 0 {
+		l.Warn(fmt.Sprintf("%s has no indexed blocks, nothing to reconcile", name))
+		return runID, nil
+	}
+	blockNumber := big.NewInt(height)
+
+	for _, kind := range opts.Kinds {
+		afterID := int64(0)
+		for {
+			page, err := reconcileService.Snapshot(ctx, chainID.Int64(), kind, afterID, int32(limit), opts.Sample > 0, height)
+			if err != nil {
+				return runID, err
+			}
+			if page.Rows == 0 {
+				break
+			}
+
+			drifts, failed, err := compareHoldings(ctx, name, client, kind, page.Holdings, blockNumber, l)
+			if err != nil {
+				l.Error(fmt.Sprintf("%s reconcile %s", name, kind), logger.Field{Key: "error", Value: err.Error()})
+				return runID, err
+			}
+
+			checked += len(page.Holdings) - failed
+			skipped += failed
+			for _, drift := range drifts {
+				err = reconcileService.RecordDrift(ctx, runID, chainID.Int64(), drift, opts.Repair)
+				if err != nil {
+					return runID, err
+				}
+				mismatched++
+			}
+
+			if opts.Sample > 0 || page.Rows < limit {
+				break
+			}
+			afterID = page.LastID
+		}
+	}
+
+	summary, err := reconcileService.Summary(ctx, runID)
+	if err != nil {
+		l.Error("balance drift summary", logger.Field{Key: "error", Value: err.Error()})
+		return runID, err
+	}
+	for _, row := range summary {
+		l.Warn(fmt.Sprintf("%s balance drift", name),
+			logger.Field{Key: "kind", Value: row.Kind},
+			logger.Field{Key: "token", Value: postgresql.BytesToHex(row.Hash)},
+			logger.Field{Key: "mismatches", Value: row.Mismatches},
+			logger.Field{Key: "totalDifference", Value: row.TotalDifference},
+		)
+	}
+
+	l.Info(fmt.Sprintf("%s reconcile finished", name),
+		logger.Field{Key: "run", Value: runID},
+		logger.Field{Key: "block", Value: height},
+		logger.Field{Key: "checked", Value: checked},
+		logger.Field{Key: "mismatched", Value: mismatched},
+		logger.Field{Key: "skipped", Value: skipped},
+	)
+
+	return runID, nil
+}
+
+// compareHoldings 온체인 값과 다른 잔액을 돌려준다. 컨트랙트 호출이 실패한 잔액은 건너뛰고 그 수를 돌려준다
+func compareHoldings(ctx context.Context, name string, client *ethclient.Client, kind reconcile.Kind, holdings []*reconcile.Holding, blockNumber *big.Int, l logger.Logger) ([]*reconcile.Drift, int, error) {
+	callOpts := &bind.CallOpts{Context: ctx, BlockNumber: blockNumber}
+
+	var drifts []*reconcile.Drift
+	skipped := 0
+	// 표준을 따르지 않거나 revert 되는 컨트랙트 하나 때문에 전체를 멈추지 않는다. RPC 연결 오류는 그대로 돌려준다
+	skip := func(err error, hash string, count int) error {
+		if !contractCallFailed(ctx, err) {
+			return err
+		}
+		skipped += count
+		l.Warn(fmt.Sprintf("%s reconcile %s call failed, skipped", name, kind), logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "token", Value: hash}, logger.Field{Key: "count", Value: count})
+		return nil
+	}
+	compare := func(h *reconcile.Holding, onchain *big.Int, onchainAddress string) {
+		stored, ok := new(big.Int).SetString(h.Balance, 10)
+		if !ok {
+			stored = big.NewInt(0)
+		}
+		if stored.Cmp(onchain) == 0 && (onchainAddress == "" || onchainAddress == h.Address) {
+			return
+		}
+		drifts = append(drifts, &reconcile.Drift{
+			Holding:        h,
+			BlockNumber:    blockNumber,
+			Stored:         stored,
+			Onchain:        onchain,
+			OnchainAddress: onchainAddress,
+			Difference:     new(big.Int).Sub(onchain, stored),
+		})
+	}
+
+	switch kind {
+	case reconcile.KindCoin:
+		for _, h := range holdings {
+			balance, err := client.BalanceAt(ctx, common.HexToAddress(h.Address), blockNumber)
+			if err != nil {
+				return nil, 0, err
+			}
+			compare(h, balance, "")
+		}
+	case reconcile.KindErc20:
+		callers := make(map[string]*erc20.Erc20Caller)
+		for _, h := range holdings {
+			caller, ok := callers[h.Hash]
+			if !ok {
+				var err error
+				caller, err = erc20.NewErc20Caller(common.HexToAddress(h.Hash), client)
+				if err != nil {
+					return nil, 0, err
+				}
+				callers[h.Hash] = caller
+			}
+			balance, err := caller.BalanceOf(callOpts, common.HexToAddress(h.Address))
+			if err != nil {
+				if err = skip(err, h.Hash, 1); err != nil {
+					return nil, 0, err
+				}
+				continue
+			}
+			compare(h, balance, "")
+		}
+	case reconcile.KindErc721:
+		callers := make(map[string]*erc721.Erc721Caller)
+		for _, h := range holdings {
+			caller, ok := callers[h.Hash]
+			if !ok {
+				var err error
+				caller, err = erc721.NewErc721Caller(common.HexToAddress(h.Hash), client)
+				if err != nil {
+					return nil, 0, err
+				}
+				callers[h.Hash] = caller
+			}
+			tokenID, _ := new(big.Int).SetString(h.TokenID, 10)
+			owner, err := caller.OwnerOf(callOpts, tokenID)
+			if err != nil {
+				// 소각된 토큰은 ownerOf 가 revert 된다
+				if !strings.Contains(err.Error(), "execution reverted") {
+					if err = skip(err, h.Hash, 1); err != nil {
+						return nil, 0, err
+					}
+					continue
+				}
+				owner = common.Address{}
+			}
+			onchainAddress := strings.ToLower(owner.String())
+			onchain := big.NewInt(1)
+			if onchainAddress != h.Address {
+				onchain = big.NewInt(0)
+			}
+			compare(h, onchain, onchainAddress)
+		}
+	case reconcile.KindErc1155:
+		// 컨트랙트별로 묶어서 balanceOfBatch 한 번에 조회
+		grouped := make(map[string][]*reconcile.Holding)
+		var order []string
+		for _, h := range holdings {
+			if _, ok := grouped[h.Hash]; !ok {
+				order = append(order, h.Hash)
+			}
+			grouped[h.Hash] = append(grouped[h.Hash], h)
+		}
+		for _, hash := range order {
+			caller, err := erc1155.NewErc1155Caller(common.HexToAddress(hash), client)
+			if err != nil {
+				return nil, 0, err
+			}
+			accounts := make([]common.Address, len(grouped[hash]))
+			ids := make([]*big.Int, len(grouped[hash]))
+			for i, h := range grouped[hash] {
+				accounts[i] = common.HexToAddress(h.Address)
+				ids[i], _ = new(big.Int).SetString(h.TokenID, 10)
+			}
+			balances, err := caller.BalanceOfBatch(callOpts, accounts, ids)
+			if err == nil && len(balances) != len(accounts) {
+				err = fmt.Errorf("%w: balanceOfBatch returned %d values for %d accounts", errMalformedResult, len(balances), len(accounts))
+			}
+			if err != nil {
+				if err = skip(err, hash, len(accounts)); err != nil {
+					return nil, 0, err
+				}
+				continue
+			}
+			for i, h := range grouped[hash] {
+				compare(h, balances[i], "")
+			}
+		}
+	default:
+		return nil, 0, fmt.Errorf("unknown balance kind %s", kind)
+	}
+
+	return drifts, skipped, nil
+}
+
+// contractCallFailed 컨트랙트 쪽 실패 (revert, 코드 없음, 디코딩 실패) 인지.
+// 그 밖의 오류 (연결, HTTP, 취소, 노드가 그 높이의 상태를 갖고 있지 않은 missing trie node 등) 는 false 로 실행을 실패시킨다
+func contractCallFailed(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, bind.ErrNoCode) || errors.Is(err, errMalformedResult) {
+		return true
+	}
+
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr.ErrorCode() == 3 || strings.Contains(rpcErr.Error(), "execution reverted")
+	}
+
+	// abi 패키지의 디코딩 오류는 따로 타입이 없어서 접두어로 구분한다
+	return strings.HasPrefix(err.Error(), "abi: ")
+}
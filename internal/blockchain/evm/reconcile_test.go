@@ -0,0 +1,60 @@
+package evm
+
+import (
+	"blockchain-tracking/internal/blockchain/evm/abi/erc20"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type testRPCError struct {
+	code    int
+	message string
+}
+
+func (e *testRPCError) Error() string  { return e.message }
+func (e *testRPCError) ErrorCode() int { return e.code }
+
+func TestContractCallFailed(t *testing.T) {
+	parsed, err := erc20.Erc20MetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, unpackErr := parsed.Unpack("balanceOf", []byte{0x01})
+	if unpackErr == nil {
+		t.Fatal("expected an unpack error")
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want bool
+	}{
+		{"revert with data", context.Background(), &testRPCError{3, "execution reverted: ERC20: paused"}, true},
+		{"revert without data", context.Background(), &testRPCError{-32000, "execution reverted"}, true},
+		{"no code", context.Background(), bind.ErrNoCode, true},
+		{"abi unpack", context.Background(), unpackErr, true},
+		{"short batch result", context.Background(), fmt.Errorf("%w: balanceOfBatch returned 1 values for 2 accounts", errMalformedResult), true},
+		{"missing trie node", context.Background(), &testRPCError{-32000, "missing trie node 0a1b (path )"}, false},
+		{"header not found", context.Background(), &testRPCError{-32000, "header not found"}, false},
+		{"http error", context.Background(), rpc.HTTPError{StatusCode: 502, Status: "502 Bad Gateway"}, false},
+		{"connection refused", context.Background(), &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, false},
+		{"canceled", canceled, &testRPCError{3, "execution reverted"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := contractCallFailed(tt.ctx, tt.err); got != tt.want {
+				t.Errorf("contractCallFailed(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,403 @@
+package evm
+
+import (
+	"blockchain-tracking/internal/blockchain/evm/evmType"
+	"blockchain-tracking/internal/blockchain/jsonRpc"
+	"blockchain-tracking/internal/core/domain/blockchain"
+	"blockchain-tracking/internal/logger"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MaxEnumerableBootstrap 컬렉션 하나에서 가져올 최대 토큰 수
+const MaxEnumerableBootstrap = 10000
+
+const seedBatchSize = 100
+
+const zeroAddress = "0x0000000000000000000000000000000000000000"
+
+// ERC721Enumerable interface ID
+var erc721EnumerableID = common.FromHex("0x780e9d63")
+
+type ethCall struct {
+	To   string
+	Data []byte
+}
+
+// SeedBalances 블록에서 처음 등장하는 주소/토큰의 잔액을 직전 블록 기준으로 읽어 block.Seeds 에 담는다.
+// DB 상태를 보고 판단하므로 Create 직전에 블록 순서대로 호출해야 한다.
+// CreateBatch 처럼 여러 블록을 모아서 저장할 때는 같은 seen 을 넘겨 배치 안에서 한 번만 시딩한다.
+func SeedBalances(ctx context.Context, rpc string, block *evmType.Block, seen map[blockchain.SeedKey]bool, jrAdapter *jsonRpc.JsonRpc, blockchainService *blockchain.Service, l logger.Logger) error {
+	if block.Number == 0 {
+		return nil
+	}
+
+	chainID := block.ChainID.Int64()
+	prev := block.Number - 1
+	prevHex := hexutil.EncodeUint64(prev)
+
+	keys, collections := collectSeedKeys(block, seen)
+	if len(keys) == 0 && len(collections) == 0 {
+		return nil
+	}
+
+	unseeded, err := blockchainService.FilterUnseeded(ctx, chainID, keys)
+	if err != nil {
+		l.Error("filter unseeded balance", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "block number", Value: block.Number})
+		return err
+	}
+
+	var wallets []blockchain.SeedKey
+	var tokens []blockchain.SeedKey
+	for _, key := range unseeded {
+		if key.Kind == "coin" {
+			wallets = append(wallets, key)
+		} else {
+			tokens = append(tokens, key)
+		}
+	}
+
+	var seeds []*evmType.BalanceSeed
+
+	// 네이티브 잔액
+	if len(wallets) > 0 {
+		params := make([][]interface{}, len(wallets))
+		for i, key := range wallets {
+			params[i] = []interface{}{key.Address, prevHex}
+		}
+		results, err := batchRequest(ctx, rpc, "eth_getBalance", params, jrAdapter)
+		if err != nil {
+			l.Error("seed eth_getBalance", logger.Field{Key: "error", Value: err.Error()})
+			return err
+		}
+		for i, key := range wallets {
+			if results[i] == nil || results[i].Error != nil {
+				return fmt.Errorf("eth_getBalance %s failed at block %d", key.Address, prev)
+			}
+			balance, err := hexutil.DecodeBig(results[i].Result)
+			if err != nil {
+				return err
+			}
+			seeds = append(seeds, &evmType.BalanceSeed{Kind: "coin", Address: key.Address, Balance: balance, BlockNumber: prev})
+		}
+	}
+
+	// 토큰 잔액 (erc20 balanceOf / erc721 ownerOf / erc1155 balanceOf)
+	if len(tokens) > 0 {
+		calls := make([]ethCall, len(tokens))
+		for i, key := range tokens {
+			calls[i] = seedCall(key)
+		}
+		results, err := batchEthCall(ctx, rpc, calls, prevHex, jrAdapter)
+		if err != nil {
+			l.Error("seed eth_call", logger.Field{Key: "error", Value: err.Error()})
+			return err
+		}
+		for i, key := range tokens {
+			if results[i] == nil {
+				// revert 된 호출은 표준을 따르지 않는 컨트랙트로 보고 건너뛴다
+				l.Warn("seed balance call reverted", logger.Field{Key: "token", Value: key.Hash}, logger.Field{Key: "address", Value: key.Address})
+				continue
+			}
+
+			tokenId, _ := new(big.Int).SetString(key.TokenId, 10)
+			seed := &evmType.BalanceSeed{Kind: key.Kind, Hash: key.Hash, TokenId: tokenId, Address: key.Address, BlockNumber: prev}
+
+			if key.Kind == "erc721" {
+				owner, ok := decodeAddress(results[i])
+				if !ok || owner == zeroAddress {
+					continue
+				}
+				seed.Address = owner
+				seed.Balance = big.NewInt(1)
+			} else {
+				seed.Balance, err = decodeUint(results[i])
+				if err != nil {
+					l.Warn("seed balance decode", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "token", Value: key.Hash})
+					continue
+				}
+			}
+			seeds = append(seeds, seed)
+		}
+	}
+
+	// 추적 시작 전에 배포된 Enumerable 컬렉션은 기존 소유자를 한 번에 가져온다
+	collections, err = blockchainService.UnseededCollections(ctx, chainID, collections)
+	if err != nil {
+		l.Error("unseeded collections", logger.Field{Key: "error", Value: err.Error()})
+		return err
+	}
+	for _, hash := range collections {
+		owners, err := bootstrapEnumerable(ctx, rpc, hash, prev, jrAdapter, l)
+		if err != nil {
+			l.Error("bootstrap erc721 enumerable", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "token", Value: hash})
+			return err
+		}
+		seeds = append(seeds, owners...)
+	}
+
+	block.Seeds = seeds
+
+	return nil
+}
+
+// collectSeedKeys 블록에서 잔액이 바뀌는 (토큰, 주소) 조합과 ERC721 컬렉션 목록 (seen 에 있는 키는 제외)
+func collectSeedKeys(block *evmType.Block, seen map[blockchain.SeedKey]bool) ([]blockchain.SeedKey, []string) {
+	var keys []blockchain.SeedKey
+	var collections []string
+	if seen == nil {
+		seen = make(map[blockchain.SeedKey]bool)
+	}
+
+	add := func(key blockchain.SeedKey) {
+		if key.Address == "" || key.Address == zeroAddress || seen[key] {
+			return
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	wallet := func(addresses ...string) {
+		for _, address := range addresses {
+			add(blockchain.SeedKey{Kind: "coin", Address: address})
+		}
+	}
+
+	for _, tx := range block.Transaction {
+		if tx.CoinLogs != nil {
+			wallet(tx.CoinLogs.From, tx.CoinLogs.To)
+		}
+		for _, log := range tx.Erc20Logs {
+			wallet(log.From, log.To)
+			add(blockchain.SeedKey{Kind: "erc20", Hash: log.ContractAddress, Address: log.From})
+			add(blockchain.SeedKey{Kind: "erc20", Hash: log.ContractAddress, Address: log.To})
+		}
+		for _, log := range tx.Erc721Logs {
+			wallet(log.From, log.To)
+			collection := blockchain.SeedKey{Kind: "collection", Hash: log.ContractAddress}
+			if !seen[collection] {
+				seen[collection] = true
+				collections = append(collections, log.ContractAddress)
+			}
+			if log.TokenId == nil {
+				continue
+			}
+			// 토큰 단위로 한 번만 본다. mint 된 토큰은 이전 소유자가 없다
+			token := blockchain.SeedKey{Kind: "erc721", Hash: log.ContractAddress, TokenId: log.TokenId.String()}
+			if seen[token] {
+				continue
+			}
+			seen[token] = true
+			if log.From != zeroAddress {
+				keys = append(keys, blockchain.SeedKey{Kind: "erc721", Hash: log.ContractAddress, TokenId: log.TokenId.String(), Address: log.From})
+			}
+		}
+		for _, log := range tx.Erc1155Logs {
+			wallet(log.From, log.To)
+			if log.TokenId == nil {
+				continue
+			}
+			add(blockchain.SeedKey{Kind: "erc1155", Hash: log.ContractAddress, TokenId: log.TokenId.String(), Address: log.From})
+			add(blockchain.SeedKey{Kind: "erc1155", Hash: log.ContractAddress, TokenId: log.TokenId.String(), Address: log.To})
+		}
+	}
+
+	return keys, collections
+}
+
+func seedCall(key blockchain.SeedKey) ethCall {
+	tokenId, _ := new(big.Int).SetString(key.TokenId, 10)
+
+	switch key.Kind {
+	case "erc721":
+		return ethCall{To: key.Hash, Data: callData("ownerOf(uint256)", uintWord(tokenId))}
+	case "erc1155":
+		return ethCall{To: key.Hash, Data: callData("balanceOf(address,uint256)", addressWord(key.Address), uintWord(tokenId))}
+	default:
+		return ethCall{To: key.Hash, Data: callData("balanceOf(address)", addressWord(key.Address))}
+	}
+}
+
+func bootstrapEnumerable(ctx context.Context, rpc, hash string, blockNumber uint64, jrAdapter *jsonRpc.JsonRpc, l logger.Logger) ([]*evmType.BalanceSeed, error) {
+	blockHex := hexutil.EncodeUint64(blockNumber)
+
+	results, err := batchEthCall(ctx, rpc, []ethCall{
+		{To: hash, Data: callData("supportsInterface(bytes4)", common.RightPadBytes(erc721EnumerableID, 32))},
+		{To: hash, Data: callData("totalSupply()")},
+	}, blockHex, jrAdapter)
+	if err != nil {
+		return nil, err
+	}
+
+	supports, err := decodeUint(results[0])
+	if err != nil || supports.Sign() == 0 {
+		return nil, nil
+	}
+	total, err := decodeUint(results[1])
+	if err != nil || total.Sign() == 0 {
+		return nil, nil
+	}
+
+	count := total.Int64()
+	if !total.IsInt64() || count > MaxEnumerableBootstrap {
+		l.Warn("erc721 enumerable bootstrap truncated", logger.Field{Key: "token", Value: hash}, logger.Field{Key: "totalSupply", Value: total.String()})
+		count = MaxEnumerableBootstrap
+	}
+
+	calls := make([]ethCall, count)
+	for i := range calls {
+		calls[i] = ethCall{To: hash, Data: callData("tokenByIndex(uint256)", uintWord(big.NewInt(int64(i))))}
+	}
+	results, err = batchEthCall(ctx, rpc, calls, blockHex, jrAdapter)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokenIds []*big.Int
+	for _, result := range results {
+		tokenId, err := decodeUint(result)
+		if err != nil {
+			continue
+		}
+		tokenIds = append(tokenIds, tokenId)
+	}
+
+	calls = make([]ethCall, len(tokenIds))
+	for i, tokenId := range tokenIds {
+		calls[i] = ethCall{To: hash, Data: callData("ownerOf(uint256)", uintWord(tokenId))}
+	}
+	results, err = batchEthCall(ctx, rpc, calls, blockHex, jrAdapter)
+	if err != nil {
+		return nil, err
+	}
+
+	var seeds []*evmType.BalanceSeed
+	for i, result := range results {
+		owner, ok := decodeAddress(result)
+		if !ok || owner == zeroAddress {
+			continue
+		}
+		seeds = append(seeds, &evmType.BalanceSeed{
+			Kind:        "erc721",
+			Hash:        hash,
+			TokenId:     tokenIds[i],
+			Address:     owner,
+			Balance:     big.NewInt(1),
+			BlockNumber: blockNumber,
+		})
+	}
+
+	l.Info("erc721 enumerable bootstrap", logger.Field{Key: "token", Value: hash}, logger.Field{Key: "owners", Value: len(seeds)})
+
+	return seeds, nil
+}
+
+// batchEthCall eth_call 을 배치로 보낸다. 실패(revert)한 호출의 결과는 nil
+func batchEthCall(ctx context.Context, rpc string, calls []ethCall, blockHex string, jrAdapter *jsonRpc.JsonRpc) ([][]byte, error) {
+	params := make([][]interface{}, len(calls))
+	for i, call := range calls {
+		params[i] = []interface{}{
+			map[string]string{"to": call.To, "data": hexutil.Encode(call.Data)},
+			blockHex,
+		}
+	}
+
+	responses, err := batchRequest(ctx, rpc, "eth_call", params, jrAdapter)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]byte, len(calls))
+	for i, res := range responses {
+		if res == nil || res.Error != nil {
+			continue
+		}
+		data, err := hexutil.Decode(res.Result)
+		if err != nil {
+			continue
+		}
+		results[i] = data
+	}
+
+	return results, nil
+}
+
+func batchRequest(ctx context.Context, rpc, method string, params [][]interface{}, jrAdapter *jsonRpc.JsonRpc) ([]*jsonRpc.EthCallResponse, error) {
+	responses := make([]*jsonRpc.EthCallResponse, len(params))
+
+	for start := 0; start < len(params); start += seedBatchSize {
+		end := start + seedBatchSize
+		if end > len(params) {
+			end = len(params)
+		}
+
+		payloads := make([]jsonRpc.Payload, 0, end-start)
+		for i := start; i < end; i++ {
+			payloads = append(payloads, jsonRpc.Payload{Jsonrpc: "2.0", Method: method, Params: params[i], ID: i})
+		}
+
+		res, err := jrAdapter.CreateRequestMultiple(ctx, rpc, payloads)
+		if err != nil {
+			return nil, err
+		}
+
+		var batch []jsonRpc.EthCallResponse
+		err = json.Unmarshal(res, &batch)
+		if err != nil {
+			return nil, fmt.Errorf("%s batch response: %w", method, err)
+		}
+		for i := range batch {
+			if batch[i].ID >= start && batch[i].ID < end {
+				responses[batch[i].ID] = &batch[i]
+			}
+		}
+	}
+
+	return responses, nil
+}
+
+func callData(signature string, words ...[]byte) []byte {
+	data := append([]byte{}, crypto.Keccak256([]byte(signature))[:4]...)
+	for _, word := range words {
+		data = append(data, word...)
+	}
+	return data
+}
+
+func addressWord(address string) []byte {
+	return common.LeftPadBytes(common.HexToAddress(address).Bytes(), 32)
+}
+
+func uintWord(v *big.Int) []byte {
+	if v == nil {
+		v = new(big.Int)
+	}
+	return common.LeftPadBytes(v.Bytes(), 32)
+}
+
+// decodeUint 아직 배포되지 않은 컨트랙트는 빈 값을 돌려주므로 0 으로 본다
+func decodeUint(data []byte) (*big.Int, error) {
+	if data == nil {
+		return nil, fmt.Errorf("call reverted")
+	}
+	if len(data) == 0 {
+		return big.NewInt(0), nil
+	}
+	if len(data) < 32 {
+		return nil, fmt.Errorf("unexpected return length %d", len(data))
+	}
+	return new(big.Int).SetBytes(data[:32]), nil
+}
+
+func decodeAddress(data []byte) (string, bool) {
+	if len(data) < 32 {
+		return "", false
+	}
+	return strings.ToLower(common.BytesToAddress(data[12:32]).Hex()), true
+}
@@ -0,0 +1,119 @@
+package evm
+
+import (
+	"blockchain-tracking/internal/health"
+	"blockchain-tracking/internal/logger"
+	"blockchain-tracking/internal/metrics"
+	"blockchain-tracking/internal/notify"
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// 재시작 대기 시간은 5초부터 두 배씩, 최대 5분
+	restartBackoffBase = 5 * time.Second
+	restartBackoffMax  = 5 * time.Minute
+	// 이 시간 넘게 돌다가 끝났으면 대기 시간을 처음부터 다시 센다
+	healthyRun = 10 * time.Minute
+)
+
+type SupervisorOptions struct {
+	// BudgetWindow 동안 RestartBudget 번 넘게 재시작하면 critical 알림을 보내고 failed 로 표시한다 (재시작은 계속한다)
+	RestartBudget int
+	BudgetWindow  time.Duration
+}
+
+// Supervisor 체인 트래커를 띄우고, 실패하거나 panic 으로 끝나면 backoff 후 다시 띄운다
+type Supervisor struct {
+	registry *health.Registry
+	notifier notify.Notifier
+	opts     SupervisorOptions
+	l        logger.Logger
+}
+
+func NewSupervisor(registry *health.Registry, notifier notify.Notifier, opts SupervisorOptions, l logger.Logger) *Supervisor {
+	return &Supervisor{
+		registry: registry,
+		notifier: notifier,
+		opts:     opts,
+		l:        l,
+	}
+}
+
+// Run ctx 가 끝날 때까지 track 을 다시 띄운다
+func (s *Supervisor) Run(ctx context.Context, name string, track func(ctx context.Context, status *health.Chain) error) {
+	status := s.registry.Chain(name)
+
+	var restarts []time.Time
+	attempt := 0
+	escalated := false
+
+	for {
+		status.Start()
+		s.l.Info(fmt.Sprintf("%s chain tracker start", name), logger.Field{Key: "chain", Value: name})
+
+		started := time.Now()
+		err := s.runOnce(ctx, name, status, track)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			err = fmt.Errorf("tracker stopped")
+		}
+
+		now := time.Now()
+		if now.Sub(started) >= healthyRun {
+			attempt = 0
+		}
+		backoff := min(restartBackoffBase<<attempt, restartBackoffMax)
+		attempt = min(attempt+1, 10)
+
+		// 최근 BudgetWindow 동안의 재시작만 센다
+		restarts = append(restarts, now)
+		for len(restarts) > 0 && now.Sub(restarts[0]) > s.opts.BudgetWindow {
+			restarts = restarts[1:]
+		}
+		overBudget := len(restarts) > s.opts.RestartBudget
+
+		metrics.Restart(name)
+		status.Restarting(err, now.Add(backoff), overBudget)
+		s.l.Error(fmt.Sprintf("%s chain tracker stopped, restarting in %s", name, backoff), logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "restarts", Value: len(restarts)})
+
+		switch {
+		case overBudget && !escalated:
+			escalated = true
+			_ = s.notifier.Notify(ctx, notify.Message{
+				Severity: notify.SeverityCritical,
+				Key:      "tracking_failed:" + name,
+				Title:    fmt.Sprintf("%s chain tracker restarted %d times in %s", name, len(restarts), s.opts.BudgetWindow),
+				Body:     fmt.Sprintf("at %s, last error: %s\nstill restarting every %s at most.", now.Format(time.RFC3339), err.Error(), restartBackoffMax),
+			})
+		case !overBudget:
+			escalated = false
+			_ = s.notifier.Notify(ctx, notify.Message{
+				Severity: notify.SeverityWarning,
+				Key:      "tracking_restarted:" + name,
+				Title:    fmt.Sprintf("%s chain tracker stopped, restarting in %s", name, backoff),
+				Body:     fmt.Sprintf("at %s, error: %s", now.Format(time.RFC3339), err.Error()),
+			})
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+	}
+}
+
+func (s *Supervisor) runOnce(ctx context.Context, name string, status *health.Chain, track func(ctx context.Context, status *health.Chain) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			metrics.Panic("supervisor")
+			err = fmt.Errorf("panic in %s chain tracker: %v", name, r)
+		}
+	}()
+
+	return track(ctx, status)
+}
@@ -1,33 +1,57 @@
 package evm
 
 import (
+	"blockchain-tracking/config"
 	"blockchain-tracking/internal/blockchain/jsonRpc"
+	"blockchain-tracking/internal/core/domain/anomaly"
 	"blockchain-tracking/internal/core/domain/blockchain"
+	"blockchain-tracking/internal/core/domain/rule"
+	"blockchain-tracking/internal/health"
 	"blockchain-tracking/internal/logger"
-	"blockchain-tracking/internal/smtp"
+	"blockchain-tracking/internal/metrics"
+	"blockchain-tracking/internal/notify"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 )
 
-func StartTrack(ctx context.Context, name, rpc string, jrAdapter *jsonRpc.JsonRpc, smtpAdapter *smtp.Smtp, blockchainService *blockchain.Service, l logger.Logger) error {
+// 이상 징후 급증 판단 구간 (알림 후 같은 구간 동안은 다시 보내지 않는다)
+const anomalyAlertWindow = 10 * time.Minute
+
+var ErrChainIDMismatch = errors.New("chain id mismatch")
+
+type AlertOptions struct {
+	AnomalyThreshold int
+}
+
+func StartTrack(ctx context.Context, chain config.Chain, jrAdapter *jsonRpc.JsonRpc, notifier notify.Notifier, blockchainService *blockchain.Service, anomalyService *anomaly.Service, rules *rule.Service, status *health.Chain, alert AlertOptions, l logger.Logger) (err error) {
+	name := chain.Name
+
+	// panic 도 에러로 돌려줘서 Supervisor 가 다시 띄우게 한다
 	defer func() {
 		if r := recover(); r != nil {
+			metrics.Panic("start_track")
 			l.Warn(fmt.Sprintf("panic occurred in StartTracking %s", name), logger.Field{
 				Key:   "recovered",
 				Value: r,
 			})
+			err = fmt.Errorf("panic in %s tracker: %v", name, r)
 		}
 	}()
 
 	// 추적 주기
-	ticker := time.NewTicker(3 * time.Second)
+	ticker := time.NewTicker(chain.PollInterval)
 	defer ticker.Stop()
 
 	// tracking 중복 방지 채널
 	isTracking := make(chan struct{}, 1)
 
 	failCount := 0
+	endpoint := 0
+
+	var lastAnomalyAlert time.Time
 
 	for {
 		select {
@@ -37,22 +61,35 @@ func StartTrack(ctx context.Context, name, rpc string, jrAdapter *jsonRpc.JsonRp
 			select {
 			case isTracking <- struct{}{}:
 
-				err := BlockScanner(ctx, name, rpc, jrAdapter, blockchainService, l)
+				err := BlockScanner(ctx, chain, chain.RPC[endpoint], jrAdapter, blockchainService, rules, status, l)
+				status.Round(err)
 				if err != nil {
 					failCount++
+					metrics.TrackFailures(name, failCount)
 					l.Error(fmt.Sprintf("tracking error on %s (failCount: %d)", name, failCount), logger.Field{
 						Key:   "error",
 						Value: err.Error(),
-					})
+					}, logger.Field{Key: "endpoint", Value: metrics.Endpoint(chain.RPC[endpoint])})
+
+					// RPC 주소가 여러 개면 다음 주소로 넘어간다
+					if len(chain.RPC) > 1 {
+						endpoint = (endpoint + 1) % len(chain.RPC)
+						l.Warn(fmt.Sprintf("%s switching rpc endpoint", name), logger.Field{Key: "endpoint", Value: metrics.Endpoint(chain.RPC[endpoint])})
+					}
 
+					// 알림과 재시작은 Supervisor 가 한다
 					if failCount >= 5 {
-						// TODO:
-						subject := fmt.Sprintf("[%s] %s chain tracking failed 5 times!", time.Now().Format(time.RFC3339), name)
-						smtpAdapter.SendEmail("test@gmail.com", subject, "check please!")
-						return err
+						return fmt.Errorf("failed 5 times in a row: %w", err)
 					}
 				} else {
 					failCount = 0 // 성공 시 카운터 초기화
+					metrics.TrackFailures(name, failCount)
+
+					if time.Since(lastAnomalyAlert) >= anomalyAlertWindow {
+						if checkAnomalySpike(ctx, name, chain.ChainID, anomalyService, notifier, alert, l) {
+							lastAnomalyAlert = time.Now()
+						}
+					}
 				}
 				<-isTracking
 			default:
@@ -61,3 +98,63 @@ func StartTrack(ctx context.Context, name, rpc string, jrAdapter *jsonRpc.JsonRp
 		}
 	}
 }
+
+// checkAnomalySpike 최근 구간의 이상 징후가 기준을 넘으면 알림을 보낸다
+func checkAnomalySpike(ctx context.Context, name string, chainID int64, anomalyService *anomaly.Service, notifier notify.Notifier, alert AlertOptions, l logger.Logger) bool {
+	if alert.AnomalyThreshold <= 0 {
+		return false
+	}
+
+	count, err := anomalyService.CountSince(ctx, chainID, anomalyAlertWindow)
+	if err != nil {
+		l.Error("count anomalies", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain", Value: name})
+		return false
+	}
+	if count < int64(alert.AnomalyThreshold) {
+		return false
+	}
+
+	l.Warn(fmt.Sprintf("%s anomaly spike", name), logger.Field{Key: "count", Value: count}, logger.Field{Key: "window", Value: anomalyAlertWindow.String()})
+
+	_ = notifier.Notify(ctx, notify.Message{
+		Severity: notify.SeverityWarning,
+		Key:      "anomaly_spike:" + name,
+		Title:    fmt.Sprintf("%s chain recorded %d anomalies in %s", name, count, anomalyAlertWindow),
+		Body:     fmt.Sprintf("run `anomaly list -chain-id %d` to check.", chainID),
+	})
+
+	return true
+}
+
+func FetchChainID(ctx context.Context, rpc string, jrAdapter *jsonRpc.JsonRpc) (int64, error) {
+	res, err := jrAdapter.CreateRequest(ctx, rpc, "eth_chainId", []interface{}{})
+	if err != nil {
+		return 0, err
+	}
+
+	var chainID jsonRpc.EthBlockNumberResponse
+	if err = json.Unmarshal(res, &chainID); err != nil {
+		return 0, err
+	}
+	if len(chainID.Result) < 3 {
+		return 0, fmt.Errorf("invalid eth_chainId result %q", chainID.Result)
+	}
+
+	return int64(HexToUint64(chainID.Result)), nil
+}
+
+// VerifyChainID 모든 RPC 주소의 eth_chainId 가 설정한 체인 아이디와 같은지 본다.
+// 다른 체인을 가리키는 주소가 있으면 ErrChainIDMismatch, 연결이 안 되는 주소는 unreachable 로 돌려준다
+func VerifyChainID(ctx context.Context, chain config.Chain, jrAdapter *jsonRpc.JsonRpc) (unreachable []string, err error) {
+	for _, rpc := range chain.RPC {
+		chainID, err := FetchChainID(ctx, rpc, jrAdapter)
+		if err != nil {
+			unreachable = append(unreachable, metrics.Endpoint(rpc))
+			continue
+		}
+		if chainID != chain.ChainID {
+			return unreachable, fmt.Errorf("%w: %s rpc %s returned %d, expected %d", ErrChainIDMismatch, chain.Name, metrics.Endpoint(rpc), chainID, chain.ChainID)
+		}
+	}
+	return unreachable, nil
+}
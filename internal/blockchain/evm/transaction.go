@@ -8,6 +8,8 @@ import (
 	"blockchain-tracking/internal/blockchain/evm/evmType"
 	"blockchain-tracking/internal/blockchain/jsonRpc"
 	"blockchain-tracking/internal/logger"
+	"blockchain-tracking/internal/metrics"
+	"blockchain-tracking/internal/tracing"
 	"context"
 	"encoding/hex"
 	"encoding/json"
@@ -17,12 +19,16 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"go.opentelemetry.io/otel/attribute"
 	"math/big"
 	"strings"
 	"sync"
 )
 
-func FetchTransactionData(ctx context.Context, rpc string, block *jsonRpc.Block, result *evmType.Block, jrAdapter *jsonRpc.JsonRpc, l logger.Logger) error {
+func FetchTransactionData(ctx context.Context, rpc string, block *jsonRpc.Block, result *evmType.Block, nftMetadata bool, jrAdapter *jsonRpc.JsonRpc, l logger.Logger) (err error) {
+	ctx, span := tracing.Start(ctx, "evm.DecodeTransactions", attribute.Int64("block.number", int64(result.Number)), attribute.Int("block.transactions", len(block.Transactions)))
+	defer func() { tracing.End(span, err) }()
+
 	tx := make([]jsonRpc.Transaction, len(block.Transactions))
 
 	payloads := make([]jsonRpc.Payload, 0, len(block.Transactions))
@@ -37,7 +43,7 @@ func FetchTransactionData(ctx context.Context, rpc string, block *jsonRpc.Block,
 		payloads = append(payloads, p)
 	}
 
-	ethGetTransactionReceiptRes, err := jrAdapter.CreateRequestMultiple(rpc, payloads)
+	ethGetTransactionReceiptRes, err := jrAdapter.CreateRequestMultiple(ctx, rpc, payloads)
 	if err != nil {
 		l.Error("eth_getTransactionReceipt", logger.Field{Key: "error", Value: err.Error()})
 		return err
@@ -79,29 +85,26 @@ func FetchTransactionData(ctx context.Context, rpc string, block *jsonRpc.Block,
 			defer wg.Done()
 			defer func() {
 				if r := recover(); r != nil {
+					metrics.Panic("fetch_transaction")
 					errMsg := fmt.Sprintf("panic: %v", r)
 					l.Warn(fmt.Sprintf("fetch transaction data recover %s", result.ChainID.String()), logger.Field{
 						Key:   "error",
 						Value: errMsg,
 					})
+					// 일부만 채워진 트랜잭션이 저장되지 않도록 블록을 실패시킨다
+					goroutineErr <- fmt.Errorf("fetch transaction %s: %s", txReceipt.TransactionHash, errMsg)
 				}
 			}()
-			gasInt := big.NewInt(0)
-			if tx[idx].Gas != "0x0" {
-				gasInt = HexToBigInt(tx[idx].Gas[2:])
-			}
-			gasPriceInt := big.NewInt(0)
+			gas := HexToUint64(tx[idx].Gas)
+			gasPrice := big.NewInt(0)
 			if tx[idx].GasPrice != "0x0" {
-				gasPriceInt = HexToBigInt(tx[idx].GasPrice[2:])
+				gasPrice = HexToBigInt(tx[idx].GasPrice[2:])
 			}
-			valueInt := big.NewInt(0)
+			value := big.NewInt(0)
 			if tx[idx].Value != "0x0" {
-				valueInt = HexToBigInt(tx[idx].Value[2:])
-			}
-			gasUsedInt := big.NewInt(0)
-			if txReceipt.GasUsed != "0x0" {
-				gasUsedInt = HexToBigInt(txReceipt.GasUsed[2:])
+				value = HexToBigInt(tx[idx].Value[2:])
 			}
+			gasUsed := HexToUint64(txReceipt.GasUsed)
 			if txReceipt.ContractAddress != nil {
 				lowered := strings.ToLower(*txReceipt.ContractAddress)
 				txReceipt.ContractAddress = &lowered
@@ -112,64 +115,47 @@ func FetchTransactionData(ctx context.Context, rpc string, block *jsonRpc.Block,
 				ChainID:          result.ChainID,
 				BlockHash:        strings.ToLower(result.Hash),
 				BlockNumber:      result.Number,
-				BlockNumberInt:   result.NumberInt,
 				From:             strings.ToLower(txReceipt.From),
-				Gas:              tx[idx].Gas,
-				GasInt:           gasInt,
-				GasPrice:         tx[idx].GasPrice,
-				GasPriceInt:      gasPriceInt,
+				Gas:              gas,
+				GasPrice:         gasPrice,
 				Hash:             strings.ToLower(txReceipt.TransactionHash),
 				R:                tx[idx].R,
 				S:                tx[idx].S,
 				V:                tx[idx].V,
-				TransactionIndex: tx[idx].TransactionIndex,
-				Value:            tx[idx].Value,
-				ValueInt:         valueInt,
-				Nonce:            tx[idx].Nonce,
+				TransactionIndex: HexToUint64(tx[idx].TransactionIndex),
+				Value:            value,
+				Nonce:            HexToUint64(tx[idx].Nonce),
 				Input:            tx[idx].Input,
 				ContractAddress:  txReceipt.ContractAddress,
-				GasUsed:          txReceipt.GasUsed,
-				GasUsedInt:       gasUsedInt,
+				GasUsed:          gasUsed,
 				Logs:             nil,
-				Status:           txReceipt.Status,
-				Type:             txReceipt.Type,
+				Status:           HexToUint64(txReceipt.Status),
+				Type:             HexToUint64(txReceipt.Type),
 				Timestamp:        result.Timestamp,
-				TimestampInt:     result.TimestampInt,
-				CreatedAt:        result.CreatedAt,
 				Contract:         nil,
 				CoinLogs:         nil,
 				Erc20Logs:        nil,
 				Erc721Logs:       nil,
 				Erc1155Logs:      nil,
-				CoinCount:        big.NewInt(0),
-				NftCount:         big.NewInt(0),
-				Erc20Count:       big.NewInt(0),
-				Erc721Count:      big.NewInt(0),
-				Erc1155Count:     big.NewInt(0),
 			}
 			if txReceipt.To != nil {
 				*txReceipt.To = strings.ToLower(*txReceipt.To)
 				result.Transaction[idx].To = *txReceipt.To
 			}
 			// coin tracking
-			if valueInt.Cmp(big.NewInt(0)) > 0 {
+			if value.Cmp(big.NewInt(0)) > 0 {
 				result.Transaction[idx].CoinLogs = &evmType.CoinLog{
 					ChainID:         result.ChainID,
 					Timestamp:       result.Timestamp,
-					TimestampInt:    result.TimestampInt,
-					CreatedAt:       result.CreatedAt,
 					TransactionHash: strings.ToLower(txReceipt.TransactionHash),
 					From:            strings.ToLower(txReceipt.From),
 					To:              *txReceipt.To,
-					Amount:          valueInt,
-					Gas:             tx[idx].Gas,
-					GasInt:          gasInt,
-					GasPrice:        tx[idx].GasPrice,
-					GasPriceInt:     gasPriceInt,
-					GasUsed:         txReceipt.GasUsed,
-					GasUsedInt:      gasUsedInt,
+					Amount:          value,
+					Gas:             gas,
+					GasPrice:        gasPrice,
+					GasUsed:         gasUsed,
 				}
-				result.Transaction[idx].CoinCount = new(big.Int).Add(result.Transaction[idx].CoinCount, big.NewInt(1))
+				result.Transaction[idx].CoinCount++
 			}
 			mu.Unlock()
 
@@ -188,7 +174,7 @@ func FetchTransactionData(ctx context.Context, rpc string, block *jsonRpc.Block,
 
 				contractAddress := common.HexToAddress(*txReceipt.ContractAddress)
 
-				typeStr, _, _, err := ContractType(contractAddress, client)
+				typeStr, _, _, err := ContractType(ctx, contractAddress, client)
 				if err == nil {
 					contractInput.Type = typeStr
 				}
@@ -200,22 +186,22 @@ func FetchTransactionData(ctx context.Context, rpc string, block *jsonRpc.Block,
 					return
 				}
 
-				name, err := contractInstance.Name(&bind.CallOpts{})
+				name, err := contractInstance.Name(&bind.CallOpts{Context: ctx})
 				if err == nil {
 					contractInput.Name = name
 				}
-				symbol, err := contractInstance.Symbol(&bind.CallOpts{})
+				symbol, err := contractInstance.Symbol(&bind.CallOpts{Context: ctx})
 				if err == nil {
 					contractInput.Symbol = symbol
 				}
 
 				erc20Instance, err := erc20.NewErc20(contractAddress, client)
 				if err == nil {
-					totalSupply, err := erc20Instance.TotalSupply(&bind.CallOpts{})
+					totalSupply, err := erc20Instance.TotalSupply(&bind.CallOpts{Context: ctx})
 					if err == nil {
 						contractInput.TotalSupply = totalSupply
 					}
-					decimals, err := erc20Instance.Decimals(&bind.CallOpts{})
+					decimals, err := erc20Instance.Decimals(&bind.CallOpts{Context: ctx})
 					if err == nil {
 						contractInput.Decimals = int(decimals)
 					}
@@ -231,118 +217,66 @@ func FetchTransactionData(ctx context.Context, rpc string, block *jsonRpc.Block,
 			mu.Unlock()
 
 			for index, txLogs := range txReceipt.Logs {
+				logIndex := HexToUint64(txLogs.LogIndex)
+
+				// 디코딩할 수 없는 로그는 건너뛰지 않고 이상 징후로 남긴다
+				malformed := func(detail string) {
+					mu.Lock()
+					result.Transaction[idx].Anomalies = append(result.Transaction[idx].Anomalies, &evmType.Anomaly{
+						Kind:            evmType.AnomalyMalformedLog,
+						TransactionHash: strings.ToLower(txReceipt.TransactionHash),
+						LogIndex:        &logIndex,
+						Contract:        strings.ToLower(txLogs.Address),
+						Detail:          detail,
+					})
+					mu.Unlock()
+				}
+
 				if len(txLogs.Topics) > 0 {
 					switch txLogs.Topics[0].Hex() {
 					case erc20Erc721TransferSigHash.Hex():
-						if txLogs.Data != "0x" {
-							// erc20
-							function := "transfer"
-
-							from := common.HexToAddress(txLogs.Topics[1].Hex())
-							to := common.HexToAddress(txLogs.Topics[2].Hex())
-
-							if from == common.HexToAddress("0x0000000000000000000000000000000000000000") {
-								function = "mint"
-							} else if to == common.HexToAddress("0x0000000000000000000000000000000000000000") || to == common.HexToAddress("0x000000000000000000000000000000000000dead") {
-								function = "burn"
-							}
+						event, decodeErr := decodeTransfer(ctx, txLogs.Topics, txLogs.Data, func(ctx context.Context) string {
+							return classifyContract(ctx, client, result.ChainID, txLogs.Address)
+						})
+						if decodeErr != nil {
+							malformed(decodeErr.Error())
+							break
+						}
 
-							contractAbi, _ := abi.JSON(strings.NewReader(string(erc20.Erc20MetaData.ABI)))
-							byteData, _ := hex.DecodeString(txLogs.Data[2:])
-							results, _ := contractAbi.Unpack("Transfer", byteData)
-
-							tokenValue := big.NewInt(0)
-							if intValue, ok := results[0].(*big.Int); ok {
-								tokenValue = intValue
-							}
+						function := transferFunction(event.From, event.To)
 
+						if event.Standard == standardErc721 {
 							name := ""
 							symbol := ""
 
-							erc20Instance, err := erc20.NewErc20(common.HexToAddress(txLogs.Address), client)
-							if err != nil {
-								l.Error("create new instance", logger.Field{Key: "error", Value: err.Error()})
-								goroutineErr <- err
-								return
-							}
-
-							tokenName, err := erc20Instance.Name(&bind.CallOpts{})
-							if err == nil {
-								name = tokenName
-							}
-							tokenSymbol, err := erc20Instance.Symbol(&bind.CallOpts{})
-							if err == nil {
-								symbol = tokenSymbol
-							}
-
-							if len(txLogs.Topics) == 3 && len(results) == 1 {
-								erc20Input := &evmType.Erc20Log{
-									ChainID:         result.ChainID,
-									Timestamp:       result.Timestamp,
-									TimestampInt:    result.TimestampInt,
-									CreatedAt:       result.CreatedAt,
-									TransactionHash: strings.ToLower(txReceipt.TransactionHash),
-									ContractAddress: strings.ToLower(txLogs.Address),
-									From:            strings.ToLower(from.String()),
-									To:              strings.ToLower(to.String()),
-									Amount:          tokenValue,
-									Function:        function,
-									Name:            name,
-									Symbol:          symbol,
+							// NFT 컨트랙트 name, symbol 은 체인 설정에서 켠 경우만 조회한다
+							if nftMetadata {
+								erc721Instance, err := erc721.NewErc721(common.HexToAddress(txLogs.Address), client)
+								if err != nil {
+									l.Error("create new instance", logger.Field{Key: "error", Value: err.Error()})
+									goroutineErr <- err
+									return
 								}
 
-								mu.Lock()
-								result.Transaction[idx].Erc20Logs = append(result.Transaction[idx].Erc20Logs, erc20Input)
-								result.Transaction[idx].Erc20Count = new(big.Int).Add(result.Transaction[idx].Erc20Count, big.NewInt(1))
-								mu.Unlock()
-							}
-						} else if txLogs.Data == "0x" && len(txLogs.Topics) == 4 {
-							// erc721
-							function := "transfer"
-							from := common.HexToAddress(txLogs.Topics[1].Hex())
-							to := common.HexToAddress(txLogs.Topics[2].Hex())
-
-							if from == common.HexToAddress("0x0000000000000000000000000000000000000000") {
-								function = "mint"
-							} else if to == common.HexToAddress("0x0000000000000000000000000000000000000000") || to == common.HexToAddress("0x000000000000000000000000000000000000dead") {
-								function = "burn"
-							}
-
-							tokenID := new(big.Int)
-							tokenIdByte, err := hex.DecodeString(txLogs.Topics[3].Hex()[2:])
-							if err == nil {
-								tokenID.SetBytes(tokenIdByte)
-							}
-
-							name := ""
-							symbol := ""
-
-							erc721Instance, err := erc721.NewErc721(common.HexToAddress(txLogs.Address), client)
-							if err != nil {
-								l.Error("create new instance", logger.Field{Key: "error", Value: err.Error()})
-								goroutineErr <- err
-								return
-							}
-
-							tokenName, err := erc721Instance.Name(&bind.CallOpts{})
-							if err == nil {
-								name = tokenName
-							}
-							tokenSymbol, err := erc721Instance.Symbol(&bind.CallOpts{})
-							if err == nil {
-								symbol = tokenSymbol
+								tokenName, err := erc721Instance.Name(&bind.CallOpts{Context: ctx})
+								if err == nil {
+									name = tokenName
+								}
+								tokenSymbol, err := erc721Instance.Symbol(&bind.CallOpts{Context: ctx})
+								if err == nil {
+									symbol = tokenSymbol
+								}
 							}
 
 							erc721Input := &evmType.Erc721Log{
 								ChainID:         result.ChainID,
 								Timestamp:       result.Timestamp,
-								TimestampInt:    result.TimestampInt,
-								CreatedAt:       result.CreatedAt,
 								TransactionHash: strings.ToLower(txReceipt.TransactionHash),
+								LogIndex:        logIndex,
 								ContractAddress: strings.ToLower(txLogs.Address),
-								From:            strings.ToLower(from.String()),
-								To:              strings.ToLower(to.String()),
-								TokenId:         tokenID,
+								From:            strings.ToLower(event.From.String()),
+								To:              strings.ToLower(event.To.String()),
+								TokenId:         event.Value,
 								Function:        function,
 								Name:            name,
 								Symbol:          symbol,
@@ -350,13 +284,61 @@ func FetchTransactionData(ctx context.Context, rpc string, block *jsonRpc.Block,
 
 							mu.Lock()
 							result.Transaction[idx].Erc721Logs = append(result.Transaction[idx].Erc721Logs, erc721Input)
-							result.Transaction[idx].Erc721Count = new(big.Int).Add(result.Transaction[idx].Erc721Count, big.NewInt(1))
+							result.Transaction[idx].Erc721Count++
 							mu.Unlock()
+							break
+						}
+
+						// erc20
+						name := ""
+						symbol := ""
+
+						erc20Instance, err := erc20.NewErc20(common.HexToAddress(txLogs.Address), client)
+						if err != nil {
+							l.Error("create new instance", logger.Field{Key: "error", Value: err.Error()})
+							goroutineErr <- err
+							return
 						}
+
+						tokenName, err := erc20Instance.Name(&bind.CallOpts{Context: ctx})
+						if err == nil {
+							name = tokenName
+						}
+						tokenSymbol, err := erc20Instance.Symbol(&bind.CallOpts{Context: ctx})
+						if err == nil {
+							symbol = tokenSymbol
+						}
+
+						erc20Input := &evmType.Erc20Log{
+							ChainID:         result.ChainID,
+							Timestamp:       result.Timestamp,
+							TransactionHash: strings.ToLower(txReceipt.TransactionHash),
+							LogIndex:        logIndex,
+							ContractAddress: strings.ToLower(txLogs.Address),
+							From:            strings.ToLower(event.From.String()),
+							To:              strings.ToLower(event.To.String()),
+							Amount:          event.Value,
+							Function:        function,
+							Name:            name,
+							Symbol:          symbol,
+						}
+
+						mu.Lock()
+						result.Transaction[idx].Erc20Logs = append(result.Transaction[idx].Erc20Logs, erc20Input)
+						result.Transaction[idx].Erc20Count++
+						mu.Unlock()
 					case erc1155TransferSingleSigHash.Hex():
+						if len(txLogs.Topics) != 4 {
+							malformed(fmt.Sprintf("erc1155 TransferSingle with %d topics", len(txLogs.Topics)))
+							break
+						}
+
 						contractAbi, _ := abi.JSON(strings.NewReader(string(erc1155.Erc1155MetaData.ABI)))
-						byteData, _ := hex.DecodeString(txLogs.Data[2:])
-						results, _ := contractAbi.Unpack("TransferSingle", byteData)
+						results, unpackErr := unpackLogData(contractAbi, "TransferSingle", txLogs.Data)
+						if unpackErr != nil || len(results) != 2 {
+							malformed(fmt.Sprintf("erc1155 TransferSingle data: %v", unpackErr))
+							break
+						}
 
 						if txLogs.Data != "0x" && len(results) == 2 {
 							function := "transfer"
@@ -383,28 +365,29 @@ func FetchTransactionData(ctx context.Context, rpc string, block *jsonRpc.Block,
 							name := ""
 							symbol := ""
 
-							erc1155Instance, err := erc1155.NewErc1155(common.HexToAddress(txLogs.Address), client)
-							if err != nil {
-								l.Error("create new instance", logger.Field{Key: "error", Value: err.Error()})
-								goroutineErr <- err
-								return
-							}
+							if nftMetadata {
+								erc1155Instance, err := erc1155.NewErc1155(common.HexToAddress(txLogs.Address), client)
+								if err != nil {
+									l.Error("create new instance", logger.Field{Key: "error", Value: err.Error()})
+									goroutineErr <- err
+									return
+								}
 
-							tokenName, err := erc1155Instance.Name(&bind.CallOpts{})
-							if err == nil {
-								name = tokenName
-							}
-							tokenSymbol, err := erc1155Instance.Symbol(&bind.CallOpts{})
-							if err == nil {
-								symbol = tokenSymbol
+								tokenName, err := erc1155Instance.Name(&bind.CallOpts{Context: ctx})
+								if err == nil {
+									name = tokenName
+								}
+								tokenSymbol, err := erc1155Instance.Symbol(&bind.CallOpts{Context: ctx})
+								if err == nil {
+									symbol = tokenSymbol
+								}
 							}
 
 							erc1155Input := &evmType.Erc1155Log{
 								ChainID:         result.ChainID,
 								Timestamp:       result.Timestamp,
-								TimestampInt:    result.TimestampInt,
-								CreatedAt:       result.CreatedAt,
 								TransactionHash: strings.ToLower(txReceipt.TransactionHash),
+								LogIndex:        logIndex,
 								ContractAddress: strings.ToLower(txLogs.Address),
 								From:            strings.ToLower(from.String()),
 								To:              strings.ToLower(to.String()),
@@ -417,13 +400,21 @@ func FetchTransactionData(ctx context.Context, rpc string, block *jsonRpc.Block,
 
 							mu.Lock()
 							result.Transaction[idx].Erc1155Logs = append(result.Transaction[idx].Erc1155Logs, erc1155Input)
-							result.Transaction[idx].Erc1155Count = new(big.Int).Add(result.Transaction[idx].Erc1155Count, big.NewInt(1))
+							result.Transaction[idx].Erc1155Count++
 							mu.Unlock()
 						}
 					case erc1155TransferBatchSigHash.Hex():
+						if len(txLogs.Topics) != 4 {
+							malformed(fmt.Sprintf("erc1155 TransferBatch with %d topics", len(txLogs.Topics)))
+							break
+						}
+
 						contractAbi, _ := abi.JSON(strings.NewReader(string(erc1155.Erc1155MetaData.ABI)))
-						byteData, _ := hex.DecodeString(txLogs.Data[2:])
-						results, _ := contractAbi.Unpack("TransferBatch", byteData)
+						results, unpackErr := unpackLogData(contractAbi, "TransferBatch", txLogs.Data)
+						if unpackErr != nil || len(results) != 2 {
+							malformed(fmt.Sprintf("erc1155 TransferBatch data: %v", unpackErr))
+							break
+						}
 
 						if txLogs.Data != "0x" && len(results) == 2 {
 							function := "transfer"
@@ -440,37 +431,43 @@ func FetchTransactionData(ctx context.Context, rpc string, block *jsonRpc.Block,
 							name := ""
 							symbol := ""
 
-							erc1155Instance, err := erc1155.NewErc1155(common.HexToAddress(txLogs.Address), client)
-							if err != nil {
-								l.Error("create new instance", logger.Field{Key: "error", Value: err.Error()})
-								goroutineErr <- err
-								return
-							}
+							if nftMetadata {
+								erc1155Instance, err := erc1155.NewErc1155(common.HexToAddress(txLogs.Address), client)
+								if err != nil {
+									l.Error("create new instance", logger.Field{Key: "error", Value: err.Error()})
+									goroutineErr <- err
+									return
+								}
 
-							tokenName, err := erc1155Instance.Name(&bind.CallOpts{})
-							if err == nil {
-								name = tokenName
-							}
-							tokenSymbol, err := erc1155Instance.Symbol(&bind.CallOpts{})
-							if err == nil {
-								symbol = tokenSymbol
+								tokenName, err := erc1155Instance.Name(&bind.CallOpts{Context: ctx})
+								if err == nil {
+									name = tokenName
+								}
+								tokenSymbol, err := erc1155Instance.Symbol(&bind.CallOpts{Context: ctx})
+								if err == nil {
+									symbol = tokenSymbol
+								}
 							}
 
-							tokenIDs := results[0].([]*big.Int)
-							tokenValues := results[1].([]*big.Int)
+							tokenIDs, okIDs := results[0].([]*big.Int)
+							tokenValues, okValues := results[1].([]*big.Int)
+							if !okIDs || !okValues || len(tokenIDs) != len(tokenValues) {
+								malformed(fmt.Sprintf("erc1155 TransferBatch ids/values mismatch (%d/%d)", len(tokenIDs), len(tokenValues)))
+								break
+							}
 
 							for i, tokenID := range tokenIDs {
 								erc1155Input := &evmType.Erc1155Log{
 									ChainID:         result.ChainID,
 									Timestamp:       result.Timestamp,
-									TimestampInt:    result.TimestampInt,
-									CreatedAt:       result.CreatedAt,
 									TransactionHash: strings.ToLower(txReceipt.TransactionHash),
+									LogIndex:        logIndex,
 									ContractAddress: strings.ToLower(txLogs.Address),
 									From:            strings.ToLower(from.String()),
 									To:              strings.ToLower(to.String()),
 									TokenId:         tokenID,
 									Amount:          tokenValues[i],
+									BatchIndex:      i,
 									Function:        function,
 									Name:            name,
 									Symbol:          symbol,
@@ -478,7 +475,7 @@ func FetchTransactionData(ctx context.Context, rpc string, block *jsonRpc.Block,
 
 								mu.Lock()
 								result.Transaction[idx].Erc1155Logs = append(result.Transaction[idx].Erc1155Logs, erc1155Input)
-								result.Transaction[idx].Erc1155Count = new(big.Int).Add(result.Transaction[idx].Erc1155Count, big.NewInt(1))
+								result.Transaction[idx].Erc1155Count++
 								mu.Unlock()
 							}
 						}
@@ -493,18 +490,15 @@ func FetchTransactionData(ctx context.Context, rpc string, block *jsonRpc.Block,
 					Address:          strings.ToLower(txLogs.Address),
 					BlockHash:        strings.ToLower(result.Hash),
 					BlockNumber:      result.Number,
-					BlockNumberInt:   result.NumberInt,
 					Data:             txLogs.Data,
-					LogIndex:         txLogs.LogIndex,
+					LogIndex:         logIndex,
 					Removed:          txLogs.Removed,
 					Topics:           txLogs.Topics,
 					TransactionHash:  strings.ToLower(txLogs.TransactionHash),
-					TransactionIndex: txLogs.TransactionIndex,
+					TransactionIndex: HexToUint64(txLogs.TransactionIndex),
 					From:             txLogs.From,
 					To:               txLogs.To,
 					Timestamp:        result.Timestamp,
-					TimestampInt:     result.TimestampInt,
-					CreatedAt:        result.CreatedAt,
 				}
 				mu.Unlock()
 			}
@@ -526,3 +520,11 @@ func FetchTransactionData(ctx context.Context, rpc string, block *jsonRpc.Block,
 
 	return nil
 }
+
+func unpackLogData(contractAbi abi.ABI, event, data string) ([]interface{}, error) {
+	byteData, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	return contractAbi.Unpack(event, byteData)
+}
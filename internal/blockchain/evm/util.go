@@ -2,12 +2,12 @@ package evm
 
 import (
 	"blockchain-tracking/internal/blockchain/evm/abi/erc20"
-	"fmt"
-	"github.com/ethereum/go-ethereum/accounts/abi"
+	"context"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"math/big"
+	"strconv"
 	"strings"
 )
 
@@ -17,41 +17,29 @@ func HexToBigInt(hexStr string) *big.Int {
 	return bi
 }
 
-func ContractType(address common.Address, client *ethclient.Client) (string, *big.Int, int, error) {
+// HexToUint64 0x quantity 를 uint64 로 바꾼다. 비어 있거나 잘못된 값은 0
+func HexToUint64(hexStr string) uint64 {
+	n, err := strconv.ParseUint(strings.TrimPrefix(hexStr, "0x"), 16, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
 
-	callOpts := &bind.CallOpts{}
+func ContractType(ctx context.Context, address common.Address, client *ethclient.Client) (string, *big.Int, int, error) {
+
+	callOpts := &bind.CallOpts{Context: ctx}
 
 	// ERC165 interface IDs
 	erc721ID := [4]byte{0x80, 0xac, 0x58, 0xcd}
 	erc1155ID := [4]byte{0xd9, 0xb6, 0x7a, 0x26}
 
-	contractAbi, err := abi.JSON(strings.NewReader(erc20.Erc20MetaData.ABI))
-	if err != nil {
-		fmt.Print("error parsing abi")
-		return "", nil, -1, err
+	// erc20 ABI 에는 supportsInterface 가 없으므로 직접 호출한다
+	if supportsInterface(ctx, client, address, erc721ID) {
+		return "721", nil, 0, nil
 	}
-
-	// Bind to generic contract
-	bound := bind.NewBoundContract(address, contractAbi, client, client, client)
-
-	// Check ERC721
-	var out []interface{}
-	if err := bound.Call(callOpts, &out, "supportsInterface", erc721ID); err == nil {
-		if len(out) > 0 {
-			if supports, ok := out[0].(bool); ok && supports {
-				return "721", nil, 0, nil
-			}
-		}
-	}
-
-	// Check ERC1155
-	out = nil
-	if err := bound.Call(callOpts, &out, "supportsInterface", erc1155ID); err == nil {
-		if len(out) > 0 {
-			if supports, ok := out[0].(bool); ok && supports {
-				return "1155", nil, 0, nil
-			}
-		}
+	if supportsInterface(ctx, client, address, erc1155ID) {
+		return "1155", nil, 0, nil
 	}
 
 	// Fallback to ERC20
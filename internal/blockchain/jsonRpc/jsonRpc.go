@@ -2,15 +2,22 @@ package jsonRpc
 
 import (
 	"blockchain-tracking/internal/logger"
+	"blockchain-tracking/internal/metrics"
+	"blockchain-tracking/internal/tracing"
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type JsonRpcAdapter interface {
-	CreateRequest(rpcUrl string, method string, params []interface{}) ([]byte, error)
-	CreateRequestMultiple(rpcUrl string, payloads []Payload) ([]byte, error)
+	CreateRequest(ctx context.Context, rpcUrl string, method string, params []interface{}) ([]byte, error)
+	CreateRequestMultiple(ctx context.Context, rpcUrl string, payloads []Payload) ([]byte, error)
 }
 
 type Payload struct {
@@ -30,7 +37,10 @@ func NewJsonRpc(l logger.Logger) *JsonRpc {
 	return &JsonRpc{logger: l}
 }
 
-func (s *JsonRpc) CreateRequest(rpcUrl string, method string, params []interface{}) ([]byte, error) {
+func (s *JsonRpc) CreateRequest(ctx context.Context, rpcUrl string, method string, params []interface{}) (_ []byte, err error) {
+	ctx, span := tracing.Start(ctx, "rpc "+method, attribute.String("rpc.method", method), attribute.String("rpc.endpoint", metrics.Endpoint(rpcUrl)))
+	defer func() { tracing.End(span, err) }()
+
 	payload := &Payload{
 		Jsonrpc: "2.0",
 		Method:  method,
@@ -45,15 +55,17 @@ func (s *JsonRpc) CreateRequest(rpcUrl string, method string, params []interface
 	}
 	body := bytes.NewReader(payloadBytes)
 
-	req, err := http.NewRequest("POST", rpcUrl, body)
+	req, err := http.NewRequestWithContext(ctx, "POST", rpcUrl, body)
 	if err != nil {
 		s.logger.Error("create http request error", logger.Field{Key: "error", Value: err.Error()})
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 
+	start := time.Now()
 	res, err := http.DefaultClient.Do(req)
 	if err != nil {
+		metrics.RPCRequest(method, rpcUrl, time.Since(start), err)
 		s.logger.Error("client request error", logger.Field{Key: "error", Value: err.Error()})
 		return nil, err
 	}
@@ -61,10 +73,23 @@ func (s *JsonRpc) CreateRequest(rpcUrl string, method string, params []interface
 	defer res.Body.Close()
 	bytes, _ := io.ReadAll(res.Body)
 
+	var reply struct {
+		Error *RpcError `json:"error"`
+	}
+	_ = json.Unmarshal(bytes, &reply)
+	// 응답의 error 는 호출한 쪽이 처리하므로 span 에만 남긴다
+	rpcErr := responseError(res, reply.Error)
+	metrics.RPCRequest(method, rpcUrl, time.Since(start), rpcErr)
+	tracing.Fail(span, rpcErr)
+
 	return bytes, nil
 }
 
-func (s *JsonRpc) CreateRequestMultiple(rpcUrl string, payloads []Payload) ([]byte, error) {
+func (s *JsonRpc) CreateRequestMultiple(ctx context.Context, rpcUrl string, payloads []Payload) (_ []byte, err error) {
+	method := batchMethod(payloads)
+	ctx, span := tracing.Start(ctx, "rpc "+method, attribute.String("rpc.method", method), attribute.String("rpc.endpoint", metrics.Endpoint(rpcUrl)), attribute.Int("rpc.batch_size", len(payloads)))
+	defer func() { tracing.End(span, err) }()
+
 	payloadBytes, err := json.Marshal(payloads)
 	if err != nil {
 		s.logger.Error("json marshal error", logger.Field{Key: "error", Value: err.Error()})
@@ -72,15 +97,17 @@ func (s *JsonRpc) CreateRequestMultiple(rpcUrl string, payloads []Payload) ([]by
 	}
 	body := bytes.NewReader(payloadBytes)
 
-	req, err := http.NewRequest("POST", rpcUrl, body)
+	req, err := http.NewRequestWithContext(ctx, "POST", rpcUrl, body)
 	if err != nil {
 		s.logger.Error("create http request error", logger.Field{Key: "error", Value: err.Error()})
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 
+	start := time.Now()
 	res, err := http.DefaultClient.Do(req)
 	if err != nil {
+		metrics.RPCRequest(method, rpcUrl, time.Since(start), err)
 		s.logger.Error("create http request error", logger.Field{Key: "error", Value: err.Error()})
 		return nil, err
 
@@ -89,5 +116,44 @@ func (s *JsonRpc) CreateRequestMultiple(rpcUrl string, payloads []Payload) ([]by
 	defer res.Body.Close()
 	bytes, _ := io.ReadAll(res.Body)
 
+	// 응답 중 하나라도 error 가 있으면 실패로 센다
+	var replies []struct {
+		Error *RpcError `json:"error"`
+	}
+	_ = json.Unmarshal(bytes, &replies)
+	var rpcErr *RpcError
+	for _, reply := range replies {
+		if reply.Error != nil {
+			rpcErr = reply.Error
+			break
+		}
+	}
+	batchErr := responseError(res, rpcErr)
+	metrics.RPCRequest(method, rpcUrl, time.Since(start), batchErr)
+	tracing.Fail(span, batchErr)
+
 	return bytes, nil
 }
+
+// batchMethod 같은 method 만 묶었으면 그 method, 섞여 있으면 "batch"
+func batchMethod(payloads []Payload) string {
+	if len(payloads) == 0 {
+		return "batch"
+	}
+	for _, payload := range payloads[1:] {
+		if payload.Method != payloads[0].Method {
+			return "batch"
+		}
+	}
+	return payloads[0].Method
+}
+
+func responseError(res *http.Response, rpcErr *RpcError) error {
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("rpc status %d", res.StatusCode)
+	}
+	if rpcErr != nil {
+		return fmt.Errorf("rpc error %d: %s", rpcErr.Code, rpcErr.Message)
+	}
+	return nil
+}
@@ -8,6 +8,18 @@ type EthBlockNumberResponse struct {
 	Result  string `json:"result"`
 }
 
+type EthCallResponse struct {
+	Jsonrpc string    `json:"jsonrpc"`
+	ID      int       `json:"id"`
+	Result  string    `json:"result"`
+	Error   *RpcError `json:"error,omitempty"`
+}
+
+type RpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
 type EthGetBlockByNumber struct {
 	Jsonrpc string `json:"jsonrpc"`
 	ID      int    `json:"id"`
@@ -0,0 +1,64 @@
+package anomaly
+
+import (
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/database/postgresql"
+	"blockchain-tracking/internal/logger"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type Service struct {
+	db *postgresql.Database
+	l  logger.Logger
+}
+
+func NewService(d *postgresql.Database, l logger.Logger) *Service {
+	return &Service{
+		db: d,
+		l:  l,
+	}
+}
+
+// List chainID 가 0, kind 가 빈 값이면 전체
+func (s *Service) List(ctx context.Context, chainID int64, kind string, resolved bool, limit int32) ([]*gen.Anomaly, error) {
+	anomalies, err := s.db.Queries.ListAnomalies(ctx, gen.ListAnomaliesParams{
+		ChainID:  sql.NullInt64{Int64: chainID, Valid: chainID != 0},
+		Kind:     sql.NullString{String: kind, Valid: kind != ""},
+		Resolved: resolved,
+		RowLimit: limit,
+	})
+	if err != nil {
+		s.l.Error("list anomalies", logger.Field{Key: "error", Value: err.Error()})
+		return nil, err
+	}
+
+	return anomalies, nil
+}
+
+func (s *Service) Resolve(ctx context.Context, id int64, resolution string) error {
+	affected, err := s.db.Queries.ResolveAnomaly(ctx, gen.ResolveAnomalyParams{
+		ID:         id,
+		ResolvedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		Resolution: sql.NullString{String: resolution, Valid: resolution != ""},
+	})
+	if err != nil {
+		s.l.Error("resolve anomaly", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "anomaly", Value: id})
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("anomaly %d not found or already resolved", id)
+	}
+
+	return nil
+}
+
+// CountSince 최근 window 동안 쌓인 이상 징후 수
+func (s *Service) CountSince(ctx context.Context, chainID int64, window time.Duration) (int64, error) {
+	return s.db.Queries.CountAnomaliesSince(ctx, gen.CountAnomaliesSinceParams{
+		ChainID:   chainID,
+		CreatedAt: time.Now().UTC().Add(-window),
+	})
+}
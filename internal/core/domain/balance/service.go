@@ -0,0 +1,107 @@
+package balance
+
+import (
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/database/postgresql"
+	"blockchain-tracking/internal/logger"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// Service balance_change 원장으로 특정 블록 시점의 잔액을 조회한다.
+// 원장은 도입 이후의 변경만 담고 있으므로 그 이전 잔액은 0 부터 계산된다.
+type Service struct {
+	db *postgresql.Database
+	l  logger.Logger
+}
+
+func NewService(d *postgresql.Database, l logger.Logger) *Service {
+	return &Service{
+		db: d,
+		l:  l,
+	}
+}
+
+// BlockAtTimestamp timestamp(unix) 이전(포함) 마지막으로 인덱싱된 블록 번호, 없으면 -1
+func (s *Service) BlockAtTimestamp(ctx context.Context, chainID int64, timestamp int64) (int64, error) {
+	number, err := s.db.Queries.GetBlockNumberAtTimestamp(ctx, gen.GetBlockNumberAtTimestampParams{
+		ChainID:   chainID,
+		Timestamp: time.Unix(timestamp, 0).UTC(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return -1, nil
+		}
+		s.l.Error("get block number at timestamp", logger.Field{Key: "error", Value: err.Error()})
+		return 0, err
+	}
+
+	return number, nil
+}
+
+func (s *Service) NativeBalanceAt(ctx context.Context, chainID int64, address string, blockNumber int64) (string, error) {
+	return s.db.Queries.GetNativeBalanceAt(ctx, gen.GetNativeBalanceAtParams{
+		ChainID:     chainID,
+		Address:     postgresql.HexToBytes(address),
+		BlockNumber: blockNumber,
+	})
+}
+
+func (s *Service) ERC20BalanceAt(ctx context.Context, chainID int64, hash, address string, blockNumber int64) (string, error) {
+	return s.db.Queries.GetERC20BalanceAt(ctx, gen.GetERC20BalanceAtParams{
+		ChainID:     chainID,
+		Hash:        postgresql.HexToBytes(hash),
+		Address:     postgresql.HexToBytes(address),
+		BlockNumber: blockNumber,
+	})
+}
+
+func (s *Service) ERC1155BalanceAt(ctx context.Context, chainID int64, hash, tokenID, address string, blockNumber int64) (string, error) {
+	return s.db.Queries.GetERC1155BalanceAt(ctx, gen.GetERC1155BalanceAtParams{
+		ChainID:     chainID,
+		Hash:        postgresql.HexToBytes(hash),
+		TokenID:     sql.NullString{String: tokenID, Valid: true},
+		Address:     postgresql.HexToBytes(address),
+		BlockNumber: blockNumber,
+	})
+}
+
+// ERC721OwnerAt 해당 블록 시점의 소유자, 기록이 없으면 ""
+func (s *Service) ERC721OwnerAt(ctx context.Context, chainID int64, hash, tokenID string, blockNumber int64) (string, error) {
+	owner, err := s.db.Queries.GetERC721OwnerAt(ctx, gen.GetERC721OwnerAtParams{
+		ChainID:     chainID,
+		Hash:        postgresql.HexToBytes(hash),
+		TokenID:     sql.NullString{String: tokenID, Valid: true},
+		BlockNumber: blockNumber,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return postgresql.BytesToHex(owner), nil
+}
+
+// HoldingsAt 주소가 해당 블록 시점에 보유한 코인/토큰 목록
+func (s *Service) HoldingsAt(ctx context.Context, chainID int64, address string, blockNumber int64) ([]*gen.ListHoldingsAtRow, error) {
+	return s.db.Queries.ListHoldingsAt(ctx, gen.ListHoldingsAtParams{
+		ChainID:     chainID,
+		Address:     postgresql.HexToBytes(address),
+		BlockNumber: blockNumber,
+	})
+}
+
+// HoldersAt 토큰의 해당 블록 시점 보유자 목록 (잔액 내림차순)
+func (s *Service) HoldersAt(ctx context.Context, chainID int64, hash string, blockNumber int64, limit, offset int32) ([]*gen.ListTokenHoldersAtRow, error) {
+	return s.db.Queries.ListTokenHoldersAt(ctx, gen.ListTokenHoldersAtParams{
+		ChainID:     chainID,
+		Hash:        postgresql.HexToBytes(hash),
+		BlockNumber: blockNumber,
+		Limit:       limit,
+		Offset:      offset,
+	})
+}
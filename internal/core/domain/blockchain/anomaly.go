@@ -0,0 +1,85 @@
+package blockchain
+
+import (
+	"blockchain-tracking/internal/blockchain/evm/evmType"
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/database/postgresql"
+	"blockchain-tracking/internal/logger"
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"time"
+)
+
+// recordAnomaly 블록과 같은 트랜잭션에서 이상 징후를 남긴다
+func (s *Service) recordAnomaly(ctx context.Context, q *gen.Queries, block *evmType.Block, anomaly *evmType.Anomaly) error {
+	params := anomalyParams(block.ChainID.Int64(), int64(block.Number), anomaly)
+
+	err := q.InsertAnomaly(ctx, params)
+	if err != nil {
+		s.l.Error("create anomaly", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "kind", Value: anomaly.Kind}, logger.Field{Key: "transaction", Value: anomaly.TransactionHash})
+		return err
+	}
+
+	s.l.Warn("balance anomaly",
+		logger.Field{Key: "kind", Value: anomaly.Kind},
+		logger.Field{Key: "block number", Value: block.Number},
+		logger.Field{Key: "transaction", Value: anomaly.TransactionHash},
+		logger.Field{Key: "address", Value: anomaly.Address},
+	)
+
+	return nil
+}
+
+func (s *Service) recordInsufficientErc1155(ctx context.Context, q *gen.Queries, block *evmType.Block, erc1155Data *evmType.Erc1155Log) error {
+	stored, err := q.GetERC1155Amount(ctx, gen.GetERC1155AmountParams{
+		ChainID: erc1155Data.ChainID.Int64(),
+		Hash:    postgresql.HexToBytes(erc1155Data.ContractAddress),
+		TokenID: erc1155Data.TokenId.String(),
+		Address: postgresql.HexToBytes(erc1155Data.From),
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		s.l.Error("get erc1155 balance", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc1155Balance", Value: erc1155Data.From})
+		return err
+	}
+
+	actual, ok := new(big.Int).SetString(stored, 10)
+	if !ok {
+		actual = big.NewInt(0)
+	}
+
+	return s.recordAnomaly(ctx, q, block, &evmType.Anomaly{
+		Kind:            evmType.AnomalyErc1155InsufficientBalance,
+		TransactionHash: erc1155Data.TransactionHash,
+		LogIndex:        &erc1155Data.LogIndex,
+		Contract:        erc1155Data.ContractAddress,
+		Address:         erc1155Data.From,
+		Expected:        erc1155Data.Amount,
+		Actual:          actual,
+	})
+}
+
+func anomalyParams(chainID, blockNumber int64, anomaly *evmType.Anomaly) gen.InsertAnomalyParams {
+	params := gen.InsertAnomalyParams{
+		ChainID:         chainID,
+		Kind:            anomaly.Kind,
+		BlockNumber:     blockNumber,
+		TransactionHash: postgresql.HexToBytes(anomaly.TransactionHash),
+		Contract:        postgresql.HexToBytes(anomaly.Contract),
+		Address:         postgresql.HexToBytes(anomaly.Address),
+		Detail:          sql.NullString{String: anomaly.Detail, Valid: anomaly.Detail != ""},
+		CreatedAt:       time.Now().UTC(),
+	}
+	if anomaly.LogIndex != nil {
+		params.LogIndex = sql.NullInt64{Int64: int64(*anomaly.LogIndex), Valid: true}
+	}
+	if anomaly.Expected != nil {
+		params.Expected = sql.NullString{String: anomaly.Expected.String(), Valid: true}
+	}
+	if anomaly.Actual != nil {
+		params.Actual = sql.NullString{String: anomaly.Actual.String(), Valid: true}
+	}
+
+	return params
+}
@@ -0,0 +1,646 @@
+package blockchain
+
+import (
+	"blockchain-tracking/internal/blockchain/evm/evmType"
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/database/postgresql"
+	"blockchain-tracking/internal/logger"
+	"blockchain-tracking/internal/metrics"
+	"blockchain-tracking/internal/tracing"
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// 각 컬럼 순서는 query.sql 의 INSERT 문, gen 파라미터 구조체 필드 순서와 같다 (TestParamColumns 가 확인한다)
+var (
+	blockColumns = []string{"chain_id", "difficulty", "hash", "gas_limit", "gas_used", "miner", "number",
+		"parent_hash", "timestamp", "total_difficulty", "transactions_root"}
+	transactionColumns = []string{"chain_id", "block_hash", "block_number", "from", "to",
+		"gas", "gas_price", "hash", "r", "s", "v", "transaction_index",
+		"value", "nonce", "contract_address", "gas_used",
+		"status", "type", "timestamp", "coin_count", "nft_count", "erc20_count", "erc721_count", "erc1155_count"}
+	transactionInputColumns = []string{"chain_id", "block_number", "hash", "input"}
+	contractColumns         = []string{"chain_id", "hash", "name", "symbol", "decimals", "total_supply", "type", "creator"}
+	coinLogColumns          = []string{"chain_id", "timestamp", "transaction_hash",
+		"from", "to", "amount", "gas", "gas_price", "gas_used"}
+	erc20LogColumns = []string{"chain_id", "timestamp", "transaction_hash",
+		"contract_address", "from", "to", "amount", "function", "name", "symbol", "log_index"}
+	erc721LogColumns = []string{"chain_id", "timestamp", "transaction_hash",
+		"contract_address", "from", "to", "token_id", "function", "name", "symbol", "log_index"}
+	erc1155LogColumns = []string{"chain_id", "timestamp", "transaction_hash",
+		"contract_address", "from", "to", "token_id", "amount", "function", "name", "symbol", "log_index", "batch_index"}
+	logColumns = []string{"chain_id", "address", "block_hash", "block_number", "data",
+		"log_index", "removed", "topics", "transaction_hash", "transaction_index", "from", "to", "timestamp"}
+	balanceChangeColumns = []string{"chain_id", "kind", "hash", "token_id", "address", "delta",
+		"block_number", "transaction_hash", "reason", "created_at"}
+	anomalyColumns = []string{"chain_id", "kind", "block_number", "transaction_hash", "log_index", "contract",
+		"address", "expected", "actual", "detail", "created_at"}
+)
+
+// erc1155Transfer 잔액 검사를 위해 순서대로 모아 두는 ERC-1155 전송
+type erc1155Transfer struct {
+	blockNumber int64
+	log         *evmType.Erc1155Log
+}
+
+type balanceKey struct {
+	hash    string
+	tokenID string
+	address string
+}
+
+// bulkStage 배치 전체의 행과 (토큰, 주소) 별로 합친 잔액 변화량. 키는 소문자 0x hex
+type bulkStage struct {
+	chainID int64
+
+	transactions [][]interface{}
+	inputs       [][]interface{}
+	contracts    map[string][]interface{}
+	coinLogs     [][]interface{}
+	erc20Logs    [][]interface{}
+	erc721Logs   [][]interface{}
+	erc1155Logs  [][]interface{}
+	logs         [][]interface{}
+	changes      [][]interface{}
+	anomalies    [][]interface{}
+
+	wallets      map[string]*big.Int
+	erc20        map[balanceKey]*big.Int
+	erc20Out     map[balanceKey]*big.Int
+	erc721Owners map[balanceKey]string
+	erc1155      map[balanceKey]*big.Int
+
+	erc1155Transfers []erc1155Transfer
+
+	erc721Tokens  map[balanceKey]bool
+	erc1155Tokens map[balanceKey]bool
+	contractTypes map[string]string
+
+	lastBlock int64
+}
+
+func newBulkStage(chainID int64) *bulkStage {
+	return &bulkStage{
+		chainID:       chainID,
+		contracts:     make(map[string][]interface{}),
+		wallets:       make(map[string]*big.Int),
+		erc20:         make(map[balanceKey]*big.Int),
+		erc20Out:      make(map[balanceKey]*big.Int),
+		erc721Owners:  make(map[balanceKey]string),
+		erc1155:       make(map[balanceKey]*big.Int),
+		erc721Tokens:  make(map[balanceKey]bool),
+		erc1155Tokens: make(map[balanceKey]bool),
+		contractTypes: make(map[string]string),
+	}
+}
+
+// CreateBatch 여러 블록을 한 트랜잭션에서 COPY 로 저장한다 (백필용).
+// Create 와 같은 행을 만들지만 잔액은 (토큰, 주소) 별로 합쳐서 한 번씩만 upsert 한다.
+// ERC-20 은 배치 반영 후 음수가 된 잔액을 이상 징후로 남기고, ERC-1155 는 Create 와 같이 전송마다 잔액을 검사해서
+// 모자란 차감은 반영하지 않고 이상 징후로 남긴다.
+func (s *Service) CreateBatch(ctx context.Context, blocks []*evmType.Block) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	chainID := blocks[0].ChainID.Int64()
+	for _, block := range blocks {
+		if block.ChainID.Int64() != chainID {
+			return fmt.Errorf("batch contains blocks from chain %d and %s", chainID, block.ChainID)
+		}
+		if err := s.partitions.Ensure(ctx, chainID, int64(block.Number), block.Timestamp); err != nil {
+			return err
+		}
+	}
+
+	ctx, span := tracing.Start(ctx, "blockchain.CreateBatch", attribute.Int64("chain.id", chainID), attribute.Int64("block.from", int64(blocks[0].Number)), attribute.Int64("block.to", int64(blocks[len(blocks)-1].Number)))
+	started := time.Now()
+	err := s.txManager.WithTransaction(ctx, sql.LevelRepeatableRead, false, func(ctx context.Context) error {
+		d := s.db.GetQueryRowerFromContext(ctx)
+
+		if err := checkStoredHashes(ctx, d.Queries, chainID, blocks...); err != nil {
+			s.l.Error("create blocks", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: chainID})
+			return err
+		}
+
+		applied, err := s.insertBlocks(ctx, d, blocks)
+		if err != nil {
+			s.l.Error("create blocks", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: chainID})
+			return err
+		}
+		if len(applied) == 0 {
+			s.l.Info("batch already applied, skipping", logger.Field{Key: "chain_id", Value: chainID})
+			return nil
+		}
+
+		// 시드는 블록 순서대로 넣어야 처음 등장한 블록의 값이 남는다
+		for _, block := range applied {
+			if err = s.applySeeds(ctx, d.Queries, block); err != nil {
+				s.l.Error("create balance seed", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "block", Value: block.Hash})
+				return err
+			}
+		}
+
+		stage := newBulkStage(chainID)
+		for _, block := range applied {
+			stage.add(block)
+		}
+
+		if err = s.writeStage(ctx, d, stage); err != nil {
+			s.l.Error("create batch", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: chainID}, logger.Field{Key: "block number", Value: stage.lastBlock})
+			return err
+		}
+
+		// 백필 중에도 감시 주소 알림과 웹훅 outbox 는 블록마다 남긴다
+		for _, block := range applied {
+			if err = s.watchlists.Match(ctx, d.Queries, block); err != nil {
+				return err
+			}
+			if err = s.webhooks.Enqueue(ctx, d.Queries, block); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	metrics.DBTransaction("create_batch", time.Since(started), err)
+	tracing.End(span, err)
+
+	return err
+}
+
+// insertBlocks 새로 들어간 블록만 돌려준다 (이미 반영된 블록은 건너뛴다)
+func (s *Service) insertBlocks(ctx context.Context, d *postgresql.Database, blocks []*evmType.Block) ([]*evmType.Block, error) {
+	rows := make([][]interface{}, len(blocks))
+	for i, block := range blocks {
+		rows[i] = paramRow(blockParams(block))
+	}
+
+	temp, err := d.CopyToTemp(ctx, "block", blockColumns, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := d.QueryContext(ctx, fmt.Sprintf(
+		"INSERT INTO block (%[1]s) SELECT %[1]s FROM %[2]s ON CONFLICT (hash, chain_id) DO NOTHING RETURNING hash",
+		columnList(blockColumns), temp,
+	))
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	inserted := make(map[string]bool)
+	for result.Next() {
+		var hash []byte
+		if err = result.Scan(&hash); err != nil {
+			return nil, err
+		}
+		inserted[postgresql.BytesToHex(hash)] = true
+	}
+	if err = result.Err(); err != nil {
+		return nil, err
+	}
+
+	var applied []*evmType.Block
+	for _, block := range blocks {
+		// BytesToHex 는 소문자이므로 RPC 가 준 해시도 소문자로 맞춘다 (checkStoredHashes 와 같음)
+		if inserted[strings.ToLower(block.Hash)] {
+			applied = append(applied, block)
+		}
+	}
+
+	return applied, nil
+}
+
+func (st *bulkStage) add(block *evmType.Block) {
+	blockNumber := int64(block.Number)
+	st.lastBlock = blockNumber
+
+	for i := range block.Transaction {
+		tx := &block.Transaction[i]
+
+		st.transactions = append(st.transactions, paramRow(transactionParams(tx)))
+		if input, ok := transactionInputParams(tx); ok {
+			st.inputs = append(st.inputs, paramRow(input))
+		}
+
+		if tx.Contract != nil {
+			st.contracts[tx.Contract.Hash] = paramRow(contractParams(tx.Contract))
+		}
+
+		if tx.CoinLogs != nil {
+			st.coinLogs = append(st.coinLogs, paramRow(coinLogParams(tx.CoinLogs)))
+			st.wallet(tx.CoinLogs.From, new(big.Int).Neg(tx.CoinLogs.Amount))
+			st.wallet(tx.CoinLogs.To, tx.CoinLogs.Amount)
+			st.change("coin", "", "", tx.CoinLogs.From, new(big.Int).Neg(tx.CoinLogs.Amount), blockNumber, tx.CoinLogs.TransactionHash, "transfer", tx.CoinLogs.Timestamp)
+			st.change("coin", "", "", tx.CoinLogs.To, tx.CoinLogs.Amount, blockNumber, tx.CoinLogs.TransactionHash, "transfer", tx.CoinLogs.Timestamp)
+		}
+
+		for _, erc20Data := range tx.Erc20Logs {
+			st.erc20Logs = append(st.erc20Logs, paramRow(erc20LogParams(erc20Data)))
+			st.wallet(erc20Data.From, nil)
+			st.wallet(erc20Data.To, nil)
+
+			if erc20Data.Function != "mint" {
+				key := balanceKey{hash: erc20Data.ContractAddress, address: erc20Data.From}
+				addDelta(st.erc20, key, new(big.Int).Neg(erc20Data.Amount))
+				addDelta(st.erc20Out, key, erc20Data.Amount)
+				st.change("erc20", erc20Data.ContractAddress, "", erc20Data.From, new(big.Int).Neg(erc20Data.Amount), blockNumber, erc20Data.TransactionHash, erc20Data.Function, erc20Data.Timestamp)
+			}
+			addDelta(st.erc20, balanceKey{hash: erc20Data.ContractAddress, address: erc20Data.To}, erc20Data.Amount)
+			st.change("erc20", erc20Data.ContractAddress, "", erc20Data.To, erc20Data.Amount, blockNumber, erc20Data.TransactionHash, erc20Data.Function, erc20Data.Timestamp)
+		}
+
+		for _, erc721Data := range tx.Erc721Logs {
+			tokenID := erc721Data.TokenId.String()
+			key := balanceKey{hash: erc721Data.ContractAddress, tokenID: tokenID}
+
+			st.erc721Logs = append(st.erc721Logs, paramRow(erc721LogParams(erc721Data)))
+			st.erc721Owners[key] = erc721Data.To
+			st.erc721Tokens[key] = true
+			st.contractTypes[erc721Data.ContractAddress] = "721"
+			st.wallet(erc721Data.From, nil)
+			st.wallet(erc721Data.To, nil)
+
+			if erc721Data.Function != "mint" {
+				st.change("erc721", erc721Data.ContractAddress, tokenID, erc721Data.From, big.NewInt(-1), blockNumber, erc721Data.TransactionHash, erc721Data.Function, erc721Data.Timestamp)
+			}
+			st.change("erc721", erc721Data.ContractAddress, tokenID, erc721Data.To, big.NewInt(1), blockNumber, erc721Data.TransactionHash, erc721Data.Function, erc721Data.Timestamp)
+		}
+
+		for _, erc1155Data := range tx.Erc1155Logs {
+			tokenID := erc1155Data.TokenId.String()
+
+			st.erc1155Logs = append(st.erc1155Logs, paramRow(erc1155LogParams(erc1155Data)))
+			st.erc1155Tokens[balanceKey{hash: erc1155Data.ContractAddress, tokenID: tokenID}] = true
+			st.contractTypes[erc1155Data.ContractAddress] = "1155"
+			st.wallet(erc1155Data.From, nil)
+			st.wallet(erc1155Data.To, nil)
+			st.erc1155Transfers = append(st.erc1155Transfers, erc1155Transfer{blockNumber: blockNumber, log: erc1155Data})
+		}
+
+		for _, anomaly := range tx.Anomalies {
+			st.anomalies = append(st.anomalies, paramRow(anomalyParams(st.chainID, blockNumber, anomaly)))
+		}
+
+		for _, txLogData := range tx.Logs {
+			if txLogData == nil {
+				continue
+			}
+			st.logs = append(st.logs, paramRow(logParams(txLogData)))
+		}
+	}
+}
+
+// wallet delta 가 nil 이면 행만 만든다 (InsertWallet 과 같음)
+func (st *bulkStage) wallet(address string, delta *big.Int) {
+	if delta == nil {
+		delta = new(big.Int)
+	}
+	addDelta(st.wallets, address, delta)
+}
+
+func (st *bulkStage) change(kind, hash, tokenID, address string, delta *big.Int, blockNumber int64, txHash, reason string, createdAt time.Time) {
+	st.changes = append(st.changes, paramRow(gen.InsertBalanceChangeParams{
+		ChainID:         st.chainID,
+		Kind:            kind,
+		Hash:            postgresql.HexToBytes(hash),
+		TokenID:         sql.NullString{String: tokenID, Valid: tokenID != ""},
+		Address:         postgresql.HexToBytes(address),
+		Delta:           delta.String(),
+		BlockNumber:     blockNumber,
+		TransactionHash: postgresql.HexToBytes(txHash),
+		Reason:          reason,
+		CreatedAt:       createdAt,
+	}))
+}
+
+// erc1155Debtors 배치에서 ERC-1155 를 보내는 (토큰, 주소)
+func (st *bulkStage) erc1155Debtors() []balanceKey {
+	seen := make(map[balanceKey]bool)
+	var keys []balanceKey
+	for _, transfer := range st.erc1155Transfers {
+		if transfer.log.Function == "mint" {
+			continue
+		}
+		key := balanceKey{hash: transfer.log.ContractAddress, tokenID: transfer.log.TokenId.String(), address: transfer.log.From}
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// applyErc1155 ERC-1155 전송을 순서대로 반영한다. balances 는 배치 직전 보유량(행이 없으면 키도 없다)에서 시작해서 반영한 만큼 바뀐다.
+// SubtractERC1155Balance 와 같이 보유량이 모자라면 차감도 원장도 남기지 않고 이상 징후로 기록한다
+func (st *bulkStage) applyErc1155(balances map[balanceKey]*big.Int) {
+	for _, transfer := range st.erc1155Transfers {
+		erc1155Data := transfer.log
+		tokenID := erc1155Data.TokenId.String()
+
+		if erc1155Data.Function != "mint" {
+			key := balanceKey{hash: erc1155Data.ContractAddress, tokenID: tokenID, address: erc1155Data.From}
+			balance, ok := balances[key]
+			if !ok || balance.Cmp(erc1155Data.Amount) < 0 {
+				actual := new(big.Int)
+				if ok {
+					actual.Set(balance)
+				}
+				st.anomalies = append(st.anomalies, paramRow(anomalyParams(st.chainID, transfer.blockNumber, &evmType.Anomaly{
+					Kind:            evmType.AnomalyErc1155InsufficientBalance,
+					TransactionHash: erc1155Data.TransactionHash,
+					LogIndex:        &erc1155Data.LogIndex,
+					Contract:        erc1155Data.ContractAddress,
+					Address:         erc1155Data.From,
+					Expected:        erc1155Data.Amount,
+					Actual:          actual,
+				})))
+			} else {
+				balances[key] = new(big.Int).Sub(balance, erc1155Data.Amount)
+				addDelta(st.erc1155, key, new(big.Int).Neg(erc1155Data.Amount))
+				st.change("erc1155", erc1155Data.ContractAddress, tokenID, erc1155Data.From, new(big.Int).Neg(erc1155Data.Amount), transfer.blockNumber, erc1155Data.TransactionHash, erc1155Data.Function, erc1155Data.Timestamp)
+			}
+		}
+
+		key := balanceKey{hash: erc1155Data.ContractAddress, tokenID: tokenID, address: erc1155Data.To}
+		addDelta(balances, key, erc1155Data.Amount)
+		addDelta(st.erc1155, key, erc1155Data.Amount)
+		st.change("erc1155", erc1155Data.ContractAddress, tokenID, erc1155Data.To, erc1155Data.Amount, transfer.blockNumber, erc1155Data.TransactionHash, erc1155Data.Function, erc1155Data.Timestamp)
+	}
+}
+
+func addDelta[K comparable](m map[K]*big.Int, key K, delta *big.Int) {
+	if current, ok := m[key]; ok {
+		current.Add(current, delta)
+		return
+	}
+	m[key] = new(big.Int).Set(delta)
+}
+
+func (s *Service) writeStage(ctx context.Context, d *postgresql.Database, st *bulkStage) error {
+	inserts := []struct {
+		table    string
+		columns  []string
+		rows     [][]interface{}
+		conflict string
+	}{
+		{"transaction", transactionColumns, st.transactions, "ON CONFLICT (chain_id, block_number, hash) DO NOTHING"},
+		{"transaction_input", transactionInputColumns, st.inputs, "ON CONFLICT (chain_id, block_number, hash) DO NOTHING"},
+		{"contract", contractColumns, mapRows(st.contracts), `ON CONFLICT (hash, chain_id) DO UPDATE
+SET name = EXCLUDED.name, symbol = EXCLUDED.symbol, decimals = EXCLUDED.decimals,
+    total_supply = EXCLUDED.total_supply, type = EXCLUDED.type, creator = EXCLUDED.creator`},
+		{"coin_log", coinLogColumns, st.coinLogs, "ON CONFLICT (chain_id, timestamp, transaction_hash) DO NOTHING"},
+		{"erc20_log", erc20LogColumns, st.erc20Logs, "ON CONFLICT (chain_id, timestamp, transaction_hash, log_index) DO NOTHING"},
+		{"erc721_log", erc721LogColumns, st.erc721Logs, "ON CONFLICT (chain_id, timestamp, transaction_hash, log_index) DO NOTHING"},
+		{"erc1155_log", erc1155LogColumns, st.erc1155Logs, "ON CONFLICT (chain_id, timestamp, transaction_hash, log_index, batch_index) DO NOTHING"},
+		{"log", logColumns, st.logs, "ON CONFLICT (chain_id, block_number, transaction_hash, log_index) DO NOTHING"},
+	}
+	for _, insert := range inserts {
+		if err := insertFromTemp(ctx, d, insert.table, insert.columns, insert.rows, insert.conflict); err != nil {
+			return fmt.Errorf("%s: %w", insert.table, err)
+		}
+	}
+
+	// 잔액 (합친 변화량을 한 번씩 upsert)
+	var walletRows [][]interface{}
+	for address, delta := range st.wallets {
+		walletRows = append(walletRows, []interface{}{st.chainID, postgresql.HexToBytes(address), delta.String()})
+	}
+	err := insertFromTemp(ctx, d, "wallet", []string{"chain_id", "address", "balance"}, walletRows,
+		"ON CONFLICT (chain_id, address) DO UPDATE SET balance = wallet.balance + EXCLUDED.balance")
+	if err != nil {
+		return fmt.Errorf("wallet: %w", err)
+	}
+
+	var erc20Rows [][]interface{}
+	for key, delta := range st.erc20 {
+		erc20Rows = append(erc20Rows, []interface{}{st.chainID, delta.String(), postgresql.HexToBytes(key.hash), postgresql.HexToBytes(key.address)})
+	}
+	negative, err := upsertReturning(ctx, d, "erc20_balance", []string{"chain_id", "balance", "hash", "address"}, erc20Rows,
+		"ON CONFLICT (hash, chain_id, address) DO UPDATE SET balance = erc20_balance.balance + EXCLUDED.balance RETURNING hash, '', address, balance")
+	if err != nil {
+		return fmt.Errorf("erc20_balance: %w", err)
+	}
+	st.negativeAnomalies(evmType.AnomalyErc20NegativeBalance, negative, st.erc20Out)
+
+	var erc721Rows [][]interface{}
+	for key, owner := range st.erc721Owners {
+		erc721Rows = append(erc721Rows, []interface{}{st.chainID, postgresql.HexToBytes(key.hash), key.tokenID, postgresql.HexToBytes(owner)})
+	}
+	err = insertFromTemp(ctx, d, "erc721_balance", []string{"chain_id", "hash", "token_id", "address"}, erc721Rows,
+		"ON CONFLICT (hash, token_id, chain_id) DO UPDATE SET address = EXCLUDED.address")
+	if err != nil {
+		return fmt.Errorf("erc721_balance: %w", err)
+	}
+
+	// ERC-1155 는 보내는 쪽의 현재 보유량을 읽어서 전송마다 검사한 뒤 합친 변화량을 반영한다
+	balances, err := erc1155Balances(ctx, d, st.chainID, st.erc1155Debtors())
+	if err != nil {
+		return fmt.Errorf("erc1155_balance: %w", err)
+	}
+	st.applyErc1155(balances)
+
+	var erc1155Rows [][]interface{}
+	for key, delta := range st.erc1155 {
+		erc1155Rows = append(erc1155Rows, []interface{}{st.chainID, postgresql.HexToBytes(key.hash), key.tokenID, postgresql.HexToBytes(key.address), delta.String()})
+	}
+	err = insertFromTemp(ctx, d, "erc1155_balance", []string{"chain_id", "hash", "token_id", "address", "amount"}, erc1155Rows,
+		"ON CONFLICT (hash, token_id, address, chain_id) DO UPDATE SET amount = erc1155_balance.amount + EXCLUDED.amount")
+	if err != nil {
+		return fmt.Errorf("erc1155_balance: %w", err)
+	}
+
+	// 토큰 목록, 컨트랙트 종류
+	if err = insertFromTemp(ctx, d, "erc721", []string{"chain_id", "hash", "token_id"}, tokenRows(st.chainID, st.erc721Tokens),
+		"ON CONFLICT (chain_id, hash, token_id) DO NOTHING"); err != nil {
+		return fmt.Errorf("erc721: %w", err)
+	}
+	if err = insertFromTemp(ctx, d, "erc1155", []string{"chain_id", "hash", "token_id"}, tokenRows(st.chainID, st.erc1155Tokens),
+		"ON CONFLICT (chain_id, hash, token_id) DO NOTHING"); err != nil {
+		return fmt.Errorf("erc1155: %w", err)
+	}
+	if len(st.contractTypes) > 0 {
+		var hashes [][]byte
+		var types []string
+		for hash, contractType := range st.contractTypes {
+			hashes = append(hashes, postgresql.HexToBytes(hash))
+			types = append(types, contractType)
+		}
+		_, err = d.ExecContext(ctx, `UPDATE contract c SET type = v.type
+FROM unnest($1::bytea[], $2::varchar[]) AS v(hash, type)
+WHERE c.chain_id = $3 AND c.hash = v.hash`, pq.Array(hashes), pq.Array(types), st.chainID)
+		if err != nil {
+			return fmt.Errorf("contract type: %w", err)
+		}
+	}
+
+	// 원장, 이상 징후는 충돌이 없으므로 바로 COPY
+	if err = d.CopyIn(ctx, "balance_change", balanceChangeColumns, st.changes); err != nil {
+		return err
+	}
+	if err = d.CopyIn(ctx, "anomaly", anomalyColumns, st.anomalies); err != nil {
+		return err
+	}
+
+	if len(st.anomalies) > 0 {
+		s.l.Warn("balance anomaly", logger.Field{Key: "count", Value: len(st.anomalies)}, logger.Field{Key: "block number", Value: st.lastBlock})
+	}
+
+	return nil
+}
+
+// negativeAnomalies 배치 반영 후 음수가 된 잔액. expected 는 배치 안에서 빠져나간 양, actual 은 그 직전 보유량
+func (st *bulkStage) negativeAnomalies(kind string, balances map[balanceKey]*big.Int, out map[balanceKey]*big.Int) {
+	for key, balance := range balances {
+		if balance.Sign() >= 0 {
+			continue
+		}
+		outflow, ok := out[key]
+		if !ok {
+			outflow = new(big.Int)
+		}
+		st.anomalies = append(st.anomalies, paramRow(anomalyParams(st.chainID, st.lastBlock, &evmType.Anomaly{
+			Kind:     kind,
+			Contract: key.hash,
+			Address:  key.address,
+			Expected: outflow,
+			Actual:   new(big.Int).Add(balance, outflow),
+			Detail:   fmt.Sprintf("negative after bulk batch ending at block %d", st.lastBlock),
+		})))
+	}
+}
+
+func insertFromTemp(ctx context.Context, d *postgresql.Database, table string, columns []string, rows [][]interface{}, conflict string) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	temp, err := d.CopyToTemp(ctx, table, columns, rows)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s %s",
+		pq.QuoteIdentifier(table), columnList(columns), columnList(columns), temp, conflict))
+	return err
+}
+
+// upsertReturning RETURNING hash, token_id, address, balance 결과를 키별로 돌려준다
+func upsertReturning(ctx context.Context, d *postgresql.Database, table string, columns []string, rows [][]interface{}, conflict string) (map[balanceKey]*big.Int, error) {
+	if len(rows) == 0 {
+		return make(map[balanceKey]*big.Int), nil
+	}
+
+	temp, err := d.CopyToTemp(ctx, table, columns, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := d.QueryContext(ctx, fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s %s",
+		pq.QuoteIdentifier(table), columnList(columns), columnList(columns), temp, conflict))
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	return scanBalances(result)
+}
+
+// erc1155Balances (토큰, 주소) 별 현재 보유량. 행이 없는 키는 돌려주지 않는다
+func erc1155Balances(ctx context.Context, d *postgresql.Database, chainID int64, keys []balanceKey) (map[balanceKey]*big.Int, error) {
+	if len(keys) == 0 {
+		return make(map[balanceKey]*big.Int), nil
+	}
+
+	hashes := make([][]byte, len(keys))
+	tokenIDs := make([]string, len(keys))
+	addresses := make([][]byte, len(keys))
+	for i, key := range keys {
+		hashes[i] = postgresql.HexToBytes(key.hash)
+		tokenIDs[i] = key.tokenID
+		addresses[i] = postgresql.HexToBytes(key.address)
+	}
+
+	result, err := d.QueryContext(ctx, `SELECT b.hash, b.token_id::text, b.address, b.amount::text
+FROM erc1155_balance b
+JOIN unnest($2::bytea[], $3::numeric[], $4::bytea[]) AS k(hash, token_id, address)
+  ON b.hash = k.hash AND b.token_id = k.token_id AND b.address = k.address
+WHERE b.chain_id = $1`, chainID, pq.Array(hashes), pq.Array(tokenIDs), pq.Array(addresses))
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	return scanBalances(result)
+}
+
+// scanBalances hash, token_id, address, balance 행을 키별로 읽는다
+func scanBalances(result *sql.Rows) (map[balanceKey]*big.Int, error) {
+	balances := make(map[balanceKey]*big.Int)
+	for result.Next() {
+		var key balanceKey
+		var hash, address []byte
+		var balance string
+		if err := result.Scan(&hash, &key.tokenID, &address, &balance); err != nil {
+			return nil, err
+		}
+		key.hash = postgresql.BytesToHex(hash)
+		key.address = postgresql.BytesToHex(address)
+		value, ok := new(big.Int).SetString(balance, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid balance %s", balance)
+		}
+		balances[key] = value
+	}
+
+	return balances, result.Err()
+}
+
+func tokenRows(chainID int64, tokens map[balanceKey]bool) [][]interface{} {
+	rows := make([][]interface{}, 0, len(tokens))
+	for key := range tokens {
+		rows = append(rows, []interface{}{chainID, postgresql.HexToBytes(key.hash), key.tokenID})
+	}
+	return rows
+}
+
+func mapRows(m map[string][]interface{}) [][]interface{} {
+	rows := make([][]interface{}, 0, len(m))
+	for _, row := range m {
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func columnList(columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = pq.QuoteIdentifier(column)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// paramRow sqlc 파라미터 구조체를 필드 순서대로 COPY 한 행으로 바꾼다
+func paramRow(params interface{}) []interface{} {
+	v := reflect.ValueOf(params)
+	row := make([]interface{}, v.NumField())
+	for i := range row {
+		field := v.Field(i).Interface()
+		switch values := field.(type) {
+		case []string, [][]byte:
+			field = pq.Array(values)
+		}
+		row[i] = field
+	}
+	return row
+}
@@ -0,0 +1,184 @@
+package blockchain
+
+import (
+	"blockchain-tracking/internal/blockchain/evm/evmType"
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/database/postgresql"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const (
+	testContract = "0x00000000000000000000000000000000000000c1"
+	testZero     = "0x0000000000000000000000000000000000000000"
+	testA        = "0x00000000000000000000000000000000000000a1"
+	testB        = "0x00000000000000000000000000000000000000b1"
+	testC        = "0x00000000000000000000000000000000000000c2"
+	testD        = "0x00000000000000000000000000000000000000d1"
+	testE        = "0x00000000000000000000000000000000000000e1"
+)
+
+type testTransfer struct {
+	from, to string
+	amount   int64
+	function string
+}
+
+func testBlock(number uint64, transfers ...testTransfer) *evmType.Block {
+	chainID := big.NewInt(1)
+	block := &evmType.Block{ChainID: chainID, Number: number, Hash: "0x01"}
+	for i, t := range transfers {
+		txHash := "0x" + big.NewInt(int64(number)*100+int64(i)).Text(16)
+		block.Transaction = append(block.Transaction, evmType.Transaction{
+			ChainID:     chainID,
+			BlockNumber: number,
+			Hash:        txHash,
+			Erc1155Logs: []*evmType.Erc1155Log{{
+				ChainID:         chainID,
+				Timestamp:       time.Unix(int64(number), 0).UTC(),
+				TransactionHash: txHash,
+				LogIndex:        uint64(i),
+				ContractAddress: testContract,
+				From:            t.from,
+				To:              t.to,
+				TokenId:         big.NewInt(7),
+				Amount:          big.NewInt(t.amount),
+				Function:        t.function,
+			}},
+		})
+	}
+	return block
+}
+
+func testKey(address string) balanceKey {
+	return balanceKey{hash: testContract, tokenID: "7", address: address}
+}
+
+var testNames = map[string]string{testZero: "0", testA: "A", testB: "B", testC: "C", testD: "D", testE: "E"}
+
+// changeRow balance_change 행을 "블록 tx 주소 delta" 로 읽는다
+func changeRow(row []interface{}) string {
+	return fmt.Sprintf("%d %s %s %s", row[6], postgresql.BytesToHex(row[7].([]byte)), testNames[postgresql.BytesToHex(row[4].([]byte))], row[5])
+}
+
+// anomalyRow anomaly 행을 "종류 블록 tx logIndex 주소 expected actual" 로 읽는다
+func anomalyRow(row []interface{}) string {
+	return fmt.Sprintf("%s %d %s %d %s %s %s", row[1], row[2], postgresql.BytesToHex(row[3].([]byte)), row[4].(sql.NullInt64).Int64,
+		testNames[postgresql.BytesToHex(row[6].([]byte))], row[7].(sql.NullString).String, row[8].(sql.NullString).String)
+}
+
+// 보유량이 모자란 차감은 잔액도 원장도 건드리지 않고 이상 징후만 남긴다. 받는 쪽은 그대로 더한다 (Create 와 같음)
+func TestApplyErc1155(t *testing.T) {
+	blocks := []*evmType.Block{
+		testBlock(100, // tx 0x2710, 0x2711
+			testTransfer{testZero, testA, 5, "mint"},
+			testTransfer{testA, testB, 3, "transfer"},
+		),
+		testBlock(101, // tx 0x2774, 0x2775, 0x2776
+			testTransfer{testA, testB, 4, "transfer"}, // A 는 2 뿐이라 차감하지 않는다
+			testTransfer{testB, testC, 7, "transfer"},
+			testTransfer{testB, testA, 1, "transfer"}, // B 는 0 이라 차감하지 않는다
+		),
+		testBlock(102, // tx 0x27d8, 0x27d9, 0x27da
+			testTransfer{testA, testC, 3, "transfer"},
+			testTransfer{testD, testA, 0, "transfer"}, // 행이 없으면 0 개라도 차감하지 않는다
+			testTransfer{testE, testA, 10, "transfer"},
+		),
+	}
+	balances := map[balanceKey]*big.Int{testKey(testE): big.NewInt(10)}
+
+	st := newBulkStage(1)
+	for _, block := range blocks {
+		st.add(block)
+	}
+	st.applyErc1155(balances)
+
+	wantChanges := []string{
+		"100 0x2710 A 5",
+		"100 0x2711 A -3",
+		"100 0x2711 B 3",
+		"101 0x2774 B 4",
+		"101 0x2775 B -7",
+		"101 0x2775 C 7",
+		"101 0x2776 A 1",
+		"102 0x27d8 A -3",
+		"102 0x27d8 C 3",
+		"102 0x27d9 A 0",
+		"102 0x27da E -10",
+		"102 0x27da A 10",
+	}
+	var gotChanges []string
+	for _, row := range st.changes {
+		gotChanges = append(gotChanges, changeRow(row))
+	}
+	if !reflect.DeepEqual(gotChanges, wantChanges) {
+		t.Errorf("balance changes:\n got %q\nwant %q", gotChanges, wantChanges)
+	}
+
+	wantAnomalies := []string{
+		"erc1155_insufficient_balance 101 0x2774 0 A 4 2",
+		"erc1155_insufficient_balance 101 0x2776 2 B 1 0",
+		"erc1155_insufficient_balance 102 0x27d9 1 D 0 0",
+	}
+	var gotAnomalies []string
+	for _, row := range st.anomalies {
+		gotAnomalies = append(gotAnomalies, anomalyRow(row))
+	}
+	if !reflect.DeepEqual(gotAnomalies, wantAnomalies) {
+		t.Errorf("anomalies:\n got %q\nwant %q", gotAnomalies, wantAnomalies)
+	}
+
+	// 배치에서 합친 변화량 (erc1155_balance 에 더할 값)과 반영 후 보유량
+	wantDeltas := map[string]int64{testA: 10, testB: 0, testC: 10, testE: -10}
+	wantBalances := map[string]int64{testA: 10, testB: 0, testC: 10, testE: 0}
+	if len(st.erc1155) != len(wantDeltas) {
+		t.Errorf("deltas for %d keys, want %d", len(st.erc1155), len(wantDeltas))
+	}
+	for address, delta := range wantDeltas {
+		if got := st.erc1155[testKey(address)]; got == nil || got.Int64() != delta {
+			t.Errorf("%s delta = %v, want %d", testNames[address], got, delta)
+		}
+	}
+	for address, balance := range wantBalances {
+		if got := balances[testKey(address)]; got == nil || got.Int64() != balance {
+			t.Errorf("%s balance = %v, want %d", testNames[address], got, balance)
+		}
+	}
+	if _, ok := balances[testKey(testD)]; ok {
+		t.Errorf("D has a balance row after a refused debit")
+	}
+}
+
+// paramRow 는 파라미터 구조체 필드 순서대로 행을 만들므로 sqlc 가 컬럼 순서를 바꾸면 여기서 잡는다
+func TestParamColumns(t *testing.T) {
+	tests := []struct {
+		params  interface{}
+		columns []string
+	}{
+		{gen.InsertBlockParams{}, blockColumns},
+		{gen.InsertTransactionParams{}, transactionColumns},
+		{gen.InsertTransactionInputParams{}, transactionInputColumns},
+		{gen.InsertContractParams{}, contractColumns},
+		{gen.InsertCoinLogParams{}, coinLogColumns},
+		{gen.InsertERC20LogParams{}, erc20LogColumns},
+		{gen.InsertERC721LogParams{}, erc721LogColumns},
+		{gen.InsertERC1155LogParams{}, erc1155LogColumns},
+		{gen.InsertLogParams{}, logColumns},
+		{gen.InsertBalanceChangeParams{}, balanceChangeColumns},
+		{gen.InsertAnomalyParams{}, anomalyColumns},
+	}
+	for _, tt := range tests {
+		typ := reflect.TypeOf(tt.params)
+		fields := make([]string, typ.NumField())
+		for i := range fields {
+			fields[i] = typ.Field(i).Tag.Get("json")
+		}
+		if !reflect.DeepEqual(fields, tt.columns) {
+			t.Errorf("%s fields %v, columns %v", typ.Name(), fields, tt.columns)
+		}
+	}
+}
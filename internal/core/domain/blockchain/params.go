@@ -0,0 +1,187 @@
+package blockchain
+
+import (
+	"blockchain-tracking/internal/blockchain/evm/evmType"
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/database/postgresql"
+	"database/sql"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Create 와 CreateBatch 가 같은 행을 만들도록 파라미터 변환을 한 곳에 둔다
+
+func blockParams(block *evmType.Block) gen.InsertBlockParams {
+	return gen.InsertBlockParams{
+		ChainID:          block.ChainID.Int64(),
+		Difficulty:       nullNumeric(block.Difficulty),
+		Hash:             postgresql.HexToBytes(block.Hash),
+		GasLimit:         sql.NullInt64{Int64: int64(block.GasLimit), Valid: true},
+		GasUsed:          sql.NullInt64{Int64: int64(block.GasUsed), Valid: true},
+		Miner:            postgresql.HexToBytes(block.Miner),
+		Number:           int64(block.Number),
+		ParentHash:       postgresql.HexToBytes(block.ParentHash),
+		Timestamp:        block.Timestamp,
+		TotalDifficulty:  nullNumeric(block.TotalDifficulty),
+		TransactionsRoot: postgresql.HexToBytes(block.TransactionsRoot),
+	}
+}
+
+func transactionParams(tx *evmType.Transaction) gen.InsertTransactionParams {
+	txInput := gen.InsertTransactionParams{
+		ChainID:          tx.ChainID.Int64(),
+		BlockHash:        postgresql.HexToBytes(tx.BlockHash),
+		BlockNumber:      int64(tx.BlockNumber),
+		From:             postgresql.HexToBytes(tx.From),
+		To:               postgresql.HexToBytes(tx.To),
+		Gas:              sql.NullInt64{Int64: int64(tx.Gas), Valid: true},
+		GasPrice:         nullNumeric(tx.GasPrice),
+		Hash:             postgresql.HexToBytes(tx.Hash),
+		R:                postgresql.HexToBytes(tx.R),
+		S:                postgresql.HexToBytes(tx.S),
+		V:                postgresql.HexToBytes(tx.V),
+		TransactionIndex: sql.NullInt64{Int64: int64(tx.TransactionIndex), Valid: true},
+		Value:            nullNumeric(tx.Value),
+		Nonce:            sql.NullInt64{Int64: int64(tx.Nonce), Valid: true},
+		GasUsed:          sql.NullInt64{Int64: int64(tx.GasUsed), Valid: true},
+		Status:           sql.NullInt16{Int16: int16(tx.Status), Valid: true},
+		Type:             sql.NullInt16{Int16: int16(tx.Type), Valid: true},
+		Timestamp:        tx.Timestamp,
+		CoinCount:        int32(tx.CoinCount),
+		NftCount:         int32(tx.NftCount),
+		Erc20Count:       int32(tx.Erc20Count),
+		Erc721Count:      int32(tx.Erc721Count),
+		Erc1155Count:     int32(tx.Erc1155Count),
+	}
+	if tx.ContractAddress != nil {
+		txInput.ContractAddress = postgresql.HexToBytes(*tx.ContractAddress)
+	}
+	return txInput
+}
+
+// transactionInputParams calldata 가 없으면 (단순 전송) 저장하지 않는다
+func transactionInputParams(tx *evmType.Transaction) (gen.InsertTransactionInputParams, bool) {
+	input := postgresql.HexToBytes(tx.Input)
+	return gen.InsertTransactionInputParams{
+		ChainID:     tx.ChainID.Int64(),
+		BlockNumber: int64(tx.BlockNumber),
+		Hash:        postgresql.HexToBytes(tx.Hash),
+		Input:       input,
+	}, len(input) > 0
+}
+
+func contractParams(contract *evmType.Contract) gen.InsertContractParams {
+	return gen.InsertContractParams{
+		ChainID:     contract.ChainID.Int64(),
+		Hash:        postgresql.HexToBytes(contract.Hash),
+		Name:        sql.NullString{String: contract.Name, Valid: contract.Name != ""},
+		Symbol:      sql.NullString{String: contract.Symbol, Valid: contract.Symbol != ""},
+		Decimals:    sql.NullInt32{Int32: int32(contract.Decimals), Valid: true},
+		TotalSupply: nullNumeric(contract.TotalSupply),
+		Type:        sql.NullString{String: contract.Type, Valid: contract.Type != ""},
+		Creator:     postgresql.HexToBytes(contract.Creator),
+	}
+}
+
+func coinLogParams(coinLog *evmType.CoinLog) gen.InsertCoinLogParams {
+	return gen.InsertCoinLogParams{
+		ChainID:         coinLog.ChainID.Int64(),
+		Timestamp:       coinLog.Timestamp,
+		TransactionHash: postgresql.HexToBytes(coinLog.TransactionHash),
+		From:            postgresql.HexToBytes(coinLog.From),
+		To:              postgresql.HexToBytes(coinLog.To),
+		Amount:          nullNumeric(coinLog.Amount),
+		Gas:             sql.NullInt64{Int64: int64(coinLog.Gas), Valid: true},
+		GasPrice:        nullNumeric(coinLog.GasPrice),
+		GasUsed:         sql.NullInt64{Int64: int64(coinLog.GasUsed), Valid: true},
+	}
+}
+
+func erc20LogParams(erc20Data *evmType.Erc20Log) gen.InsertERC20LogParams {
+	return gen.InsertERC20LogParams{
+		ChainID:         erc20Data.ChainID.Int64(),
+		Timestamp:       erc20Data.Timestamp,
+		TransactionHash: postgresql.HexToBytes(erc20Data.TransactionHash),
+		ContractAddress: postgresql.HexToBytes(erc20Data.ContractAddress),
+		From:            postgresql.HexToBytes(erc20Data.From),
+		To:              postgresql.HexToBytes(erc20Data.To),
+		Amount:          erc20Data.Amount.String(),
+		Function:        erc20Data.Function,
+		Name:            sql.NullString{String: erc20Data.Name, Valid: erc20Data.Name != ""},
+		Symbol:          sql.NullString{String: erc20Data.Symbol, Valid: erc20Data.Symbol != ""},
+		LogIndex:        int64(erc20Data.LogIndex),
+	}
+}
+
+func erc721LogParams(erc721Data *evmType.Erc721Log) gen.InsertERC721LogParams {
+	return gen.InsertERC721LogParams{
+		ChainID:         erc721Data.ChainID.Int64(),
+		Timestamp:       erc721Data.Timestamp,
+		TransactionHash: postgresql.HexToBytes(erc721Data.TransactionHash),
+		ContractAddress: postgresql.HexToBytes(erc721Data.ContractAddress),
+		From:            postgresql.HexToBytes(erc721Data.From),
+		To:              postgresql.HexToBytes(erc721Data.To),
+		TokenID:         erc721Data.TokenId.String(),
+		Function:        sql.NullString{String: erc721Data.Function, Valid: erc721Data.Function != ""},
+		Name:            sql.NullString{String: erc721Data.Name, Valid: erc721Data.Name != ""},
+		Symbol:          sql.NullString{String: erc721Data.Symbol, Valid: erc721Data.Symbol != ""},
+		LogIndex:        int64(erc721Data.LogIndex),
+	}
+}
+
+func erc1155LogParams(erc1155Data *evmType.Erc1155Log) gen.InsertERC1155LogParams {
+	return gen.InsertERC1155LogParams{
+		ChainID:         erc1155Data.ChainID.Int64(),
+		Timestamp:       erc1155Data.Timestamp,
+		TransactionHash: postgresql.HexToBytes(erc1155Data.TransactionHash),
+		ContractAddress: postgresql.HexToBytes(erc1155Data.ContractAddress),
+		From:            postgresql.HexToBytes(erc1155Data.From),
+		To:              postgresql.HexToBytes(erc1155Data.To),
+		TokenID:         nullNumeric(erc1155Data.TokenId),
+		Amount:          nullNumeric(erc1155Data.Amount),
+		Function:        sql.NullString{String: erc1155Data.Function, Valid: erc1155Data.Function != ""},
+		Name:            sql.NullString{String: erc1155Data.Name, Valid: erc1155Data.Name != ""},
+		Symbol:          sql.NullString{String: erc1155Data.Symbol, Valid: erc1155Data.Symbol != ""},
+		LogIndex:        int64(erc1155Data.LogIndex),
+		BatchIndex:      int32(erc1155Data.BatchIndex),
+	}
+}
+
+func logParams(txLogData *evmType.Log) gen.InsertLogParams {
+	topics := make([][]byte, len(txLogData.Topics))
+	for i, topic := range txLogData.Topics {
+		topics[i] = topic.Bytes()
+	}
+
+	return gen.InsertLogParams{
+		ChainID:          txLogData.ChainID.Int64(),
+		Address:          postgresql.HexToBytes(txLogData.Address),
+		BlockHash:        postgresql.HexToBytes(txLogData.BlockHash),
+		BlockNumber:      int64(txLogData.BlockNumber),
+		Data:             postgresql.HexToBytes(txLogData.Data),
+		LogIndex:         int64(txLogData.LogIndex),
+		Removed:          txLogData.Removed,
+		Topics:           topics,
+		TransactionHash:  postgresql.HexToBytes(txLogData.TransactionHash),
+		TransactionIndex: int64(txLogData.TransactionIndex),
+		From:             addressBytes(txLogData.From),
+		To:               addressBytes(txLogData.To),
+		Timestamp:        txLogData.Timestamp,
+	}
+}
+
+func nullNumeric(n *big.Int) sql.NullString {
+	if n == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: n.String(), Valid: true}
+}
+
+// addressBytes 값이 없는 (zero) 주소는 NULL 로 저장한다
+func addressBytes(address common.Address) []byte {
+	if address == (common.Address{}) {
+		return nil
+	}
+	return address.Bytes()
+}
@@ -0,0 +1,143 @@
+package blockchain
+
+import (
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/logger"
+	"blockchain-tracking/internal/metrics"
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Unindex from~to 구간에 저장된 블록과 그 블록에서 나온 데이터를 지우고 잔액을 되돌린다.
+// 지운 구간은 BlockScanner 로 다시 받은 뒤 SyncERC721Owners 를 부른다
+func (s *Service) Unindex(ctx context.Context, chainID, from, to int64) error {
+	started := time.Now()
+	err := s.txManager.WithTransaction(ctx, sql.LevelRepeatableRead, false, func(ctx context.Context) error {
+		q := s.db.GetQueryRowerFromContext(ctx).Queries
+
+		bounds, err := q.GetBlockRangeTimestamps(ctx, gen.GetBlockRangeTimestampsParams{ChainID: chainID, FromBlock: from, ToBlock: to})
+		if err != nil {
+			return err
+		}
+
+		// 원장을 지우기 전에 잔액부터 되돌린다
+		reverted, err := q.RevertWalletBalances(ctx, gen.RevertWalletBalancesParams{ChainID: chainID, FromBlock: from, ToBlock: to})
+		if err != nil {
+			s.l.Error("revert wallet balances", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: chainID})
+			return err
+		}
+		n, err := q.RevertERC20Balances(ctx, gen.RevertERC20BalancesParams{ChainID: chainID, FromBlock: from, ToBlock: to})
+		if err != nil {
+			s.l.Error("revert erc20 balances", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: chainID})
+			return err
+		}
+		reverted += n
+		n, err = q.RevertERC1155Balances(ctx, gen.RevertERC1155BalancesParams{ChainID: chainID, FromBlock: from, ToBlock: to})
+		if err != nil {
+			s.l.Error("revert erc1155 balances", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: chainID})
+			return err
+		}
+		reverted += n
+
+		tokens, err := q.ListChangedERC721Tokens(ctx, gen.ListChangedERC721TokensParams{ChainID: chainID, FromBlock: from, ToBlock: to})
+		if err != nil {
+			return err
+		}
+
+		if _, err = q.DeleteBalanceChanges(ctx, gen.DeleteBalanceChangesParams{ChainID: chainID, FromBlock: from, ToBlock: to}); err != nil {
+			return err
+		}
+
+		// erc721 은 구간 밖에서 마지막으로 받은 주소로 돌려놓는다
+		if err = s.syncERC721Owners(ctx, q, chainID, tokens); err != nil {
+			return err
+		}
+
+		// 로그 테이블은 transaction 으로 찾으므로 transaction 보다 먼저 지운다
+		logs := gen.DeleteCoinLogsParams{ChainID: chainID, FromTime: bounds.FromTime, ToTime: bounds.ToTime, FromBlock: from, ToBlock: to}
+		if _, err = q.DeleteCoinLogs(ctx, logs); err != nil {
+			return err
+		}
+		if _, err = q.DeleteERC20Logs(ctx, gen.DeleteERC20LogsParams(logs)); err != nil {
+			return err
+		}
+		if _, err = q.DeleteERC721Logs(ctx, gen.DeleteERC721LogsParams(logs)); err != nil {
+			return err
+		}
+		if _, err = q.DeleteERC1155Logs(ctx, gen.DeleteERC1155LogsParams(logs)); err != nil {
+			return err
+		}
+
+		if _, err = q.DeleteLogs(ctx, gen.DeleteLogsParams{ChainID: chainID, FromBlock: from, ToBlock: to}); err != nil {
+			return err
+		}
+		if _, err = q.DeleteTransactionInputs(ctx, gen.DeleteTransactionInputsParams{ChainID: chainID, FromBlock: from, ToBlock: to}); err != nil {
+			return err
+		}
+		if _, err = q.DeleteTransactions(ctx, gen.DeleteTransactionsParams{ChainID: chainID, FromBlock: from, ToBlock: to}); err != nil {
+			return err
+		}
+		if _, err = q.DeleteAnomalies(ctx, gen.DeleteAnomaliesParams{ChainID: chainID, FromBlock: from, ToBlock: to}); err != nil {
+			return err
+		}
+		if _, err = q.DeletePendingWatchAlerts(ctx, gen.DeletePendingWatchAlertsParams{ChainID: chainID, FromBlock: from, ToBlock: to}); err != nil {
+			return err
+		}
+		if _, err = q.DeletePendingWebhookDeliveries(ctx, gen.DeletePendingWebhookDeliveriesParams{ChainID: chainID, FromBlock: from, ToBlock: to}); err != nil {
+			return err
+		}
+
+		blocks, err := q.DeleteBlocks(ctx, gen.DeleteBlocksParams{ChainID: chainID, FromBlock: from, ToBlock: to})
+		if err != nil {
+			return err
+		}
+
+		s.l.Info("unindexed blocks", logger.Field{Key: "chain_id", Value: chainID}, logger.Field{Key: "from", Value: from}, logger.Field{Key: "to", Value: to},
+			logger.Field{Key: "blocks", Value: blocks}, logger.Field{Key: "balances", Value: reverted}, logger.Field{Key: "erc721", Value: len(tokens)})
+		return nil
+	})
+	metrics.DBTransaction("unindex", time.Since(started), err)
+	if err != nil {
+		s.l.Error("unindex blocks", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: chainID}, logger.Field{Key: "from", Value: from}, logger.Field{Key: "to", Value: to})
+	}
+
+	return err
+}
+
+// SyncERC721Owners 다시 받은 구간에서 주인이 바뀐 erc721 토큰을 원장의 마지막 주인으로 맞춘다.
+// 구간 뒤의 블록에서 다시 옮겨진 토큰을 구간 안의 주소로 덮어쓰지 않게 한다
+func (s *Service) SyncERC721Owners(ctx context.Context, chainID, from, to int64) error {
+	return s.txManager.WithTransaction(ctx, sql.LevelRepeatableRead, false, func(ctx context.Context) error {
+		q := s.db.GetQueryRowerFromContext(ctx).Queries
+
+		tokens, err := q.ListChangedERC721Tokens(ctx, gen.ListChangedERC721TokensParams{ChainID: chainID, FromBlock: from, ToBlock: to})
+		if err != nil {
+			return err
+		}
+		return s.syncERC721Owners(ctx, q, chainID, tokens)
+	})
+}
+
+func (s *Service) syncERC721Owners(ctx context.Context, q *gen.Queries, chainID int64, tokens []*gen.ListChangedERC721TokensRow) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	hashes := make([][]byte, len(tokens))
+	tokenIds := make([]string, len(tokens))
+	for i, token := range tokens {
+		hashes[i] = token.Hash
+		tokenIds[i] = token.TokenID.String
+	}
+
+	if _, err := q.SyncERC721Owners(ctx, gen.SyncERC721OwnersParams{ChainID: chainID, Hashes: hashes, TokenIds: tokenIds}); err != nil {
+		s.l.Error("sync erc721 owners", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: chainID})
+		return err
+	}
+	if _, err := q.DeleteUnownedERC721Balances(ctx, gen.DeleteUnownedERC721BalancesParams{ChainID: chainID, Hashes: hashes, TokenIds: tokenIds}); err != nil {
+		s.l.Error("delete unowned erc721 balances", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: chainID})
+		return err
+	}
+	return nil
+}
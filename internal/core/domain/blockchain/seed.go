@@ -0,0 +1,190 @@
+package blockchain
+
+import (
+	"blockchain-tracking/internal/blockchain/evm/evmType"
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/database/postgresql"
+	"context"
+	"database/sql"
+)
+
+// SeedKey 시딩 여부를 확인할 (토큰, 주소) 조합
+type SeedKey struct {
+	Kind    string
+	Hash    string
+	TokenId string
+	Address string
+}
+
+// FilterUnseeded DB 에 아직 행이 없는 키만 돌려준다. Hash, Address 는 소문자 0x hex
+func (s *Service) FilterUnseeded(ctx context.Context, chainId int64, keys []SeedKey) ([]SeedKey, error) {
+	var wallets, erc20Hashes, erc20Addresses, erc721Hashes, erc721TokenIds, erc1155Hashes, erc1155TokenIds, erc1155Addresses []string
+	for _, key := range keys {
+		switch key.Kind {
+		case "coin":
+			wallets = append(wallets, key.Address)
+		case "erc20":
+			erc20Hashes = append(erc20Hashes, key.Hash)
+			erc20Addresses = append(erc20Addresses, key.Address)
+		case "erc721":
+			erc721Hashes = append(erc721Hashes, key.Hash)
+			erc721TokenIds = append(erc721TokenIds, key.TokenId)
+		case "erc1155":
+			erc1155Hashes = append(erc1155Hashes, key.Hash)
+			erc1155TokenIds = append(erc1155TokenIds, key.TokenId)
+			erc1155Addresses = append(erc1155Addresses, key.Address)
+		}
+	}
+
+	existing := make(map[SeedKey]bool)
+
+	if len(wallets) > 0 {
+		rows, err := s.db.Queries.ListExistingWallets(ctx, gen.ListExistingWalletsParams{ChainID: chainId, Addresses: postgresql.HexListToBytes(wallets)})
+		if err != nil {
+			return nil, err
+		}
+		for _, address := range rows {
+			existing[SeedKey{Kind: "coin", Address: postgresql.BytesToHex(address)}] = true
+		}
+	}
+
+	if len(erc20Hashes) > 0 {
+		rows, err := s.db.Queries.ListExistingERC20Balances(ctx, gen.ListExistingERC20BalancesParams{ChainID: chainId, Hashes: postgresql.HexListToBytes(erc20Hashes), Addresses: postgresql.HexListToBytes(erc20Addresses)})
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			existing[SeedKey{Kind: "erc20", Hash: postgresql.BytesToHex(row.Hash), Address: postgresql.BytesToHex(row.Address)}] = true
+		}
+	}
+
+	if len(erc721Hashes) > 0 {
+		rows, err := s.db.Queries.ListExistingERC721Tokens(ctx, gen.ListExistingERC721TokensParams{ChainID: chainId, Hashes: postgresql.HexListToBytes(erc721Hashes), TokenIds: erc721TokenIds})
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			existing[SeedKey{Kind: "erc721", Hash: postgresql.BytesToHex(row.Hash), TokenId: row.TokenID}] = true
+		}
+	}
+
+	if len(erc1155Hashes) > 0 {
+		rows, err := s.db.Queries.ListExistingERC1155Balances(ctx, gen.ListExistingERC1155BalancesParams{
+			ChainID:   chainId,
+			Hashes:    postgresql.HexListToBytes(erc1155Hashes),
+			TokenIds:  erc1155TokenIds,
+			Addresses: postgresql.HexListToBytes(erc1155Addresses),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			existing[SeedKey{Kind: "erc1155", Hash: postgresql.BytesToHex(row.Hash), TokenId: row.TokenID, Address: postgresql.BytesToHex(row.Address)}] = true
+		}
+	}
+
+	var unseeded []SeedKey
+	for _, key := range keys {
+		lookup := key
+		if key.Kind == "erc721" {
+			// erc721 은 토큰 단위로 한 행이라 주소는 비교하지 않는다
+			lookup.Address = ""
+		}
+		if !existing[lookup] {
+			unseeded = append(unseeded, key)
+		}
+	}
+
+	return unseeded, nil
+}
+
+// UnseededCollections 추적 시작 전에 배포되어 소유자 정보가 없는 ERC721 컬렉션
+func (s *Service) UnseededCollections(ctx context.Context, chainId int64, hashes []string) ([]string, error) {
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+
+	rows, err := s.db.Queries.ListUnseededCollections(ctx, gen.ListUnseededCollectionsParams{Hashes: postgresql.HexListToBytes(hashes), ChainID: chainId})
+	if err != nil {
+		return nil, err
+	}
+
+	collections := make([]string, len(rows))
+	for i, hash := range rows {
+		collections[i] = postgresql.BytesToHex(hash)
+	}
+	return collections, nil
+}
+
+// applySeeds 블록의 델타를 반영하기 전에 시드 잔액을 넣는다.
+// 이미 행이 있으면 (다른 블록에서 먼저 시딩된 경우) 건너뛰고 원장에도 남기지 않는다.
+func (s *Service) applySeeds(ctx context.Context, q *gen.Queries, block *evmType.Block) error {
+	chainId := block.ChainID.Int64()
+
+	for _, seed := range block.Seeds {
+		var affected int64
+		var err error
+
+		tokenId := ""
+		if seed.TokenId != nil {
+			tokenId = seed.TokenId.String()
+		}
+
+		switch seed.Kind {
+		case "coin":
+			affected, err = q.SeedWallet(ctx, gen.SeedWalletParams{
+				ChainID: chainId,
+				Address: postgresql.HexToBytes(seed.Address),
+				Balance: seed.Balance.String(),
+			})
+		case "erc20":
+			affected, err = q.SeedERC20Balance(ctx, gen.SeedERC20BalanceParams{
+				ChainID: chainId,
+				Balance: seed.Balance.String(),
+				Hash:    postgresql.HexToBytes(seed.Hash),
+				Address: postgresql.HexToBytes(seed.Address),
+			})
+		case "erc721":
+			affected, err = q.SeedERC721Balance(ctx, gen.SeedERC721BalanceParams{
+				ChainID: chainId,
+				Hash:    postgresql.HexToBytes(seed.Hash),
+				TokenID: tokenId,
+				Address: postgresql.HexToBytes(seed.Address),
+			})
+		case "erc1155":
+			affected, err = q.SeedERC1155Balance(ctx, gen.SeedERC1155BalanceParams{
+				ChainID: chainId,
+				Hash:    postgresql.HexToBytes(seed.Hash),
+				TokenID: tokenId,
+				Address: postgresql.HexToBytes(seed.Address),
+				Amount:  seed.Balance.String(),
+			})
+		default:
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 || seed.Balance.Sign() == 0 {
+			continue
+		}
+
+		err = q.InsertBalanceChange(ctx, gen.InsertBalanceChangeParams{
+			ChainID:     chainId,
+			Kind:        seed.Kind,
+			Hash:        postgresql.HexToBytes(seed.Hash),
+			TokenID:     sql.NullString{String: tokenId, Valid: tokenId != ""},
+			Address:     postgresql.HexToBytes(seed.Address),
+			Delta:       seed.Balance.String(),
+			BlockNumber: int64(seed.BlockNumber),
+			Reason:      "seed",
+			CreatedAt:   block.Timestamp,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,199 +2,195 @@ package blockchain
 
 import (
 	"blockchain-tracking/internal/blockchain/evm/evmType"
+	"blockchain-tracking/internal/core/domain/partition"
+	"blockchain-tracking/internal/core/domain/watchlist"
+	"blockchain-tracking/internal/core/domain/webhook"
 	"blockchain-tracking/internal/database/gen"
 	"blockchain-tracking/internal/database/postgresql"
 	"blockchain-tracking/internal/logger"
+	"blockchain-tracking/internal/metrics"
+	"blockchain-tracking/internal/tracing"
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// ErrBlockHashMismatch 같은 높이에 해시가 다른 블록이 이미 저장되어 있다 (reorg)
+var ErrBlockHashMismatch = errors.New("block hash mismatch")
+
 type Service struct {
-	db        *postgresql.Database
-	txManager postgresql.DBTransactionManager
-	l         logger.Logger
+	db         *postgresql.Database
+	txManager  postgresql.DBTransactionManager
+	partitions *partition.Service
+	watchlists *watchlist.Service
+	webhooks   *webhook.Service
+	l          logger.Logger
 }
 
-func NewService(d *postgresql.Database, tx postgresql.DBTransactionManager, l logger.Logger) *Service {
+func NewService(d *postgresql.Database, tx postgresql.DBTransactionManager, partitions *partition.Service, watchlists *watchlist.Service, webhooks *webhook.Service, l logger.Logger) *Service {
 	return &Service{
-		db:        d,
-		txManager: tx,
-		l:         l,
+		db:         d,
+		txManager:  tx,
+		partitions: partitions,
+		watchlists: watchlists,
+		webhooks:   webhooks,
+		l:          l,
 	}
 }
 
-func (s *Service) GetBlockHeight(ctx context.Context, chainId string) (string, error) {
+func (s *Service) GetBlockHeight(ctx context.Context, chainId int64) (int64, error) {
 	height, err := s.db.Queries.GetBlockHeight(ctx, chainId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return "-1", nil
+			return -1, nil
 		}
-		return "", err
+		return 0, err
 	}
 
 	return height, nil
 }
 
 func (s *Service) Create(ctx context.Context, block *evmType.Block) error {
-	err := s.txManager.WithTransaction(ctx, sql.LevelRepeatableRead, false, func(ctx context.Context) error {
-
-		err := s.db.Queries.InsertBlock(ctx, gen.InsertBlockParams{
-			ChainID:          block.ChainID.String(),
-			Difficulty:       sql.NullString{String: block.Difficulty, Valid: block.Difficulty != ""},
-			Hash:             block.Hash,
-			GasLimit:         sql.NullString{String: block.GasLimit, Valid: block.GasLimit != ""},
-			GasUsed:          sql.NullString{String: block.GasUsed, Valid: block.GasUsed != ""},
-			Miner:            sql.NullString{String: block.Miner, Valid: block.Miner != ""},
-			Number:           block.Number,
-			NumberInt:        block.NumberInt.String(),
-			ParentHash:       sql.NullString{String: block.ParentHash, Valid: block.ParentHash != ""},
-			Timestamp:        block.Timestamp,
-			TimestampInt:     block.TimestampInt.String(),
-			CreatedAt:        block.CreatedAt,
-			TotalDifficulty:  sql.NullString{String: block.TotalDifficulty, Valid: block.TotalDifficulty != ""},
-			TransactionsRoot: sql.NullString{String: block.TransactionsRoot, Valid: block.TransactionsRoot != ""},
-		})
+	// 파티션 생성(DDL)은 블록 저장 트랜잭션 밖에서 먼저 한다
+	err := s.partitions.Ensure(ctx, block.ChainID.Int64(), int64(block.Number), block.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	ctx, span := tracing.Start(ctx, "blockchain.Create", attribute.Int64("chain.id", block.ChainID.Int64()), attribute.Int64("block.number", int64(block.Number)))
+	started := time.Now()
+	err = s.txManager.WithTransaction(ctx, sql.LevelRepeatableRead, false, func(ctx context.Context) error {
+		q := s.db.GetQueryRowerFromContext(ctx).Queries
+
+		err = checkStoredHashes(ctx, q, block.ChainID.Int64(), block)
 		if err != nil {
 			s.l.Error("create block", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "block", Value: block.Hash})
 			return err
 		}
 
+		applied, err := q.InsertBlock(ctx, blockParams(block))
+		if err != nil {
+			s.l.Error("create block", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "block", Value: block.Hash})
+			return err
+		}
+
+		// 같은 블록이 이미 반영되어 있으면 잔액을 다시 반영하지 않는다
+		if applied == 0 {
+			s.l.Info("block already applied, skipping", logger.Field{Key: "block", Value: block.Hash}, logger.Field{Key: "block number", Value: block.Number})
+			return nil
+		}
+
+		// 추적 시작 전부터 있던 잔액은 델타보다 먼저 넣는다
+		err = s.applySeeds(ctx, q, block)
+		if err != nil {
+			s.l.Error("create balance seed", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "block", Value: block.Hash})
+			return err
+		}
+
 		if block.Transaction != nil && len(block.Transaction) > 0 {
 			for _, tx := range block.Transaction {
-				txInput := gen.InsertTransactionParams{
-					ChainID:          tx.ChainID.String(),
-					BlockHash:        tx.BlockHash,
-					BlockNumber:      tx.BlockNumber,
-					BlockNumberInt:   tx.BlockNumberInt.String(),
-					From:             sql.NullString{String: tx.From, Valid: tx.From != ""},
-					To:               sql.NullString{String: tx.To, Valid: tx.To != ""},
-					Gas:              sql.NullString{String: tx.Gas, Valid: tx.Gas != ""},
-					GasInt:           sql.NullString{String: tx.GasInt.String(), Valid: tx.GasInt.String() != ""},
-					GasPrice:         sql.NullString{String: tx.GasPrice, Valid: tx.GasPrice != ""},
-					GasPriceInt:      sql.NullString{String: tx.GasPriceInt.String(), Valid: tx.GasPriceInt.String() != ""},
-					Hash:             tx.Hash,
-					R:                sql.NullString{String: tx.R, Valid: tx.R != ""},
-					S:                sql.NullString{String: tx.S, Valid: tx.S != ""},
-					V:                sql.NullString{String: tx.V, Valid: tx.V != ""},
-					TransactionIndex: sql.NullString{String: tx.TransactionIndex, Valid: tx.TransactionIndex != ""},
-					Value:            sql.NullString{String: tx.Value, Valid: tx.Value != ""},
-					ValueInt:         sql.NullString{String: tx.ValueInt.String(), Valid: tx.ValueInt != nil},
-					Nonce:            sql.NullString{String: tx.Nonce, Valid: tx.Nonce != ""},
-					Input:            sql.NullString{String: tx.Input, Valid: tx.Input != ""},
-					GasUsed:          sql.NullString{String: tx.GasUsed, Valid: tx.GasUsed != ""},
-					GasUsedInt:       sql.NullString{String: tx.GasUsedInt.String(), Valid: tx.GasUsedInt != nil},
-					Status:           sql.NullString{String: tx.Status, Valid: tx.Status != ""},
-					Type:             sql.NullString{String: tx.Type, Valid: tx.Type != ""},
-					Timestamp:        tx.Timestamp,
-					TimestampInt:     tx.TimestampInt.String(),
-					CreatedAt:        tx.CreatedAt,
-					CoinCount:        tx.CoinCount.String(),
-					NftCount:         tx.NftCount.String(),
-					Erc20Count:       tx.Erc20Count.String(),
-					Erc721Count:      tx.Erc721Count.String(),
-					Erc1155Count:     tx.Erc1155Count.String(),
-				}
-				if tx.ContractAddress != nil {
-					txInput.ContractAddress = sql.NullString{String: *tx.ContractAddress, Valid: *tx.ContractAddress != ""}
-				} else {
-					txInput.ContractAddress = sql.NullString{Valid: false}
-				}
-				err = s.db.Queries.InsertTransaction(ctx, txInput)
+				txInput := transactionParams(&tx)
+				err = q.InsertTransaction(ctx, txInput)
 				if err != nil {
 					s.l.Error("create transaction", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "transaction", Value: tx.Hash})
 					return err
 				}
 
+				if input, ok := transactionInputParams(&tx); ok {
+					err = q.InsertTransactionInput(ctx, input)
+					if err != nil {
+						s.l.Error("create transaction input", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "transaction", Value: tx.Hash})
+						return err
+					}
+				}
+
 				if tx.Contract != nil {
-					err = s.db.Queries.InsertContract(ctx, gen.InsertContractParams{
-						ChainID:     tx.Contract.ChainID.String(),
-						Hash:        tx.Contract.Hash,
-						Name:        sql.NullString{String: tx.Contract.Name, Valid: tx.Contract.Name != ""},
-						Symbol:      sql.NullString{String: tx.Contract.Symbol, Valid: tx.Contract.Symbol != ""},
-						Decimals:    sql.NullInt32{Int32: int32(tx.Contract.Decimals), Valid: true},
-						TotalSupply: sql.NullString{String: tx.Contract.TotalSupply.String(), Valid: tx.Contract.TotalSupply.String() != ""},
-						Type:        sql.NullString{String: tx.Contract.Type, Valid: tx.Contract.Type != ""},
-						Creator:     sql.NullString{String: tx.Contract.Creator, Valid: tx.Contract.Creator != ""},
-					})
+					err = q.InsertContract(ctx, contractParams(tx.Contract))
 					if err != nil {
 						s.l.Error("create contract", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "contract", Value: tx.Contract.Hash})
 						return err
 					}
 				}
 				if tx.CoinLogs != nil {
-					err = s.db.Queries.InsertCoinLog(ctx, gen.InsertCoinLogParams{
-						ChainID:         tx.CoinLogs.ChainID.String(),
-						Timestamp:       tx.CoinLogs.Timestamp,
-						TimestampInt:    tx.CoinLogs.TimestampInt.String(),
-						CreatedAt:       tx.CoinLogs.CreatedAt,
-						TransactionHash: sql.NullString{String: tx.CoinLogs.TransactionHash, Valid: tx.CoinLogs.TransactionHash != ""},
-						From:            sql.NullString{String: tx.CoinLogs.From, Valid: tx.CoinLogs.From != ""},
-						To:              sql.NullString{String: tx.CoinLogs.To, Valid: tx.CoinLogs.To != ""},
-						Amount:          sql.NullString{String: tx.CoinLogs.Amount.String(), Valid: tx.CoinLogs.Amount.String() != ""},
-						Gas:             sql.NullString{String: tx.CoinLogs.Gas, Valid: tx.CoinLogs.Gas != ""},
-						GasInt:          sql.NullString{String: tx.CoinLogs.GasInt.String(), Valid: tx.CoinLogs.GasInt.String() != ""},
-						GasPrice:        sql.NullString{String: tx.CoinLogs.GasPrice, Valid: tx.CoinLogs.GasPrice != ""},
-						GasPriceInt:     sql.NullString{String: tx.CoinLogs.GasPriceInt.String(), Valid: tx.CoinLogs.GasPriceInt.String() != ""},
-						GasUsed:         sql.NullString{String: tx.CoinLogs.GasUsed, Valid: tx.CoinLogs.GasUsed != ""},
-						GasUsedInt:      sql.NullString{String: tx.CoinLogs.GasUsedInt.String(), Valid: tx.CoinLogs.GasUsedInt.String() != ""},
-					})
+					err = q.InsertCoinLog(ctx, coinLogParams(tx.CoinLogs))
 					if err != nil {
 						s.l.Error("create coin logs", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "coinLog", Value: tx.CoinLogs.TransactionHash})
 						return err
 					}
 
-					err = s.db.Queries.UpsertWalletBalance(ctx, gen.UpsertWalletBalanceParams{
-						ChainID: tx.CoinLogs.ChainID.String(),
-						Address: tx.CoinLogs.From,
+					err = q.UpsertWalletBalance(ctx, gen.UpsertWalletBalanceParams{
+						ChainID: tx.CoinLogs.ChainID.Int64(),
+						Address: postgresql.HexToBytes(tx.CoinLogs.From),
 						Balance: "-" + tx.CoinLogs.Amount.String(),
 					})
 					if err != nil {
 						s.l.Error("create wallet", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "wallet", Value: tx.CoinLogs.From})
 						return err
 					}
-					err = s.db.Queries.UpsertWalletBalance(ctx, gen.UpsertWalletBalanceParams{
-						ChainID: tx.CoinLogs.ChainID.String(),
-						Address: tx.CoinLogs.To,
+					err = q.UpsertWalletBalance(ctx, gen.UpsertWalletBalanceParams{
+						ChainID: tx.CoinLogs.ChainID.Int64(),
+						Address: postgresql.HexToBytes(tx.CoinLogs.To),
 						Balance: tx.CoinLogs.Amount.String(),
 					})
 					if err != nil {
 						s.l.Error("create wallet", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "wallet", Value: tx.CoinLogs.To})
 						return err
 					}
+
+					err = q.InsertBalanceChange(ctx, gen.InsertBalanceChangeParams{
+						ChainID:         tx.CoinLogs.ChainID.Int64(),
+						Kind:            "coin",
+						Address:         postgresql.HexToBytes(tx.CoinLogs.From),
+						Delta:           "-" + tx.CoinLogs.Amount.String(),
+						BlockNumber:     int64(block.Number),
+						TransactionHash: postgresql.HexToBytes(tx.CoinLogs.TransactionHash),
+						Reason:          "transfer",
+						CreatedAt:       tx.CoinLogs.Timestamp,
+					})
+					if err != nil {
+						s.l.Error("create balance change", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "wallet", Value: tx.CoinLogs.From})
+						return err
+					}
+					err = q.InsertBalanceChange(ctx, gen.InsertBalanceChangeParams{
+						ChainID:         tx.CoinLogs.ChainID.Int64(),
+						Kind:            "coin",
+						Address:         postgresql.HexToBytes(tx.CoinLogs.To),
+						Delta:           tx.CoinLogs.Amount.String(),
+						BlockNumber:     int64(block.Number),
+						TransactionHash: postgresql.HexToBytes(tx.CoinLogs.TransactionHash),
+						Reason:          "transfer",
+						CreatedAt:       tx.CoinLogs.Timestamp,
+					})
+					if err != nil {
+						s.l.Error("create balance change", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "wallet", Value: tx.CoinLogs.To})
+						return err
+					}
 				}
 				if tx.Erc20Logs != nil && len(tx.Erc20Logs) > 0 {
 					for _, erc20Data := range tx.Erc20Logs {
-						err = s.db.Queries.InsertERC20Log(ctx, gen.InsertERC20LogParams{
-							ChainID:         erc20Data.ChainID.String(),
-							Timestamp:       erc20Data.Timestamp,
-							TimestampInt:    erc20Data.TimestampInt.String(),
-							CreatedAt:       erc20Data.CreatedAt,
-							TransactionHash: erc20Data.TransactionHash,
-							ContractAddress: erc20Data.ContractAddress,
-							From:            erc20Data.From,
-							To:              erc20Data.To,
-							Amount:          erc20Data.Amount.String(),
-							Function:        erc20Data.Function,
-							Name:            sql.NullString{String: erc20Data.Name, Valid: erc20Data.Name != ""},
-							Symbol:          sql.NullString{String: erc20Data.Symbol, Valid: erc20Data.Symbol != ""},
-						})
+						err = q.InsertERC20Log(ctx, erc20LogParams(erc20Data))
 						if err != nil {
 							s.l.Error("create erc20 log", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc20Log", Value: erc20Data.TransactionHash})
 							return err
 						}
 
-						err = s.db.Queries.InsertWallet(ctx, gen.InsertWalletParams{
-							ChainID: erc20Data.ChainID.String(),
-							Address: erc20Data.From,
+						err = q.InsertWallet(ctx, gen.InsertWalletParams{
+							ChainID: erc20Data.ChainID.Int64(),
+							Address: postgresql.HexToBytes(erc20Data.From),
 						})
 						if err != nil {
 							s.l.Error("create erc20 log wallet", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc20LogWallet", Value: erc20Data.From})
 							return err
 						}
-						err = s.db.Queries.InsertWallet(ctx, gen.InsertWalletParams{
-							ChainID: erc20Data.ChainID.String(),
-							Address: erc20Data.To,
+						err = q.InsertWallet(ctx, gen.InsertWalletParams{
+							ChainID: erc20Data.ChainID.Int64(),
+							Address: postgresql.HexToBytes(erc20Data.To),
 						})
 						if err != nil {
 							s.l.Error("create erc20 log wallet", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc20LogWallet", Value: erc20Data.To})
@@ -202,125 +198,189 @@ func (s *Service) Create(ctx context.Context, block *evmType.Block) error {
 						}
 
 						if erc20Data.Function != "mint" {
-							err = s.db.Queries.UpsertERC20Balance(ctx, gen.UpsertERC20BalanceParams{
-								ChainID: erc20Data.ChainID.String(),
+							balance, err := q.UpsertERC20Balance(ctx, gen.UpsertERC20BalanceParams{
+								ChainID: erc20Data.ChainID.Int64(),
 								Balance: "-" + erc20Data.Amount.String(),
-								Hash:    erc20Data.ContractAddress,
-								Address: erc20Data.From,
+								Hash:    postgresql.HexToBytes(erc20Data.ContractAddress),
+								Address: postgresql.HexToBytes(erc20Data.From),
 							})
 							if err != nil {
 								s.l.Error("create erc20 balance", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc20Balance", Value: erc20Data.From})
 								return err
 							}
+
+							// 음수가 된 잔액은 그대로 두고 이상 징후로 남긴다
+							if remaining, ok := new(big.Int).SetString(balance, 10); ok && remaining.Sign() < 0 {
+								err = s.recordAnomaly(ctx, q, block, &evmType.Anomaly{
+									Kind:            evmType.AnomalyErc20NegativeBalance,
+									TransactionHash: erc20Data.TransactionHash,
+									LogIndex:        &erc20Data.LogIndex,
+									Contract:        erc20Data.ContractAddress,
+									Address:         erc20Data.From,
+									Expected:        erc20Data.Amount,
+									Actual:          new(big.Int).Add(remaining, erc20Data.Amount),
+								})
+								if err != nil {
+									return err
+								}
+							}
+
+							err = q.InsertBalanceChange(ctx, gen.InsertBalanceChangeParams{
+								ChainID:         erc20Data.ChainID.Int64(),
+								Kind:            "erc20",
+								Hash:            postgresql.HexToBytes(erc20Data.ContractAddress),
+								Address:         postgresql.HexToBytes(erc20Data.From),
+								Delta:           "-" + erc20Data.Amount.String(),
+								BlockNumber:     int64(block.Number),
+								TransactionHash: postgresql.HexToBytes(erc20Data.TransactionHash),
+								Reason:          erc20Data.Function,
+								CreatedAt:       erc20Data.Timestamp,
+							})
+							if err != nil {
+								s.l.Error("create balance change", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc20Balance", Value: erc20Data.From})
+								return err
+							}
 						}
-						err = s.db.Queries.UpsertERC20Balance(ctx, gen.UpsertERC20BalanceParams{
-							ChainID: erc20Data.ChainID.String(),
+						_, err = q.UpsertERC20Balance(ctx, gen.UpsertERC20BalanceParams{
+							ChainID: erc20Data.ChainID.Int64(),
 							Balance: erc20Data.Amount.String(),
-							Hash:    erc20Data.ContractAddress,
-							Address: erc20Data.To,
+							Hash:    postgresql.HexToBytes(erc20Data.ContractAddress),
+							Address: postgresql.HexToBytes(erc20Data.To),
 						})
 						if err != nil {
 							s.l.Error("create erc20 balance", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc20Balance", Value: erc20Data.To})
 							return err
 						}
+
+						err = q.InsertBalanceChange(ctx, gen.InsertBalanceChangeParams{
+							ChainID:         erc20Data.ChainID.Int64(),
+							Kind:            "erc20",
+							Hash:            postgresql.HexToBytes(erc20Data.ContractAddress),
+							Address:         postgresql.HexToBytes(erc20Data.To),
+							Delta:           erc20Data.Amount.String(),
+							BlockNumber:     int64(block.Number),
+							TransactionHash: postgresql.HexToBytes(erc20Data.TransactionHash),
+							Reason:          erc20Data.Function,
+							CreatedAt:       erc20Data.Timestamp,
+						})
+						if err != nil {
+							s.l.Error("create balance change", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc20Balance", Value: erc20Data.To})
+							return err
+						}
 					}
 				}
 				if tx.Erc721Logs != nil && len(tx.Erc721Logs) > 0 {
 					for _, erc721Data := range tx.Erc721Logs {
-						err = s.db.Queries.InsertERC721Log(ctx, gen.InsertERC721LogParams{
-							ChainID:         erc721Data.ChainID.String(),
-							Timestamp:       erc721Data.Timestamp,
-							TimestampInt:    erc721Data.TimestampInt.String(),
-							CreatedAt:       erc721Data.CreatedAt,
-							TransactionHash: erc721Data.TransactionHash,
-							ContractAddress: erc721Data.ContractAddress,
-							From:            erc721Data.From,
-							To:              erc721Data.To,
-							TokenID:         erc721Data.TokenId.String(),
-							Function:        sql.NullString{String: erc721Data.Function, Valid: erc721Data.Function != ""},
-							Name:            sql.NullString{String: erc721Data.Name, Valid: erc721Data.Name != ""},
-							Symbol:          sql.NullString{String: erc721Data.Symbol, Valid: erc721Data.Symbol != ""},
-						})
+						err = q.InsertERC721Log(ctx, erc721LogParams(erc721Data))
 						if err != nil {
 							s.l.Error("create erc721 log", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc721Log", Value: erc721Data.TransactionHash})
 							return err
 						}
 
-						err = s.db.Queries.UpsertERC721Balance(ctx, gen.UpsertERC721BalanceParams{
-							ChainID: erc721Data.ChainID.String(),
-							Hash:    erc721Data.ContractAddress,
+						err = q.UpsertERC721Balance(ctx, gen.UpsertERC721BalanceParams{
+							ChainID: erc721Data.ChainID.Int64(),
+							Hash:    postgresql.HexToBytes(erc721Data.ContractAddress),
 							TokenID: erc721Data.TokenId.String(),
-							Address: erc721Data.To,
+							Address: postgresql.HexToBytes(erc721Data.To),
 						})
 						if err != nil {
 							s.l.Error("create erc721 balance", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc721Balance", Value: erc721Data.To})
 							return err
 						}
 
-						err = s.db.Queries.InsertWallet(ctx, gen.InsertWalletParams{
-							ChainID: erc721Data.ChainID.String(),
-							Address: erc721Data.From,
+						if erc721Data.Function != "mint" {
+							err = q.InsertBalanceChange(ctx, gen.InsertBalanceChangeParams{
+								ChainID:         erc721Data.ChainID.Int64(),
+								Kind:            "erc721",
+								Hash:            postgresql.HexToBytes(erc721Data.ContractAddress),
+								TokenID:         sql.NullString{String: erc721Data.TokenId.String(), Valid: true},
+								Address:         postgresql.HexToBytes(erc721Data.From),
+								Delta:           "-1",
+								BlockNumber:     int64(block.Number),
+								TransactionHash: postgresql.HexToBytes(erc721Data.TransactionHash),
+								Reason:          erc721Data.Function,
+								CreatedAt:       erc721Data.Timestamp,
+							})
+							if err != nil {
+								s.l.Error("create balance change", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc721Balance", Value: erc721Data.From})
+								return err
+							}
+						}
+						err = q.InsertBalanceChange(ctx, gen.InsertBalanceChangeParams{
+							ChainID:         erc721Data.ChainID.Int64(),
+							Kind:            "erc721",
+							Hash:            postgresql.HexToBytes(erc721Data.ContractAddress),
+							TokenID:         sql.NullString{String: erc721Data.TokenId.String(), Valid: true},
+							Address:         postgresql.HexToBytes(erc721Data.To),
+							Delta:           "1",
+							BlockNumber:     int64(block.Number),
+							TransactionHash: postgresql.HexToBytes(erc721Data.TransactionHash),
+							Reason:          erc721Data.Function,
+							CreatedAt:       erc721Data.Timestamp,
+						})
+						if err != nil {
+							s.l.Error("create balance change", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc721Balance", Value: erc721Data.To})
+							return err
+						}
+
+						err = q.InsertWallet(ctx, gen.InsertWalletParams{
+							ChainID: erc721Data.ChainID.Int64(),
+							Address: postgresql.HexToBytes(erc721Data.From),
 						})
 						if err != nil {
 							s.l.Error("create erc721 log wallet", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc721LogWallet", Value: erc721Data.From})
 							return err
 						}
 
-						err = s.db.Queries.InsertWallet(ctx, gen.InsertWalletParams{
-							ChainID: erc721Data.ChainID.String(),
-							Address: erc721Data.To,
+						err = q.InsertWallet(ctx, gen.InsertWalletParams{
+							ChainID: erc721Data.ChainID.Int64(),
+							Address: postgresql.HexToBytes(erc721Data.To),
 						})
 						if err != nil {
 							s.l.Error("create erc721 log wallet", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc721LogWallet", Value: erc721Data.To})
 							return err
 						}
 
-						err = s.db.Queries.CreateErc721(ctx, gen.CreateErc721Params{
-							ChainID: erc721Data.ChainID.String(),
-							Hash:    erc721Data.ContractAddress,
+						err = q.CreateErc721(ctx, gen.CreateErc721Params{
+							ChainID: erc721Data.ChainID.Int64(),
+							Hash:    postgresql.HexToBytes(erc721Data.ContractAddress),
 							TokenID: erc721Data.TokenId.String(),
 						})
+						if err != nil {
+							s.l.Error("create erc721", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc721", Value: erc721Data.ContractAddress})
+							return err
+						}
 
-						err = s.db.Queries.UpdateContractType(ctx, gen.UpdateContractTypeParams{
+						err = q.UpdateContractType(ctx, gen.UpdateContractTypeParams{
 							Type:    sql.NullString{String: "721", Valid: true},
-							ChainID: erc721Data.ChainID.String(),
-							Hash:    erc721Data.ContractAddress,
+							ChainID: erc721Data.ChainID.Int64(),
+							Hash:    postgresql.HexToBytes(erc721Data.ContractAddress),
 						})
+						if err != nil {
+							s.l.Error("update contract type", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "contract", Value: erc721Data.ContractAddress})
+							return err
+						}
 					}
 				}
 				if tx.Erc1155Logs != nil && len(tx.Erc1155Logs) > 0 {
 					for _, erc1155Data := range tx.Erc1155Logs {
-						err = s.db.Queries.InsertERC1155Log(ctx, gen.InsertERC1155LogParams{
-							ChainID:         erc1155Data.ChainID.String(),
-							Timestamp:       erc1155Data.Timestamp,
-							TimestampInt:    erc1155Data.TimestampInt.String(),
-							CreatedAt:       erc1155Data.CreatedAt,
-							TransactionHash: erc1155Data.TransactionHash,
-							ContractAddress: sql.NullString{String: erc1155Data.ContractAddress, Valid: erc1155Data.ContractAddress != ""},
-							From:            sql.NullString{String: erc1155Data.From, Valid: erc1155Data.From != ""},
-							To:              sql.NullString{String: erc1155Data.To, Valid: erc1155Data.To != ""},
-							TokenID:         sql.NullString{String: erc1155Data.TokenId.String(), Valid: erc1155Data.TokenId.String() != ""},
-							Amount:          sql.NullString{String: erc1155Data.Amount.String(), Valid: erc1155Data.Amount.String() != ""},
-							Function:        sql.NullString{String: erc1155Data.Function, Valid: erc1155Data.Function != ""},
-							Name:            sql.NullString{String: erc1155Data.Name, Valid: erc1155Data.Name != ""},
-							Symbol:          sql.NullString{String: erc1155Data.Symbol, Valid: erc1155Data.Symbol != ""},
-						})
+						err = q.InsertERC1155Log(ctx, erc1155LogParams(erc1155Data))
 						if err != nil {
 							s.l.Error("create erc1155 log", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc1155Log", Value: erc1155Data.TransactionHash})
 							return err
 						}
 
-						err = s.db.Queries.InsertWallet(ctx, gen.InsertWalletParams{
-							ChainID: erc1155Data.ChainID.String(),
-							Address: erc1155Data.From,
+						err = q.InsertWallet(ctx, gen.InsertWalletParams{
+							ChainID: erc1155Data.ChainID.Int64(),
+							Address: postgresql.HexToBytes(erc1155Data.From),
 						})
 						if err != nil {
 							s.l.Error("create erc1155 log wallet", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc1155LogWallet", Value: erc1155Data.From})
 							return err
 						}
-						err = s.db.Queries.InsertWallet(ctx, gen.InsertWalletParams{
-							ChainID: erc1155Data.ChainID.String(),
-							Address: erc1155Data.To,
+						err = q.InsertWallet(ctx, gen.InsertWalletParams{
+							ChainID: erc1155Data.ChainID.Int64(),
+							Address: postgresql.HexToBytes(erc1155Data.To),
 						})
 						if err != nil {
 							s.l.Error("create erc1155 log wallet", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc1155LogWallet", Value: erc1155Data.To})
@@ -328,24 +388,49 @@ func (s *Service) Create(ctx context.Context, block *evmType.Block) error {
 						}
 
 						if erc1155Data.Function != "mint" {
-							err = s.db.Queries.SubtractERC1155Balance(ctx, gen.SubtractERC1155BalanceParams{
-								ChainID: erc1155Data.ChainID.String(),
-								Hash:    erc1155Data.ContractAddress,
+							subtracted, err := q.SubtractERC1155Balance(ctx, gen.SubtractERC1155BalanceParams{
+								ChainID: erc1155Data.ChainID.Int64(),
+								Hash:    postgresql.HexToBytes(erc1155Data.ContractAddress),
 								TokenID: erc1155Data.TokenId.String(),
-								Address: erc1155Data.From,
+								Address: postgresql.HexToBytes(erc1155Data.From),
 								Amount:  erc1155Data.Amount.String(),
 							})
 							if err != nil {
 								s.l.Error("create erc1155 balance", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc1155Balance", Value: erc1155Data.From})
 								return err
 							}
+
+							// 잔액 부족으로 차감되지 않은 경우 원장에는 남기지 않고 이상 징후로 기록한다
+							if subtracted == 0 {
+								err = s.recordInsufficientErc1155(ctx, q, block, erc1155Data)
+								if err != nil {
+									return err
+								}
+							} else {
+								err = q.InsertBalanceChange(ctx, gen.InsertBalanceChangeParams{
+									ChainID:         erc1155Data.ChainID.Int64(),
+									Kind:            "erc1155",
+									Hash:            postgresql.HexToBytes(erc1155Data.ContractAddress),
+									TokenID:         sql.NullString{String: erc1155Data.TokenId.String(), Valid: true},
+									Address:         postgresql.HexToBytes(erc1155Data.From),
+									Delta:           "-" + erc1155Data.Amount.String(),
+									BlockNumber:     int64(block.Number),
+									TransactionHash: postgresql.HexToBytes(erc1155Data.TransactionHash),
+									Reason:          erc1155Data.Function,
+									CreatedAt:       erc1155Data.Timestamp,
+								})
+								if err != nil {
+									s.l.Error("create balance change", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc1155Balance", Value: erc1155Data.From})
+									return err
+								}
+							}
 						}
 
-						err = s.db.Queries.UpsertERC1155Balance_Add(ctx, gen.UpsertERC1155Balance_AddParams{
-							ChainID: erc1155Data.ChainID.String(),
-							Hash:    erc1155Data.ContractAddress,
+						err = q.UpsertERC1155Balance_Add(ctx, gen.UpsertERC1155Balance_AddParams{
+							ChainID: erc1155Data.ChainID.Int64(),
+							Hash:    postgresql.HexToBytes(erc1155Data.ContractAddress),
 							TokenID: erc1155Data.TokenId.String(),
-							Address: erc1155Data.To,
+							Address: postgresql.HexToBytes(erc1155Data.To),
 							Amount:  erc1155Data.Amount.String(),
 						})
 						if err != nil {
@@ -353,44 +438,53 @@ func (s *Service) Create(ctx context.Context, block *evmType.Block) error {
 							return err
 						}
 
-						err = s.db.Queries.CreateErc1155(ctx, gen.CreateErc1155Params{
-							ChainID: erc1155Data.ChainID.String(),
-							Hash:    erc1155Data.ContractAddress,
+						err = q.InsertBalanceChange(ctx, gen.InsertBalanceChangeParams{
+							ChainID:         erc1155Data.ChainID.Int64(),
+							Kind:            "erc1155",
+							Hash:            postgresql.HexToBytes(erc1155Data.ContractAddress),
+							TokenID:         sql.NullString{String: erc1155Data.TokenId.String(), Valid: true},
+							Address:         postgresql.HexToBytes(erc1155Data.To),
+							Delta:           erc1155Data.Amount.String(),
+							BlockNumber:     int64(block.Number),
+							TransactionHash: postgresql.HexToBytes(erc1155Data.TransactionHash),
+							Reason:          erc1155Data.Function,
+							CreatedAt:       erc1155Data.Timestamp,
+						})
+						if err != nil {
+							s.l.Error("create balance change", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc1155Balance", Value: erc1155Data.To})
+							return err
+						}
+
+						err = q.CreateErc1155(ctx, gen.CreateErc1155Params{
+							ChainID: erc1155Data.ChainID.Int64(),
+							Hash:    postgresql.HexToBytes(erc1155Data.ContractAddress),
 							TokenID: erc1155Data.TokenId.String(),
 						})
+						if err != nil {
+							s.l.Error("create erc1155", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "erc1155", Value: erc1155Data.ContractAddress})
+							return err
+						}
 
-						err = s.db.Queries.UpdateContractType(ctx, gen.UpdateContractTypeParams{
+						err = q.UpdateContractType(ctx, gen.UpdateContractTypeParams{
 							Type:    sql.NullString{String: "1155", Valid: true},
-							ChainID: erc1155Data.ChainID.String(),
-							Hash:    erc1155Data.ContractAddress,
+							ChainID: erc1155Data.ChainID.Int64(),
+							Hash:    postgresql.HexToBytes(erc1155Data.ContractAddress),
 						})
+						if err != nil {
+							s.l.Error("update contract type", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "contract", Value: erc1155Data.ContractAddress})
+							return err
+						}
+					}
+				}
+				for _, anomaly := range tx.Anomalies {
+					err = s.recordAnomaly(ctx, q, block, anomaly)
+					if err != nil {
+						return err
 					}
 				}
 				if tx.Logs != nil && len(tx.Logs) > 0 {
 					for _, txLogData := range tx.Logs {
-						topics := make([]string, len(txLogData.Topics))
-						for i, topic := range txLogData.Topics {
-							topics[i] = topic.Hex()
-						}
-
-						err = s.db.Queries.InsertLog(ctx, gen.InsertLogParams{
-							ChainID:          txLogData.ChainID.String(),
-							Address:          sql.NullString{String: txLogData.Address, Valid: txLogData.Address != ""},
-							BlockHash:        txLogData.BlockHash,
-							BlockNumber:      txLogData.BlockNumber,
-							BlockNumberInt:   sql.NullString{String: txLogData.BlockNumberInt.String(), Valid: txLogData.BlockNumberInt.String() != ""},
-							Data:             sql.NullString{String: txLogData.Data, Valid: txLogData.Data != ""},
-							LogIndex:         sql.NullString{String: txLogData.LogIndex, Valid: txLogData.LogIndex != ""},
-							Removed:          txLogData.Removed,
-							Topics:           topics,
-							TransactionHash:  txLogData.TransactionHash,
-							TransactionIndex: txLogData.TransactionIndex,
-							From:             sql.NullString{String: txLogData.From.String(), Valid: txLogData.From.String() != ""},
-							To:               sql.NullString{String: txLogData.To.String(), Valid: txLogData.To.String() != ""},
-							Timestamp:        txLogData.Timestamp,
-							TimestampInt:     txLogData.TimestampInt.String(),
-							CreatedAt:        txLogData.CreatedAt,
-						})
+						err = q.InsertLog(ctx, logParams(txLogData))
 						if err != nil {
 							s.l.Error("create log", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "log", Value: txLogData.TransactionHash})
 							return err
@@ -401,8 +495,39 @@ func (s *Service) Create(ctx context.Context, block *evmType.Block) error {
 			}
 		}
 
-		return nil
+		// 감시 주소 알림과 웹훅 outbox 도 블록과 같이 커밋한다
+		if err = s.watchlists.Match(ctx, q, block); err != nil {
+			return err
+		}
+		return s.webhooks.Enqueue(ctx, q, block)
 	})
+	metrics.DBTransaction("create", time.Since(started), err)
+	tracing.End(span, err)
 
 	return err
 }
+
+// checkStoredHashes 같은 높이에 다른 해시로 저장된 블록이 있으면 건너뛰지 않고 에러를 돌려준다
+func checkStoredHashes(ctx context.Context, q *gen.Queries, chainID int64, blocks ...*evmType.Block) error {
+	numbers := make([]int64, len(blocks))
+	for i, block := range blocks {
+		numbers[i] = int64(block.Number)
+	}
+
+	stored, err := q.ListBlocksByNumber(ctx, gen.ListBlocksByNumberParams{ChainID: chainID, Numbers: numbers})
+	if err != nil {
+		return err
+	}
+
+	hashes := make(map[int64]string, len(stored))
+	for _, block := range stored {
+		hashes[block.Number] = postgresql.BytesToHex(block.Hash)
+	}
+	for _, block := range blocks {
+		if hash, ok := hashes[int64(block.Number)]; ok && hash != strings.ToLower(block.Hash) {
+			return fmt.Errorf("%w: block %d is stored as %s, got %s", ErrBlockHashMismatch, block.Number, hash, block.Hash)
+		}
+	}
+
+	return nil
+}
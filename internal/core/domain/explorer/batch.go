@@ -0,0 +1,259 @@
+package explorer
+
+import (
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/database/postgresql"
+	"blockchain-tracking/internal/logger"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// 한 체인의 여러 키를 쿼리 한 번으로 읽는다 (GraphQL dataloader 용). 결과에 없는 키는 map 에 없다
+
+// TxRef 트랜잭션의 로그/전송/input 을 찾는 키. 블록 번호와 시각으로 파티션을 좁힌다
+type TxRef struct {
+	BlockNumber int64
+	Timestamp   time.Time
+	Hash        string
+}
+
+// NFTRef NFT 메타데이터를 찾는 키
+type NFTRef struct {
+	Kind    Kind
+	Hash    string
+	TokenID string
+}
+
+// TokensByHash 컨트랙트 정보. key 는 컨트랙트 주소
+func (s *Service) TokensByHash(ctx context.Context, chainID int64, hashes []string) (map[string]*Token, error) {
+	rows, err := s.db.Queries.ListContractsByHash(ctx, gen.ListContractsByHashParams{ChainID: chainID, Hashes: postgresql.HexListToBytes(hashes)})
+	if err != nil {
+		s.l.Error("list contracts", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: chainID})
+		return nil, err
+	}
+
+	tokens := make(map[string]*Token, len(rows))
+	for _, row := range rows {
+		token := toToken(row)
+		tokens[token.Hash] = token
+	}
+	return tokens, nil
+}
+
+// BlocksByNumber 블록 헤더 (Transactions 는 비어 있다)
+func (s *Service) BlocksByNumber(ctx context.Context, chainID int64, numbers []int64) (map[int64]*Block, error) {
+	rows, err := s.db.Queries.ListBlocksByNumber(ctx, gen.ListBlocksByNumberParams{ChainID: chainID, Numbers: numbers})
+	if err != nil {
+		s.l.Error("list blocks", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: chainID})
+		return nil, err
+	}
+
+	blocks := make(map[int64]*Block, len(rows))
+	for _, row := range rows {
+		blocks[row.Number] = toBlock(row)
+	}
+	return blocks, nil
+}
+
+// InputsByTransaction calldata. key 는 트랜잭션 해시
+func (s *Service) InputsByTransaction(ctx context.Context, chainID int64, refs []TxRef) (map[string]string, error) {
+	blockNumbers, hashes, _ := splitRefs(refs)
+	rows, err := s.db.Queries.ListTransactionInputs(ctx, gen.ListTransactionInputsParams{ChainID: chainID, BlockNumbers: blockNumbers, Hashes: hashes})
+	if err != nil {
+		s.l.Error("list transaction inputs", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: chainID})
+		return nil, err
+	}
+
+	inputs := make(map[string]string, len(rows))
+	for _, row := range rows {
+		inputs[postgresql.BytesToHex(row.Hash)] = postgresql.BytesToHex(row.Input)
+	}
+	return inputs, nil
+}
+
+// LogsByTransaction 원본 로그 (log_index 순). key 는 트랜잭션 해시
+func (s *Service) LogsByTransaction(ctx context.Context, chainID int64, refs []TxRef) (map[string][]*Log, error) {
+	blockNumbers, hashes, _ := splitRefs(refs)
+	rows, err := s.db.Queries.ListLogsByTransactions(ctx, gen.ListLogsByTransactionsParams{ChainID: chainID, BlockNumbers: blockNumbers, Hashes: hashes})
+	if err != nil {
+		s.l.Error("list transaction logs", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: chainID})
+		return nil, err
+	}
+
+	logs := make(map[string][]*Log)
+	for _, row := range rows {
+		log := toLog(row)
+		logs[log.TransactionHash] = append(logs[log.TransactionHash], log)
+	}
+	return logs, nil
+}
+
+// TransfersByTransaction 디코딩된 ERC20/721/1155 전송 (log_index 순). key 는 트랜잭션 해시
+func (s *Service) TransfersByTransaction(ctx context.Context, chainID int64, refs []TxRef) (map[string][]*TokenTransfer, error) {
+	_, hashes, timestamps := splitRefs(refs)
+
+	var transfers []*TokenTransfer
+	erc20Logs, err := s.db.Queries.ListERC20LogsByTransactions(ctx, gen.ListERC20LogsByTransactionsParams{ChainID: chainID, Timestamps: timestamps, Hashes: hashes})
+	if err != nil {
+		s.l.Error("list transaction erc20 transfers", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: chainID})
+		return nil, err
+	}
+	for _, row := range erc20Logs {
+		transfers = append(transfers, fromErc20Log(row))
+	}
+
+	erc721Logs, err := s.db.Queries.ListERC721LogsByTransactions(ctx, gen.ListERC721LogsByTransactionsParams{ChainID: chainID, Timestamps: timestamps, Hashes: hashes})
+	if err != nil {
+		s.l.Error("list transaction erc721 transfers", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: chainID})
+		return nil, err
+	}
+	for _, row := range erc721Logs {
+		transfers = append(transfers, fromErc721Log(row))
+	}
+
+	erc1155Logs, err := s.db.Queries.ListERC1155LogsByTransactions(ctx, gen.ListERC1155LogsByTransactionsParams{ChainID: chainID, Timestamps: timestamps, Hashes: hashes})
+	if err != nil {
+		s.l.Error("list transaction erc1155 transfers", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: chainID})
+		return nil, err
+	}
+	for _, row := range erc1155Logs {
+		transfers = append(transfers, fromErc1155Log(row))
+	}
+
+	sort.SliceStable(transfers, func(i, j int) bool {
+		if transfers[i].LogIndex != transfers[j].LogIndex {
+			return transfers[i].LogIndex < transfers[j].LogIndex
+		}
+		return transfers[i].BatchIndex < transfers[j].BatchIndex
+	})
+
+	byTransaction := make(map[string][]*TokenTransfer)
+	for _, transfer := range transfers {
+		byTransaction[transfer.TransactionHash] = append(byTransaction[transfer.TransactionHash], transfer)
+	}
+	return byTransaction, nil
+}
+
+// NFTsByRef NFT 메타데이터. 트래커가 기록하지 않은 토큰은 결과에 없다
+func (s *Service) NFTsByRef(ctx context.Context, chainID int64, refs []NFTRef) (map[NFTRef]*NFT, error) {
+	var erc721Hashes, erc1155Hashes []string
+	var erc721IDs, erc1155IDs []string
+	for _, ref := range refs {
+		switch ref.Kind {
+		case KindErc721:
+			erc721Hashes = append(erc721Hashes, ref.Hash)
+			erc721IDs = append(erc721IDs, ref.TokenID)
+		case KindErc1155:
+			erc1155Hashes = append(erc1155Hashes, ref.Hash)
+			erc1155IDs = append(erc1155IDs, ref.TokenID)
+		default:
+			return nil, fmt.Errorf("unknown nft kind %s", ref.Kind)
+		}
+	}
+
+	nfts := make(map[NFTRef]*NFT, len(refs))
+	if len(erc721Hashes) > 0 {
+		rows, err := s.db.Queries.ListERC721TokensByID(ctx, gen.ListERC721TokensByIDParams{ChainID: chainID, Hashes: postgresql.HexListToBytes(erc721Hashes), TokenIds: erc721IDs})
+		if err != nil {
+			s.l.Error("list erc721 tokens", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: chainID})
+			return nil, err
+		}
+		for _, row := range rows {
+			nft := &NFT{Kind: KindErc721, Hash: postgresql.BytesToHex(row.Hash), TokenID: row.TokenID, URL: row.Url.String, ImageURL: row.ImageUrl.String}
+			nfts[NFTRef{Kind: nft.Kind, Hash: nft.Hash, TokenID: nft.TokenID}] = nft
+		}
+	}
+	if len(erc1155Hashes) > 0 {
+		rows, err := s.db.Queries.ListERC1155TokensByID(ctx, gen.ListERC1155TokensByIDParams{ChainID: chainID, Hashes: postgresql.HexListToBytes(erc1155Hashes), TokenIds: erc1155IDs})
+		if err != nil {
+			s.l.Error("list erc1155 tokens", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: chainID})
+			return nil, err
+		}
+		for _, row := range rows {
+			nft := &NFT{Kind: KindErc1155, Hash: postgresql.BytesToHex(row.Hash), TokenID: row.TokenID, URL: row.Url.String, ImageURL: row.ImageUrl.String}
+			nfts[NFTRef{Kind: nft.Kind, Hash: nft.Hash, TokenID: nft.TokenID}] = nft
+		}
+	}
+	return nfts, nil
+}
+
+// BlocksFrom from 이상의 블록 헤더 (번호 순, Transactions 는 비어 있다)
+func (s *Service) BlocksFrom(ctx context.Context, chainID, from int64, limit int32) ([]*Block, error) {
+	rows, err := s.db.Queries.ListBlocksFrom(ctx, gen.ListBlocksFromParams{ChainID: chainID, Number: from, RowLimit: limit})
+	if err != nil {
+		s.l.Error("list blocks", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: chainID}, logger.Field{Key: "block number", Value: from})
+		return nil, err
+	}
+
+	blocks := make([]*Block, len(rows))
+	for i, row := range rows {
+		blocks[i] = toBlock(row)
+	}
+	return blocks, nil
+}
+
+// BlockTransfers 블록의 코인/토큰 전송. 트랜잭션 순서대로, 한 트랜잭션 안에서는 코인 전송이 먼저다
+func (s *Service) BlockTransfers(ctx context.Context, chainID int64, block *Block) ([]*TokenTransfer, error) {
+	transactions, err := s.db.Queries.ListBlockTransactions(ctx, gen.ListBlockTransactionsParams{ChainID: chainID, BlockNumber: block.Number})
+	if err != nil {
+		s.l.Error("list block transactions", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "block number", Value: block.Number})
+		return nil, err
+	}
+	if len(transactions) == 0 {
+		return nil, nil
+	}
+
+	refs := make([]TxRef, len(transactions))
+	for i, tx := range transactions {
+		refs[i] = TxRef{BlockNumber: block.Number, Timestamp: tx.Timestamp, Hash: postgresql.BytesToHex(tx.Hash)}
+	}
+
+	_, hashes, timestamps := splitRefs(refs)
+	coinLogs, err := s.db.Queries.ListCoinLogsByTransactions(ctx, gen.ListCoinLogsByTransactionsParams{ChainID: chainID, Timestamps: timestamps, Hashes: hashes})
+	if err != nil {
+		s.l.Error("list block coin transfers", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "block number", Value: block.Number})
+		return nil, err
+	}
+	coins := make(map[string]*TokenTransfer, len(coinLogs))
+	for _, row := range coinLogs {
+		transfer := fromCoinLog(row)
+		coins[transfer.TransactionHash] = transfer
+	}
+
+	tokens, err := s.TransfersByTransaction(ctx, chainID, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	var transfers []*TokenTransfer
+	for _, ref := range refs {
+		if coin, ok := coins[ref.Hash]; ok {
+			transfers = append(transfers, coin)
+		}
+		transfers = append(transfers, tokens[ref.Hash]...)
+	}
+	return transfers, nil
+}
+
+// splitRefs 쿼리 배열 파라미터. 블록 번호와 시각은 중복을 뺀다
+func splitRefs(refs []TxRef) ([]int64, [][]byte, []time.Time) {
+	blockNumbers := make([]int64, 0, len(refs))
+	hashes := make([][]byte, 0, len(refs))
+	timestamps := make([]time.Time, 0, len(refs))
+	seenBlocks := make(map[int64]bool)
+	seenTimes := make(map[int64]bool)
+	for _, ref := range refs {
+		hashes = append(hashes, postgresql.HexToBytes(ref.Hash))
+		if !seenBlocks[ref.BlockNumber] {
+			seenBlocks[ref.BlockNumber] = true
+			blockNumbers = append(blockNumbers, ref.BlockNumber)
+		}
+		if !seenTimes[ref.Timestamp.UnixNano()] {
+			seenTimes[ref.Timestamp.UnixNano()] = true
+			timestamps = append(timestamps, ref.Timestamp)
+		}
+	}
+	return blockNumbers, hashes, timestamps
+}
@@ -0,0 +1,160 @@
+package explorer
+
+import (
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/database/postgresql"
+	"blockchain-tracking/internal/logger"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound 블록/트랜잭션/토큰이 인덱싱되어 있지 않다
+var ErrNotFound = errors.New("not found")
+
+// Block 블록과 블록의 트랜잭션. hash 가 빈 값이면 number 로 찾는다
+func (s *Service) Block(ctx context.Context, chainID int64, number int64, hash string) (*Block, error) {
+	var row *gen.Block
+	var err error
+	if hash != "" {
+		row, err = s.db.Queries.GetBlockByHash(ctx, gen.GetBlockByHashParams{ChainID: chainID, Hash: postgresql.HexToBytes(hash)})
+	} else {
+		row, err = s.db.Queries.GetBlockByNumber(ctx, gen.GetBlockByNumberParams{ChainID: chainID, Number: number})
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		s.l.Error("get block", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "block number", Value: number}, logger.Field{Key: "block", Value: hash})
+		return nil, err
+	}
+
+	block := toBlock(row)
+	rows, err := s.db.Queries.ListBlockTransactions(ctx, gen.ListBlockTransactionsParams{ChainID: chainID, BlockNumber: row.Number})
+	if err != nil {
+		s.l.Error("list block transactions", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "block number", Value: row.Number})
+		return nil, err
+	}
+	block.Transactions = make([]*Transaction, len(rows))
+	for i, tx := range rows {
+		block.Transactions[i] = toTransaction(tx)
+	}
+
+	return block, nil
+}
+
+// Transaction 해시로 찾은 트랜잭션
+func (s *Service) Transaction(ctx context.Context, chainID int64, hash string) (*Transaction, error) {
+	row, err := s.db.Queries.GetTransactionByHash(ctx, gen.GetTransactionByHashParams{ChainID: chainID, Hash: postgresql.HexToBytes(hash)})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		s.l.Error("get transaction", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "transaction", Value: hash})
+		return nil, err
+	}
+
+	return toTransaction(row), nil
+}
+
+// TransactionDetail 트랜잭션과 calldata, 디코딩된 토큰 전송, 원본 로그
+func (s *Service) TransactionDetail(ctx context.Context, chainID int64, hash string) (*TransactionDetail, error) {
+	tx, err := s.Transaction(ctx, chainID, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &TransactionDetail{Transaction: tx, Transfers: []*TokenTransfer{}, Logs: []*Log{}}
+	detail.Input, err = s.Input(ctx, chainID, tx.BlockNumber, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	txHash := postgresql.HexToBytes(tx.Hash)
+	logs, err := s.db.Queries.ListTransactionLogs(ctx, gen.ListTransactionLogsParams{ChainID: chainID, BlockNumber: tx.BlockNumber, TransactionHash: txHash})
+	if err != nil {
+		s.l.Error("list transaction logs", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "transaction", Value: hash})
+		return nil, err
+	}
+	for _, log := range logs {
+		detail.Logs = append(detail.Logs, toLog(log))
+	}
+
+	erc20Logs, err := s.db.Queries.ListTransactionERC20Transfers(ctx, gen.ListTransactionERC20TransfersParams{ChainID: chainID, Timestamp: tx.Timestamp, TransactionHash: txHash})
+	if err != nil {
+		s.l.Error("list transaction erc20 transfers", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "transaction", Value: hash})
+		return nil, err
+	}
+	for _, log := range erc20Logs {
+		detail.Transfers = append(detail.Transfers, fromErc20Log(log))
+	}
+
+	erc721Logs, err := s.db.Queries.ListTransactionERC721Transfers(ctx, gen.ListTransactionERC721TransfersParams{ChainID: chainID, Timestamp: tx.Timestamp, TransactionHash: txHash})
+	if err != nil {
+		s.l.Error("list transaction erc721 transfers", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "transaction", Value: hash})
+		return nil, err
+	}
+	for _, log := range erc721Logs {
+		detail.Transfers = append(detail.Transfers, fromErc721Log(log))
+	}
+
+	erc1155Logs, err := s.db.Queries.ListTransactionERC1155Transfers(ctx, gen.ListTransactionERC1155TransfersParams{ChainID: chainID, Timestamp: tx.Timestamp, TransactionHash: txHash})
+	if err != nil {
+		s.l.Error("list transaction erc1155 transfers", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "transaction", Value: hash})
+		return nil, err
+	}
+	for _, log := range erc1155Logs {
+		detail.Transfers = append(detail.Transfers, fromErc1155Log(log))
+	}
+
+	return detail, nil
+}
+
+// Token 컨트랙트 정보. Kind 는 컨트랙트 생성 시 판별한 타입 (모르면 빈 값)
+func (s *Service) Token(ctx context.Context, chainID int64, hash string) (*Token, error) {
+	row, err := s.db.Queries.GetContract(ctx, gen.GetContractParams{ChainID: chainID, Hash: postgresql.HexToBytes(hash)})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		s.l.Error("get contract", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "hash", Value: hash})
+		return nil, err
+	}
+
+	return toToken(row), nil
+}
+
+// Owners NFT 한 개의 소유자. erc721 은 한 명, erc1155 는 수량이 있는 주소 목록
+func (s *Service) Owners(ctx context.Context, chainID int64, kind Kind, hash, tokenID string, cursor Cursor, limit int32) ([]*Holder, *Cursor, error) {
+	limit = pageSize(limit)
+
+	switch kind {
+	case KindErc721:
+		row, err := s.db.Queries.GetERC721TokenOwner(ctx, gen.GetERC721TokenOwnerParams{ChainID: chainID, Hash: postgresql.HexToBytes(hash), TokenID: tokenID})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, nil, ErrNotFound
+			}
+			s.l.Error("get erc721 owner", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "hash", Value: hash}, logger.Field{Key: "token_id", Value: tokenID})
+			return nil, nil, err
+		}
+		return []*Holder{{ID: row.ID, Address: postgresql.BytesToHex(row.Address), TokenID: row.TokenID, Balance: "1"}}, nil, nil
+	case KindErc1155:
+		rows, err := s.db.Queries.ListERC1155TokenHolders(ctx, gen.ListERC1155TokenHoldersParams{ChainID: chainID, Hash: postgresql.HexToBytes(hash), TokenID: tokenID, ID: cursor.before(), Limit: limit})
+		if err != nil {
+			s.l.Error("list erc1155 token holders", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "hash", Value: hash}, logger.Field{Key: "token_id", Value: tokenID})
+			return nil, nil, err
+		}
+		holders := make([]*Holder, len(rows))
+		for i, row := range rows {
+			holders[i] = &Holder{ID: row.ID, Address: postgresql.BytesToHex(row.Address), TokenID: row.TokenID, Balance: row.Amount}
+		}
+		if len(holders) < int(limit) {
+			return holders, nil, nil
+		}
+		return holders, &Cursor{ID: holders[len(holders)-1].ID}, nil
+	}
+
+	return nil, nil, fmt.Errorf("unknown nft kind %s", kind)
+}
@@ -0,0 +1,332 @@
+package explorer
+
+import (
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/database/postgresql"
+	"blockchain-tracking/internal/logger"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+type Kind string
+
+const (
+	KindErc20   Kind = "erc20"
+	KindErc721  Kind = "erc721"
+	KindErc1155 Kind = "erc1155"
+	// KindCoin 코인 전송 (스트림/웹훅에서만 쓴다)
+	KindCoin Kind = "coin"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// uint256 최댓값보다 큰 수. 첫 페이지의 잔액 cursor 로 쓴다
+var maxBalance = "1" + strings.Repeat("0", 78)
+
+// Cursor 목록의 다음 페이지 위치. 빈 값이면 첫 페이지.
+// 목록은 최신 행(id 내림차순)부터, ERC20 보유자는 잔액 내림차순으로 Balance 도 같이 쓴다.
+type Cursor struct {
+	ID      int64  `json:"id"`
+	Balance string `json:"balance,omitempty"`
+}
+
+// Encode API 응답에 내려줄 cursor 문자열 (클라이언트는 내용을 읽거나 바꿀 수 없다)
+func (c Cursor) Encode(codec *postgresql.Cursor) (string, error) {
+	plaintext, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return codec.Seal(plaintext)
+}
+
+// DecodeCursor 빈 문자열이면 첫 페이지
+func DecodeCursor(codec *postgresql.Cursor, encoded string) (Cursor, error) {
+	var c Cursor
+	if encoded == "" {
+		return c, nil
+	}
+
+	plaintext, err := codec.Open(encoded)
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(plaintext, &c)
+	return c, err
+}
+
+// Service 주소/토큰 기준 조회. 트래커가 쓰는 테이블을 읽기만 한다
+type Service struct {
+	db *postgresql.Database
+	l  logger.Logger
+}
+
+func NewService(d *postgresql.Database, l logger.Logger) *Service {
+	return &Service{
+		db: d,
+		l:  l,
+	}
+}
+
+// Transactions address 가 보낸 사람이거나 받는 사람인 트랜잭션
+func (s *Service) Transactions(ctx context.Context, chainID int64, address string, cursor Cursor, limit int32) ([]*Transaction, *Cursor, error) {
+	limit = pageSize(limit)
+	rows, err := s.db.Queries.ListAddressTransactions(ctx, gen.ListAddressTransactionsParams{
+		ChainID: chainID,
+		Address: postgresql.HexToBytes(address),
+		ID:      cursor.before(),
+		Limit:   limit,
+	})
+	if err != nil {
+		s.l.Error("list address transactions", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "address", Value: address})
+		return nil, nil, err
+	}
+
+	transactions := make([]*Transaction, len(rows))
+	for i, row := range rows {
+		transactions[i] = toTransaction(row)
+	}
+	if len(transactions) < int(limit) {
+		return transactions, nil, nil
+	}
+
+	return transactions, &Cursor{ID: transactions[len(transactions)-1].ID}, nil
+}
+
+// Input 트랜잭션 calldata. 단순 전송이거나 보관 정책으로 지워졌으면 ""
+func (s *Service) Input(ctx context.Context, chainID, blockNumber int64, hash string) (string, error) {
+	input, err := s.db.Queries.GetTransactionInput(ctx, gen.GetTransactionInputParams{
+		ChainID:     chainID,
+		BlockNumber: blockNumber,
+		Hash:        postgresql.HexToBytes(hash),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		s.l.Error("get transaction input", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "transaction", Value: hash})
+		return "", err
+	}
+
+	return postgresql.BytesToHex(input), nil
+}
+
+// TokenTransfers contract 가 있으면 해당 컨트랙트의 전송, 없으면 address 가 보내거나 받은 전송
+func (s *Service) TokenTransfers(ctx context.Context, chainID int64, kind Kind, address, contract string, cursor Cursor, limit int32) ([]*TokenTransfer, *Cursor, error) {
+	limit = pageSize(limit)
+	before := cursor.before()
+
+	var transfers []*TokenTransfer
+	var err error
+	switch kind {
+	case KindErc20:
+		var rows []*gen.Erc20Log
+		if contract != "" {
+			rows, err = s.db.Queries.ListContractERC20Transfers(ctx, gen.ListContractERC20TransfersParams{ChainID: chainID, ContractAddress: postgresql.HexToBytes(contract), ID: before, Limit: limit})
+		} else {
+			rows, err = s.db.Queries.ListAddressERC20Transfers(ctx, gen.ListAddressERC20TransfersParams{ChainID: chainID, Address: postgresql.HexToBytes(address), ID: before, Limit: limit})
+		}
+		for _, row := range rows {
+			transfers = append(transfers, fromErc20Log(row))
+		}
+	case KindErc721:
+		var rows []*gen.Erc721Log
+		if contract != "" {
+			rows, err = s.db.Queries.ListContractERC721Transfers(ctx, gen.ListContractERC721TransfersParams{ChainID: chainID, ContractAddress: postgresql.HexToBytes(contract), ID: before, Limit: limit})
+		} else {
+			rows, err = s.db.Queries.ListAddressERC721Transfers(ctx, gen.ListAddressERC721TransfersParams{ChainID: chainID, Address: postgresql.HexToBytes(address), ID: before, Limit: limit})
+		}
+		for _, row := range rows {
+			transfers = append(transfers, fromErc721Log(row))
+		}
+	case KindErc1155:
+		var rows []*gen.Erc1155Log
+		if contract != "" {
+			rows, err = s.db.Queries.ListContractERC1155Transfers(ctx, gen.ListContractERC1155TransfersParams{ChainID: chainID, ContractAddress: postgresql.HexToBytes(contract), ID: before, Limit: limit})
+		} else {
+			rows, err = s.db.Queries.ListAddressERC1155Transfers(ctx, gen.ListAddressERC1155TransfersParams{ChainID: chainID, Address: postgresql.HexToBytes(address), ID: before, Limit: limit})
+		}
+		for _, row := range rows {
+			transfers = append(transfers, fromErc1155Log(row))
+		}
+	default:
+		return nil, nil, fmt.Errorf("unknown token kind %s", kind)
+	}
+	if err != nil {
+		s.l.Error("list token transfers", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "kind", Value: kind})
+		return nil, nil, err
+	}
+	if len(transfers) < int(limit) {
+		return transfers, nil, nil
+	}
+
+	return transfers, &Cursor{ID: transfers[len(transfers)-1].ID}, nil
+}
+
+// Logs address 가 남긴 로그, topic0 이 있으면 이벤트 시그니처로 거른다
+func (s *Service) Logs(ctx context.Context, chainID int64, address, topic0 string, cursor Cursor, limit int32) ([]*Log, *Cursor, error) {
+	limit = pageSize(limit)
+
+	var rows []*gen.Log
+	var err error
+	if topic0 != "" {
+		rows, err = s.db.Queries.ListAddressTopicLogs(ctx, gen.ListAddressTopicLogsParams{
+			ChainID: chainID,
+			Address: postgresql.HexToBytes(address),
+			Topic0:  postgresql.HexToBytes(topic0),
+			ID:      cursor.before(),
+			Limit:   limit,
+		})
+	} else {
+		rows, err = s.db.Queries.ListAddressLogs(ctx, gen.ListAddressLogsParams{
+			ChainID: chainID,
+			Address: postgresql.HexToBytes(address),
+			ID:      cursor.before(),
+			Limit:   limit,
+		})
+	}
+	if err != nil {
+		s.l.Error("list address logs", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "address", Value: address})
+		return nil, nil, err
+	}
+
+	logs := make([]*Log, len(rows))
+	for i, row := range rows {
+		logs[i] = toLog(row)
+	}
+	if len(logs) < int(limit) {
+		return logs, nil, nil
+	}
+
+	return logs, &Cursor{ID: logs[len(logs)-1].ID}, nil
+}
+
+// NativeBalance 코인 잔액, 한 번도 등장하지 않은 주소는 "0"
+func (s *Service) NativeBalance(ctx context.Context, chainID int64, address string) (string, error) {
+	wallet, err := s.db.Queries.GetWallet(ctx, gen.GetWalletParams{
+		ChainID: chainID,
+		Address: postgresql.HexToBytes(address),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "0", nil
+		}
+		s.l.Error("get wallet", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "address", Value: address})
+		return "", err
+	}
+
+	return wallet.Balance, nil
+}
+
+// Holdings address 가 현재 보유한 토큰
+func (s *Service) Holdings(ctx context.Context, chainID int64, kind Kind, address string, cursor Cursor, limit int32) ([]*Holding, *Cursor, error) {
+	limit = pageSize(limit)
+	before := cursor.before()
+
+	var holdings []*Holding
+	var err error
+	switch kind {
+	case KindErc20:
+		var rows []*gen.ListAddressERC20HoldingsRow
+		rows, err = s.db.Queries.ListAddressERC20Holdings(ctx, gen.ListAddressERC20HoldingsParams{ChainID: chainID, Address: postgresql.HexToBytes(address), ID: before, Limit: limit})
+		for _, row := range rows {
+			holdings = append(holdings, &Holding{ID: row.ID, Kind: kind, Hash: postgresql.BytesToHex(row.Hash), Balance: row.Balance, Name: row.Name.String, Symbol: row.Symbol.String, Decimals: nullInt32(row.Decimals)})
+		}
+	case KindErc721:
+		var rows []*gen.ListAddressERC721HoldingsRow
+		rows, err = s.db.Queries.ListAddressERC721Holdings(ctx, gen.ListAddressERC721HoldingsParams{ChainID: chainID, Address: postgresql.HexToBytes(address), ID: before, Limit: limit})
+		for _, row := range rows {
+			holdings = append(holdings, &Holding{ID: row.ID, Kind: kind, Hash: postgresql.BytesToHex(row.Hash), TokenID: row.TokenID, Balance: "1", Name: row.Name.String, Symbol: row.Symbol.String})
+		}
+	case KindErc1155:
+		var rows []*gen.ListAddressERC1155HoldingsRow
+		rows, err = s.db.Queries.ListAddressERC1155Holdings(ctx, gen.ListAddressERC1155HoldingsParams{ChainID: chainID, Address: postgresql.HexToBytes(address), ID: before, Limit: limit})
+		for _, row := range rows {
+			holdings = append(holdings, &Holding{ID: row.ID, Kind: kind, Hash: postgresql.BytesToHex(row.Hash), TokenID: row.TokenID, Balance: row.Amount, Name: row.Name.String, Symbol: row.Symbol.String})
+		}
+	default:
+		return nil, nil, fmt.Errorf("unknown token kind %s", kind)
+	}
+	if err != nil {
+		s.l.Error("list holdings", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "kind", Value: kind}, logger.Field{Key: "address", Value: address})
+		return nil, nil, err
+	}
+	if len(holdings) < int(limit) {
+		return holdings, nil, nil
+	}
+
+	return holdings, &Cursor{ID: holdings[len(holdings)-1].ID}, nil
+}
+
+// Holders 토큰 보유자. erc20 은 잔액 내림차순, 나머지는 최근 기록 순
+func (s *Service) Holders(ctx context.Context, chainID int64, kind Kind, hash string, cursor Cursor, limit int32) ([]*Holder, *Cursor, error) {
+	limit = pageSize(limit)
+
+	var holders []*Holder
+	var next *Cursor
+	var err error
+	switch kind {
+	case KindErc20:
+		balance := cursor.Balance
+		if balance == "" {
+			balance = maxBalance
+		}
+		var rows []*gen.ListERC20HoldersRow
+		rows, err = s.db.Queries.ListERC20Holders(ctx, gen.ListERC20HoldersParams{ChainID: chainID, Hash: postgresql.HexToBytes(hash), Balance: balance, ID: cursor.before(), Limit: limit})
+		for _, row := range rows {
+			holders = append(holders, &Holder{ID: row.ID, Address: postgresql.BytesToHex(row.Address), Balance: row.Balance})
+		}
+		if len(rows) == int(limit) {
+			last := rows[len(rows)-1]
+			next = &Cursor{ID: last.ID, Balance: last.Balance}
+		}
+	case KindErc721:
+		var rows []*gen.ListERC721HoldersRow
+		rows, err = s.db.Queries.ListERC721Holders(ctx, gen.ListERC721HoldersParams{ChainID: chainID, Hash: postgresql.HexToBytes(hash), ID: cursor.before(), Limit: limit})
+		for _, row := range rows {
+			holders = append(holders, &Holder{ID: row.ID, Address: postgresql.BytesToHex(row.Address), TokenID: row.TokenID, Balance: "1"})
+		}
+	case KindErc1155:
+		var rows []*gen.ListERC1155HoldersRow
+		rows, err = s.db.Queries.ListERC1155Holders(ctx, gen.ListERC1155HoldersParams{ChainID: chainID, Hash: postgresql.HexToBytes(hash), ID: cursor.before(), Limit: limit})
+		for _, row := range rows {
+			holders = append(holders, &Holder{ID: row.ID, Address: postgresql.BytesToHex(row.Address), TokenID: row.TokenID, Balance: row.Amount})
+		}
+	default:
+		return nil, nil, fmt.Errorf("unknown token kind %s", kind)
+	}
+	if err != nil {
+		s.l.Error("list holders", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "kind", Value: kind}, logger.Field{Key: "hash", Value: hash})
+		return nil, nil, err
+	}
+	if kind != KindErc20 && len(holders) == int(limit) {
+		next = &Cursor{ID: holders[len(holders)-1].ID}
+	}
+
+	return holders, next, nil
+}
+
+// before 이 id 보다 작은 행부터 가져온다
+func (c Cursor) before() int64 {
+	if c.ID <= 0 {
+		return math.MaxInt64
+	}
+	return c.ID
+}
+
+func pageSize(limit int32) int32 {
+	if limit <= 0 {
+		return DefaultPageSize
+	}
+	if limit > MaxPageSize {
+		return MaxPageSize
+	}
+	return limit
+}
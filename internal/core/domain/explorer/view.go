@@ -0,0 +1,277 @@
+package explorer
+
+import (
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/database/postgresql"
+	"database/sql"
+	"time"
+)
+
+// 조회 결과. 해시/주소는 소문자 0x hex, 수량은 10진수 문자열
+
+type Transaction struct {
+	ID               int64     `json:"-"`
+	Hash             string    `json:"hash"`
+	BlockHash        string    `json:"blockHash"`
+	BlockNumber      int64     `json:"blockNumber"`
+	TransactionIndex int64     `json:"transactionIndex"`
+	From             string    `json:"from"`
+	To               string    `json:"to,omitempty"`
+	ContractAddress  string    `json:"contractAddress,omitempty"`
+	Value            string    `json:"value"`
+	Gas              int64     `json:"gas"`
+	GasPrice         string    `json:"gasPrice"`
+	GasUsed          int64     `json:"gasUsed"`
+	Nonce            int64     `json:"nonce"`
+	Status           int16     `json:"status"`
+	Type             int16     `json:"type"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+type Block struct {
+	Number           int64          `json:"number"`
+	Hash             string         `json:"hash"`
+	ParentHash       string         `json:"parentHash"`
+	Miner            string         `json:"miner"`
+	GasLimit         int64          `json:"gasLimit"`
+	GasUsed          int64          `json:"gasUsed"`
+	Difficulty       string         `json:"difficulty"`
+	TotalDifficulty  string         `json:"totalDifficulty"`
+	TransactionsRoot string         `json:"transactionsRoot"`
+	Timestamp        time.Time      `json:"timestamp"`
+	Transactions     []*Transaction `json:"transactions"`
+}
+
+// TransactionDetail 트랜잭션과 같은 트랜잭션에서 디코딩된 전송, 원본 로그.
+// Input 과 Logs 는 보관 정책으로 지워졌으면 비어 있다
+type TransactionDetail struct {
+	*Transaction
+	Input     string           `json:"input"`
+	Transfers []*TokenTransfer `json:"transfers"`
+	Logs      []*Log           `json:"logs"`
+}
+
+type TokenTransfer struct {
+	ID              int64     `json:"-"`
+	Kind            Kind      `json:"kind"`
+	ContractAddress string    `json:"contractAddress"`
+	TransactionHash string    `json:"transactionHash"`
+	LogIndex        int64     `json:"logIndex"`
+	BatchIndex      int32     `json:"batchIndex,omitempty"`
+	From            string    `json:"from"`
+	To              string    `json:"to"`
+	TokenID         string    `json:"tokenId,omitempty"`
+	Amount          string    `json:"amount,omitempty"`
+	Function        string    `json:"function,omitempty"`
+	Name            string    `json:"name,omitempty"`
+	Symbol          string    `json:"symbol,omitempty"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+type Log struct {
+	ID               int64     `json:"-"`
+	Address          string    `json:"address"`
+	BlockHash        string    `json:"blockHash"`
+	BlockNumber      int64     `json:"blockNumber"`
+	TransactionHash  string    `json:"transactionHash"`
+	TransactionIndex int64     `json:"transactionIndex"`
+	LogIndex         int64     `json:"logIndex"`
+	Topics           []string  `json:"topics"`
+	Data             string    `json:"data"`
+	Removed          bool      `json:"removed"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+type Token struct {
+	Hash        string `json:"hash"`
+	Kind        Kind   `json:"kind,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Symbol      string `json:"symbol,omitempty"`
+	Decimals    *int32 `json:"decimals,omitempty"`
+	TotalSupply string `json:"totalSupply,omitempty"`
+	Creator     string `json:"creator,omitempty"`
+}
+
+// NFT 토큰 하나의 메타데이터 (트래커가 기록한 tokenURI, 이미지)
+type NFT struct {
+	Kind     Kind   `json:"kind"`
+	Hash     string `json:"hash"`
+	TokenID  string `json:"tokenId"`
+	URL      string `json:"url,omitempty"`
+	ImageURL string `json:"imageUrl,omitempty"`
+}
+
+// Holding 주소가 보유한 토큰 한 건 (erc721 은 Balance 가 항상 1)
+type Holding struct {
+	ID       int64  `json:"-"`
+	Kind     Kind   `json:"kind"`
+	Hash     string `json:"hash"`
+	TokenID  string `json:"tokenId,omitempty"`
+	Balance  string `json:"balance"`
+	Name     string `json:"name,omitempty"`
+	Symbol   string `json:"symbol,omitempty"`
+	Decimals *int32 `json:"decimals,omitempty"`
+}
+
+// Holder 토큰 보유자 한 건
+type Holder struct {
+	ID      int64  `json:"-"`
+	Address string `json:"address"`
+	TokenID string `json:"tokenId,omitempty"`
+	Balance string `json:"balance"`
+}
+
+func toBlock(row *gen.Block) *Block {
+	return &Block{
+		Number:           row.Number,
+		Hash:             postgresql.BytesToHex(row.Hash),
+		ParentHash:       postgresql.BytesToHex(row.ParentHash),
+		Miner:            postgresql.BytesToHex(row.Miner),
+		GasLimit:         row.GasLimit.Int64,
+		GasUsed:          row.GasUsed.Int64,
+		Difficulty:       numeric(row.Difficulty),
+		TotalDifficulty:  numeric(row.TotalDifficulty),
+		TransactionsRoot: postgresql.BytesToHex(row.TransactionsRoot),
+		Timestamp:        row.Timestamp,
+	}
+}
+
+func toToken(row *gen.Contract) *Token {
+	token := &Token{
+		Hash:     postgresql.BytesToHex(row.Hash),
+		Name:     row.Name.String,
+		Symbol:   row.Symbol.String,
+		Decimals: nullInt32(row.Decimals),
+		Creator:  postgresql.BytesToHex(row.Creator),
+	}
+	if row.TotalSupply.Valid {
+		token.TotalSupply = row.TotalSupply.String
+	}
+	// contract.type 은 "20" / "721" / "1155"
+	if row.Type.Valid && row.Type.String != "" {
+		token.Kind = Kind("erc" + row.Type.String)
+	}
+	return token
+}
+
+func toTransaction(row *gen.Transaction) *Transaction {
+	return &Transaction{
+		ID:               row.ID,
+		Hash:             postgresql.BytesToHex(row.Hash),
+		BlockHash:        postgresql.BytesToHex(row.BlockHash),
+		BlockNumber:      row.BlockNumber,
+		TransactionIndex: row.TransactionIndex.Int64,
+		From:             postgresql.BytesToHex(row.From),
+		To:               postgresql.BytesToHex(row.To),
+		ContractAddress:  postgresql.BytesToHex(row.ContractAddress),
+		Value:            numeric(row.Value),
+		Gas:              row.Gas.Int64,
+		GasPrice:         numeric(row.GasPrice),
+		GasUsed:          row.GasUsed.Int64,
+		Nonce:            row.Nonce.Int64,
+		Status:           row.Status.Int16,
+		Type:             row.Type.Int16,
+		Timestamp:        row.Timestamp,
+	}
+}
+
+func toLog(row *gen.Log) *Log {
+	topics := make([]string, len(row.Topics))
+	for i, topic := range row.Topics {
+		topics[i] = postgresql.BytesToHex(topic)
+	}
+
+	return &Log{
+		ID:               row.ID,
+		Address:          postgresql.BytesToHex(row.Address),
+		BlockHash:        postgresql.BytesToHex(row.BlockHash),
+		BlockNumber:      row.BlockNumber,
+		TransactionHash:  postgresql.BytesToHex(row.TransactionHash),
+		TransactionIndex: row.TransactionIndex,
+		LogIndex:         row.LogIndex,
+		Topics:           topics,
+		Data:             postgresql.BytesToHex(row.Data),
+		Removed:          row.Removed,
+		Timestamp:        row.Timestamp,
+	}
+}
+
+func fromCoinLog(row *gen.CoinLog) *TokenTransfer {
+	return &TokenTransfer{
+		ID:              row.ID,
+		Kind:            KindCoin,
+		TransactionHash: postgresql.BytesToHex(row.TransactionHash),
+		From:            postgresql.BytesToHex(row.From),
+		To:              postgresql.BytesToHex(row.To),
+		Amount:          numeric(row.Amount),
+		Timestamp:       row.Timestamp,
+	}
+}
+
+func fromErc20Log(row *gen.Erc20Log) *TokenTransfer {
+	return &TokenTransfer{
+		ID:              row.ID,
+		Kind:            KindErc20,
+		ContractAddress: postgresql.BytesToHex(row.ContractAddress),
+		TransactionHash: postgresql.BytesToHex(row.TransactionHash),
+		LogIndex:        row.LogIndex,
+		From:            postgresql.BytesToHex(row.From),
+		To:              postgresql.BytesToHex(row.To),
+		Amount:          row.Amount,
+		Function:        row.Function,
+		Name:            row.Name.String,
+		Symbol:          row.Symbol.String,
+		Timestamp:       row.Timestamp,
+	}
+}
+
+func fromErc721Log(row *gen.Erc721Log) *TokenTransfer {
+	return &TokenTransfer{
+		ID:              row.ID,
+		Kind:            KindErc721,
+		ContractAddress: postgresql.BytesToHex(row.ContractAddress),
+		TransactionHash: postgresql.BytesToHex(row.TransactionHash),
+		LogIndex:        row.LogIndex,
+		From:            postgresql.BytesToHex(row.From),
+		To:              postgresql.BytesToHex(row.To),
+		TokenID:         row.TokenID,
+		Amount:          "1",
+		Function:        row.Function.String,
+		Name:            row.Name.String,
+		Symbol:          row.Symbol.String,
+		Timestamp:       row.Timestamp,
+	}
+}
+
+func fromErc1155Log(row *gen.Erc1155Log) *TokenTransfer {
+	return &TokenTransfer{
+		ID:              row.ID,
+		Kind:            KindErc1155,
+		ContractAddress: postgresql.BytesToHex(row.ContractAddress),
+		TransactionHash: postgresql.BytesToHex(row.TransactionHash),
+		LogIndex:        row.LogIndex,
+		BatchIndex:      row.BatchIndex,
+		From:            postgresql.BytesToHex(row.From),
+		To:              postgresql.BytesToHex(row.To),
+		TokenID:         row.TokenID.String,
+		Amount:          row.Amount.String,
+		Function:        row.Function.String,
+		Name:            row.Name.String,
+		Symbol:          row.Symbol.String,
+		Timestamp:       row.Timestamp,
+	}
+}
+
+func numeric(value sql.NullString) string {
+	if !value.Valid {
+		return "0"
+	}
+	return value.String
+}
+
+func nullInt32(value sql.NullInt32) *int32 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Int32
+}
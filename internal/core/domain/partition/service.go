@@ -0,0 +1,192 @@
+package partition
+
+import (
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/database/postgresql"
+	"blockchain-tracking/internal/logger"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// 블록 범위로 나누는 테이블과 월(UTC)로 나누는 테이블. 보관 정책은 log, transaction_input 에만 적용된다
+var (
+	BlockTables = []string{"transaction", "transaction_input", "log"}
+	MonthTables = []string{"coin_log", "erc20_log", "erc721_log", "erc1155_log"}
+)
+
+type key struct {
+	parent  string
+	chainID int64
+}
+
+// bounds [lower, upper) 블록 번호 또는 unix 초
+type bounds struct {
+	lower int64
+	upper int64
+}
+
+type Service struct {
+	db *postgresql.Database
+	l  logger.Logger
+
+	mu    sync.Mutex
+	known map[key]bounds
+}
+
+func NewService(d *postgresql.Database, l logger.Logger) *Service {
+	return &Service{
+		db:    d,
+		l:     l,
+		known: make(map[key]bounds),
+	}
+}
+
+// Ensure 블록을 저장하기 전에 들어갈 하위 파티션을 만든다.
+// DDL 이라 블록 저장 트랜잭션 밖에서 호출하고, 마지막으로 확인한 범위 안이면 DB 를 다시 보지 않는다.
+func (s *Service) Ensure(ctx context.Context, chainID, blockNumber int64, timestamp time.Time) error {
+	for _, parent := range BlockTables {
+		if s.covered(parent, chainID, blockNumber) {
+			continue
+		}
+
+		err := s.db.Queries.EnsureBlockPartition(ctx, gen.EnsureBlockPartitionParams{
+			Parent:      parent,
+			ChainID:     chainID,
+			BlockNumber: blockNumber,
+		})
+		if err != nil {
+			s.l.Error("ensure block partition", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "table", Value: parent}, logger.Field{Key: "block number", Value: blockNumber})
+			return err
+		}
+
+		r, err := s.db.Queries.GetPartitionRange(ctx, gen.GetPartitionRangeParams{
+			Parent:      parent,
+			ChainID:     chainID,
+			BlockNumber: blockNumber,
+		})
+		if err != nil {
+			s.l.Error("get partition range", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "table", Value: parent}, logger.Field{Key: "block number", Value: blockNumber})
+			return err
+		}
+		s.remember(parent, chainID, bounds{lower: r.LowerBound, upper: r.UpperBound})
+	}
+
+	month := time.Date(timestamp.UTC().Year(), timestamp.UTC().Month(), 1, 0, 0, 0, 0, time.UTC)
+	for _, parent := range MonthTables {
+		if s.covered(parent, chainID, month.Unix()) {
+			continue
+		}
+
+		err := s.db.Queries.EnsureMonthPartition(ctx, gen.EnsureMonthPartitionParams{
+			Parent:    parent,
+			ChainID:   chainID,
+			Timestamp: timestamp,
+		})
+		if err != nil {
+			s.l.Error("ensure month partition", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "table", Value: parent}, logger.Field{Key: "timestamp", Value: timestamp})
+			return err
+		}
+		s.remember(parent, chainID, bounds{lower: month.Unix(), upper: month.AddDate(0, 1, 0).Unix()})
+	}
+
+	return nil
+}
+
+func (s *Service) covered(parent string, chainID, value int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.known[key{parent: parent, chainID: chainID}]
+	return ok && b.lower <= value && value < b.upper
+}
+
+func (s *Service) remember(parent string, chainID int64, b bounds) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.known[key{parent: parent, chainID: chainID}] = b
+}
+
+// SetPolicy retainBlocks 가 0 이면 원본 로그/input 을 지우지 않는다.
+// blockRange 는 이후에 새로 만드는 파티션에만 적용된다
+func (s *Service) SetPolicy(ctx context.Context, chainID, blockRange, retainBlocks int64) error {
+	if blockRange <= 0 {
+		return fmt.Errorf("block range must be positive")
+	}
+	if retainBlocks < 0 {
+		return fmt.Errorf("retain blocks must not be negative")
+	}
+
+	err := s.db.Queries.UpsertPartitionPolicy(ctx, gen.UpsertPartitionPolicyParams{
+		ChainID:      chainID,
+		BlockRange:   blockRange,
+		RetainBlocks: sql.NullInt64{Int64: retainBlocks, Valid: retainBlocks > 0},
+	})
+	if err != nil {
+		s.l.Error("upsert partition policy", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: chainID})
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) Policies(ctx context.Context) ([]*gen.PartitionPolicy, error) {
+	return s.db.Queries.ListPartitionPolicies(ctx)
+}
+
+func (s *Service) Ranges(ctx context.Context, chainID int64) ([]*gen.PartitionRange, error) {
+	return s.db.Queries.ListPartitionRanges(ctx, chainID)
+}
+
+// Prune 인덱싱 높이 기준으로 보관 기간이 지난 log / transaction_input 파티션을 지운다.
+// 디코딩된 전송 로그(coin_log, erc*_log)와 잔액은 그대로 둔다
+func (s *Service) Prune(ctx context.Context, chainID int64) (int32, error) {
+	height, err := s.db.Queries.GetBlockHeight(ctx, chainID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	pruned, err := s.db.Queries.PruneRawData(ctx, gen.PruneRawDataParams{ChainID: chainID, Height: height})
+	if err != nil {
+		s.l.Error("prune raw data", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: chainID})
+		return 0, err
+	}
+
+	return pruned, nil
+}
+
+// RunRetention interval 마다 보관 정책이 있는 체인을 정리한다. ctx 가 끝나면 돌아온다
+func (s *Service) RunRetention(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		policies, err := s.Policies(ctx)
+		if err != nil {
+			s.l.Error("list partition policies", logger.Field{Key: "error", Value: err.Error()})
+		}
+		for _, policy := range policies {
+			if !policy.RetainBlocks.Valid {
+				continue
+			}
+
+			pruned, err := s.Prune(ctx, policy.ChainID)
+			if err == nil && pruned > 0 {
+				s.l.Info("pruned raw data partitions", logger.Field{Key: "chain_id", Value: policy.ChainID}, logger.Field{Key: "partitions", Value: pruned})
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
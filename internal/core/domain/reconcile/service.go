@@ -0,0 +1,387 @@
+package reconcile
+
+import (
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/database/postgresql"
+	"blockchain-tracking/internal/logger"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+type Kind string
+
+const (
+	KindCoin    Kind = "coin"
+	KindErc20   Kind = "erc20"
+	KindErc721  Kind = "erc721"
+	KindErc1155 Kind = "erc1155"
+)
+
+var Kinds = []Kind{KindCoin, KindErc20, KindErc721, KindErc1155}
+
+// Holding DB에 저장된 잔액 한 건 (erc721 은 Balance 가 항상 1)
+type Holding struct {
+	ID      int64
+	Kind    Kind
+	Hash    string
+	TokenID string
+	Address string
+	Balance string
+}
+
+// Page 잔액 한 페이지. Rows, LastID 는 고정한 높이 뒤에 생겨서 뺀 잔액까지 센 값 (다음 페이지 위치)
+type Page struct {
+	Holdings []*Holding
+	Rows     int
+	LastID   int64
+}
+
+type Drift struct {
+	*Holding
+	BlockNumber    *big.Int
+	Stored         *big.Int
+	Onchain        *big.Int
+	OnchainAddress string
+	Difference     *big.Int
+}
+
+type Service struct {
+	db        *postgresql.Database
+	txManager postgresql.DBTransactionManager
+	l         logger.Logger
+}
+
+func NewService(d *postgresql.Database, tx postgresql.DBTransactionManager, l logger.Logger) *Service {
+	return &Service{
+		db:        d,
+		txManager: tx,
+		l:         l,
+	}
+}
+
+// Height 비교할 높이 (저장된 마지막 블록). 저장된 블록이 없으면 -1
+func (s *Service) Height(ctx context.Context, chainID int64) (int64, error) {
+	height, err := s.db.Queries.GetBlockHeight(ctx, chainID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return -1, nil
+	}
+	return height, err
+}
+
+// Snapshot 잔액 한 페이지를 읽어서 height 의 값으로 되돌린다.
+// 트래커가 동시에 돌고 있어도 height 이후에 반영된 델타는 원장(balance_change)으로 빼므로 한 실행의 모든 페이지를 같은 높이에서 비교한다.
+func (s *Service) Snapshot(ctx context.Context, chainID int64, kind Kind, afterID int64, limit int32, sample bool, height int64) (*Page, error) {
+	page := &Page{}
+	var holdings []*Holding
+
+	err := s.txManager.WithTransaction(ctx, sql.LevelRepeatableRead, true, func(ctx context.Context) error {
+		q := s.db.GetQueryRowerFromContext(ctx).Queries
+
+		var err error
+		switch kind {
+		case KindCoin:
+			var rows []*gen.Wallet
+			if sample {
+				rows, err = q.SampleWallets(ctx, gen.SampleWalletsParams{ChainID: chainID, Limit: limit})
+			} else {
+				rows, err = q.ListWallets(ctx, gen.ListWalletsParams{ChainID: chainID, ID: afterID, Limit: limit})
+			}
+			for _, row := range rows {
+				holdings = append(holdings, &Holding{ID: row.ID, Kind: kind, Address: postgresql.BytesToHex(row.Address), Balance: row.Balance})
+			}
+		case KindErc20:
+			var rows []*gen.Erc20Balance
+			if sample {
+				rows, err = q.SampleERC20Balances(ctx, gen.SampleERC20BalancesParams{ChainID: chainID, Limit: limit})
+			} else {
+				rows, err = q.ListERC20Balances(ctx, gen.ListERC20BalancesParams{ChainID: chainID, ID: afterID, Limit: limit})
+			}
+			for _, row := range rows {
+				holdings = append(holdings, &Holding{ID: row.ID, Kind: kind, Hash: postgresql.BytesToHex(row.Hash), Address: postgresql.BytesToHex(row.Address), Balance: row.Balance})
+			}
+		case KindErc721:
+			var rows []*gen.Erc721Balance
+			if sample {
+				rows, err = q.SampleERC721Balances(ctx, gen.SampleERC721BalancesParams{ChainID: chainID, Limit: limit})
+			} else {
+				rows, err = q.ListERC721Balances(ctx, gen.ListERC721BalancesParams{ChainID: chainID, ID: afterID, Limit: limit})
+			}
+			for _, row := range rows {
+				holdings = append(holdings, &Holding{ID: row.ID, Kind: kind, Hash: postgresql.BytesToHex(row.Hash), TokenID: row.TokenID, Address: postgresql.BytesToHex(row.Address), Balance: "1"})
+			}
+		case KindErc1155:
+			var rows []*gen.Erc1155Balance
+			if sample {
+				rows, err = q.SampleERC1155Balances(ctx, gen.SampleERC1155BalancesParams{ChainID: chainID, Limit: limit})
+			} else {
+				rows, err = q.ListERC1155Balances(ctx, gen.ListERC1155BalancesParams{ChainID: chainID, ID: afterID, Limit: limit})
+			}
+			for _, row := range rows {
+				holdings = append(holdings, &Holding{ID: row.ID, Kind: kind, Hash: postgresql.BytesToHex(row.Hash), TokenID: row.TokenID, Address: postgresql.BytesToHex(row.Address), Balance: row.Amount})
+			}
+		default:
+			return fmt.Errorf("unknown balance kind %s", kind)
+		}
+		if err != nil || len(holdings) == 0 {
+			return err
+		}
+
+		page.Rows = len(holdings)
+		page.LastID = holdings[len(holdings)-1].ID
+		page.Holdings, err = s.atHeight(ctx, q, chainID, kind, height, holdings)
+		return err
+	})
+	if err != nil {
+		s.l.Error("reconcile snapshot", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "kind", Value: kind})
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// atHeight 잔액에서 height 이후의 델타를 뺀다. height 이후에 시딩된 잔액은 그 높이에서 알 수 없으므로 뺀다
+func (s *Service) atHeight(ctx context.Context, q *gen.Queries, chainID int64, kind Kind, height int64, holdings []*Holding) ([]*Holding, error) {
+	addresses := make([][]byte, 0, len(holdings))
+	seen := make(map[string]bool)
+	for _, h := range holdings {
+		if !seen[h.Address] {
+			seen[h.Address] = true
+			addresses = append(addresses, postgresql.HexToBytes(h.Address))
+		}
+	}
+
+	rows, err := q.ListBalanceChangesAfter(ctx, gen.ListBalanceChangesAfterParams{ChainID: chainID, Kind: string(kind), BlockNumber: height, Addresses: addresses})
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return holdings, nil
+	}
+
+	type change struct {
+		delta  *big.Int
+		seeded bool
+	}
+	changes := make(map[string]change, len(rows))
+	for _, row := range rows {
+		delta, _ := new(big.Int).SetString(row.Delta, 10)
+		changes[holdingKey(postgresql.BytesToHex(row.Hash), row.TokenID.String, postgresql.BytesToHex(row.Address))] = change{delta: delta, seeded: row.Seeded}
+	}
+
+	var kept, moved []*Holding
+	for _, h := range holdings {
+		c, ok := changes[holdingKey(h.Hash, h.TokenID, h.Address)]
+		switch {
+		case !ok || c.delta == nil:
+			kept = append(kept, h)
+		case c.seeded:
+		case kind == KindErc721:
+			// 지금 주인이 height 뒤에 받은 토큰은 height 의 주인을 원장에서 찾는다
+			if c.delta.Sign() == 0 {
+				kept = append(kept, h)
+			} else {
+				moved = append(moved, h)
+			}
+		default:
+			balance, _ := new(big.Int).SetString(h.Balance, 10)
+			if balance == nil {
+				balance = new(big.Int)
+			}
+			h.Balance = balance.Sub(balance, c.delta).String()
+			kept = append(kept, h)
+		}
+	}
+	if len(moved) == 0 {
+		return kept, nil
+	}
+
+	hashes := make([][]byte, len(moved))
+	tokenIds := make([]string, len(moved))
+	for i, h := range moved {
+		hashes[i] = postgresql.HexToBytes(h.Hash)
+		tokenIds[i] = h.TokenID
+	}
+	owners, err := q.ListERC721OwnersAt(ctx, gen.ListERC721OwnersAtParams{ChainID: chainID, BlockNumber: height, Hashes: hashes, TokenIds: tokenIds})
+	if err != nil {
+		return nil, err
+	}
+	ownerAt := make(map[string]string, len(owners))
+	for _, row := range owners {
+		ownerAt[holdingKey(postgresql.BytesToHex(row.Hash), row.TokenID.String, "")] = postgresql.BytesToHex(row.Address)
+	}
+	// height 에 주인이 없던 토큰 (그 뒤에 민팅) 은 뺀다
+	for _, h := range moved {
+		if owner, ok := ownerAt[holdingKey(h.Hash, h.TokenID, "")]; ok {
+			h.Address = owner
+			kept = append(kept, h)
+		}
+	}
+
+	return kept, nil
+}
+
+func holdingKey(hash, tokenID, address string) string {
+	return hash + "/" + tokenID + "/" + address
+}
+
+func (s *Service) StartRun(ctx context.Context, chainID int64, mode string, repair bool) (int64, error) {
+	id, err := s.db.Queries.CreateReconcileRun(ctx, gen.CreateReconcileRunParams{
+		ChainID:   chainID,
+		Mode:      mode,
+		Repair:    repair,
+		StartedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		s.l.Error("create reconcile run", logger.Field{Key: "error", Value: err.Error()})
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// RecordDrift 불일치를 기록하고, repair 모드면 같은 트랜잭션에서 온체인 값으로 덮어쓴다.
+// 잔액은 차이만큼 더해서 맞추기 때문에 스냅샷 이후 반영된 델타는 유지된다.
+func (s *Service) RecordDrift(ctx context.Context, runID int64, chainID int64, drift *Drift, repair bool) error {
+	return s.txManager.WithTransaction(ctx, sql.LevelReadCommitted, false, func(ctx context.Context) error {
+		q := s.db.GetQueryRowerFromContext(ctx).Queries
+
+		repaired := false
+		if repair {
+			var err error
+			repaired, err = s.repair(ctx, q, chainID, drift)
+			if err != nil {
+				s.l.Error("repair balance", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "address", Value: drift.Address})
+				return err
+			}
+			if repaired {
+				err = s.recordRepair(ctx, q, chainID, drift)
+				if err != nil {
+					s.l.Error("create balance change", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "address", Value: drift.Address})
+					return err
+				}
+			}
+		}
+
+		err := q.InsertBalanceDrift(ctx, gen.InsertBalanceDriftParams{
+			RunID:          runID,
+			ChainID:        chainID,
+			Kind:           string(drift.Kind),
+			Hash:           postgresql.HexToBytes(drift.Hash),
+			TokenID:        sql.NullString{String: drift.TokenID, Valid: drift.TokenID != ""},
+			Address:        postgresql.HexToBytes(drift.Address),
+			OnchainAddress: postgresql.HexToBytes(drift.OnchainAddress),
+			BlockNumber:    drift.BlockNumber.Int64(),
+			Stored:         drift.Stored.String(),
+			Onchain:        drift.Onchain.String(),
+			Difference:     drift.Difference.String(),
+			Repaired:       repaired,
+			CreatedAt:      time.Now().UTC(),
+		})
+		if err != nil {
+			s.l.Error("create balance drift", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "address", Value: drift.Address})
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (s *Service) repair(ctx context.Context, q *gen.Queries, chainID int64, drift *Drift) (bool, error) {
+	switch drift.Kind {
+	case KindCoin:
+		err := q.UpsertWalletBalance(ctx, gen.UpsertWalletBalanceParams{
+			ChainID: chainID,
+			Address: postgresql.HexToBytes(drift.Address),
+			Balance: drift.Difference.String(),
+		})
+		return err == nil, err
+	case KindErc20:
+		_, err := q.UpsertERC20Balance(ctx, gen.UpsertERC20BalanceParams{
+			ChainID: chainID,
+			Balance: drift.Difference.String(),
+			Hash:    postgresql.HexToBytes(drift.Hash),
+			Address: postgresql.HexToBytes(drift.Address),
+		})
+		return err == nil, err
+	case KindErc721:
+		affected, err := q.RepairERC721Owner(ctx, gen.RepairERC721OwnerParams{
+			NewAddress: postgresql.HexToBytes(drift.OnchainAddress),
+			ChainID:    chainID,
+			Hash:       postgresql.HexToBytes(drift.Hash),
+			TokenID:    drift.TokenID,
+			OldAddress: postgresql.HexToBytes(drift.Address),
+		})
+		return affected > 0, err
+	case KindErc1155:
+		err := q.UpsertERC1155Balance_Add(ctx, gen.UpsertERC1155Balance_AddParams{
+			ChainID: chainID,
+			Hash:    postgresql.HexToBytes(drift.Hash),
+			TokenID: drift.TokenID,
+			Address: postgresql.HexToBytes(drift.Address),
+			Amount:  drift.Difference.String(),
+		})
+		return err == nil, err
+	}
+
+	return false, fmt.Errorf("unknown balance kind %s", drift.Kind)
+}
+
+// recordRepair 복구로 바뀐 잔액도 balance_change 원장에 남긴다
+func (s *Service) recordRepair(ctx context.Context, q *gen.Queries, chainID int64, drift *Drift) error {
+	change := gen.InsertBalanceChangeParams{
+		ChainID:     chainID,
+		Kind:        string(drift.Kind),
+		Hash:        postgresql.HexToBytes(drift.Hash),
+		TokenID:     sql.NullString{String: drift.TokenID, Valid: drift.TokenID != ""},
+		Address:     postgresql.HexToBytes(drift.Address),
+		Delta:       drift.Difference.String(),
+		BlockNumber: drift.BlockNumber.Int64(),
+		Reason:      "repair",
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	if drift.Kind == KindErc721 {
+		change.Delta = "-1"
+		if err := q.InsertBalanceChange(ctx, change); err != nil {
+			return err
+		}
+		change.Address = postgresql.HexToBytes(drift.OnchainAddress)
+		change.Delta = "1"
+	}
+
+	return q.InsertBalanceChange(ctx, change)
+}
+
+// FinishRun runErr 가 있으면 failed 로 남긴다
+func (s *Service) FinishRun(ctx context.Context, runID int64, checked, mismatched, skipped int, runErr error) error {
+	status := "finished"
+	var message sql.NullString
+	if runErr != nil {
+		status = "failed"
+		message = sql.NullString{String: runErr.Error(), Valid: true}
+	}
+
+	err := s.db.Queries.FinishReconcileRun(ctx, gen.FinishReconcileRunParams{
+		ID:         runID,
+		Checked:    int64(checked),
+		Mismatched: int64(mismatched),
+		Skipped:    int64(skipped),
+		Status:     status,
+		Error:      message,
+		FinishedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+	})
+	if err != nil {
+		s.l.Error("finish reconcile run", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "run", Value: runID})
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) Summary(ctx context.Context, runID int64) ([]*gen.GetBalanceDriftSummaryRow, error) {
+	return s.db.Queries.GetBalanceDriftSummary(ctx, runID)
+}
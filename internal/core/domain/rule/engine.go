@@ -0,0 +1,333 @@
+package rule
+
+import (
+	"blockchain-tracking/internal/blockchain/evm/evmType"
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/logger"
+	"blockchain-tracking/internal/notify"
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+)
+
+// event 규칙이 울린 건. Run 에서 기록하고 알림을 보낸다
+type event struct {
+	rule        *Rule
+	chainID     int64
+	blockNumber int64
+	subject     string
+	title       string
+	body        string
+	at          time.Time
+}
+
+// state 블록 사이에 이어지는 값. 키는 규칙 이름:체인
+type state struct {
+	lastBlock map[int64]uint64
+	fired     map[string]time.Time              // 규칙:체인:subject -> 마지막으로 울린 시각 (cooldown)
+	transfers map[string]map[string][]time.Time // 규칙:체인 -> 컨트랙트 -> 최근 1분 전송 시각 (블록 시각)
+	gas       map[string][]*big.Int             // 규칙:체인 -> 최근 블록 중간 gas price
+}
+
+func newState() *state {
+	return &state{
+		lastBlock: make(map[int64]uint64),
+		fired:     make(map[string]time.Time),
+		transfers: make(map[string]map[string][]time.Time),
+		gas:       make(map[string][]*big.Int),
+	}
+}
+
+// forget 지금 규칙의 cooldown 이 모두 지난 기록과 없어진 규칙의 구간 값을 지운다
+func (st *state) forget(rules []*Rule) {
+	cooldown := time.Duration(defaultCooldown) * time.Second
+	names := make(map[string]bool)
+	for _, r := range rules {
+		cooldown = max(cooldown, time.Duration(r.CooldownSeconds)*time.Second)
+		names[r.Name] = true
+	}
+
+	now := time.Now()
+	for key, last := range st.fired {
+		if now.Sub(last) >= cooldown {
+			delete(st.fired, key)
+		}
+	}
+	for key := range st.transfers {
+		if !names[ruleName(key)] {
+			delete(st.transfers, key)
+		}
+	}
+	for key := range st.gas {
+		if !names[ruleName(key)] {
+			delete(st.gas, key)
+		}
+	}
+}
+
+// ruleName "규칙:체인" 키의 규칙 이름
+func ruleName(key string) string {
+	if i := strings.LastIndex(key, ":"); i >= 0 {
+		return key[:i]
+	}
+	return key
+}
+
+// Run 규칙을 reload 주기마다 다시 읽고, 울린 규칙을 기록하고 알림을 보낸다. ctx 가 끝나면 돌아온다
+func (s *Service) Run(ctx context.Context, reload time.Duration) {
+	if err := s.Reload(ctx); err != nil {
+		s.l.Error("load alert rules", logger.Field{Key: "error", Value: err.Error()})
+	}
+
+	ticker := time.NewTicker(reload)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Reload(ctx); err != nil {
+				s.l.Error("reload alert rules", logger.Field{Key: "error", Value: err.Error()})
+			}
+		case e := <-s.events:
+			s.publish(ctx, e)
+		}
+	}
+}
+
+func (s *Service) publish(ctx context.Context, e event) {
+	err := s.db.Queries.InsertAlertRuleEvent(ctx, gen.InsertAlertRuleEventParams{
+		RuleName:    e.rule.Name,
+		ChainID:     e.chainID,
+		BlockNumber: e.blockNumber,
+		Subject:     e.subject,
+		Title:       e.title,
+		Body:        e.body,
+		CreatedAt:   e.at,
+	})
+	if err != nil {
+		s.l.Error("create alert rule event", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "rule", Value: e.rule.Name})
+	}
+
+	// cooldown 은 규칙이 맡으므로 Router 중복 제거에 걸리지 않게 블록 번호까지 키에 넣는다
+	_ = s.notifier.Notify(ctx, notify.Message{
+		Severity: e.rule.severity,
+		Key:      fmt.Sprintf("rule:%s:%d:%s:%d", e.rule.Name, e.chainID, e.subject, e.blockNumber),
+		Title:    e.title,
+		Body:     e.body,
+	})
+}
+
+// Evaluate 디코딩된 블록에 규칙을 적용한다. 이미 본 블록(재시도)은 건너뛴다
+func (s *Service) Evaluate(block *evmType.Block) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.rules) == 0 {
+		return
+	}
+
+	chainID := block.ChainID.Int64()
+	if last, ok := s.state.lastBlock[chainID]; ok && block.Number <= last {
+		return
+	}
+	s.state.lastBlock[chainID] = block.Number
+
+	transfers := block.Transfers()
+	for _, r := range s.rules {
+		if r.ChainID != 0 && r.ChainID != chainID {
+			continue
+		}
+
+		switch r.Type {
+		case TypeLargeTransfer, TypeLargeMint, TypeLargeBurn:
+			s.largeTransfers(r, block, transfers)
+		case TypeContractDeployed:
+			s.deployments(r, block)
+		case TypeTransferRate:
+			s.transferRate(r, block, transfers)
+		case TypeGasPriceSpike:
+			s.gasPrice(r, block)
+		}
+	}
+}
+
+func (s *Service) largeTransfers(r *Rule, block *evmType.Block, transfers []*evmType.Transfer) {
+	p := r.Params
+	for _, t := range transfers {
+		if (p.Kind != "" && t.Kind != p.Kind) || (p.Contract != "" && t.Contract != p.Contract) {
+			continue
+		}
+		if p.Address != "" && t.From != p.Address && t.To != p.Address {
+			continue
+		}
+		if (r.Type == TypeLargeMint && t.Function != "mint") || (r.Type == TypeLargeBurn && t.Function != "burn") {
+			continue
+		}
+		if t.Amount == nil || t.Amount.Cmp(r.minAmount) < 0 {
+			continue
+		}
+
+		token := t.Contract
+		if t.Kind == "coin" {
+			token = "coin"
+		}
+		amount := evmType.FormatUnits(t.Amount, r.decimals())
+		if t.TokenId != nil {
+			amount = fmt.Sprintf("%s of token #%s", amount, t.TokenId)
+		}
+
+		s.fire(r, block, token,
+			fmt.Sprintf("%s: %s %s on chain %s", r.Name, amount, token, block.ChainID),
+			fmt.Sprintf("%s %s -> %s\ntx %s\nblock %d", t.Kind, t.From, t.To, t.TransactionHash, block.Number))
+	}
+}
+
+func (s *Service) deployments(r *Rule, block *evmType.Block) {
+	for _, tx := range block.Transaction {
+		if tx.Contract == nil {
+			continue
+		}
+		if r.Params.ContractType != "" && tx.Contract.Type != r.Params.ContractType {
+			continue
+		}
+
+		kind := "contract"
+		if tx.Contract.Type != "" {
+			kind = "erc" + tx.Contract.Type
+		}
+		s.fire(r, block, tx.Contract.Hash,
+			fmt.Sprintf("%s: %s %s deployed on chain %s", r.Name, kind, tx.Contract.Hash, block.ChainID),
+			fmt.Sprintf("name %s, symbol %s\ncreator %s\ntx %s\nblock %d", tx.Contract.Name, tx.Contract.Symbol, tx.From, tx.Hash, block.Number))
+	}
+}
+
+// transferRate address 가 있으면 그 주소가 받은 전송, 없으면 토큰 컨트랙트별 전송을 1분 단위(블록 시각)로 센다
+func (s *Service) transferRate(r *Rule, block *evmType.Block, transfers []*evmType.Transfer) {
+	key := fmt.Sprintf("%s:%s", r.Name, block.ChainID)
+	window, ok := s.state.transfers[key]
+	if !ok {
+		window = make(map[string][]time.Time)
+		s.state.transfers[key] = window
+	}
+
+	p := r.Params
+	counted := make(map[string]bool)
+	for _, t := range transfers {
+		if p.Kind != "" && t.Kind != p.Kind {
+			continue
+		}
+
+		subject := t.Contract
+		if t.Kind == "coin" {
+			subject = "coin"
+		}
+		if p.Address != "" {
+			if t.To != p.Address {
+				continue
+			}
+			subject = p.Address
+		}
+		if p.Contract != "" && t.Contract != p.Contract {
+			continue
+		}
+		window[subject] = append(window[subject], block.Timestamp)
+		counted[subject] = true
+	}
+
+	since := block.Timestamp.Add(-time.Minute)
+	for subject, times := range window {
+		times = slices.DeleteFunc(times, func(t time.Time) bool { return !t.After(since) })
+		if len(times) == 0 {
+			delete(window, subject)
+			continue
+		}
+		window[subject] = times
+
+		if counted[subject] && len(times) > p.MaxPerMinute {
+			s.fire(r, block, subject,
+				fmt.Sprintf("%s: %d transfers for %s in a minute on chain %s", r.Name, len(times), subject, block.ChainID),
+				fmt.Sprintf("limit %d per minute\nblock %d", p.MaxPerMinute, block.Number))
+		}
+	}
+}
+
+func (s *Service) gasPrice(r *Rule, block *evmType.Block) {
+	var prices []*big.Int
+	for _, tx := range block.Transaction {
+		if tx.GasPrice != nil && tx.GasPrice.Sign() > 0 {
+			prices = append(prices, tx.GasPrice)
+		}
+	}
+	if len(prices) == 0 {
+		return
+	}
+	slices.SortFunc(prices, func(a, b *big.Int) int { return a.Cmp(b) })
+	median := prices[len(prices)/2]
+
+	key := fmt.Sprintf("%s:%s", r.Name, block.ChainID)
+	recent := s.state.gas[key]
+	defer func() {
+		recent = append(recent, median)
+		if len(recent) > r.Params.Window {
+			recent = recent[len(recent)-r.Params.Window:]
+		}
+		s.state.gas[key] = recent
+	}()
+
+	if r.minWei != nil && median.Cmp(r.minWei) < 0 {
+		return
+	}
+
+	detail := fmt.Sprintf("median %s gwei", evmType.FormatUnits(median, 9))
+	if r.Params.Multiplier > 0 {
+		// 평균을 낼 블록이 절반도 안 모였으면 판단하지 않는다
+		if len(recent) < (r.Params.Window+1)/2 {
+			return
+		}
+		sum := new(big.Int)
+		for _, price := range recent {
+			sum.Add(sum, price)
+		}
+		average := new(big.Float).Quo(new(big.Float).SetInt(sum), big.NewFloat(float64(len(recent))))
+		limit := new(big.Float).Mul(average, big.NewFloat(r.Params.Multiplier))
+		if new(big.Float).SetInt(median).Cmp(limit) < 0 {
+			return
+		}
+		averageWei, _ := average.Int(nil)
+		detail += fmt.Sprintf(", average of last %d blocks %s gwei", len(recent), evmType.FormatUnits(averageWei, 9))
+	}
+
+	s.fire(r, block, "gas",
+		fmt.Sprintf("%s: gas price spike on chain %s", r.Name, block.ChainID),
+		fmt.Sprintf("%s\nblock %d", detail, block.Number))
+}
+
+// fire 같은 규칙/체인/subject 는 cooldown 동안 한 번만 울린다. 보내는 건 Run 이 한다
+func (s *Service) fire(r *Rule, block *evmType.Block, subject, title, body string) {
+	now := time.Now()
+	key := fmt.Sprintf("%s:%s:%s", r.Name, block.ChainID, subject)
+	if last, ok := s.state.fired[key]; ok && now.Sub(last) < time.Duration(r.CooldownSeconds)*time.Second {
+		return
+	}
+	s.state.fired[key] = now
+
+	e := event{
+		rule:        r,
+		chainID:     block.ChainID.Int64(),
+		blockNumber: int64(block.Number),
+		subject:     subject,
+		title:       title,
+		body:        body,
+		at:          now.UTC(),
+	}
+	select {
+	case s.events <- e:
+	default:
+		s.l.Warn("alert rule event queue is full, dropping", logger.Field{Key: "rule", Value: r.Name}, logger.Field{Key: "subject", Value: subject})
+	}
+}
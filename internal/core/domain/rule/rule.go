@@ -0,0 +1,149 @@
+package rule
+
+import (
+	"blockchain-tracking/internal/blockchain/evm/evmType"
+	"blockchain-tracking/internal/notify"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// TypeLargeTransfer 수량이 기준 이상인 전송
+	TypeLargeTransfer = "large_transfer"
+	// TypeLargeMint / TypeLargeBurn 수량이 기준 이상인 mint / burn
+	TypeLargeMint = "large_mint"
+	TypeLargeBurn = "large_burn"
+	// TypeContractDeployed 새 컨트랙트 배포 (contractType 으로 종류를 좁힌다)
+	TypeContractDeployed = "contract_deployed"
+	// TypeTransferRate 1분 동안 한 컨트랙트의 전송이 maxPerMinute 을 넘음
+	TypeTransferRate = "transfer_rate"
+	// TypeGasPriceSpike 블록 중간 gas price 가 최근 평균의 multiplier 배 이상 (또는 minGwei 이상)
+	TypeGasPriceSpike = "gas_price_spike"
+)
+
+// 쿨다운 기본값 (초)
+const defaultCooldown = 300
+
+// Params 규칙 종류별로 쓰는 값만 채운다
+type Params struct {
+	Kind         string  `json:"kind,omitempty"`         // coin / erc20 / erc721 / erc1155, 비어 있으면 전체
+	Contract     string  `json:"contract,omitempty"`     // 토큰 컨트랙트
+	Address      string  `json:"address,omitempty"`      // large_*: from 또는 to, transfer_rate: 받는 주소
+	MinAmount    string  `json:"minAmount,omitempty"`    // decimals 를 적용한 수량 (예: "1000000" USDC)
+	Decimals     int     `json:"decimals,omitempty"`     // minAmount 의 소수점 자리수 (coin 은 18)
+	ContractType string  `json:"contractType,omitempty"` // erc20 / erc721 / erc1155
+	MaxPerMinute int     `json:"maxPerMinute,omitempty"`
+	Multiplier   float64 `json:"multiplier,omitempty"`
+	MinGwei      float64 `json:"minGwei,omitempty"`
+	Window       int     `json:"window,omitempty"` // gas 평균을 낼 블록 수 (기본 20)
+}
+
+// Rule DB 나 RULES_FILE 에서 읽은 규칙 하나
+type Rule struct {
+	Name            string `json:"name"`
+	ChainID         int64  `json:"chainId,omitempty"` // 0 이면 전체 체인
+	Type            string `json:"type"`
+	Params          Params `json:"params"`
+	Severity        string `json:"severity,omitempty"`        // 기본 warning
+	CooldownSeconds int    `json:"cooldownSeconds,omitempty"` // 기본 300
+	Enabled         *bool  `json:"enabled,omitempty"`         // 파일 규칙은 생략하면 켜짐
+	Source          string `json:"-"`                         // db / file
+
+	severity  notify.Severity
+	minAmount *big.Int
+	minWei    *big.Int
+}
+
+// compile 값을 검사하고 비교에 쓸 값을 미리 만든다
+func (r *Rule) compile() error {
+	if r.Name == "" {
+		return fmt.Errorf("rule name is required")
+	}
+
+	severity := r.Severity
+	if severity == "" {
+		severity = "warning"
+	}
+	var err error
+	if r.severity, err = notify.ParseSeverity(severity); err != nil {
+		return fmt.Errorf("rule %s: %w", r.Name, err)
+	}
+	if r.CooldownSeconds == 0 {
+		r.CooldownSeconds = defaultCooldown
+	}
+
+	p := &r.Params
+	switch p.Kind {
+	case "", "coin", "erc20", "erc721", "erc1155":
+	default:
+		return fmt.Errorf("rule %s: unknown kind %s", r.Name, p.Kind)
+	}
+	for _, address := range []*string{&p.Contract, &p.Address} {
+		if *address == "" {
+			continue
+		}
+		if !common.IsHexAddress(*address) {
+			return fmt.Errorf("rule %s: invalid address %s", r.Name, *address)
+		}
+		*address = strings.ToLower(*address)
+	}
+
+	switch r.Type {
+	case TypeLargeTransfer, TypeLargeMint, TypeLargeBurn:
+		if r.minAmount, err = parseUnits(p.MinAmount, r.decimals()); err != nil {
+			return fmt.Errorf("rule %s: %w", r.Name, err)
+		}
+	case TypeContractDeployed:
+		p.ContractType = strings.TrimPrefix(strings.ToLower(p.ContractType), "erc")
+		switch p.ContractType {
+		case "", "20", "721", "1155":
+		default:
+			return fmt.Errorf("rule %s: unknown contract type %s", r.Name, p.ContractType)
+		}
+	case TypeTransferRate:
+		if p.MaxPerMinute <= 0 {
+			return fmt.Errorf("rule %s: maxPerMinute must be positive", r.Name)
+		}
+	case TypeGasPriceSpike:
+		if p.Multiplier <= 0 && p.MinGwei <= 0 {
+			return fmt.Errorf("rule %s: multiplier or minGwei is required", r.Name)
+		}
+		if p.Window <= 0 {
+			p.Window = 20
+		}
+		if p.MinGwei > 0 {
+			r.minWei, _ = new(big.Float).Mul(big.NewFloat(p.MinGwei), big.NewFloat(1e9)).Int(nil)
+		}
+	default:
+		return fmt.Errorf("rule %s: unknown type %s", r.Name, r.Type)
+	}
+
+	return nil
+}
+
+// decimals minAmount 와 알림 수량의 소수점 자리수. coin 은 따로 정하지 않으면 18
+func (r *Rule) decimals() int {
+	if r.Params.Kind == "coin" && r.Params.Decimals == 0 {
+		return 18
+	}
+	return r.Params.Decimals
+}
+
+func (r *Rule) enabled() bool {
+	return r.Enabled == nil || *r.Enabled
+}
+
+func parseUnits(value string, decimals int) (*big.Int, error) {
+	if value == "" {
+		return nil, fmt.Errorf("minAmount is required")
+	}
+
+	amount, err := evmType.ParseUnits(value, decimals)
+	if err != nil || amount.Sign() < 0 {
+		return nil, fmt.Errorf("invalid minAmount %s", value)
+	}
+	return amount, nil
+}
@@ -0,0 +1,198 @@
+package rule
+
+import (
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/database/postgresql"
+	"blockchain-tracking/internal/logger"
+	"blockchain-tracking/internal/notify"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+type Service struct {
+	db       *postgresql.Database
+	notifier notify.Notifier
+	file     string // RULES_FILE, 비어 있으면 DB 규칙만
+	l        logger.Logger
+
+	mu     sync.Mutex
+	rules  []*Rule
+	state  *state
+	events chan event
+}
+
+func NewService(d *postgresql.Database, notifier notify.Notifier, file string, l logger.Logger) *Service {
+	return &Service{
+		db:       d,
+		notifier: notifier,
+		file:     file,
+		l:        l,
+		state:    newState(),
+		events:   make(chan event, 256),
+	}
+}
+
+// Create DB 규칙을 추가한다. 실행 중인 트래커는 다음 reload 때 읽는다
+func (s *Service) Create(ctx context.Context, r *Rule) (int64, error) {
+	if err := r.compile(); err != nil {
+		return 0, err
+	}
+
+	params, err := json.Marshal(r.Params)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := s.db.Queries.CreateAlertRule(ctx, gen.CreateAlertRuleParams{
+		Name:            r.Name,
+		ChainID:         sql.NullInt64{Int64: r.ChainID, Valid: r.ChainID != 0},
+		Type:            r.Type,
+		Params:          params,
+		Severity:        r.severity.String(),
+		CooldownSeconds: int32(r.CooldownSeconds),
+		CreatedAt:       time.Now().UTC(),
+	})
+	if err != nil {
+		s.l.Error("create alert rule", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "rule", Value: r.Name})
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// List DB 규칙과 파일 규칙 (이름이 같으면 DB 규칙만)
+func (s *Service) List(ctx context.Context) ([]*Rule, error) {
+	rows, err := s.db.Queries.ListAlertRules(ctx)
+	if err != nil {
+		s.l.Error("list alert rules", logger.Field{Key: "error", Value: err.Error()})
+		return nil, err
+	}
+
+	var rules []*Rule
+	names := make(map[string]bool)
+	for _, row := range rows {
+		enabled := row.Enabled
+		r := &Rule{
+			Name:            row.Name,
+			ChainID:         row.ChainID.Int64,
+			Type:            row.Type,
+			Severity:        row.Severity,
+			CooldownSeconds: int(row.CooldownSeconds),
+			Enabled:         &enabled,
+			Source:          "db",
+		}
+		if err = json.Unmarshal(row.Params, &r.Params); err != nil {
+			return nil, fmt.Errorf("rule %s: invalid params: %w", row.Name, err)
+		}
+		names[r.Name] = true
+		rules = append(rules, r)
+	}
+
+	fileRules, err := s.readFile()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range fileRules {
+		if names[r.Name] {
+			s.l.Warn("rule in file is overridden by db rule", logger.Field{Key: "rule", Value: r.Name})
+			continue
+		}
+		rules = append(rules, r)
+	}
+
+	return rules, nil
+}
+
+func (s *Service) SetEnabled(ctx context.Context, name string, enabled bool) error {
+	affected, err := s.db.Queries.SetAlertRuleEnabled(ctx, gen.SetAlertRuleEnabledParams{Name: name, Enabled: enabled})
+	if err != nil {
+		s.l.Error("update alert rule", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "rule", Value: name})
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("db rule %s not found", name)
+	}
+
+	return nil
+}
+
+func (s *Service) Delete(ctx context.Context, name string) error {
+	affected, err := s.db.Queries.DeleteAlertRule(ctx, name)
+	if err != nil {
+		s.l.Error("delete alert rule", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "rule", Value: name})
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("db rule %s not found", name)
+	}
+
+	return nil
+}
+
+// Events name 이 빈 값이면 전체 규칙 (최신순)
+func (s *Service) Events(ctx context.Context, name string, limit int32) ([]*gen.AlertRuleEvent, error) {
+	events, err := s.db.Queries.ListAlertRuleEvents(ctx, gen.ListAlertRuleEventsParams{
+		RuleName: sql.NullString{String: name, Valid: name != ""},
+		RowLimit: limit,
+	})
+	if err != nil {
+		s.l.Error("list alert rule events", logger.Field{Key: "error", Value: err.Error()})
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// Reload 켜진 규칙을 다시 읽는다. 잘못된 규칙은 건너뛰고 로그만 남긴다
+func (s *Service) Reload(ctx context.Context) error {
+	rules, err := s.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	var active []*Rule
+	for _, r := range rules {
+		if !r.enabled() {
+			continue
+		}
+		if err = r.compile(); err != nil {
+			s.l.Error("invalid alert rule", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "rule", Value: r.Name})
+			continue
+		}
+		active = append(active, r)
+	}
+
+	s.mu.Lock()
+	s.rules = active
+	s.state.forget(active)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// readFile RULES_FILE 의 JSON 배열
+func (s *Service) readFile() ([]*Rule, error) {
+	if s.file == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(s.file)
+	if err != nil {
+		return nil, fmt.Errorf("read rules file: %w", err)
+	}
+
+	var rules []*Rule
+	if err = json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("parse rules file %s: %w", s.file, err)
+	}
+	for _, r := range rules {
+		r.Source = "file"
+	}
+
+	return rules, nil
+}
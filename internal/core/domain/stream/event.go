@@ -0,0 +1,103 @@
+package stream
+
+import (
+	"blockchain-tracking/internal/core/domain/explorer"
+	"time"
+)
+
+type Type string
+
+const (
+	TypeBlock   Type = "block"
+	TypeCoin    Type = "coin"
+	TypeErc20   Type = "erc20"
+	TypeErc721  Type = "erc721"
+	TypeErc1155 Type = "erc1155"
+	// TypeRemoved 저장됐던 블록이 지워졌다 (재인덱싱/롤백). 그 블록에서 받은 이벤트는 무효다
+	TypeRemoved Type = "removed"
+)
+
+// Event 구독자에게 보내는 메시지 한 건. 전송 이벤트는 Transfer, block/removed 는 블록 정보만 있다
+type Event struct {
+	Type        Type                    `json:"type"`
+	ChainID     int64                   `json:"chainId"`
+	BlockNumber int64                   `json:"blockNumber"`
+	BlockHash   string                  `json:"blockHash"`
+	Timestamp   time.Time               `json:"timestamp,omitzero"`
+	Transfer    *explorer.TokenTransfer `json:"transfer,omitempty"`
+}
+
+// Batch 한 블록의 이벤트. 구독자는 블록 단위로 받고, 다 받은 블록 번호를 재접속 위치로 쓴다
+type Batch struct {
+	ChainID     int64
+	BlockNumber int64
+	BlockHash   string
+	Timestamp   time.Time
+	Removed     bool
+	Events      []*Event
+}
+
+// Filter 빈 값은 조건 없음. removed 는 Types 와 상관없이 체인이 맞으면 보낸다
+type Filter struct {
+	ChainID  int64
+	Address  string // 보낸 사람 또는 받는 사람
+	Contract string
+	Types    map[Type]bool
+}
+
+func (f Filter) Match(e *Event) bool {
+	if e.ChainID != f.ChainID {
+		return false
+	}
+	if e.Type == TypeRemoved {
+		return true
+	}
+	if len(f.Types) > 0 && !f.Types[e.Type] {
+		return false
+	}
+	if e.Transfer == nil {
+		// 블록 이벤트는 주소/컨트랙트 조건이 없을 때만
+		return f.Address == "" && f.Contract == ""
+	}
+	if f.Address != "" && e.Transfer.From != f.Address && e.Transfer.To != f.Address {
+		return false
+	}
+	if f.Contract != "" && e.Transfer.ContractAddress != f.Contract {
+		return false
+	}
+	return true
+}
+
+// filter 구독자에게 보낼 이벤트만 남긴다. 남는 게 없어도 블록 번호는 전달한다
+func (b *Batch) filter(f Filter) *Batch {
+	filtered := &Batch{ChainID: b.ChainID, BlockNumber: b.BlockNumber, BlockHash: b.BlockHash, Timestamp: b.Timestamp, Removed: b.Removed}
+	for _, e := range b.Events {
+		if f.Match(e) {
+			filtered.Events = append(filtered.Events, e)
+		}
+	}
+	return filtered
+}
+
+func blockBatch(chainID int64, block *explorer.Block, transfers []*explorer.TokenTransfer) *Batch {
+	batch := &Batch{ChainID: chainID, BlockNumber: block.Number, BlockHash: block.Hash, Timestamp: block.Timestamp, Events: make([]*Event, 0, len(transfers)+1)}
+	for _, transfer := range transfers {
+		batch.Events = append(batch.Events, &Event{
+			Type:        Type(transfer.Kind),
+			ChainID:     chainID,
+			BlockNumber: block.Number,
+			BlockHash:   block.Hash,
+			Timestamp:   block.Timestamp,
+			Transfer:    transfer,
+		})
+	}
+	// 블록 이벤트는 마지막에 (클라이언트는 이걸 받으면 블록을 다 받은 것이다)
+	batch.Events = append(batch.Events, &Event{
+		Type:        TypeBlock,
+		ChainID:     chainID,
+		BlockNumber: block.Number,
+		BlockHash:   block.Hash,
+		Timestamp:   block.Timestamp,
+	})
+	return batch
+}
@@ -0,0 +1,258 @@
+package stream
+
+import (
+	"blockchain-tracking/internal/core/domain/explorer"
+	"blockchain-tracking/internal/database/postgresql"
+	"blockchain-tracking/internal/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// 000007_block_notify 트리거가 보내는 채널
+const (
+	channelCommitted = "block_committed"
+	channelRemoved   = "block_removed"
+)
+
+const (
+	// 구독자 한 명이 밀려 있을 수 있는 블록 수. 넘으면 끊고 재접속해서 이어 받게 한다
+	subscriberBuffer = 256
+	replayPageSize   = 100
+	listenerPing     = 90 * time.Second
+)
+
+// ErrDropped 구독이 서버 쪽에서 끊겼다 (느린 구독자, LISTEN 재연결). 마지막 블록부터 다시 구독하면 된다
+var ErrDropped = errors.New("subscription dropped, resume from the last block")
+
+type notification struct {
+	ChainID int64  `json:"chain_id"`
+	Number  int64  `json:"number"`
+	Hash    string `json:"hash"`
+}
+
+type Subscription struct {
+	filter  Filter
+	batches chan *Batch
+	dropped chan struct{}
+	once    sync.Once
+}
+
+// Batches 블록 순서대로 온다
+func (sub *Subscription) Batches() <-chan *Batch {
+	return sub.batches
+}
+
+// Dropped 닫히면 더 이상 받을 수 없다
+func (sub *Subscription) Dropped() <-chan struct{} {
+	return sub.dropped
+}
+
+func (sub *Subscription) drop() {
+	sub.once.Do(func() { close(sub.dropped) })
+}
+
+// Service 블록 저장 알림을 받아 구독자에게 나눠준다. API 서버 프로세스에서 Run 을 한 번 띄운다
+type Service struct {
+	db       *postgresql.Database
+	explorer *explorer.Service
+	l        logger.Logger
+
+	mu          sync.Mutex
+	subscribers map[*Subscription]struct{}
+}
+
+func NewService(d *postgresql.Database, explorerService *explorer.Service, l logger.Logger) *Service {
+	return &Service{
+		db:          d,
+		explorer:    explorerService,
+		l:           l,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+func (s *Service) Subscribe(filter Filter) *Subscription {
+	sub := &Subscription{
+		filter:  filter,
+		batches: make(chan *Batch, subscriberBuffer),
+		dropped: make(chan struct{}),
+	}
+
+	s.mu.Lock()
+	s.subscribers[sub] = struct{}{}
+	s.mu.Unlock()
+	return sub
+}
+
+func (s *Service) Unsubscribe(sub *Subscription) {
+	s.mu.Lock()
+	delete(s.subscribers, sub)
+	s.mu.Unlock()
+	sub.drop()
+}
+
+// Run LISTEN 해서 알림이 올 때마다 블록 이벤트를 읽어 구독자에게 보낸다. ctx 가 끝나면 돌아온다
+func (s *Service) Run(ctx context.Context) error {
+	listener, err := s.db.Listen(channelCommitted, channelRemoved)
+	if err != nil {
+		s.l.Error("listen block notifications", logger.Field{Key: "error", Value: err.Error()})
+		return err
+	}
+	defer listener.Close()
+
+	ticker := time.NewTicker(listenerPing)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			go func() { _ = listener.Ping() }()
+		case n := <-listener.Notify:
+			if n == nil {
+				// 끊긴 동안의 알림은 잃었으므로 모두 끊어서 재접속 후 재전송으로 메우게 한다
+				s.l.Warn("block notification listener reconnected, dropping subscribers")
+				s.dropAll()
+				continue
+			}
+			s.dispatch(ctx, n)
+		}
+	}
+}
+
+func (s *Service) dispatch(ctx context.Context, n *pq.Notification) {
+	var payload notification
+	if err := json.Unmarshal([]byte(n.Extra), &payload); err != nil {
+		s.l.Warn("invalid block notification", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "payload", Value: n.Extra})
+		return
+	}
+	if !s.hasSubscribers(payload.ChainID) {
+		return
+	}
+
+	var batch *Batch
+	switch n.Channel {
+	case channelRemoved:
+		batch = &Batch{ChainID: payload.ChainID, BlockNumber: payload.Number, BlockHash: payload.Hash, Removed: true, Events: []*Event{{
+			Type:        TypeRemoved,
+			ChainID:     payload.ChainID,
+			BlockNumber: payload.Number,
+			BlockHash:   payload.Hash,
+		}}}
+	case channelCommitted:
+		blocks, err := s.explorer.BlocksByNumber(ctx, payload.ChainID, []int64{payload.Number})
+		if err != nil {
+			s.l.Error("load streamed block", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: payload.ChainID}, logger.Field{Key: "block number", Value: payload.Number})
+			s.dropChain(payload.ChainID)
+			return
+		}
+		block, ok := blocks[payload.Number]
+		if !ok {
+			// 알림 후 바로 지워졌다. removed 알림이 따라온다
+			return
+		}
+		if batch, err = s.load(ctx, payload.ChainID, block); err != nil {
+			s.l.Error("load streamed block transfers", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "chain_id", Value: payload.ChainID}, logger.Field{Key: "block number", Value: payload.Number})
+			s.dropChain(payload.ChainID)
+			return
+		}
+	default:
+		return
+	}
+
+	s.publish(batch)
+}
+
+func (s *Service) load(ctx context.Context, chainID int64, block *explorer.Block) (*Batch, error) {
+	transfers, err := s.explorer.BlockTransfers(ctx, chainID, block)
+	if err != nil {
+		return nil, err
+	}
+	return blockBatch(chainID, block, transfers), nil
+}
+
+// publish 버퍼가 찬 구독자는 기다리지 않고 끊는다
+func (s *Service) publish(batch *Batch) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for sub := range s.subscribers {
+		if sub.filter.ChainID != batch.ChainID {
+			continue
+		}
+		select {
+		case sub.batches <- batch.filter(sub.filter):
+		default:
+			s.l.Warn("stream subscriber is too slow, dropping", logger.Field{Key: "chain_id", Value: batch.ChainID}, logger.Field{Key: "block number", Value: batch.BlockNumber})
+			delete(s.subscribers, sub)
+			sub.drop()
+		}
+	}
+}
+
+func (s *Service) hasSubscribers(chainID int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for sub := range s.subscribers {
+		if sub.filter.ChainID == chainID {
+			return true
+		}
+	}
+	return false
+}
+
+// dropChain 블록을 보내지 못한 체인의 구독자를 끊는다. 재접속하면 Last-Event-ID 부터 재전송으로 메운다
+func (s *Service) dropChain(chainID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for sub := range s.subscribers {
+		if sub.filter.ChainID != chainID {
+			continue
+		}
+		delete(s.subscribers, sub)
+		sub.drop()
+	}
+}
+
+func (s *Service) dropAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for sub := range s.subscribers {
+		delete(s.subscribers, sub)
+		sub.drop()
+	}
+}
+
+// Replay from 블록부터 지금 저장된 마지막 블록까지 DB 에서 읽어 fn 에 넘긴다. 마지막으로 넘긴 블록 번호를 돌려준다 (없으면 from-1)
+func (s *Service) Replay(ctx context.Context, filter Filter, from int64, fn func(*Batch) error) (int64, error) {
+	last := from - 1
+	for {
+		blocks, err := s.explorer.BlocksFrom(ctx, filter.ChainID, last+1, replayPageSize)
+		if err != nil {
+			return last, err
+		}
+
+		for _, block := range blocks {
+			batch, err := s.load(ctx, filter.ChainID, block)
+			if err != nil {
+				return last, err
+			}
+			if err = fn(batch.filter(filter)); err != nil {
+				return last, err
+			}
+			last = block.Number
+		}
+
+		if len(blocks) < replayPageSize {
+			return last, nil
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package watchlist
+
+import (
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/database/postgresql"
+	"blockchain-tracking/internal/logger"
+	"blockchain-tracking/internal/notify"
+	"context"
+	"database/sql"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+)
+
+// 한 번에 읽는 미전송 알림 수. 남은 건 다음 주기에 보낸다
+const deliveryBatch = 500
+
+// RunDelivery interval 마다 쌓인 알림을 watchlist 별로 묶어 메일 한 통씩 보낸다. ctx 가 끝나면 돌아온다
+func (s *Service) RunDelivery(ctx context.Context, sender notify.Mailer, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.deliver(ctx, sender)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) deliver(ctx context.Context, sender notify.Mailer) {
+	alerts, err := s.db.Queries.ListPendingWatchAlerts(ctx, deliveryBatch)
+	if err != nil {
+		s.l.Error("list pending watch alerts", logger.Field{Key: "error", Value: err.Error()})
+		return
+	}
+
+	var order []int64
+	groups := make(map[int64][]*gen.ListPendingWatchAlertsRow)
+	for _, alert := range alerts {
+		if _, ok := groups[alert.WatchlistID]; !ok {
+			order = append(order, alert.WatchlistID)
+		}
+		groups[alert.WatchlistID] = append(groups[alert.WatchlistID], alert)
+	}
+
+	for _, watchlistID := range order {
+		group := groups[watchlistID]
+		name, email := group[0].WatchlistName, group[0].Email.String
+
+		subject := fmt.Sprintf("[%s] %d transfers on watched addresses", name, len(group))
+		if err = sender.SendEmail(email, subject, alertBody(group)); err != nil {
+			// 표시하지 않고 두면 다음 주기에 다시 보낸다
+			s.l.Error("send watch alerts", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "watchlist", Value: name})
+			continue
+		}
+
+		ids := make([]int64, len(group))
+		for i, alert := range group {
+			ids[i] = alert.ID
+		}
+		err = s.db.Queries.MarkWatchAlertsDelivered(ctx, gen.MarkWatchAlertsDeliveredParams{
+			DeliveredAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+			Ids:         ids,
+		})
+		if err != nil {
+			s.l.Error("mark watch alerts delivered", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "watchlist", Value: name})
+		}
+	}
+}
+
+func alertBody(alerts []*gen.ListPendingWatchAlertsRow) string {
+	var b strings.Builder
+	b.WriteString("<table border=\"1\" cellpadding=\"4\" cellspacing=\"0\">")
+	b.WriteString("<tr><th>chain</th><th>block</th><th>tx</th><th>kind</th><th>address</th><th>direction</th><th>counterparty</th><th>token</th><th>amount</th></tr>")
+	for _, alert := range alerts {
+		token := postgresql.BytesToHex(alert.Contract)
+		if alert.Symbol.Valid && alert.Symbol.String != "" {
+			token = alert.Symbol.String + " " + token
+		}
+		amount := alert.Value
+		if alert.TokenID.Valid {
+			amount = "#" + alert.TokenID.String + " x " + amount
+		}
+
+		fmt.Fprintf(&b, "<tr><td>%d</td><td>%d</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>",
+			alert.ChainID, alert.BlockNumber, postgresql.BytesToHex(alert.TransactionHash), alert.Kind,
+			postgresql.BytesToHex(alert.Address), alert.Direction, postgresql.BytesToHex(alert.Counterparty),
+			html.EscapeString(token), html.EscapeString(amount))
+	}
+	b.WriteString("</table>")
+	return b.String()
+}
@@ -0,0 +1,149 @@
+package watchlist
+
+import (
+	"blockchain-tracking/internal/blockchain/evm/evmType"
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/database/postgresql"
+	"blockchain-tracking/internal/logger"
+	"context"
+	"database/sql"
+	"math/big"
+)
+
+const (
+	DirectionIn  = "in"
+	DirectionOut = "out"
+)
+
+// Match 블록의 전송 중 감시 주소가 보내거나 받은 건을 알림으로 남긴다.
+// 블록을 저장하는 트랜잭션 안에서 호출해서 블록과 알림이 같이 커밋된다
+func (s *Service) Match(ctx context.Context, q *gen.Queries, block *evmType.Block) error {
+	transfers := block.Transfers()
+	if len(transfers) == 0 {
+		return nil
+	}
+
+	chainID := block.ChainID.Int64()
+	seen := make(map[string]bool)
+	var addresses [][]byte
+	for _, t := range transfers {
+		for _, address := range []string{t.From, t.To} {
+			if address == "" || seen[address] {
+				continue
+			}
+			seen[address] = true
+			addresses = append(addresses, postgresql.HexToBytes(address))
+		}
+	}
+
+	rows, err := q.MatchWatchlistAddresses(ctx, gen.MatchWatchlistAddressesParams{ChainID: chainID, Addresses: addresses})
+	if err != nil {
+		s.l.Error("match watchlist addresses", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "block", Value: block.Hash})
+		return err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	watchers := make(map[string][]int64)
+	for _, row := range rows {
+		address := postgresql.BytesToHex(row.Address)
+		watchers[address] = append(watchers[address], row.WatchlistID)
+	}
+
+	tokens, err := s.tokens(ctx, q, chainID, transfers, watchers)
+	if err != nil {
+		s.l.Error("list watched token contracts", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "block", Value: block.Hash})
+		return err
+	}
+
+	for _, t := range transfers {
+		for _, side := range []struct{ address, counterparty, direction string }{
+			{t.From, t.To, DirectionOut},
+			{t.To, t.From, DirectionIn},
+		} {
+			for _, watchlistID := range watchers[side.address] {
+				err = q.InsertWatchAlert(ctx, alertParams(watchlistID, block, t, side.address, side.counterparty, side.direction, tokens[t.Contract]))
+				if err != nil {
+					s.l.Error("create watch alert", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "transaction", Value: t.TransactionHash}, logger.Field{Key: "address", Value: side.address})
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// tokens 감시 주소가 관련된 erc20 전송의 컨트랙트 (decimals, symbol)
+func (s *Service) tokens(ctx context.Context, q *gen.Queries, chainID int64, transfers []*evmType.Transfer, watchers map[string][]int64) (map[string]*gen.Contract, error) {
+	seen := make(map[string]bool)
+	var hashes [][]byte
+	for _, t := range transfers {
+		if t.Contract == "" || seen[t.Contract] {
+			continue
+		}
+		if len(watchers[t.From]) == 0 && len(watchers[t.To]) == 0 {
+			continue
+		}
+		seen[t.Contract] = true
+		hashes = append(hashes, postgresql.HexToBytes(t.Contract))
+	}
+
+	tokens := make(map[string]*gen.Contract)
+	if len(hashes) == 0 {
+		return tokens, nil
+	}
+
+	contracts, err := q.ListContractsByHash(ctx, gen.ListContractsByHashParams{ChainID: chainID, Hashes: hashes})
+	if err != nil {
+		return nil, err
+	}
+	for _, contract := range contracts {
+		tokens[postgresql.BytesToHex(contract.Hash)] = contract
+	}
+
+	return tokens, nil
+}
+
+// alertParams coin 은 체인 기본 코인 자리수(기본 18), erc20 은 컨트랙트 decimals 로 나눈 값을 같이 남긴다. nft 는 원본 수량
+func alertParams(watchlistID int64, block *evmType.Block, t *evmType.Transfer, address, counterparty, direction string, token *gen.Contract) gen.InsertWatchAlertParams {
+	amount := t.Amount
+	if amount == nil {
+		amount = new(big.Int)
+	}
+
+	params := gen.InsertWatchAlertParams{
+		WatchlistID:     watchlistID,
+		ChainID:         block.ChainID.Int64(),
+		BlockNumber:     int64(block.Number),
+		TransactionHash: postgresql.HexToBytes(t.TransactionHash),
+		LogIndex:        t.LogIndex,
+		BatchIndex:      int32(t.BatchIndex),
+		Kind:            t.Kind,
+		Contract:        postgresql.HexToBytes(t.Contract),
+		Address:         postgresql.HexToBytes(address),
+		Direction:       direction,
+		Counterparty:    postgresql.HexToBytes(counterparty),
+		Amount:          amount.String(),
+		Value:           amount.String(),
+		CreatedAt:       block.Timestamp,
+	}
+	if t.TokenId != nil {
+		params.TokenID = sql.NullString{String: t.TokenId.String(), Valid: true}
+	}
+
+	switch t.Kind {
+	case "coin":
+		params.Value = evmType.FormatUnits(amount, block.CoinDecimals())
+	case "erc20":
+		if token != nil && token.Decimals.Valid {
+			params.Value = evmType.FormatUnits(amount, int(token.Decimals.Int32))
+		}
+	}
+	if token != nil {
+		params.Symbol = token.Symbol
+	}
+
+	return params
+}
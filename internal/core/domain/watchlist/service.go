@@ -0,0 +1,189 @@
+package watchlist
+
+import (
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/database/postgresql"
+	"blockchain-tracking/internal/logger"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrNotFound 이름으로 찾은 watchlist 가 없다
+var ErrNotFound = errors.New("watchlist not found")
+
+type Service struct {
+	db *postgresql.Database
+	l  logger.Logger
+}
+
+func NewService(d *postgresql.Database, l logger.Logger) *Service {
+	return &Service{
+		db: d,
+		l:  l,
+	}
+}
+
+// Create email 이 빈 값이면 알림을 쌓기만 하고 보내지 않는다
+func (s *Service) Create(ctx context.Context, name, email string) (int64, error) {
+	if name == "" {
+		return 0, fmt.Errorf("watchlist name is required")
+	}
+
+	id, err := s.db.Queries.CreateWatchlist(ctx, gen.CreateWatchlistParams{
+		Name:      name,
+		Email:     sql.NullString{String: email, Valid: email != ""},
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		s.l.Error("create watchlist", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "watchlist", Value: name})
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *Service) Get(ctx context.Context, name string) (*gen.Watchlist, error) {
+	watchlist, err := s.db.Queries.GetWatchlistByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+		s.l.Error("get watchlist", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "watchlist", Value: name})
+		return nil, err
+	}
+
+	return watchlist, nil
+}
+
+func (s *Service) List(ctx context.Context) ([]*gen.ListWatchlistsRow, error) {
+	watchlists, err := s.db.Queries.ListWatchlists(ctx)
+	if err != nil {
+		s.l.Error("list watchlists", logger.Field{Key: "error", Value: err.Error()})
+		return nil, err
+	}
+
+	return watchlists, nil
+}
+
+// SetEmail 빈 값이면 메일 알림을 끈다
+func (s *Service) SetEmail(ctx context.Context, name, email string) error {
+	watchlist, err := s.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Queries.UpdateWatchlistEmail(ctx, gen.UpdateWatchlistEmailParams{
+		ID:    watchlist.ID,
+		Email: sql.NullString{String: email, Valid: email != ""},
+	})
+	if err != nil {
+		s.l.Error("update watchlist email", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "watchlist", Value: name})
+		return err
+	}
+
+	return nil
+}
+
+// Delete 주소와 쌓인 알림도 같이 지운다
+func (s *Service) Delete(ctx context.Context, name string) error {
+	watchlist, err := s.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if _, err = s.db.Queries.DeleteWatchlist(ctx, watchlist.ID); err != nil {
+		s.l.Error("delete watchlist", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "watchlist", Value: name})
+		return err
+	}
+
+	return nil
+}
+
+// AddAddress 이미 있는 주소면 label 만 바꾼다
+func (s *Service) AddAddress(ctx context.Context, name string, chainID int64, address, label string) error {
+	if !common.IsHexAddress(address) {
+		return fmt.Errorf("invalid address %s", address)
+	}
+
+	watchlist, err := s.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.Queries.AddWatchlistAddress(ctx, gen.AddWatchlistAddressParams{
+		WatchlistID: watchlist.ID,
+		ChainID:     chainID,
+		Address:     postgresql.HexToBytes(strings.ToLower(address)),
+		Label:       sql.NullString{String: label, Valid: label != ""},
+		CreatedAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		s.l.Error("add watchlist address", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "watchlist", Value: name}, logger.Field{Key: "address", Value: address})
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) RemoveAddress(ctx context.Context, name string, chainID int64, address string) error {
+	watchlist, err := s.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	removed, err := s.db.Queries.RemoveWatchlistAddress(ctx, gen.RemoveWatchlistAddressParams{
+		WatchlistID: watchlist.ID,
+		ChainID:     chainID,
+		Address:     postgresql.HexToBytes(address),
+	})
+	if err != nil {
+		s.l.Error("remove watchlist address", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "watchlist", Value: name}, logger.Field{Key: "address", Value: address})
+		return err
+	}
+	if removed == 0 {
+		return fmt.Errorf("address %s is not in watchlist %s on chain %d", address, name, chainID)
+	}
+
+	return nil
+}
+
+func (s *Service) Addresses(ctx context.Context, name string) ([]*gen.WatchlistAddress, error) {
+	watchlist, err := s.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses, err := s.db.Queries.ListWatchlistAddresses(ctx, watchlist.ID)
+	if err != nil {
+		s.l.Error("list watchlist addresses", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "watchlist", Value: name})
+		return nil, err
+	}
+
+	return addresses, nil
+}
+
+// Alerts name 이 빈 값이면 전체 watchlist 의 알림 (최신순)
+func (s *Service) Alerts(ctx context.Context, name string, limit int32) ([]*gen.WatchAlert, error) {
+	var watchlistID sql.NullInt64
+	if name != "" {
+		watchlist, err := s.Get(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		watchlistID = sql.NullInt64{Int64: watchlist.ID, Valid: true}
+	}
+
+	alerts, err := s.db.Queries.ListWatchAlerts(ctx, gen.ListWatchAlertsParams{WatchlistID: watchlistID, RowLimit: limit})
+	if err != nil {
+		s.l.Error("list watch alerts", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "watchlist", Value: name})
+		return nil, err
+	}
+
+	return alerts, nil
+}
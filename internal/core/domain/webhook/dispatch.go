@@ -0,0 +1,170 @@
+package webhook
+
+import (
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/logger"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// 한 번에 가져가는 전송 수와 동시에 보내는 요청 수
+	dispatchBatch   = 100
+	dispatchWorkers = 8
+	// 보내는 동안 다른 디스패처가 가져가지 못하게 잡아 두는 시간. 프로세스가 죽으면 이 시간이 지나고 다시 보낸다
+	dispatchLease = time.Minute
+
+	// 1m, 2m, 4m ... 최대 6시간 간격으로 12번 (약 하루) 실패하면 dead
+	maxAttempts = 12
+	baseBackoff = time.Minute
+	maxBackoff  = 6 * time.Hour
+)
+
+// RunDispatcher interval 마다 보낼 차례가 된 outbox 를 보낸다. ctx 가 끝나면 돌아온다
+func (s *Service) RunDispatcher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// 가득 채워 가져갔으면 밀린 건이 더 있으니 기다리지 않고 이어서 보낸다
+		if s.dispatch(ctx) == dispatchBatch {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch 가져간 건 수를 돌려준다
+func (s *Service) dispatch(ctx context.Context) int {
+	now := time.Now().UTC()
+	deliveries, err := s.db.Queries.ClaimWebhookDeliveries(ctx, gen.ClaimWebhookDeliveriesParams{
+		LeaseUntil: now.Add(dispatchLease),
+		Now:        now,
+		RowLimit:   dispatchBatch,
+	})
+	if err != nil {
+		s.l.Error("claim webhook deliveries", logger.Field{Key: "error", Value: err.Error()})
+		return 0
+	}
+
+	jobs := make(chan *gen.ClaimWebhookDeliveriesRow)
+	var wg sync.WaitGroup
+	for i := 0; i < dispatchWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for delivery := range jobs {
+				s.deliver(ctx, delivery)
+			}
+		}()
+	}
+	for _, delivery := range deliveries {
+		jobs <- delivery
+	}
+	close(jobs)
+	wg.Wait()
+
+	return len(deliveries)
+}
+
+func (s *Service) deliver(ctx context.Context, delivery *gen.ClaimWebhookDeliveriesRow) {
+	status, err := s.post(ctx, delivery)
+	if err == nil {
+		err = s.db.Queries.MarkWebhookDelivered(ctx, gen.MarkWebhookDeliveredParams{
+			ID:          delivery.ID,
+			LastStatus:  sql.NullInt32{Int32: int32(status), Valid: true},
+			DeliveredAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		})
+		if err != nil {
+			// 표시하지 못하면 lease 가 끝난 뒤 한 번 더 보낸다 (at-least-once)
+			s.l.Error("mark webhook delivered", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "delivery", Value: delivery.ID})
+		}
+		return
+	}
+
+	attempts := int(delivery.Attempts) + 1
+	next := nextStatus(attempts)
+	if next == StatusDead {
+		s.l.Warn("webhook delivery dead", logger.Field{Key: "delivery", Value: delivery.ID}, logger.Field{Key: "subscription", Value: delivery.SubscriptionID}, logger.Field{Key: "error", Value: err.Error()})
+	}
+
+	err = s.db.Queries.MarkWebhookFailed(ctx, gen.MarkWebhookFailedParams{
+		ID:            delivery.ID,
+		Status:        next,
+		NextAttemptAt: time.Now().UTC().Add(backoff(attempts)),
+		LastStatus:    sql.NullInt32{Int32: int32(status), Valid: status != 0},
+		LastError:     sql.NullString{String: err.Error(), Valid: true},
+	})
+	if err != nil {
+		s.l.Error("mark webhook failed", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "delivery", Value: delivery.ID})
+	}
+}
+
+// post 2xx 가 아니면 실패. 응답 코드를 같이 돌려준다 (연결 실패면 0)
+func (s *Service) post(ctx context.Context, delivery *gen.ClaimWebhookDeliveriesRow) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Id", delivery.EventKey)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", "sha256="+Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign hex(HMAC-SHA256(secret, timestamp + "." + body)). 받는 쪽은 같은 값을 계산해 비교하고 timestamp 로 재전송 공격을 막는다
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// nextStatus attempts 번 실패한 뒤의 상태. maxAttempts 번째 실패면 dead
+func nextStatus(attempts int) string {
+	if attempts >= maxAttempts {
+		return StatusDead
+	}
+	return StatusPending
+}
+
+// backoff attempts 번 실패한 뒤 기다리는 시간. 한꺼번에 몰리지 않게 최대 20% 를 더한다
+func backoff(attempts int) time.Duration {
+	delay := maxBackoff
+	if attempts < 20 {
+		delay = min(baseBackoff<<(attempts-1), maxBackoff)
+	}
+	return delay + time.Duration(rand.Int64N(int64(delay)/5+1))
+}
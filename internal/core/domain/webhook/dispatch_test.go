@@ -0,0 +1,53 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+)
+
+// 받는 쪽이 검증에 쓰는 값이라 바뀌면 안 된다
+func TestSign(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{"id":"1:0xabc:0","type":"transfer"}`, "b0d674a993194e264315e74910d9db3de24f544e7c35bb00b2fe706eb89acfef"},
+		{``, "5967f3c560522fa40cf2876ebc3c3a08551dd6959aaade3b413460591895bdcc"},
+	}
+	for _, tt := range tests {
+		if got := Sign("whsec_test", "1700000000", []byte(tt.body)); got != tt.want {
+			t.Errorf("Sign(%q) = %s, want %s", tt.body, got, tt.want)
+		}
+	}
+
+	if Sign("whsec_test", "1700000001", []byte(tests[0].body)) == tests[0].want {
+		t.Error("signature does not depend on the timestamp")
+	}
+}
+
+func TestRetrySchedule(t *testing.T) {
+	want := []time.Duration{
+		time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute, 16 * time.Minute, 32 * time.Minute,
+		64 * time.Minute, 128 * time.Minute, 256 * time.Minute, 6 * time.Hour, 6 * time.Hour,
+	}
+	for i, base := range want {
+		attempts := i + 1
+		if status := nextStatus(attempts); status != StatusPending {
+			t.Errorf("attempt %d: status %s, want %s", attempts, status, StatusPending)
+		}
+		for range 50 {
+			if delay := backoff(attempts); delay < base || delay > base+base/5 {
+				t.Errorf("attempt %d: backoff %s, want %s..%s", attempts, delay, base, base+base/5)
+				break
+			}
+		}
+	}
+
+	if status := nextStatus(12); status != StatusDead {
+		t.Errorf("attempt 12: status %s, want %s", status, StatusDead)
+	}
+	// 아주 큰 값에서도 넘치지 않고 최대값에 머문다
+	if delay := backoff(64); delay < 6*time.Hour || delay > 6*time.Hour+6*time.Hour/5 {
+		t.Errorf("attempt 64: backoff %s", delay)
+	}
+}
@@ -0,0 +1,125 @@
+package webhook
+
+import (
+	"blockchain-tracking/internal/blockchain/evm/evmType"
+	"blockchain-tracking/internal/core/domain/explorer"
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/database/postgresql"
+	"blockchain-tracking/internal/logger"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Event 웹훅 본문. ID 는 같은 전송이면 항상 같아서 받는 쪽에서 중복 제거에 쓴다
+type Event struct {
+	ID          string                  `json:"id"`
+	Type        string                  `json:"type"` // transfer
+	ChainID     int64                   `json:"chainId"`
+	BlockNumber int64                   `json:"blockNumber"`
+	BlockHash   string                  `json:"blockHash"`
+	Timestamp   time.Time               `json:"timestamp"`
+	Transfer    *explorer.TokenTransfer `json:"transfer"`
+}
+
+// Enqueue 블록의 전송 중 구독 필터에 맞는 건을 outbox 에 쌓는다.
+// 블록을 저장하는 트랜잭션 안에서 호출해서 블록이 커밋되면 이벤트도 반드시 남는다
+func (s *Service) Enqueue(ctx context.Context, q *gen.Queries, block *evmType.Block) error {
+	transfers := block.Transfers()
+	if len(transfers) == 0 {
+		return nil
+	}
+
+	chainID := block.ChainID.Int64()
+	subscriptions, err := q.ListActiveWebhookSubscriptions(ctx, sql.NullInt64{Int64: chainID, Valid: true})
+	if err != nil {
+		s.l.Error("list active webhook subscriptions", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "block", Value: block.Hash})
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	for _, t := range transfers {
+		var payload json.RawMessage
+		event := newEvent(block, t)
+
+		for _, subscription := range subscriptions {
+			if !matches(subscription, t) {
+				continue
+			}
+			if payload == nil {
+				if payload, err = json.Marshal(event); err != nil {
+					return err
+				}
+			}
+
+			err = q.InsertWebhookDelivery(ctx, gen.InsertWebhookDeliveryParams{
+				SubscriptionID: subscription.ID,
+				EventKey:       event.ID,
+				Payload:        payload,
+				NextAttemptAt:  now,
+			})
+			if err != nil {
+				s.l.Error("create webhook delivery", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "subscription", Value: subscription.ID}, logger.Field{Key: "transaction", Value: t.TransactionHash})
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func matches(subscription *gen.WebhookSubscription, t *evmType.Transfer) bool {
+	if len(subscription.Kinds) > 0 && !slices.Contains(subscription.Kinds, t.Kind) {
+		return false
+	}
+	if len(subscription.Contracts) > 0 && !containsAddress(subscription.Contracts, t.Contract) {
+		return false
+	}
+	if len(subscription.Addresses) > 0 && !containsAddress(subscription.Addresses, t.From) && !containsAddress(subscription.Addresses, t.To) {
+		return false
+	}
+	return true
+}
+
+func containsAddress(addresses [][]byte, address string) bool {
+	if address == "" {
+		return false
+	}
+	return slices.ContainsFunc(addresses, func(a []byte) bool { return postgresql.BytesToHex(a) == address })
+}
+
+func newEvent(block *evmType.Block, t *evmType.Transfer) *Event {
+	transfer := &explorer.TokenTransfer{
+		Kind:            explorer.Kind(t.Kind),
+		ContractAddress: t.Contract,
+		TransactionHash: t.TransactionHash,
+		LogIndex:        t.LogIndex,
+		BatchIndex:      int32(t.BatchIndex),
+		From:            t.From,
+		To:              t.To,
+		Function:        t.Function,
+		Timestamp:       t.Timestamp,
+	}
+	if t.TokenId != nil {
+		transfer.TokenID = t.TokenId.String()
+	}
+	if t.Amount != nil {
+		transfer.Amount = t.Amount.String()
+	}
+
+	return &Event{
+		ID:          fmt.Sprintf("%d:%s:%d:%d", block.ChainID.Int64(), t.TransactionHash, t.LogIndex, t.BatchIndex),
+		Type:        "transfer",
+		ChainID:     block.ChainID.Int64(),
+		BlockNumber: int64(block.Number),
+		BlockHash:   block.Hash,
+		Timestamp:   block.Timestamp,
+		Transfer:    transfer,
+	}
+}
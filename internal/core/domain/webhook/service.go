@@ -0,0 +1,181 @@
+package webhook
+
+import (
+	"blockchain-tracking/internal/database/gen"
+	"blockchain-tracking/internal/database/postgresql"
+	"blockchain-tracking/internal/logger"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	StatusDead      = "dead"
+)
+
+var kinds = map[string]bool{"coin": true, "erc20": true, "erc721": true, "erc1155": true}
+
+type Service struct {
+	db     *postgresql.Database
+	client *http.Client
+	l      logger.Logger
+}
+
+func NewService(d *postgresql.Database, l logger.Logger) *Service {
+	return &Service{
+		db:     d,
+		client: &http.Client{Timeout: 10 * time.Second},
+		l:      l,
+	}
+}
+
+// Filter 비어 있는 항목은 전체. ChainID 가 0 이면 모든 체인
+type Filter struct {
+	ChainID   int64
+	Kinds     []string
+	Addresses []string
+	Contracts []string
+}
+
+// Subscribe secret 이 빈 값이면 새로 만들어 돌려준다
+func (s *Service) Subscribe(ctx context.Context, endpoint, secret string, filter Filter) (int64, string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return 0, "", fmt.Errorf("invalid webhook url %s", endpoint)
+	}
+	for _, kind := range filter.Kinds {
+		if !kinds[kind] {
+			return 0, "", fmt.Errorf("unknown kind %s, expected coin, erc20, erc721 or erc1155", kind)
+		}
+	}
+	addresses, err := hexAddresses(filter.Addresses)
+	if err != nil {
+		return 0, "", err
+	}
+	contracts, err := hexAddresses(filter.Contracts)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if secret == "" {
+		key := make([]byte, 32)
+		if _, err = rand.Read(key); err != nil {
+			return 0, "", err
+		}
+		secret = hex.EncodeToString(key)
+	}
+
+	id, err := s.db.Queries.CreateWebhookSubscription(ctx, gen.CreateWebhookSubscriptionParams{
+		Url:       endpoint,
+		Secret:    secret,
+		ChainID:   sql.NullInt64{Int64: filter.ChainID, Valid: filter.ChainID != 0},
+		Kinds:     append([]string{}, filter.Kinds...),
+		Addresses: addresses,
+		Contracts: contracts,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		s.l.Error("create webhook subscription", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "url", Value: endpoint})
+		return 0, "", err
+	}
+
+	return id, secret, nil
+}
+
+func (s *Service) List(ctx context.Context) ([]*gen.WebhookSubscription, error) {
+	subscriptions, err := s.db.Queries.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		s.l.Error("list webhook subscriptions", logger.Field{Key: "error", Value: err.Error()})
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// SetActive 멈춘 구독은 새 이벤트를 쌓지 않는다. 이미 쌓인 건은 그대로 보낸다
+func (s *Service) SetActive(ctx context.Context, id int64, active bool) error {
+	affected, err := s.db.Queries.SetWebhookSubscriptionActive(ctx, gen.SetWebhookSubscriptionActiveParams{ID: id, Active: active})
+	if err != nil {
+		s.l.Error("update webhook subscription", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "subscription", Value: id})
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("webhook subscription %d not found", id)
+	}
+
+	return nil
+}
+
+func (s *Service) Delete(ctx context.Context, id int64) error {
+	affected, err := s.db.Queries.DeleteWebhookSubscription(ctx, id)
+	if err != nil {
+		s.l.Error("delete webhook subscription", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "subscription", Value: id})
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("webhook subscription %d not found", id)
+	}
+
+	return nil
+}
+
+// Deliveries subscriptionID 가 0, status 가 빈 값이면 전체 (최신순)
+func (s *Service) Deliveries(ctx context.Context, subscriptionID int64, status string, limit int32) ([]*gen.ListWebhookDeliveriesRow, error) {
+	deliveries, err := s.db.Queries.ListWebhookDeliveries(ctx, gen.ListWebhookDeliveriesParams{
+		SubscriptionID: sql.NullInt64{Int64: subscriptionID, Valid: subscriptionID != 0},
+		Status:         sql.NullString{String: status, Valid: status != ""},
+		RowLimit:       limit,
+	})
+	if err != nil {
+		s.l.Error("list webhook deliveries", logger.Field{Key: "error", Value: err.Error()})
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// Replay 전송 한 건을 처음부터 다시 보낸다 (dead, delivered 모두)
+func (s *Service) Replay(ctx context.Context, deliveryID int64) error {
+	affected, err := s.db.Queries.ReplayWebhookDelivery(ctx, gen.ReplayWebhookDeliveryParams{ID: deliveryID, NextAttemptAt: time.Now().UTC()})
+	if err != nil {
+		s.l.Error("replay webhook delivery", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "delivery", Value: deliveryID})
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("webhook delivery %d not found", deliveryID)
+	}
+
+	return nil
+}
+
+// ReplayDead 구독의 dead 건을 모두 다시 보낸다
+func (s *Service) ReplayDead(ctx context.Context, subscriptionID int64) (int64, error) {
+	affected, err := s.db.Queries.ReplayDeadWebhookDeliveries(ctx, gen.ReplayDeadWebhookDeliveriesParams{SubscriptionID: subscriptionID, NextAttemptAt: time.Now().UTC()})
+	if err != nil {
+		s.l.Error("replay dead webhook deliveries", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "subscription", Value: subscriptionID})
+		return 0, err
+	}
+
+	return affected, nil
+}
+
+func hexAddresses(values []string) ([][]byte, error) {
+	addresses := [][]byte{}
+	for _, value := range values {
+		if !common.IsHexAddress(value) {
+			return nil, fmt.Errorf("invalid address %s", value)
+		}
+		addresses = append(addresses, postgresql.HexToBytes(strings.ToLower(value)))
+	}
+	return addresses, nil
+}
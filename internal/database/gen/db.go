@@ -24,15 +24,159 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.addWatchlistAddressStmt, err = db.PrepareContext(ctx, addWatchlistAddress); err != nil {
+		return nil, fmt.Errorf("error preparing query AddWatchlistAddress: %w", err)
+	}
+	if q.claimWebhookDeliveriesStmt, err = db.PrepareContext(ctx, claimWebhookDeliveries); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimWebhookDeliveries: %w", err)
+	}
+	if q.countAnomaliesSinceStmt, err = db.PrepareContext(ctx, countAnomaliesSince); err != nil {
+		return nil, fmt.Errorf("error preparing query CountAnomaliesSince: %w", err)
+	}
+	if q.createAlertRuleStmt, err = db.PrepareContext(ctx, createAlertRule); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateAlertRule: %w", err)
+	}
 	if q.createErc1155Stmt, err = db.PrepareContext(ctx, createErc1155); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateErc1155: %w", err)
 	}
 	if q.createErc721Stmt, err = db.PrepareContext(ctx, createErc721); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateErc721: %w", err)
 	}
+	if q.createReconcileRunStmt, err = db.PrepareContext(ctx, createReconcileRun); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateReconcileRun: %w", err)
+	}
+	if q.createWatchlistStmt, err = db.PrepareContext(ctx, createWatchlist); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWatchlist: %w", err)
+	}
+	if q.createWebhookSubscriptionStmt, err = db.PrepareContext(ctx, createWebhookSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWebhookSubscription: %w", err)
+	}
+	if q.deleteAlertRuleStmt, err = db.PrepareContext(ctx, deleteAlertRule); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteAlertRule: %w", err)
+	}
+	if q.deleteAnomaliesStmt, err = db.PrepareContext(ctx, deleteAnomalies); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteAnomalies: %w", err)
+	}
+	if q.deleteBalanceChangesStmt, err = db.PrepareContext(ctx, deleteBalanceChanges); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteBalanceChanges: %w", err)
+	}
+	if q.deleteBlocksStmt, err = db.PrepareContext(ctx, deleteBlocks); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteBlocks: %w", err)
+	}
+	if q.deleteCoinLogsStmt, err = db.PrepareContext(ctx, deleteCoinLogs); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCoinLogs: %w", err)
+	}
+	if q.deleteERC1155LogsStmt, err = db.PrepareContext(ctx, deleteERC1155Logs); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteERC1155Logs: %w", err)
+	}
+	if q.deleteERC20LogsStmt, err = db.PrepareContext(ctx, deleteERC20Logs); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteERC20Logs: %w", err)
+	}
+	if q.deleteERC721LogsStmt, err = db.PrepareContext(ctx, deleteERC721Logs); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteERC721Logs: %w", err)
+	}
+	if q.deleteLogsStmt, err = db.PrepareContext(ctx, deleteLogs); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteLogs: %w", err)
+	}
+	if q.deletePendingWatchAlertsStmt, err = db.PrepareContext(ctx, deletePendingWatchAlerts); err != nil {
+		return nil, fmt.Errorf("error preparing query DeletePendingWatchAlerts: %w", err)
+	}
+	if q.deletePendingWebhookDeliveriesStmt, err = db.PrepareContext(ctx, deletePendingWebhookDeliveries); err != nil {
+		return nil, fmt.Errorf("error preparing query DeletePendingWebhookDeliveries: %w", err)
+	}
+	if q.deleteTransactionInputsStmt, err = db.PrepareContext(ctx, deleteTransactionInputs); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteTransactionInputs: %w", err)
+	}
+	if q.deleteTransactionsStmt, err = db.PrepareContext(ctx, deleteTransactions); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteTransactions: %w", err)
+	}
+	if q.deleteUnownedERC721BalancesStmt, err = db.PrepareContext(ctx, deleteUnownedERC721Balances); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteUnownedERC721Balances: %w", err)
+	}
+	if q.deleteWatchlistStmt, err = db.PrepareContext(ctx, deleteWatchlist); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteWatchlist: %w", err)
+	}
+	if q.deleteWebhookSubscriptionStmt, err = db.PrepareContext(ctx, deleteWebhookSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteWebhookSubscription: %w", err)
+	}
+	if q.ensureBlockPartitionStmt, err = db.PrepareContext(ctx, ensureBlockPartition); err != nil {
+		return nil, fmt.Errorf("error preparing query EnsureBlockPartition: %w", err)
+	}
+	if q.ensureMonthPartitionStmt, err = db.PrepareContext(ctx, ensureMonthPartition); err != nil {
+		return nil, fmt.Errorf("error preparing query EnsureMonthPartition: %w", err)
+	}
+	if q.finishReconcileRunStmt, err = db.PrepareContext(ctx, finishReconcileRun); err != nil {
+		return nil, fmt.Errorf("error preparing query FinishReconcileRun: %w", err)
+	}
+	if q.getBalanceDriftSummaryStmt, err = db.PrepareContext(ctx, getBalanceDriftSummary); err != nil {
+		return nil, fmt.Errorf("error preparing query GetBalanceDriftSummary: %w", err)
+	}
+	if q.getBlockByHashStmt, err = db.PrepareContext(ctx, getBlockByHash); err != nil {
+		return nil, fmt.Errorf("error preparing query GetBlockByHash: %w", err)
+	}
+	if q.getBlockByNumberStmt, err = db.PrepareContext(ctx, getBlockByNumber); err != nil {
+		return nil, fmt.Errorf("error preparing query GetBlockByNumber: %w", err)
+	}
 	if q.getBlockHeightStmt, err = db.PrepareContext(ctx, getBlockHeight); err != nil {
 		return nil, fmt.Errorf("error preparing query GetBlockHeight: %w", err)
 	}
+	if q.getBlockNumberAtTimestampStmt, err = db.PrepareContext(ctx, getBlockNumberAtTimestamp); err != nil {
+		return nil, fmt.Errorf("error preparing query GetBlockNumberAtTimestamp: %w", err)
+	}
+	if q.getBlockRangeTimestampsStmt, err = db.PrepareContext(ctx, getBlockRangeTimestamps); err != nil {
+		return nil, fmt.Errorf("error preparing query GetBlockRangeTimestamps: %w", err)
+	}
+	if q.getContractStmt, err = db.PrepareContext(ctx, getContract); err != nil {
+		return nil, fmt.Errorf("error preparing query GetContract: %w", err)
+	}
+	if q.getERC1155AmountStmt, err = db.PrepareContext(ctx, getERC1155Amount); err != nil {
+		return nil, fmt.Errorf("error preparing query GetERC1155Amount: %w", err)
+	}
+	if q.getERC1155BalanceAtStmt, err = db.PrepareContext(ctx, getERC1155BalanceAt); err != nil {
+		return nil, fmt.Errorf("error preparing query GetERC1155BalanceAt: %w", err)
+	}
+	if q.getERC20BalanceAtStmt, err = db.PrepareContext(ctx, getERC20BalanceAt); err != nil {
+		return nil, fmt.Errorf("error preparing query GetERC20BalanceAt: %w", err)
+	}
+	if q.getERC721OwnerAtStmt, err = db.PrepareContext(ctx, getERC721OwnerAt); err != nil {
+		return nil, fmt.Errorf("error preparing query GetERC721OwnerAt: %w", err)
+	}
+	if q.getERC721TokenOwnerStmt, err = db.PrepareContext(ctx, getERC721TokenOwner); err != nil {
+		return nil, fmt.Errorf("error preparing query GetERC721TokenOwner: %w", err)
+	}
+	if q.getNativeBalanceAtStmt, err = db.PrepareContext(ctx, getNativeBalanceAt); err != nil {
+		return nil, fmt.Errorf("error preparing query GetNativeBalanceAt: %w", err)
+	}
+	if q.getPartitionPolicyStmt, err = db.PrepareContext(ctx, getPartitionPolicy); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPartitionPolicy: %w", err)
+	}
+	if q.getPartitionRangeStmt, err = db.PrepareContext(ctx, getPartitionRange); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPartitionRange: %w", err)
+	}
+	if q.getTransactionByHashStmt, err = db.PrepareContext(ctx, getTransactionByHash); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransactionByHash: %w", err)
+	}
+	if q.getTransactionInputStmt, err = db.PrepareContext(ctx, getTransactionInput); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransactionInput: %w", err)
+	}
+	if q.getWalletStmt, err = db.PrepareContext(ctx, getWallet); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWallet: %w", err)
+	}
+	if q.getWatchlistByNameStmt, err = db.PrepareContext(ctx, getWatchlistByName); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWatchlistByName: %w", err)
+	}
+	if q.insertAlertRuleEventStmt, err = db.PrepareContext(ctx, insertAlertRuleEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertAlertRuleEvent: %w", err)
+	}
+	if q.insertAnomalyStmt, err = db.PrepareContext(ctx, insertAnomaly); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertAnomaly: %w", err)
+	}
+	if q.insertBalanceChangeStmt, err = db.PrepareContext(ctx, insertBalanceChange); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertBalanceChange: %w", err)
+	}
+	if q.insertBalanceDriftStmt, err = db.PrepareContext(ctx, insertBalanceDrift); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertBalanceDrift: %w", err)
+	}
 	if q.insertBlockStmt, err = db.PrepareContext(ctx, insertBlock); err != nil {
 		return nil, fmt.Errorf("error preparing query InsertBlock: %w", err)
 	}
@@ -57,15 +201,273 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.insertTransactionStmt, err = db.PrepareContext(ctx, insertTransaction); err != nil {
 		return nil, fmt.Errorf("error preparing query InsertTransaction: %w", err)
 	}
+	if q.insertTransactionInputStmt, err = db.PrepareContext(ctx, insertTransactionInput); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertTransactionInput: %w", err)
+	}
 	if q.insertWalletStmt, err = db.PrepareContext(ctx, insertWallet); err != nil {
 		return nil, fmt.Errorf("error preparing query InsertWallet: %w", err)
 	}
+	if q.insertWatchAlertStmt, err = db.PrepareContext(ctx, insertWatchAlert); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertWatchAlert: %w", err)
+	}
+	if q.insertWebhookDeliveryStmt, err = db.PrepareContext(ctx, insertWebhookDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertWebhookDelivery: %w", err)
+	}
+	if q.listActiveWebhookSubscriptionsStmt, err = db.PrepareContext(ctx, listActiveWebhookSubscriptions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListActiveWebhookSubscriptions: %w", err)
+	}
+	if q.listAddressERC1155HoldingsStmt, err = db.PrepareContext(ctx, listAddressERC1155Holdings); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAddressERC1155Holdings: %w", err)
+	}
+	if q.listAddressERC1155TransfersStmt, err = db.PrepareContext(ctx, listAddressERC1155Transfers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAddressERC1155Transfers: %w", err)
+	}
+	if q.listAddressERC20HoldingsStmt, err = db.PrepareContext(ctx, listAddressERC20Holdings); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAddressERC20Holdings: %w", err)
+	}
+	if q.listAddressERC20TransfersStmt, err = db.PrepareContext(ctx, listAddressERC20Transfers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAddressERC20Transfers: %w", err)
+	}
+	if q.listAddressERC721HoldingsStmt, err = db.PrepareContext(ctx, listAddressERC721Holdings); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAddressERC721Holdings: %w", err)
+	}
+	if q.listAddressERC721TransfersStmt, err = db.PrepareContext(ctx, listAddressERC721Transfers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAddressERC721Transfers: %w", err)
+	}
+	if q.listAddressLogsStmt, err = db.PrepareContext(ctx, listAddressLogs); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAddressLogs: %w", err)
+	}
+	if q.listAddressTopicLogsStmt, err = db.PrepareContext(ctx, listAddressTopicLogs); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAddressTopicLogs: %w", err)
+	}
+	if q.listAddressTransactionsStmt, err = db.PrepareContext(ctx, listAddressTransactions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAddressTransactions: %w", err)
+	}
+	if q.listAlertRuleEventsStmt, err = db.PrepareContext(ctx, listAlertRuleEvents); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAlertRuleEvents: %w", err)
+	}
+	if q.listAlertRulesStmt, err = db.PrepareContext(ctx, listAlertRules); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAlertRules: %w", err)
+	}
+	if q.listAnomaliesStmt, err = db.PrepareContext(ctx, listAnomalies); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAnomalies: %w", err)
+	}
+	if q.listBalanceChangesAfterStmt, err = db.PrepareContext(ctx, listBalanceChangesAfter); err != nil {
+		return nil, fmt.Errorf("error preparing query ListBalanceChangesAfter: %w", err)
+	}
+	if q.listBlockTransactionsStmt, err = db.PrepareContext(ctx, listBlockTransactions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListBlockTransactions: %w", err)
+	}
+	if q.listBlocksByNumberStmt, err = db.PrepareContext(ctx, listBlocksByNumber); err != nil {
+		return nil, fmt.Errorf("error preparing query ListBlocksByNumber: %w", err)
+	}
+	if q.listBlocksFromStmt, err = db.PrepareContext(ctx, listBlocksFrom); err != nil {
+		return nil, fmt.Errorf("error preparing query ListBlocksFrom: %w", err)
+	}
+	if q.listChangedERC721TokensStmt, err = db.PrepareContext(ctx, listChangedERC721Tokens); err != nil {
+		return nil, fmt.Errorf("error preparing query ListChangedERC721Tokens: %w", err)
+	}
+	if q.listCoinLogsByTransactionsStmt, err = db.PrepareContext(ctx, listCoinLogsByTransactions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCoinLogsByTransactions: %w", err)
+	}
+	if q.listContractERC1155TransfersStmt, err = db.PrepareContext(ctx, listContractERC1155Transfers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListContractERC1155Transfers: %w", err)
+	}
+	if q.listContractERC20TransfersStmt, err = db.PrepareContext(ctx, listContractERC20Transfers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListContractERC20Transfers: %w", err)
+	}
+	if q.listContractERC721TransfersStmt, err = db.PrepareContext(ctx, listContractERC721Transfers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListContractERC721Transfers: %w", err)
+	}
+	if q.listContractsByHashStmt, err = db.PrepareContext(ctx, listContractsByHash); err != nil {
+		return nil, fmt.Errorf("error preparing query ListContractsByHash: %w", err)
+	}
+	if q.listERC1155BalancesStmt, err = db.PrepareContext(ctx, listERC1155Balances); err != nil {
+		return nil, fmt.Errorf("error preparing query ListERC1155Balances: %w", err)
+	}
+	if q.listERC1155HoldersStmt, err = db.PrepareContext(ctx, listERC1155Holders); err != nil {
+		return nil, fmt.Errorf("error preparing query ListERC1155Holders: %w", err)
+	}
+	if q.listERC1155LogsByTransactionsStmt, err = db.PrepareContext(ctx, listERC1155LogsByTransactions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListERC1155LogsByTransactions: %w", err)
+	}
+	if q.listERC1155TokenHoldersStmt, err = db.PrepareContext(ctx, listERC1155TokenHolders); err != nil {
+		return nil, fmt.Errorf("error preparing query ListERC1155TokenHolders: %w", err)
+	}
+	if q.listERC1155TokensByIDStmt, err = db.PrepareContext(ctx, listERC1155TokensByID); err != nil {
+		return nil, fmt.Errorf("error preparing query ListERC1155TokensByID: %w", err)
+	}
+	if q.listERC20BalancesStmt, err = db.PrepareContext(ctx, listERC20Balances); err != nil {
+		return nil, fmt.Errorf("error preparing query ListERC20Balances: %w", err)
+	}
+	if q.listERC20HoldersStmt, err = db.PrepareContext(ctx, listERC20Holders); err != nil {
+		return nil, fmt.Errorf("error preparing query ListERC20Holders: %w", err)
+	}
+	if q.listERC20LogsByTransactionsStmt, err = db.PrepareContext(ctx, listERC20LogsByTransactions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListERC20LogsByTransactions: %w", err)
+	}
+	if q.listERC721BalancesStmt, err = db.PrepareContext(ctx, listERC721Balances); err != nil {
+		return nil, fmt.Errorf("error preparing query ListERC721Balances: %w", err)
+	}
+	if q.listERC721HoldersStmt, err = db.PrepareContext(ctx, listERC721Holders); err != nil {
+		return nil, fmt.Errorf("error preparing query ListERC721Holders: %w", err)
+	}
+	if q.listERC721LogsByTransactionsStmt, err = db.PrepareContext(ctx, listERC721LogsByTransactions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListERC721LogsByTransactions: %w", err)
+	}
+	if q.listERC721OwnersAtStmt, err = db.PrepareContext(ctx, listERC721OwnersAt); err != nil {
+		return nil, fmt.Errorf("error preparing query ListERC721OwnersAt: %w", err)
+	}
+	if q.listERC721TokensByIDStmt, err = db.PrepareContext(ctx, listERC721TokensByID); err != nil {
+		return nil, fmt.Errorf("error preparing query ListERC721TokensByID: %w", err)
+	}
+	if q.listExistingERC1155BalancesStmt, err = db.PrepareContext(ctx, listExistingERC1155Balances); err != nil {
+		return nil, fmt.Errorf("error preparing query ListExistingERC1155Balances: %w", err)
+	}
+	if q.listExistingERC20BalancesStmt, err = db.PrepareContext(ctx, listExistingERC20Balances); err != nil {
+		return nil, fmt.Errorf("error preparing query ListExistingERC20Balances: %w", err)
+	}
+	if q.listExistingERC721TokensStmt, err = db.PrepareContext(ctx, listExistingERC721Tokens); err != nil {
+		return nil, fmt.Errorf("error preparing query ListExistingERC721Tokens: %w", err)
+	}
+	if q.listExistingWalletsStmt, err = db.PrepareContext(ctx, listExistingWallets); err != nil {
+		return nil, fmt.Errorf("error preparing query ListExistingWallets: %w", err)
+	}
+	if q.listHoldingsAtStmt, err = db.PrepareContext(ctx, listHoldingsAt); err != nil {
+		return nil, fmt.Errorf("error preparing query ListHoldingsAt: %w", err)
+	}
+	if q.listLogsByTransactionsStmt, err = db.PrepareContext(ctx, listLogsByTransactions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListLogsByTransactions: %w", err)
+	}
+	if q.listPartitionPoliciesStmt, err = db.PrepareContext(ctx, listPartitionPolicies); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPartitionPolicies: %w", err)
+	}
+	if q.listPartitionRangesStmt, err = db.PrepareContext(ctx, listPartitionRanges); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPartitionRanges: %w", err)
+	}
+	if q.listPendingWatchAlertsStmt, err = db.PrepareContext(ctx, listPendingWatchAlerts); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPendingWatchAlerts: %w", err)
+	}
+	if q.listTokenHoldersAtStmt, err = db.PrepareContext(ctx, listTokenHoldersAt); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTokenHoldersAt: %w", err)
+	}
+	if q.listTransactionERC1155TransfersStmt, err = db.PrepareContext(ctx, listTransactionERC1155Transfers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTransactionERC1155Transfers: %w", err)
+	}
+	if q.listTransactionERC20TransfersStmt, err = db.PrepareContext(ctx, listTransactionERC20Transfers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTransactionERC20Transfers: %w", err)
+	}
+	if q.listTransactionERC721TransfersStmt, err = db.PrepareContext(ctx, listTransactionERC721Transfers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTransactionERC721Transfers: %w", err)
+	}
+	if q.listTransactionInputsStmt, err = db.PrepareContext(ctx, listTransactionInputs); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTransactionInputs: %w", err)
+	}
+	if q.listTransactionLogsStmt, err = db.PrepareContext(ctx, listTransactionLogs); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTransactionLogs: %w", err)
+	}
+	if q.listUnseededCollectionsStmt, err = db.PrepareContext(ctx, listUnseededCollections); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUnseededCollections: %w", err)
+	}
+	if q.listWalletsStmt, err = db.PrepareContext(ctx, listWallets); err != nil {
+		return nil, fmt.Errorf("error preparing query ListWallets: %w", err)
+	}
+	if q.listWatchAlertsStmt, err = db.PrepareContext(ctx, listWatchAlerts); err != nil {
+		return nil, fmt.Errorf("error preparing query ListWatchAlerts: %w", err)
+	}
+	if q.listWatchlistAddressesStmt, err = db.PrepareContext(ctx, listWatchlistAddresses); err != nil {
+		return nil, fmt.Errorf("error preparing query ListWatchlistAddresses: %w", err)
+	}
+	if q.listWatchlistsStmt, err = db.PrepareContext(ctx, listWatchlists); err != nil {
+		return nil, fmt.Errorf("error preparing query ListWatchlists: %w", err)
+	}
+	if q.listWebhookDeliveriesStmt, err = db.PrepareContext(ctx, listWebhookDeliveries); err != nil {
+		return nil, fmt.Errorf("error preparing query ListWebhookDeliveries: %w", err)
+	}
+	if q.listWebhookSubscriptionsStmt, err = db.PrepareContext(ctx, listWebhookSubscriptions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListWebhookSubscriptions: %w", err)
+	}
+	if q.markWatchAlertsDeliveredStmt, err = db.PrepareContext(ctx, markWatchAlertsDelivered); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkWatchAlertsDelivered: %w", err)
+	}
+	if q.markWebhookDeliveredStmt, err = db.PrepareContext(ctx, markWebhookDelivered); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkWebhookDelivered: %w", err)
+	}
+	if q.markWebhookFailedStmt, err = db.PrepareContext(ctx, markWebhookFailed); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkWebhookFailed: %w", err)
+	}
+	if q.matchWatchlistAddressesStmt, err = db.PrepareContext(ctx, matchWatchlistAddresses); err != nil {
+		return nil, fmt.Errorf("error preparing query MatchWatchlistAddresses: %w", err)
+	}
+	if q.pruneRawDataStmt, err = db.PrepareContext(ctx, pruneRawData); err != nil {
+		return nil, fmt.Errorf("error preparing query PruneRawData: %w", err)
+	}
+	if q.removeWatchlistAddressStmt, err = db.PrepareContext(ctx, removeWatchlistAddress); err != nil {
+		return nil, fmt.Errorf("error preparing query RemoveWatchlistAddress: %w", err)
+	}
+	if q.repairERC721OwnerStmt, err = db.PrepareContext(ctx, repairERC721Owner); err != nil {
+		return nil, fmt.Errorf("error preparing query RepairERC721Owner: %w", err)
+	}
+	if q.replayDeadWebhookDeliveriesStmt, err = db.PrepareContext(ctx, replayDeadWebhookDeliveries); err != nil {
+		return nil, fmt.Errorf("error preparing query ReplayDeadWebhookDeliveries: %w", err)
+	}
+	if q.replayWebhookDeliveryStmt, err = db.PrepareContext(ctx, replayWebhookDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query ReplayWebhookDelivery: %w", err)
+	}
+	if q.resolveAnomalyStmt, err = db.PrepareContext(ctx, resolveAnomaly); err != nil {
+		return nil, fmt.Errorf("error preparing query ResolveAnomaly: %w", err)
+	}
+	if q.revertERC1155BalancesStmt, err = db.PrepareContext(ctx, revertERC1155Balances); err != nil {
+		return nil, fmt.Errorf("error preparing query RevertERC1155Balances: %w", err)
+	}
+	if q.revertERC20BalancesStmt, err = db.PrepareContext(ctx, revertERC20Balances); err != nil {
+		return nil, fmt.Errorf("error preparing query RevertERC20Balances: %w", err)
+	}
+	if q.revertWalletBalancesStmt, err = db.PrepareContext(ctx, revertWalletBalances); err != nil {
+		return nil, fmt.Errorf("error preparing query RevertWalletBalances: %w", err)
+	}
+	if q.sampleERC1155BalancesStmt, err = db.PrepareContext(ctx, sampleERC1155Balances); err != nil {
+		return nil, fmt.Errorf("error preparing query SampleERC1155Balances: %w", err)
+	}
+	if q.sampleERC20BalancesStmt, err = db.PrepareContext(ctx, sampleERC20Balances); err != nil {
+		return nil, fmt.Errorf("error preparing query SampleERC20Balances: %w", err)
+	}
+	if q.sampleERC721BalancesStmt, err = db.PrepareContext(ctx, sampleERC721Balances); err != nil {
+		return nil, fmt.Errorf("error preparing query SampleERC721Balances: %w", err)
+	}
+	if q.sampleWalletsStmt, err = db.PrepareContext(ctx, sampleWallets); err != nil {
+		return nil, fmt.Errorf("error preparing query SampleWallets: %w", err)
+	}
+	if q.seedERC1155BalanceStmt, err = db.PrepareContext(ctx, seedERC1155Balance); err != nil {
+		return nil, fmt.Errorf("error preparing query SeedERC1155Balance: %w", err)
+	}
+	if q.seedERC20BalanceStmt, err = db.PrepareContext(ctx, seedERC20Balance); err != nil {
+		return nil, fmt.Errorf("error preparing query SeedERC20Balance: %w", err)
+	}
+	if q.seedERC721BalanceStmt, err = db.PrepareContext(ctx, seedERC721Balance); err != nil {
+		return nil, fmt.Errorf("error preparing query SeedERC721Balance: %w", err)
+	}
+	if q.seedWalletStmt, err = db.PrepareContext(ctx, seedWallet); err != nil {
+		return nil, fmt.Errorf("error preparing query SeedWallet: %w", err)
+	}
+	if q.setAlertRuleEnabledStmt, err = db.PrepareContext(ctx, setAlertRuleEnabled); err != nil {
+		return nil, fmt.Errorf("error preparing query SetAlertRuleEnabled: %w", err)
+	}
+	if q.setWebhookSubscriptionActiveStmt, err = db.PrepareContext(ctx, setWebhookSubscriptionActive); err != nil {
+		return nil, fmt.Errorf("error preparing query SetWebhookSubscriptionActive: %w", err)
+	}
 	if q.subtractERC1155BalanceStmt, err = db.PrepareContext(ctx, subtractERC1155Balance); err != nil {
 		return nil, fmt.Errorf("error preparing query SubtractERC1155Balance: %w", err)
 	}
+	if q.syncERC721OwnersStmt, err = db.PrepareContext(ctx, syncERC721Owners); err != nil {
+		return nil, fmt.Errorf("error preparing query SyncERC721Owners: %w", err)
+	}
 	if q.updateContractTypeStmt, err = db.PrepareContext(ctx, updateContractType); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateContractType: %w", err)
 	}
+	if q.updateWatchlistEmailStmt, err = db.PrepareContext(ctx, updateWatchlistEmail); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateWatchlistEmail: %w", err)
+	}
 	if q.upsertERC1155Balance_AddStmt, err = db.PrepareContext(ctx, upsertERC1155Balance_Add); err != nil {
 		return nil, fmt.Errorf("error preparing query UpsertERC1155Balance_Add: %w", err)
 	}
@@ -75,6 +477,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.upsertERC721BalanceStmt, err = db.PrepareContext(ctx, upsertERC721Balance); err != nil {
 		return nil, fmt.Errorf("error preparing query UpsertERC721Balance: %w", err)
 	}
+	if q.upsertPartitionPolicyStmt, err = db.PrepareContext(ctx, upsertPartitionPolicy); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertPartitionPolicy: %w", err)
+	}
 	if q.upsertWalletBalanceStmt, err = db.PrepareContext(ctx, upsertWalletBalance); err != nil {
 		return nil, fmt.Errorf("error preparing query UpsertWalletBalance: %w", err)
 	}
@@ -83,6 +488,26 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 
 func (q *Queries) Close() error {
 	var err error
+	if q.addWatchlistAddressStmt != nil {
+		if cerr := q.addWatchlistAddressStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addWatchlistAddressStmt: %w", cerr)
+		}
+	}
+	if q.claimWebhookDeliveriesStmt != nil {
+		if cerr := q.claimWebhookDeliveriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimWebhookDeliveriesStmt: %w", cerr)
+		}
+	}
+	if q.countAnomaliesSinceStmt != nil {
+		if cerr := q.countAnomaliesSinceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countAnomaliesSinceStmt: %w", cerr)
+		}
+	}
+	if q.createAlertRuleStmt != nil {
+		if cerr := q.createAlertRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createAlertRuleStmt: %w", cerr)
+		}
+	}
 	if q.createErc1155Stmt != nil {
 		if cerr := q.createErc1155Stmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createErc1155Stmt: %w", cerr)
@@ -93,11 +518,231 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createErc721Stmt: %w", cerr)
 		}
 	}
+	if q.createReconcileRunStmt != nil {
+		if cerr := q.createReconcileRunStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createReconcileRunStmt: %w", cerr)
+		}
+	}
+	if q.createWatchlistStmt != nil {
+		if cerr := q.createWatchlistStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWatchlistStmt: %w", cerr)
+		}
+	}
+	if q.createWebhookSubscriptionStmt != nil {
+		if cerr := q.createWebhookSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWebhookSubscriptionStmt: %w", cerr)
+		}
+	}
+	if q.deleteAlertRuleStmt != nil {
+		if cerr := q.deleteAlertRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteAlertRuleStmt: %w", cerr)
+		}
+	}
+	if q.deleteAnomaliesStmt != nil {
+		if cerr := q.deleteAnomaliesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteAnomaliesStmt: %w", cerr)
+		}
+	}
+	if q.deleteBalanceChangesStmt != nil {
+		if cerr := q.deleteBalanceChangesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteBalanceChangesStmt: %w", cerr)
+		}
+	}
+	if q.deleteBlocksStmt != nil {
+		if cerr := q.deleteBlocksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteBlocksStmt: %w", cerr)
+		}
+	}
+	if q.deleteCoinLogsStmt != nil {
+		if cerr := q.deleteCoinLogsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCoinLogsStmt: %w", cerr)
+		}
+	}
+	if q.deleteERC1155LogsStmt != nil {
+		if cerr := q.deleteERC1155LogsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteERC1155LogsStmt: %w", cerr)
+		}
+	}
+	if q.deleteERC20LogsStmt != nil {
+		if cerr := q.deleteERC20LogsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteERC20LogsStmt: %w", cerr)
+		}
+	}
+	if q.deleteERC721LogsStmt != nil {
+		if cerr := q.deleteERC721LogsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteERC721LogsStmt: %w", cerr)
+		}
+	}
+	if q.deleteLogsStmt != nil {
+		if cerr := q.deleteLogsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteLogsStmt: %w", cerr)
+		}
+	}
+	if q.deletePendingWatchAlertsStmt != nil {
+		if cerr := q.deletePendingWatchAlertsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deletePendingWatchAlertsStmt: %w", cerr)
+		}
+	}
+	if q.deletePendingWebhookDeliveriesStmt != nil {
+		if cerr := q.deletePendingWebhookDeliveriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deletePendingWebhookDeliveriesStmt: %w", cerr)
+		}
+	}
+	if q.deleteTransactionInputsStmt != nil {
+		if cerr := q.deleteTransactionInputsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteTransactionInputsStmt: %w", cerr)
+		}
+	}
+	if q.deleteTransactionsStmt != nil {
+		if cerr := q.deleteTransactionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteTransactionsStmt: %w", cerr)
+		}
+	}
+	if q.deleteUnownedERC721BalancesStmt != nil {
+		if cerr := q.deleteUnownedERC721BalancesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteUnownedERC721BalancesStmt: %w", cerr)
+		}
+	}
+	if q.deleteWatchlistStmt != nil {
+		if cerr := q.deleteWatchlistStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteWatchlistStmt: %w", cerr)
+		}
+	}
+	if q.deleteWebhookSubscriptionStmt != nil {
+		if cerr := q.deleteWebhookSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteWebhookSubscriptionStmt: %w", cerr)
+		}
+	}
+	if q.ensureBlockPartitionStmt != nil {
+		if cerr := q.ensureBlockPartitionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing ensureBlockPartitionStmt: %w", cerr)
+		}
+	}
+	if q.ensureMonthPartitionStmt != nil {
+		if cerr := q.ensureMonthPartitionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing ensureMonthPartitionStmt: %w", cerr)
+		}
+	}
+	if q.finishReconcileRunStmt != nil {
+		if cerr := q.finishReconcileRunStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing finishReconcileRunStmt: %w", cerr)
+		}
+	}
+	if q.getBalanceDriftSummaryStmt != nil {
+		if cerr := q.getBalanceDriftSummaryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getBalanceDriftSummaryStmt: %w", cerr)
+		}
+	}
+	if q.getBlockByHashStmt != nil {
+		if cerr := q.getBlockByHashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getBlockByHashStmt: %w", cerr)
+		}
+	}
+	if q.getBlockByNumberStmt != nil {
+		if cerr := q.getBlockByNumberStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getBlockByNumberStmt: %w", cerr)
+		}
+	}
 	if q.getBlockHeightStmt != nil {
 		if cerr := q.getBlockHeightStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getBlockHeightStmt: %w", cerr)
 		}
 	}
+	if q.getBlockNumberAtTimestampStmt != nil {
+		if cerr := q.getBlockNumberAtTimestampStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getBlockNumberAtTimestampStmt: %w", cerr)
+		}
+	}
+	if q.getBlockRangeTimestampsStmt != nil {
+		if cerr := q.getBlockRangeTimestampsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getBlockRangeTimestampsStmt: %w", cerr)
+		}
+	}
+	if q.getContractStmt != nil {
+		if cerr := q.getContractStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getContractStmt: %w", cerr)
+		}
+	}
+	if q.getERC1155AmountStmt != nil {
+		if cerr := q.getERC1155AmountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getERC1155AmountStmt: %w", cerr)
+		}
+	}
+	if q.getERC1155BalanceAtStmt != nil {
+		if cerr := q.getERC1155BalanceAtStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getERC1155BalanceAtStmt: %w", cerr)
+		}
+	}
+	if q.getERC20BalanceAtStmt != nil {
+		if cerr := q.getERC20BalanceAtStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getERC20BalanceAtStmt: %w", cerr)
+		}
+	}
+	if q.getERC721OwnerAtStmt != nil {
+		if cerr := q.getERC721OwnerAtStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getERC721OwnerAtStmt: %w", cerr)
+		}
+	}
+	if q.getERC721TokenOwnerStmt != nil {
+		if cerr := q.getERC721TokenOwnerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getERC721TokenOwnerStmt: %w", cerr)
+		}
+	}
+	if q.getNativeBalanceAtStmt != nil {
+		if cerr := q.getNativeBalanceAtStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getNativeBalanceAtStmt: %w", cerr)
+		}
+	}
+	if q.getPartitionPolicyStmt != nil {
+		if cerr := q.getPartitionPolicyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPartitionPolicyStmt: %w", cerr)
+		}
+	}
+	if q.getPartitionRangeStmt != nil {
+		if cerr := q.getPartitionRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPartitionRangeStmt: %w", cerr)
+		}
+	}
+	if q.getTransactionByHashStmt != nil {
+		if cerr := q.getTransactionByHashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransactionByHashStmt: %w", cerr)
+		}
+	}
+	if q.getTransactionInputStmt != nil {
+		if cerr := q.getTransactionInputStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransactionInputStmt: %w", cerr)
+		}
+	}
+	if q.getWalletStmt != nil {
+		if cerr := q.getWalletStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWalletStmt: %w", cerr)
+		}
+	}
+	if q.getWatchlistByNameStmt != nil {
+		if cerr := q.getWatchlistByNameStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWatchlistByNameStmt: %w", cerr)
+		}
+	}
+	if q.insertAlertRuleEventStmt != nil {
+		if cerr := q.insertAlertRuleEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertAlertRuleEventStmt: %w", cerr)
+		}
+	}
+	if q.insertAnomalyStmt != nil {
+		if cerr := q.insertAnomalyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertAnomalyStmt: %w", cerr)
+		}
+	}
+	if q.insertBalanceChangeStmt != nil {
+		if cerr := q.insertBalanceChangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertBalanceChangeStmt: %w", cerr)
+		}
+	}
+	if q.insertBalanceDriftStmt != nil {
+		if cerr := q.insertBalanceDriftStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertBalanceDriftStmt: %w", cerr)
+		}
+	}
 	if q.insertBlockStmt != nil {
 		if cerr := q.insertBlockStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing insertBlockStmt: %w", cerr)
@@ -138,21 +783,451 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing insertTransactionStmt: %w", cerr)
 		}
 	}
+	if q.insertTransactionInputStmt != nil {
+		if cerr := q.insertTransactionInputStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertTransactionInputStmt: %w", cerr)
+		}
+	}
 	if q.insertWalletStmt != nil {
 		if cerr := q.insertWalletStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing insertWalletStmt: %w", cerr)
 		}
 	}
+	if q.insertWatchAlertStmt != nil {
+		if cerr := q.insertWatchAlertStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertWatchAlertStmt: %w", cerr)
+		}
+	}
+	if q.insertWebhookDeliveryStmt != nil {
+		if cerr := q.insertWebhookDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertWebhookDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.listActiveWebhookSubscriptionsStmt != nil {
+		if cerr := q.listActiveWebhookSubscriptionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listActiveWebhookSubscriptionsStmt: %w", cerr)
+		}
+	}
+	if q.listAddressERC1155HoldingsStmt != nil {
+		if cerr := q.listAddressERC1155HoldingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAddressERC1155HoldingsStmt: %w", cerr)
+		}
+	}
+	if q.listAddressERC1155TransfersStmt != nil {
+		if cerr := q.listAddressERC1155TransfersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAddressERC1155TransfersStmt: %w", cerr)
+		}
+	}
+	if q.listAddressERC20HoldingsStmt != nil {
+		if cerr := q.listAddressERC20HoldingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAddressERC20HoldingsStmt: %w", cerr)
+		}
+	}
+	if q.listAddressERC20TransfersStmt != nil {
+		if cerr := q.listAddressERC20TransfersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAddressERC20TransfersStmt: %w", cerr)
+		}
+	}
+	if q.listAddressERC721HoldingsStmt != nil {
+		if cerr := q.listAddressERC721HoldingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAddressERC721HoldingsStmt: %w", cerr)
+		}
+	}
+	if q.listAddressERC721TransfersStmt != nil {
+		if cerr := q.listAddressERC721TransfersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAddressERC721TransfersStmt: %w", cerr)
+		}
+	}
+	if q.listAddressLogsStmt != nil {
+		if cerr := q.listAddressLogsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAddressLogsStmt: %w", cerr)
+		}
+	}
+	if q.listAddressTopicLogsStmt != nil {
+		if cerr := q.listAddressTopicLogsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAddressTopicLogsStmt: %w", cerr)
+		}
+	}
+	if q.listAddressTransactionsStmt != nil {
+		if cerr := q.listAddressTransactionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAddressTransactionsStmt: %w", cerr)
+		}
+	}
+	if q.listAlertRuleEventsStmt != nil {
+		if cerr := q.listAlertRuleEventsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAlertRuleEventsStmt: %w", cerr)
+		}
+	}
+	if q.listAlertRulesStmt != nil {
+		if cerr := q.listAlertRulesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAlertRulesStmt: %w", cerr)
+		}
+	}
+	if q.listAnomaliesStmt != nil {
+		if cerr := q.listAnomaliesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAnomaliesStmt: %w", cerr)
+		}
+	}
+	if q.listBalanceChangesAfterStmt != nil {
+		if cerr := q.listBalanceChangesAfterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listBalanceChangesAfterStmt: %w", cerr)
+		}
+	}
+	if q.listBlockTransactionsStmt != nil {
+		if cerr := q.listBlockTransactionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listBlockTransactionsStmt: %w", cerr)
+		}
+	}
+	if q.listBlocksByNumberStmt != nil {
+		if cerr := q.listBlocksByNumberStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listBlocksByNumberStmt: %w", cerr)
+		}
+	}
+	if q.listBlocksFromStmt != nil {
+		if cerr := q.listBlocksFromStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listBlocksFromStmt: %w", cerr)
+		}
+	}
+	if q.listChangedERC721TokensStmt != nil {
+		if cerr := q.listChangedERC721TokensStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listChangedERC721TokensStmt: %w", cerr)
+		}
+	}
+	if q.listCoinLogsByTransactionsStmt != nil {
+		if cerr := q.listCoinLogsByTransactionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCoinLogsByTransactionsStmt: %w", cerr)
+		}
+	}
+	if q.listContractERC1155TransfersStmt != nil {
+		if cerr := q.listContractERC1155TransfersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listContractERC1155TransfersStmt: %w", cerr)
+		}
+	}
+	if q.listContractERC20TransfersStmt != nil {
+		if cerr := q.listContractERC20TransfersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listContractERC20TransfersStmt: %w", cerr)
+		}
+	}
+	if q.listContractERC721TransfersStmt != nil {
+		if cerr := q.listContractERC721TransfersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listContractERC721TransfersStmt: %w", cerr)
+		}
+	}
+	if q.listContractsByHashStmt != nil {
+		if cerr := q.listContractsByHashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listContractsByHashStmt: %w", cerr)
+		}
+	}
+	if q.listERC1155BalancesStmt != nil {
+		if cerr := q.listERC1155BalancesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listERC1155BalancesStmt: %w", cerr)
+		}
+	}
+	if q.listERC1155HoldersStmt != nil {
+		if cerr := q.listERC1155HoldersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listERC1155HoldersStmt: %w", cerr)
+		}
+	}
+	if q.listERC1155LogsByTransactionsStmt != nil {
+		if cerr := q.listERC1155LogsByTransactionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listERC1155LogsByTransactionsStmt: %w", cerr)
+		}
+	}
+	if q.listERC1155TokenHoldersStmt != nil {
+		if cerr := q.listERC1155TokenHoldersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listERC1155TokenHoldersStmt: %w", cerr)
+		}
+	}
+	if q.listERC1155TokensByIDStmt != nil {
+		if cerr := q.listERC1155TokensByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listERC1155TokensByIDStmt: %w", cerr)
+		}
+	}
+	if q.listERC20BalancesStmt != nil {
+		if cerr := q.listERC20BalancesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listERC20BalancesStmt: %w", cerr)
+		}
+	}
+	if q.listERC20HoldersStmt != nil {
+		if cerr := q.listERC20HoldersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listERC20HoldersStmt: %w", cerr)
+		}
+	}
+	if q.listERC20LogsByTransactionsStmt != nil {
+		if cerr := q.listERC20LogsByTransactionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listERC20LogsByTransactionsStmt: %w", cerr)
+		}
+	}
+	if q.listERC721BalancesStmt != nil {
+		if cerr := q.listERC721BalancesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listERC721BalancesStmt: %w", cerr)
+		}
+	}
+	if q.listERC721HoldersStmt != nil {
+		if cerr := q.listERC721HoldersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listERC721HoldersStmt: %w", cerr)
+		}
+	}
+	if q.listERC721LogsByTransactionsStmt != nil {
+		if cerr := q.listERC721LogsByTransactionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listERC721LogsByTransactionsStmt: %w", cerr)
+		}
+	}
+	if q.listERC721OwnersAtStmt != nil {
+		if cerr := q.listERC721OwnersAtStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listERC721OwnersAtStmt: %w", cerr)
+		}
+	}
+	if q.listERC721TokensByIDStmt != nil {
+		if cerr := q.listERC721TokensByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listERC721TokensByIDStmt: %w", cerr)
+		}
+	}
+	if q.listExistingERC1155BalancesStmt != nil {
+		if cerr := q.listExistingERC1155BalancesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listExistingERC1155BalancesStmt: %w", cerr)
+		}
+	}
+	if q.listExistingERC20BalancesStmt != nil {
+		if cerr := q.listExistingERC20BalancesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listExistingERC20BalancesStmt: %w", cerr)
+		}
+	}
+	if q.listExistingERC721TokensStmt != nil {
+		if cerr := q.listExistingERC721TokensStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listExistingERC721TokensStmt: %w", cerr)
+		}
+	}
+	if q.listExistingWalletsStmt != nil {
+		if cerr := q.listExistingWalletsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listExistingWalletsStmt: %w", cerr)
+		}
+	}
+	if q.listHoldingsAtStmt != nil {
+		if cerr := q.listHoldingsAtStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listHoldingsAtStmt: %w", cerr)
+		}
+	}
+	if q.listLogsByTransactionsStmt != nil {
+		if cerr := q.listLogsByTransactionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listLogsByTransactionsStmt: %w", cerr)
+		}
+	}
+	if q.listPartitionPoliciesStmt != nil {
+		if cerr := q.listPartitionPoliciesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPartitionPoliciesStmt: %w", cerr)
+		}
+	}
+	if q.listPartitionRangesStmt != nil {
+		if cerr := q.listPartitionRangesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPartitionRangesStmt: %w", cerr)
+		}
+	}
+	if q.listPendingWatchAlertsStmt != nil {
+		if cerr := q.listPendingWatchAlertsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPendingWatchAlertsStmt: %w", cerr)
+		}
+	}
+	if q.listTokenHoldersAtStmt != nil {
+		if cerr := q.listTokenHoldersAtStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTokenHoldersAtStmt: %w", cerr)
+		}
+	}
+	if q.listTransactionERC1155TransfersStmt != nil {
+		if cerr := q.listTransactionERC1155TransfersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTransactionERC1155TransfersStmt: %w", cerr)
+		}
+	}
+	if q.listTransactionERC20TransfersStmt != nil {
+		if cerr := q.listTransactionERC20TransfersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTransactionERC20TransfersStmt: %w", cerr)
+		}
+	}
+	if q.listTransactionERC721TransfersStmt != nil {
+		if cerr := q.listTransactionERC721TransfersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTransactionERC721TransfersStmt: %w", cerr)
+		}
+	}
+	if q.listTransactionInputsStmt != nil {
+		if cerr := q.listTransactionInputsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTransactionInputsStmt: %w", cerr)
+		}
+	}
+	if q.listTransactionLogsStmt != nil {
+		if cerr := q.listTransactionLogsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTransactionLogsStmt: %w", cerr)
+		}
+	}
+	if q.listUnseededCollectionsStmt != nil {
+		if cerr := q.listUnseededCollectionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUnseededCollectionsStmt: %w", cerr)
+		}
+	}
+	if q.listWalletsStmt != nil {
+		if cerr := q.listWalletsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listWalletsStmt: %w", cerr)
+		}
+	}
+	if q.listWatchAlertsStmt != nil {
+		if cerr := q.listWatchAlertsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listWatchAlertsStmt: %w", cerr)
+		}
+	}
+	if q.listWatchlistAddressesStmt != nil {
+		if cerr := q.listWatchlistAddressesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listWatchlistAddressesStmt: %w", cerr)
+		}
+	}
+	if q.listWatchlistsStmt != nil {
+		if cerr := q.listWatchlistsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listWatchlistsStmt: %w", cerr)
+		}
+	}
+	if q.listWebhookDeliveriesStmt != nil {
+		if cerr := q.listWebhookDeliveriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listWebhookDeliveriesStmt: %w", cerr)
+		}
+	}
+	if q.listWebhookSubscriptionsStmt != nil {
+		if cerr := q.listWebhookSubscriptionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listWebhookSubscriptionsStmt: %w", cerr)
+		}
+	}
+	if q.markWatchAlertsDeliveredStmt != nil {
+		if cerr := q.markWatchAlertsDeliveredStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markWatchAlertsDeliveredStmt: %w", cerr)
+		}
+	}
+	if q.markWebhookDeliveredStmt != nil {
+		if cerr := q.markWebhookDeliveredStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markWebhookDeliveredStmt: %w", cerr)
+		}
+	}
+	if q.markWebhookFailedStmt != nil {
+		if cerr := q.markWebhookFailedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markWebhookFailedStmt: %w", cerr)
+		}
+	}
+	if q.matchWatchlistAddressesStmt != nil {
+		if cerr := q.matchWatchlistAddressesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing matchWatchlistAddressesStmt: %w", cerr)
+		}
+	}
+	if q.pruneRawDataStmt != nil {
+		if cerr := q.pruneRawDataStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing pruneRawDataStmt: %w", cerr)
+		}
+	}
+	if q.removeWatchlistAddressStmt != nil {
+		if cerr := q.removeWatchlistAddressStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing removeWatchlistAddressStmt: %w", cerr)
+		}
+	}
+	if q.repairERC721OwnerStmt != nil {
+		if cerr := q.repairERC721OwnerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing repairERC721OwnerStmt: %w", cerr)
+		}
+	}
+	if q.replayDeadWebhookDeliveriesStmt != nil {
+		if cerr := q.replayDeadWebhookDeliveriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing replayDeadWebhookDeliveriesStmt: %w", cerr)
+		}
+	}
+	if q.replayWebhookDeliveryStmt != nil {
+		if cerr := q.replayWebhookDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing replayWebhookDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.resolveAnomalyStmt != nil {
+		if cerr := q.resolveAnomalyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing resolveAnomalyStmt: %w", cerr)
+		}
+	}
+	if q.revertERC1155BalancesStmt != nil {
+		if cerr := q.revertERC1155BalancesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revertERC1155BalancesStmt: %w", cerr)
+		}
+	}
+	if q.revertERC20BalancesStmt != nil {
+		if cerr := q.revertERC20BalancesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revertERC20BalancesStmt: %w", cerr)
+		}
+	}
+	if q.revertWalletBalancesStmt != nil {
+		if cerr := q.revertWalletBalancesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revertWalletBalancesStmt: %w", cerr)
+		}
+	}
+	if q.sampleERC1155BalancesStmt != nil {
+		if cerr := q.sampleERC1155BalancesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing sampleERC1155BalancesStmt: %w", cerr)
+		}
+	}
+	if q.sampleERC20BalancesStmt != nil {
+		if cerr := q.sampleERC20BalancesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing sampleERC20BalancesStmt: %w", cerr)
+		}
+	}
+	if q.sampleERC721BalancesStmt != nil {
+		if cerr := q.sampleERC721BalancesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing sampleERC721BalancesStmt: %w", cerr)
+		}
+	}
+	if q.sampleWalletsStmt != nil {
+		if cerr := q.sampleWalletsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing sampleWalletsStmt: %w", cerr)
+		}
+	}
+	if q.seedERC1155BalanceStmt != nil {
+		if cerr := q.seedERC1155BalanceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing seedERC1155BalanceStmt: %w", cerr)
+		}
+	}
+	if q.seedERC20BalanceStmt != nil {
+		if cerr := q.seedERC20BalanceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing seedERC20BalanceStmt: %w", cerr)
+		}
+	}
+	if q.seedERC721BalanceStmt != nil {
+		if cerr := q.seedERC721BalanceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing seedERC721BalanceStmt: %w", cerr)
+		}
+	}
+	if q.seedWalletStmt != nil {
+		if cerr := q.seedWalletStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing seedWalletStmt: %w", cerr)
+		}
+	}
+	if q.setAlertRuleEnabledStmt != nil {
+		if cerr := q.setAlertRuleEnabledStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setAlertRuleEnabledStmt: %w", cerr)
+		}
+	}
+	if q.setWebhookSubscriptionActiveStmt != nil {
+		if cerr := q.setWebhookSubscriptionActiveStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setWebhookSubscriptionActiveStmt: %w", cerr)
+		}
+	}
 	if q.subtractERC1155BalanceStmt != nil {
 		if cerr := q.subtractERC1155BalanceStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing subtractERC1155BalanceStmt: %w", cerr)
 		}
 	}
+	if q.syncERC721OwnersStmt != nil {
+		if cerr := q.syncERC721OwnersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing syncERC721OwnersStmt: %w", cerr)
+		}
+	}
 	if q.updateContractTypeStmt != nil {
 		if cerr := q.updateContractTypeStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateContractTypeStmt: %w", cerr)
 		}
 	}
+	if q.updateWatchlistEmailStmt != nil {
+		if cerr := q.updateWatchlistEmailStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateWatchlistEmailStmt: %w", cerr)
+		}
+	}
 	if q.upsertERC1155Balance_AddStmt != nil {
 		if cerr := q.upsertERC1155Balance_AddStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing upsertERC1155Balance_AddStmt: %w", cerr)
@@ -168,6 +1243,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing upsertERC721BalanceStmt: %w", cerr)
 		}
 	}
+	if q.upsertPartitionPolicyStmt != nil {
+		if cerr := q.upsertPartitionPolicyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertPartitionPolicyStmt: %w", cerr)
+		}
+	}
 	if q.upsertWalletBalanceStmt != nil {
 		if cerr := q.upsertWalletBalanceStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing upsertWalletBalanceStmt: %w", cerr)
@@ -210,49 +1290,319 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                           DBTX
-	tx                           *sql.Tx
-	createErc1155Stmt            *sql.Stmt
-	createErc721Stmt             *sql.Stmt
-	getBlockHeightStmt           *sql.Stmt
-	insertBlockStmt              *sql.Stmt
-	insertCoinLogStmt            *sql.Stmt
-	insertContractStmt           *sql.Stmt
-	insertERC1155LogStmt         *sql.Stmt
-	insertERC20LogStmt           *sql.Stmt
-	insertERC721LogStmt          *sql.Stmt
-	insertLogStmt                *sql.Stmt
-	insertTransactionStmt        *sql.Stmt
-	insertWalletStmt             *sql.Stmt
-	subtractERC1155BalanceStmt   *sql.Stmt
-	updateContractTypeStmt       *sql.Stmt
-	upsertERC1155Balance_AddStmt *sql.Stmt
-	upsertERC20BalanceStmt       *sql.Stmt
-	upsertERC721BalanceStmt      *sql.Stmt
-	upsertWalletBalanceStmt      *sql.Stmt
+	db                                  DBTX
+	tx                                  *sql.Tx
+	addWatchlistAddressStmt             *sql.Stmt
+	claimWebhookDeliveriesStmt          *sql.Stmt
+	countAnomaliesSinceStmt             *sql.Stmt
+	createAlertRuleStmt                 *sql.Stmt
+	createErc1155Stmt                   *sql.Stmt
+	createErc721Stmt                    *sql.Stmt
+	createReconcileRunStmt              *sql.Stmt
+	createWatchlistStmt                 *sql.Stmt
+	createWebhookSubscriptionStmt       *sql.Stmt
+	deleteAlertRuleStmt                 *sql.Stmt
+	deleteAnomaliesStmt                 *sql.Stmt
+	deleteBalanceChangesStmt            *sql.Stmt
+	deleteBlocksStmt                    *sql.Stmt
+	deleteCoinLogsStmt                  *sql.Stmt
+	deleteERC1155LogsStmt               *sql.Stmt
+	deleteERC20LogsStmt                 *sql.Stmt
+	deleteERC721LogsStmt                *sql.Stmt
+	deleteLogsStmt                      *sql.Stmt
+	deletePendingWatchAlertsStmt        *sql.Stmt
+	deletePendingWebhookDeliveriesStmt  *sql.Stmt
+	deleteTransactionInputsStmt         *sql.Stmt
+	deleteTransactionsStmt              *sql.Stmt
+	deleteUnownedERC721BalancesStmt     *sql.Stmt
+	deleteWatchlistStmt                 *sql.Stmt
+	deleteWebhookSubscriptionStmt       *sql.Stmt
+	ensureBlockPartitionStmt            *sql.Stmt
+	ensureMonthPartitionStmt            *sql.Stmt
+	finishReconcileRunStmt              *sql.Stmt
+	getBalanceDriftSummaryStmt          *sql.Stmt
+	getBlockByHashStmt                  *sql.Stmt
+	getBlockByNumberStmt                *sql.Stmt
+	getBlockHeightStmt                  *sql.Stmt
+	getBlockNumberAtTimestampStmt       *sql.Stmt
+	getBlockRangeTimestampsStmt         *sql.Stmt
+	getContractStmt                     *sql.Stmt
+	getERC1155AmountStmt                *sql.Stmt
+	getERC1155BalanceAtStmt             *sql.Stmt
+	getERC20BalanceAtStmt               *sql.Stmt
+	getERC721OwnerAtStmt                *sql.Stmt
+	getERC721TokenOwnerStmt             *sql.Stmt
+	getNativeBalanceAtStmt              *sql.Stmt
+	getPartitionPolicyStmt              *sql.Stmt
+	getPartitionRangeStmt               *sql.Stmt
+	getTransactionByHashStmt            *sql.Stmt
+	getTransactionInputStmt             *sql.Stmt
+	getWalletStmt                       *sql.Stmt
+	getWatchlistByNameStmt              *sql.Stmt
+	insertAlertRuleEventStmt            *sql.Stmt
+	insertAnomalyStmt                   *sql.Stmt
+	insertBalanceChangeStmt             *sql.Stmt
+	insertBalanceDriftStmt              *sql.Stmt
+	insertBlockStmt                     *sql.Stmt
+	insertCoinLogStmt                   *sql.Stmt
+	insertContractStmt                  *sql.Stmt
+	insertERC1155LogStmt                *sql.Stmt
+	insertERC20LogStmt                  *sql.Stmt
+	insertERC721LogStmt                 *sql.Stmt
+	insertLogStmt                       *sql.Stmt
+	insertTransactionStmt               *sql.Stmt
+	insertTransactionInputStmt          *sql.Stmt
+	insertWalletStmt                    *sql.Stmt
+	insertWatchAlertStmt                *sql.Stmt
+	insertWebhookDeliveryStmt           *sql.Stmt
+	listActiveWebhookSubscriptionsStmt  *sql.Stmt
+	listAddressERC1155HoldingsStmt      *sql.Stmt
+	listAddressERC1155TransfersStmt     *sql.Stmt
+	listAddressERC20HoldingsStmt        *sql.Stmt
+	listAddressERC20TransfersStmt       *sql.Stmt
+	listAddressERC721HoldingsStmt       *sql.Stmt
+	listAddressERC721TransfersStmt      *sql.Stmt
+	listAddressLogsStmt                 *sql.Stmt
+	listAddressTopicLogsStmt            *sql.Stmt
+	listAddressTransactionsStmt         *sql.Stmt
+	listAlertRuleEventsStmt             *sql.Stmt
+	listAlertRulesStmt                  *sql.Stmt
+	listAnomaliesStmt                   *sql.Stmt
+	listBalanceChangesAfterStmt         *sql.Stmt
+	listBlockTransactionsStmt           *sql.Stmt
+	listBlocksByNumberStmt              *sql.Stmt
+	listBlocksFromStmt                  *sql.Stmt
+	listChangedERC721TokensStmt         *sql.Stmt
+	listCoinLogsByTransactionsStmt      *sql.Stmt
+	listContractERC1155TransfersStmt    *sql.Stmt
+	listContractERC20TransfersStmt      *sql.Stmt
+	listContractERC721TransfersStmt     *sql.Stmt
+	listContractsByHashStmt             *sql.Stmt
+	listERC1155BalancesStmt             *sql.Stmt
+	listERC1155HoldersStmt              *sql.Stmt
+	listERC1155LogsByTransactionsStmt   *sql.Stmt
+	listERC1155TokenHoldersStmt         *sql.Stmt
+	listERC1155TokensByIDStmt           *sql.Stmt
+	listERC20BalancesStmt               *sql.Stmt
+	listERC20HoldersStmt                *sql.Stmt
+	listERC20LogsByTransactionsStmt     *sql.Stmt
+	listERC721BalancesStmt              *sql.Stmt
+	listERC721HoldersStmt               *sql.Stmt
+	listERC721LogsByTransactionsStmt    *sql.Stmt
+	listERC721OwnersAtStmt              *sql.Stmt
+	listERC721TokensByIDStmt            *sql.Stmt
+	listExistingERC1155BalancesStmt     *sql.Stmt
+	listExistingERC20BalancesStmt       *sql.Stmt
+	listExistingERC721TokensStmt        *sql.Stmt
+	listExistingWalletsStmt             *sql.Stmt
+	listHoldingsAtStmt                  *sql.Stmt
+	listLogsByTransactionsStmt          *sql.Stmt
+	listPartitionPoliciesStmt           *sql.Stmt
+	listPartitionRangesStmt             *sql.Stmt
+	listPendingWatchAlertsStmt          *sql.Stmt
+	listTokenHoldersAtStmt              *sql.Stmt
+	listTransactionERC1155TransfersStmt *sql.Stmt
+	listTransactionERC20TransfersStmt   *sql.Stmt
+	listTransactionERC721TransfersStmt  *sql.Stmt
+	listTransactionInputsStmt           *sql.Stmt
+	listTransactionLogsStmt             *sql.Stmt
+	listUnseededCollectionsStmt         *sql.Stmt
+	listWalletsStmt                     *sql.Stmt
+	listWatchAlertsStmt                 *sql.Stmt
+	listWatchlistAddressesStmt          *sql.Stmt
+	listWatchlistsStmt                  *sql.Stmt
+	listWebhookDeliveriesStmt           *sql.Stmt
+	listWebhookSubscriptionsStmt        *sql.Stmt
+	markWatchAlertsDeliveredStmt        *sql.Stmt
+	markWebhookDeliveredStmt            *sql.Stmt
+	markWebhookFailedStmt               *sql.Stmt
+	matchWatchlistAddressesStmt         *sql.Stmt
+	pruneRawDataStmt                    *sql.Stmt
+	removeWatchlistAddressStmt          *sql.Stmt
+	repairERC721OwnerStmt               *sql.Stmt
+	replayDeadWebhookDeliveriesStmt     *sql.Stmt
+	replayWebhookDeliveryStmt           *sql.Stmt
+	resolveAnomalyStmt                  *sql.Stmt
+	revertERC1155BalancesStmt           *sql.Stmt
+	revertERC20BalancesStmt             *sql.Stmt
+	revertWalletBalancesStmt            *sql.Stmt
+	sampleERC1155BalancesStmt           *sql.Stmt
+	sampleERC20BalancesStmt             *sql.Stmt
+	sampleERC721BalancesStmt            *sql.Stmt
+	sampleWalletsStmt                   *sql.Stmt
+	seedERC1155BalanceStmt              *sql.Stmt
+	seedERC20BalanceStmt                *sql.Stmt
+	seedERC721BalanceStmt               *sql.Stmt
+	seedWalletStmt                      *sql.Stmt
+	setAlertRuleEnabledStmt             *sql.Stmt
+	setWebhookSubscriptionActiveStmt    *sql.Stmt
+	subtractERC1155BalanceStmt          *sql.Stmt
+	syncERC721OwnersStmt                *sql.Stmt
+	updateContractTypeStmt              *sql.Stmt
+	updateWatchlistEmailStmt            *sql.Stmt
+	upsertERC1155Balance_AddStmt        *sql.Stmt
+	upsertERC20BalanceStmt              *sql.Stmt
+	upsertERC721BalanceStmt             *sql.Stmt
+	upsertPartitionPolicyStmt           *sql.Stmt
+	upsertWalletBalanceStmt             *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                           tx,
-		tx:                           tx,
-		createErc1155Stmt:            q.createErc1155Stmt,
-		createErc721Stmt:             q.createErc721Stmt,
-		getBlockHeightStmt:           q.getBlockHeightStmt,
-		insertBlockStmt:              q.insertBlockStmt,
-		insertCoinLogStmt:            q.insertCoinLogStmt,
-		insertContractStmt:           q.insertContractStmt,
-		insertERC1155LogStmt:         q.insertERC1155LogStmt,
-		insertERC20LogStmt:           q.insertERC20LogStmt,
-		insertERC721LogStmt:          q.insertERC721LogStmt,
-		insertLogStmt:                q.insertLogStmt,
-		insertTransactionStmt:        q.insertTransactionStmt,
-		insertWalletStmt:             q.insertWalletStmt,
-		subtractERC1155BalanceStmt:   q.subtractERC1155BalanceStmt,
-		updateContractTypeStmt:       q.updateContractTypeStmt,
-		upsertERC1155Balance_AddStmt: q.upsertERC1155Balance_AddStmt,
-		upsertERC20BalanceStmt:       q.upsertERC20BalanceStmt,
-		upsertERC721BalanceStmt:      q.upsertERC721BalanceStmt,
-		upsertWalletBalanceStmt:      q.upsertWalletBalanceStmt,
+		db:                                  tx,
+		tx:                                  tx,
+		addWatchlistAddressStmt:             q.addWatchlistAddressStmt,
+		claimWebhookDeliveriesStmt:          q.claimWebhookDeliveriesStmt,
+		countAnomaliesSinceStmt:             q.countAnomaliesSinceStmt,
+		createAlertRuleStmt:                 q.createAlertRuleStmt,
+		createErc1155Stmt:                   q.createErc1155Stmt,
+		createErc721Stmt:                    q.createErc721Stmt,
+		createReconcileRunStmt:              q.createReconcileRunStmt,
+		createWatchlistStmt:                 q.createWatchlistStmt,
+		createWebhookSubscriptionStmt:       q.createWebhookSubscriptionStmt,
+		deleteAlertRuleStmt:                 q.deleteAlertRuleStmt,
+		deleteAnomaliesStmt:                 q.deleteAnomaliesStmt,
+		deleteBalanceChangesStmt:            q.deleteBalanceChangesStmt,
+		deleteBlocksStmt:                    q.deleteBlocksStmt,
+		deleteCoinLogsStmt:                  q.deleteCoinLogsStmt,
+		deleteERC1155LogsStmt:               q.deleteERC1155LogsStmt,
+		deleteERC20LogsStmt:                 q.deleteERC20LogsStmt,
+		deleteERC721LogsStmt:                q.deleteERC721LogsStmt,
+		deleteLogsStmt:                      q.deleteLogsStmt,
+		deletePendingWatchAlertsStmt:        q.deletePendingWatchAlertsStmt,
+		deletePendingWebhookDeliveriesStmt:  q.deletePendingWebhookDeliveriesStmt,
+		deleteTransactionInputsStmt:         q.deleteTransactionInputsStmt,
+		deleteTransactionsStmt:              q.deleteTransactionsStmt,
+		deleteUnownedERC721BalancesStmt:     q.deleteUnownedERC721BalancesStmt,
+		deleteWatchlistStmt:                 q.deleteWatchlistStmt,
+		deleteWebhookSubscriptionStmt:       q.deleteWebhookSubscriptionStmt,
+		ensureBlockPartitionStmt:            q.ensureBlockPartitionStmt,
+		ensureMonthPartitionStmt:            q.ensureMonthPartitionStmt,
+		finishReconcileRunStmt:              q.finishReconcileRunStmt,
+		getBalanceDriftSummaryStmt:          q.getBalanceDriftSummaryStmt,
+		getBlockByHashStmt:                  q.getBlockByHashStmt,
+		getBlockByNumberStmt:                q.getBlockByNumberStmt,
+		getBlockHeightStmt:                  q.getBlockHeightStmt,
+		getBlockNumberAtTimestampStmt:       q.getBlockNumberAtTimestampStmt,
+		getBlockRangeTimestampsStmt:         q.getBlockRangeTimestampsStmt,
+		getContractStmt:                     q.getContractStmt,
+		getERC1155AmountStmt:                q.getERC1155AmountStmt,
+		getERC1155BalanceAtStmt:             q.getERC1155BalanceAtStmt,
+		getERC20BalanceAtStmt:               q.getERC20BalanceAtStmt,
+		getERC721OwnerAtStmt:                q.getERC721OwnerAtStmt,
+		getERC721TokenOwnerStmt:             q.getERC721TokenOwnerStmt,
+		getNativeBalanceAtStmt:              q.getNativeBalanceAtStmt,
+		getPartitionPolicyStmt:              q.getPartitionPolicyStmt,
+		getPartitionRangeStmt:               q.getPartitionRangeStmt,
+		getTransactionByHashStmt:            q.getTransactionByHashStmt,
+		getTransactionInputStmt:             q.getTransactionInputStmt,
+		getWalletStmt:                       q.getWalletStmt,
+		getWatchlistByNameStmt:              q.getWatchlistByNameStmt,
+		insertAlertRuleEventStmt:            q.insertAlertRuleEventStmt,
+		insertAnomalyStmt:                   q.insertAnomalyStmt,
+		insertBalanceChangeStmt:             q.insertBalanceChangeStmt,
+		insertBalanceDriftStmt:              q.insertBalanceDriftStmt,
+		insertBlockStmt:                     q.insertBlockStmt,
+		insertCoinLogStmt:                   q.insertCoinLogStmt,
+		insertContractStmt:                  q.insertContractStmt,
+		insertERC1155LogStmt:                q.insertERC1155LogStmt,
+		insertERC20LogStmt:                  q.insertERC20LogStmt,
+		insertERC721LogStmt:                 q.insertERC721LogStmt,
+		insertLogStmt:                       q.insertLogStmt,
+		insertTransactionStmt:               q.insertTransactionStmt,
+		insertTransactionInputStmt:          q.insertTransactionInputStmt,
+		insertWalletStmt:                    q.insertWalletStmt,
+		insertWatchAlertStmt:                q.insertWatchAlertStmt,
+		insertWebhookDeliveryStmt:           q.insertWebhookDeliveryStmt,
+		listActiveWebhookSubscriptionsStmt:  q.listActiveWebhookSubscriptionsStmt,
+		listAddressERC1155HoldingsStmt:      q.listAddressERC1155HoldingsStmt,
+		listAddressERC1155TransfersStmt:     q.listAddressERC1155TransfersStmt,
+		listAddressERC20HoldingsStmt:        q.listAddressERC20HoldingsStmt,
+		listAddressERC20TransfersStmt:       q.listAddressERC20TransfersStmt,
+		listAddressERC721HoldingsStmt:       q.listAddressERC721HoldingsStmt,
+		listAddressERC721TransfersStmt:      q.listAddressERC721TransfersStmt,
+		listAddressLogsStmt:                 q.listAddressLogsStmt,
+		listAddressTopicLogsStmt:            q.listAddressTopicLogsStmt,
+		listAddressTransactionsStmt:         q.listAddressTransactionsStmt,
+		listAlertRuleEventsStmt:             q.listAlertRuleEventsStmt,
+		listAlertRulesStmt:                  q.listAlertRulesStmt,
+		listAnomaliesStmt:                   q.listAnomaliesStmt,
+		listBalanceChangesAfterStmt:         q.listBalanceChangesAfterStmt,
+		listBlockTransactionsStmt:           q.listBlockTransactionsStmt,
+		listBlocksByNumberStmt:              q.listBlocksByNumberStmt,
+		listBlocksFromStmt:                  q.listBlocksFromStmt,
+		listChangedERC721TokensStmt:         q.listChangedERC721TokensStmt,
+		listCoinLogsByTransactionsStmt:      q.listCoinLogsByTransactionsStmt,
+		listContractERC1155TransfersStmt:    q.listContractERC1155TransfersStmt,
+		listContractERC20TransfersStmt:      q.listContractERC20TransfersStmt,
+		listContractERC721TransfersStmt:     q.listContractERC721TransfersStmt,
+		listContractsByHashStmt:             q.listContractsByHashStmt,
+		listERC1155BalancesStmt:             q.listERC1155BalancesStmt,
+		listERC1155HoldersStmt:              q.listERC1155HoldersStmt,
+		listERC1155LogsByTransactionsStmt:   q.listERC1155LogsByTransactionsStmt,
+		listERC1155TokenHoldersStmt:         q.listERC1155TokenHoldersStmt,
+		listERC1155TokensByIDStmt:           q.listERC1155TokensByIDStmt,
+		listERC20BalancesStmt:               q.listERC20BalancesStmt,
+		listERC20HoldersStmt:                q.listERC20HoldersStmt,
+		listERC20LogsByTransactionsStmt:     q.listERC20LogsByTransactionsStmt,
+		listERC721BalancesStmt:              q.listERC721BalancesStmt,
+		listERC721HoldersStmt:               q.listERC721HoldersStmt,
+		listERC721LogsByTransactionsStmt:    q.listERC721LogsByTransactionsStmt,
+		listERC721OwnersAtStmt:              q.listERC721OwnersAtStmt,
+		listERC721TokensByIDStmt:            q.listERC721TokensByIDStmt,
+		listExistingERC1155BalancesStmt:     q.listExistingERC1155BalancesStmt,
+		listExistingERC20BalancesStmt:       q.listExistingERC20BalancesStmt,
+		listExistingERC721TokensStmt:        q.listExistingERC721TokensStmt,
+		listExistingWalletsStmt:             q.listExistingWalletsStmt,
+		listHoldingsAtStmt:                  q.listHoldingsAtStmt,
+		listLogsByTransactionsStmt:          q.listLogsByTransactionsStmt,
+		listPartitionPoliciesStmt:           q.listPartitionPoliciesStmt,
+		listPartitionRangesStmt:             q.listPartitionRangesStmt,
+		listPendingWatchAlertsStmt:          q.listPendingWatchAlertsStmt,
+		listTokenHoldersAtStmt:              q.listTokenHoldersAtStmt,
+		listTransactionERC1155TransfersStmt: q.listTransactionERC1155TransfersStmt,
+		listTransactionERC20TransfersStmt:   q.listTransactionERC20TransfersStmt,
+		listTransactionERC721TransfersStmt:  q.listTransactionERC721TransfersStmt,
+		listTransactionInputsStmt:           q.listTransactionInputsStmt,
+		listTransactionLogsStmt:             q.listTransactionLogsStmt,
+		listUnseededCollectionsStmt:         q.listUnseededCollectionsStmt,
+		listWalletsStmt:                     q.listWalletsStmt,
+		listWatchAlertsStmt:                 q.listWatchAlertsStmt,
+		listWatchlistAddressesStmt:          q.listWatchlistAddressesStmt,
+		listWatchlistsStmt:                  q.listWatchlistsStmt,
+		listWebhookDeliveriesStmt:           q.listWebhookDeliveriesStmt,
+		listWebhookSubscriptionsStmt:        q.listWebhookSubscriptionsStmt,
+		markWatchAlertsDeliveredStmt:        q.markWatchAlertsDeliveredStmt,
+		markWebhookDeliveredStmt:            q.markWebhookDeliveredStmt,
+		markWebhookFailedStmt:               q.markWebhookFailedStmt,
+		matchWatchlistAddressesStmt:         q.matchWatchlistAddressesStmt,
+		pruneRawDataStmt:                    q.pruneRawDataStmt,
+		removeWatchlistAddressStmt:          q.removeWatchlistAddressStmt,
+		repairERC721OwnerStmt:               q.repairERC721OwnerStmt,
+		replayDeadWebhookDeliveriesStmt:     q.replayDeadWebhookDeliveriesStmt,
+		replayWebhookDeliveryStmt:           q.replayWebhookDeliveryStmt,
+		resolveAnomalyStmt:                  q.resolveAnomalyStmt,
+		revertERC1155BalancesStmt:           q.revertERC1155BalancesStmt,
+		revertERC20BalancesStmt:             q.revertERC20BalancesStmt,
+		revertWalletBalancesStmt:            q.revertWalletBalancesStmt,
+		sampleERC1155BalancesStmt:           q.sampleERC1155BalancesStmt,
+		sampleERC20BalancesStmt:             q.sampleERC20BalancesStmt,
+		sampleERC721BalancesStmt:            q.sampleERC721BalancesStmt,
+		sampleWalletsStmt:                   q.sampleWalletsStmt,
+		seedERC1155BalanceStmt:              q.seedERC1155BalanceStmt,
+		seedERC20BalanceStmt:                q.seedERC20BalanceStmt,
+		seedERC721BalanceStmt:               q.seedERC721BalanceStmt,
+		seedWalletStmt:                      q.seedWalletStmt,
+		setAlertRuleEnabledStmt:             q.setAlertRuleEnabledStmt,
+		setWebhookSubscriptionActiveStmt:    q.setWebhookSubscriptionActiveStmt,
+		subtractERC1155BalanceStmt:          q.subtractERC1155BalanceStmt,
+		syncERC721OwnersStmt:                q.syncERC721OwnersStmt,
+		updateContractTypeStmt:              q.updateContractTypeStmt,
+		updateWatchlistEmailStmt:            q.updateWatchlistEmailStmt,
+		upsertERC1155Balance_AddStmt:        q.upsertERC1155Balance_AddStmt,
+		upsertERC20BalanceStmt:              q.upsertERC20BalanceStmt,
+		upsertERC721BalanceStmt:             q.upsertERC721BalanceStmt,
+		upsertPartitionPolicyStmt:           q.upsertPartitionPolicyStmt,
+		upsertWalletBalanceStmt:             q.upsertWalletBalanceStmt,
 	}
 }
@@ -6,210 +6,362 @@ package gen
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 )
 
+type AlertRule struct {
+	ID              int64           `json:"id"`
+	Name            string          `json:"name"`
+	ChainID         sql.NullInt64   `json:"chain_id"`
+	Type            string          `json:"type"`
+	Params          json.RawMessage `json:"params"`
+	Severity        string          `json:"severity"`
+	CooldownSeconds int32           `json:"cooldown_seconds"`
+	Enabled         bool            `json:"enabled"`
+	CreatedAt       time.Time       `json:"created_at"`
+}
+
+type AlertRuleEvent struct {
+	ID          int64     `json:"id"`
+	RuleName    string    `json:"rule_name"`
+	ChainID     int64     `json:"chain_id"`
+	BlockNumber int64     `json:"block_number"`
+	Subject     string    `json:"subject"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type Anomaly struct {
+	ID              int64          `json:"id"`
+	ChainID         int64          `json:"chain_id"`
+	Kind            string         `json:"kind"`
+	BlockNumber     int64          `json:"block_number"`
+	TransactionHash []byte         `json:"transaction_hash"`
+	LogIndex        sql.NullInt64  `json:"log_index"`
+	Contract        []byte         `json:"contract"`
+	Address         []byte         `json:"address"`
+	Expected        sql.NullString `json:"expected"`
+	Actual          sql.NullString `json:"actual"`
+	Detail          sql.NullString `json:"detail"`
+	Resolved        bool           `json:"resolved"`
+	ResolvedAt      sql.NullTime   `json:"resolved_at"`
+	Resolution      sql.NullString `json:"resolution"`
+	CreatedAt       time.Time      `json:"created_at"`
+}
+
+type BalanceChange struct {
+	ID              int64          `json:"id"`
+	ChainID         int64          `json:"chain_id"`
+	Kind            string         `json:"kind"`
+	Hash            []byte         `json:"hash"`
+	TokenID         sql.NullString `json:"token_id"`
+	Address         []byte         `json:"address"`
+	Delta           string         `json:"delta"`
+	BlockNumber     int64          `json:"block_number"`
+	TransactionHash []byte         `json:"transaction_hash"`
+	Reason          string         `json:"reason"`
+	CreatedAt       time.Time      `json:"created_at"`
+}
+
+type BalanceDrift struct {
+	ID             int64          `json:"id"`
+	RunID          int64          `json:"run_id"`
+	ChainID        int64          `json:"chain_id"`
+	Kind           string         `json:"kind"`
+	Hash           []byte         `json:"hash"`
+	TokenID        sql.NullString `json:"token_id"`
+	Address        []byte         `json:"address"`
+	OnchainAddress []byte         `json:"onchain_address"`
+	BlockNumber    int64          `json:"block_number"`
+	Stored         string         `json:"stored"`
+	Onchain        string         `json:"onchain"`
+	Difference     string         `json:"difference"`
+	Repaired       bool           `json:"repaired"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
 type Block struct {
-	ID               int32          `json:"id"`
-	ChainID          string         `json:"chain_id"`
+	ID               int64          `json:"id"`
+	ChainID          int64          `json:"chain_id"`
 	Difficulty       sql.NullString `json:"difficulty"`
-	Hash             string         `json:"hash"`
-	GasLimit         sql.NullString `json:"gas_limit"`
-	GasUsed          sql.NullString `json:"gas_used"`
-	Miner            sql.NullString `json:"miner"`
-	Number           string         `json:"number"`
-	NumberInt        string         `json:"number_int"`
-	ParentHash       sql.NullString `json:"parent_hash"`
-	Timestamp        string         `json:"timestamp"`
-	TimestampInt     string         `json:"timestamp_int"`
-	CreatedAt        time.Time      `json:"created_at"`
+	Hash             []byte         `json:"hash"`
+	GasLimit         sql.NullInt64  `json:"gas_limit"`
+	GasUsed          sql.NullInt64  `json:"gas_used"`
+	Miner            []byte         `json:"miner"`
+	Number           int64          `json:"number"`
+	ParentHash       []byte         `json:"parent_hash"`
+	Timestamp        time.Time      `json:"timestamp"`
 	TotalDifficulty  sql.NullString `json:"total_difficulty"`
-	TransactionsRoot sql.NullString `json:"transactions_root"`
+	TransactionsRoot []byte         `json:"transactions_root"`
 }
 
 type CoinLog struct {
-	ID              int32          `json:"id"`
-	ChainID         string         `json:"chain_id"`
-	Timestamp       string         `json:"timestamp"`
-	TimestampInt    string         `json:"timestamp_int"`
-	CreatedAt       time.Time      `json:"created_at"`
-	TransactionHash sql.NullString `json:"transaction_hash"`
-	From            sql.NullString `json:"from"`
-	To              sql.NullString `json:"to"`
+	ID              int64          `json:"id"`
+	ChainID         int64          `json:"chain_id"`
+	Timestamp       time.Time      `json:"timestamp"`
+	TransactionHash []byte         `json:"transaction_hash"`
+	From            []byte         `json:"from"`
+	To              []byte         `json:"to"`
 	Amount          sql.NullString `json:"amount"`
-	Gas             sql.NullString `json:"gas"`
-	GasInt          sql.NullString `json:"gas_int"`
+	Gas             sql.NullInt64  `json:"gas"`
 	GasPrice        sql.NullString `json:"gas_price"`
-	GasPriceInt     sql.NullString `json:"gas_price_int"`
-	GasUsed         sql.NullString `json:"gas_used"`
-	GasUsedInt      sql.NullString `json:"gas_used_int"`
+	GasUsed         sql.NullInt64  `json:"gas_used"`
 }
 
 type Contract struct {
-	ID            int32          `json:"id"`
-	ChainID       string         `json:"chain_id"`
-	Hash          string         `json:"hash"`
+	ID            int64          `json:"id"`
+	ChainID       int64          `json:"chain_id"`
+	Hash          []byte         `json:"hash"`
 	Name          sql.NullString `json:"name"`
 	Symbol        sql.NullString `json:"symbol"`
 	Decimals      sql.NullInt32  `json:"decimals"`
 	TotalSupply   sql.NullString `json:"total_supply"`
 	Type          sql.NullString `json:"type"`
-	Creator       sql.NullString `json:"creator"`
+	Creator       []byte         `json:"creator"`
 	LogoUrl       sql.NullString `json:"logo_url"`
 	BackgroundUrl sql.NullString `json:"background_url"`
 }
 
 type Erc1155 struct {
-	ID       int32          `json:"id"`
-	ChainID  string         `json:"chain_id"`
-	Hash     string         `json:"hash"`
+	ID       int64          `json:"id"`
+	ChainID  int64          `json:"chain_id"`
+	Hash     []byte         `json:"hash"`
 	TokenID  string         `json:"token_id"`
 	Url      sql.NullString `json:"url"`
 	ImageUrl sql.NullString `json:"image_url"`
 }
 
 type Erc1155Balance struct {
-	ID      int32  `json:"id"`
-	ChainID string `json:"chain_id"`
-	Hash    string `json:"hash"`
+	ID      int64  `json:"id"`
+	ChainID int64  `json:"chain_id"`
+	Hash    []byte `json:"hash"`
 	TokenID string `json:"token_id"`
-	Address string `json:"address"`
+	Address []byte `json:"address"`
 	Amount  string `json:"amount"`
 }
 
 type Erc1155Log struct {
-	ID              int32          `json:"id"`
-	ChainID         string         `json:"chain_id"`
-	Timestamp       string         `json:"timestamp"`
-	TimestampInt    string         `json:"timestamp_int"`
-	CreatedAt       time.Time      `json:"created_at"`
-	TransactionHash string         `json:"transaction_hash"`
-	ContractAddress sql.NullString `json:"contract_address"`
-	From            sql.NullString `json:"from"`
-	To              sql.NullString `json:"to"`
+	ID              int64          `json:"id"`
+	ChainID         int64          `json:"chain_id"`
+	Timestamp       time.Time      `json:"timestamp"`
+	TransactionHash []byte         `json:"transaction_hash"`
+	ContractAddress []byte         `json:"contract_address"`
+	From            []byte         `json:"from"`
+	To              []byte         `json:"to"`
 	TokenID         sql.NullString `json:"token_id"`
 	Amount          sql.NullString `json:"amount"`
 	Function        sql.NullString `json:"function"`
 	Name            sql.NullString `json:"name"`
 	Symbol          sql.NullString `json:"symbol"`
+	LogIndex        int64          `json:"log_index"`
+	BatchIndex      int32          `json:"batch_index"`
 }
 
 type Erc20Balance struct {
-	ID      int32  `json:"id"`
-	ChainID string `json:"chain_id"`
+	ID      int64  `json:"id"`
+	ChainID int64  `json:"chain_id"`
 	Balance string `json:"balance"`
-	Hash    string `json:"hash"`
-	Address string `json:"address"`
+	Hash    []byte `json:"hash"`
+	Address []byte `json:"address"`
 }
 
 type Erc20Log struct {
-	ID              int32          `json:"id"`
-	ChainID         string         `json:"chain_id"`
-	Timestamp       string         `json:"timestamp"`
-	TimestampInt    string         `json:"timestamp_int"`
-	CreatedAt       time.Time      `json:"created_at"`
-	TransactionHash string         `json:"transaction_hash"`
-	ContractAddress string         `json:"contract_address"`
-	From            string         `json:"from"`
-	To              string         `json:"to"`
+	ID              int64          `json:"id"`
+	ChainID         int64          `json:"chain_id"`
+	Timestamp       time.Time      `json:"timestamp"`
+	TransactionHash []byte         `json:"transaction_hash"`
+	ContractAddress []byte         `json:"contract_address"`
+	From            []byte         `json:"from"`
+	To              []byte         `json:"to"`
 	Amount          string         `json:"amount"`
 	Function        string         `json:"function"`
 	Name            sql.NullString `json:"name"`
 	Symbol          sql.NullString `json:"symbol"`
+	LogIndex        int64          `json:"log_index"`
 }
 
 type Erc721 struct {
-	ID       int32          `json:"id"`
-	ChainID  string         `json:"chain_id"`
-	Hash     string         `json:"hash"`
+	ID       int64          `json:"id"`
+	ChainID  int64          `json:"chain_id"`
+	Hash     []byte         `json:"hash"`
 	TokenID  string         `json:"token_id"`
 	Url      sql.NullString `json:"url"`
 	ImageUrl sql.NullString `json:"image_url"`
 }
 
 type Erc721Balance struct {
-	ID      int32  `json:"id"`
-	ChainID string `json:"chain_id"`
-	Hash    string `json:"hash"`
+	ID      int64  `json:"id"`
+	ChainID int64  `json:"chain_id"`
+	Hash    []byte `json:"hash"`
 	TokenID string `json:"token_id"`
-	Address string `json:"address"`
+	Address []byte `json:"address"`
 }
 
 type Erc721Log struct {
-	ID              int32          `json:"id"`
-	ChainID         string         `json:"chain_id"`
-	Timestamp       string         `json:"timestamp"`
-	TimestampInt    string         `json:"timestamp_int"`
-	CreatedAt       time.Time      `json:"created_at"`
-	TransactionHash string         `json:"transaction_hash"`
-	ContractAddress string         `json:"contract_address"`
-	From            string         `json:"from"`
-	To              string         `json:"to"`
+	ID              int64          `json:"id"`
+	ChainID         int64          `json:"chain_id"`
+	Timestamp       time.Time      `json:"timestamp"`
+	TransactionHash []byte         `json:"transaction_hash"`
+	ContractAddress []byte         `json:"contract_address"`
+	From            []byte         `json:"from"`
+	To              []byte         `json:"to"`
 	TokenID         string         `json:"token_id"`
 	Function        sql.NullString `json:"function"`
 	Name            sql.NullString `json:"name"`
 	Symbol          sql.NullString `json:"symbol"`
+	LogIndex        int64          `json:"log_index"`
 }
 
 type Log struct {
-	ID               int32          `json:"id"`
-	ChainID          string         `json:"chain_id"`
-	Address          sql.NullString `json:"address"`
-	BlockHash        string         `json:"block_hash"`
-	BlockNumber      string         `json:"block_number"`
-	BlockNumberInt   sql.NullString `json:"block_number_int"`
-	Data             sql.NullString `json:"data"`
-	LogIndex         sql.NullString `json:"log_index"`
-	Removed          bool           `json:"removed"`
-	Topics           []string       `json:"topics"`
-	TransactionHash  string         `json:"transaction_hash"`
-	TransactionIndex string         `json:"transaction_index"`
-	From             sql.NullString `json:"from"`
-	To               sql.NullString `json:"to"`
-	Timestamp        string         `json:"timestamp"`
-	TimestampInt     string         `json:"timestamp_int"`
-	CreatedAt        time.Time      `json:"created_at"`
+	ID               int64     `json:"id"`
+	ChainID          int64     `json:"chain_id"`
+	Address          []byte    `json:"address"`
+	BlockHash        []byte    `json:"block_hash"`
+	BlockNumber      int64     `json:"block_number"`
+	Data             []byte    `json:"data"`
+	LogIndex         int64     `json:"log_index"`
+	Removed          bool      `json:"removed"`
+	Topics           [][]byte  `json:"topics"`
+	TransactionHash  []byte    `json:"transaction_hash"`
+	TransactionIndex int64     `json:"transaction_index"`
+	From             []byte    `json:"from"`
+	To               []byte    `json:"to"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+type PartitionPolicy struct {
+	ChainID      int64         `json:"chain_id"`
+	BlockRange   int64         `json:"block_range"`
+	RetainBlocks sql.NullInt64 `json:"retain_blocks"`
+	PrunedBelow  int64         `json:"pruned_below"`
+}
+
+type PartitionRange struct {
+	Parent     string    `json:"parent"`
+	ChainID    int64     `json:"chain_id"`
+	Name       string    `json:"name"`
+	LowerBound int64     `json:"lower_bound"`
+	UpperBound int64     `json:"upper_bound"`
+	Pruned     bool      `json:"pruned"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ReconcileRun struct {
+	ID         int64          `json:"id"`
+	ChainID    int64          `json:"chain_id"`
+	Mode       string         `json:"mode"`
+	Repair     bool           `json:"repair"`
+	Checked    int64          `json:"checked"`
+	Mismatched int64          `json:"mismatched"`
+	StartedAt  time.Time      `json:"started_at"`
+	FinishedAt sql.NullTime   `json:"finished_at"`
+	Skipped    int64          `json:"skipped"`
+	Status     string         `json:"status"`
+	Error      sql.NullString `json:"error"`
 }
 
 type Transaction struct {
-	ID               int32          `json:"id"`
-	ChainID          string         `json:"chain_id"`
-	BlockHash        string         `json:"block_hash"`
-	BlockNumber      string         `json:"block_number"`
-	BlockNumberInt   string         `json:"block_number_int"`
-	From             sql.NullString `json:"from"`
-	To               sql.NullString `json:"to"`
-	Gas              sql.NullString `json:"gas"`
-	GasInt           sql.NullString `json:"gas_int"`
+	ID               int64          `json:"id"`
+	ChainID          int64          `json:"chain_id"`
+	BlockHash        []byte         `json:"block_hash"`
+	BlockNumber      int64          `json:"block_number"`
+	From             []byte         `json:"from"`
+	To               []byte         `json:"to"`
+	Gas              sql.NullInt64  `json:"gas"`
 	GasPrice         sql.NullString `json:"gas_price"`
-	GasPriceInt      sql.NullString `json:"gas_price_int"`
-	Hash             string         `json:"hash"`
-	R                sql.NullString `json:"r"`
-	S                sql.NullString `json:"s"`
-	V                sql.NullString `json:"v"`
-	TransactionIndex sql.NullString `json:"transaction_index"`
+	Hash             []byte         `json:"hash"`
+	R                []byte         `json:"r"`
+	S                []byte         `json:"s"`
+	V                []byte         `json:"v"`
+	TransactionIndex sql.NullInt64  `json:"transaction_index"`
 	Value            sql.NullString `json:"value"`
-	ValueInt         sql.NullString `json:"value_int"`
-	Nonce            sql.NullString `json:"nonce"`
-	Input            sql.NullString `json:"input"`
-	ContractAddress  sql.NullString `json:"contract_address"`
-	GasUsed          sql.NullString `json:"gas_used"`
-	GasUsedInt       sql.NullString `json:"gas_used_int"`
-	Status           sql.NullString `json:"status"`
-	Type             sql.NullString `json:"type"`
-	Timestamp        string         `json:"timestamp"`
-	TimestampInt     string         `json:"timestamp_int"`
-	CreatedAt        time.Time      `json:"created_at"`
-	CoinCount        string         `json:"coin_count"`
-	NftCount         string         `json:"nft_count"`
-	Erc20Count       string         `json:"erc20_count"`
-	Erc721Count      string         `json:"erc721_count"`
-	Erc1155Count     string         `json:"erc1155_count"`
+	Nonce            sql.NullInt64  `json:"nonce"`
+	ContractAddress  []byte         `json:"contract_address"`
+	GasUsed          sql.NullInt64  `json:"gas_used"`
+	Status           sql.NullInt16  `json:"status"`
+	Type             sql.NullInt16  `json:"type"`
+	Timestamp        time.Time      `json:"timestamp"`
+	CoinCount        int32          `json:"coin_count"`
+	NftCount         int32          `json:"nft_count"`
+	Erc20Count       int32          `json:"erc20_count"`
+	Erc721Count      int32          `json:"erc721_count"`
+	Erc1155Count     int32          `json:"erc1155_count"`
+}
+
+type TransactionInput struct {
+	ChainID     int64  `json:"chain_id"`
+	BlockNumber int64  `json:"block_number"`
+	Hash        []byte `json:"hash"`
+	Input       []byte `json:"input"`
 }
 
 type Wallet struct {
-	ID      int32  `json:"id"`
-	ChainID string `json:"chain_id"`
-	Address string `json:"address"`
+	ID      int64  `json:"id"`
+	ChainID int64  `json:"chain_id"`
+	Address []byte `json:"address"`
 	Balance string `json:"balance"`
 }
+
+type WatchAlert struct {
+	ID              int64          `json:"id"`
+	WatchlistID     int64          `json:"watchlist_id"`
+	ChainID         int64          `json:"chain_id"`
+	BlockNumber     int64          `json:"block_number"`
+	TransactionHash []byte         `json:"transaction_hash"`
+	LogIndex        int64          `json:"log_index"`
+	BatchIndex      int32          `json:"batch_index"`
+	Kind            string         `json:"kind"`
+	Contract        []byte         `json:"contract"`
+	TokenID         sql.NullString `json:"token_id"`
+	Address         []byte         `json:"address"`
+	Direction       string         `json:"direction"`
+	Counterparty    []byte         `json:"counterparty"`
+	Amount          string         `json:"amount"`
+	Value           string         `json:"value"`
+	Symbol          sql.NullString `json:"symbol"`
+	CreatedAt       time.Time      `json:"created_at"`
+	DeliveredAt     sql.NullTime   `json:"delivered_at"`
+}
+
+type Watchlist struct {
+	ID        int64          `json:"id"`
+	Name      string         `json:"name"`
+	Email     sql.NullString `json:"email"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type WatchlistAddress struct {
+	WatchlistID int64          `json:"watchlist_id"`
+	ChainID     int64          `json:"chain_id"`
+	Address     []byte         `json:"address"`
+	Label       sql.NullString `json:"label"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	EventKey       string          `json:"event_key"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int32           `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastStatus     sql.NullInt32   `json:"last_status"`
+	LastError      sql.NullString  `json:"last_error"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    sql.NullTime    `json:"delivered_at"`
+}
+
+type WebhookSubscription struct {
+	ID        int64         `json:"id"`
+	Url       string        `json:"url"`
+	Secret    string        `json:"secret"`
+	ChainID   sql.NullInt64 `json:"chain_id"`
+	Kinds     []string      `json:"kinds"`
+	Addresses [][]byte      `json:"addresses"`
+	Contracts [][]byte      `json:"contracts"`
+	Active    bool          `json:"active"`
+	CreatedAt time.Time     `json:"created_at"`
+}
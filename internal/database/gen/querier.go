@@ -6,15 +6,97 @@ package gen
 
 import (
 	"context"
+	"database/sql"
 )
 
 type Querier interface {
+	// 이미 있으면 label 만 바꾼다
+	AddWatchlistAddress(ctx context.Context, arg AddWatchlistAddressParams) error
+	// 보낼 차례가 된 건을 lease 시각까지 가져간다. 보내다 프로세스가 죽으면 lease 가 끝난 뒤 다시 보낸다.
+	// 멈춘 구독의 건은 가져가지 않고 pending 으로 남겨 두었다가 다시 켜면 보낸다
+	ClaimWebhookDeliveries(ctx context.Context, arg ClaimWebhookDeliveriesParams) ([]*ClaimWebhookDeliveriesRow, error)
+	// 최근 구간 이상 징후 수 (알림용)
+	CountAnomaliesSince(ctx context.Context, arg CountAnomaliesSinceParams) (int64, error)
+	CreateAlertRule(ctx context.Context, arg CreateAlertRuleParams) (int64, error)
 	CreateErc1155(ctx context.Context, arg CreateErc1155Params) error
 	CreateErc721(ctx context.Context, arg CreateErc721Params) error
+	// Reconcile Run Insert
+	CreateReconcileRun(ctx context.Context, arg CreateReconcileRunParams) (int64, error)
+	CreateWatchlist(ctx context.Context, arg CreateWatchlistParams) (int64, error)
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (int64, error)
+	DeleteAlertRule(ctx context.Context, name string) (int64, error)
+	// 블록 저장 중에 남긴 이상 징후는 다시 받을 때 새로 남는다
+	DeleteAnomalies(ctx context.Context, arg DeleteAnomaliesParams) (int64, error)
+	DeleteBalanceChanges(ctx context.Context, arg DeleteBalanceChangesParams) (int64, error)
+	// block_removed 알림이 블록마다 나간다 (000007_block_notify)
+	DeleteBlocks(ctx context.Context, arg DeleteBlocksParams) (int64, error)
+	DeleteCoinLogs(ctx context.Context, arg DeleteCoinLogsParams) (int64, error)
+	DeleteERC1155Logs(ctx context.Context, arg DeleteERC1155LogsParams) (int64, error)
+	DeleteERC20Logs(ctx context.Context, arg DeleteERC20LogsParams) (int64, error)
+	DeleteERC721Logs(ctx context.Context, arg DeleteERC721LogsParams) (int64, error)
+	DeleteLogs(ctx context.Context, arg DeleteLogsParams) (int64, error)
+	// 이미 보낸 감시 주소 알림은 남긴다 (다시 받아도 unique 로 중복되지 않는다)
+	DeletePendingWatchAlerts(ctx context.Context, arg DeletePendingWatchAlertsParams) (int64, error)
+	DeletePendingWebhookDeliveries(ctx context.Context, arg DeletePendingWebhookDeliveriesParams) (int64, error)
+	DeleteTransactionInputs(ctx context.Context, arg DeleteTransactionInputsParams) (int64, error)
+	DeleteTransactions(ctx context.Context, arg DeleteTransactionsParams) (int64, error)
+	// 원장에 받은 기록이 하나도 남지 않은 토큰은 구간 안에서 처음 나온 토큰이다
+	DeleteUnownedERC721Balances(ctx context.Context, arg DeleteUnownedERC721BalancesParams) (int64, error)
+	// 주소와 알림도 같이 지워진다 (on delete cascade)
+	DeleteWatchlist(ctx context.Context, id int64) (int64, error)
+	// 쌓인 전송 이력도 같이 지워진다 (on delete cascade)
+	DeleteWebhookSubscription(ctx context.Context, id int64) (int64, error)
+	// 블록 범위 파티션 생성 (transaction, transaction_input, log)
+	EnsureBlockPartition(ctx context.Context, arg EnsureBlockPartitionParams) error
+	// 월 파티션 생성 (coin_log, erc20_log, erc721_log, erc1155_log)
+	EnsureMonthPartition(ctx context.Context, arg EnsureMonthPartitionParams) error
+	// Reconcile Run Finish (status 는 finished / failed)
+	FinishReconcileRun(ctx context.Context, arg FinishReconcileRunParams) error
+	// Balance Drift Summary (토큰별)
+	GetBalanceDriftSummary(ctx context.Context, runID int64) ([]*GetBalanceDriftSummaryRow, error)
+	// Block By Hash
+	GetBlockByHash(ctx context.Context, arg GetBlockByHashParams) (*Block, error)
+	// Block By Number
+	GetBlockByNumber(ctx context.Context, arg GetBlockByNumberParams) (*Block, error)
 	// Block Height
-	GetBlockHeight(ctx context.Context, chainID string) (string, error)
-	// Block Insert
-	InsertBlock(ctx context.Context, arg InsertBlockParams) error
+	GetBlockHeight(ctx context.Context, chainID int64) (int64, error)
+	// Block Number At Timestamp
+	GetBlockNumberAtTimestamp(ctx context.Context, arg GetBlockNumberAtTimestampParams) (int64, error)
+	// 재인덱싱 구간의 블록 timestamp 범위 (로그 파티션을 좁힌다)
+	GetBlockRangeTimestamps(ctx context.Context, arg GetBlockRangeTimestampsParams) (*GetBlockRangeTimestampsRow, error)
+	// Token
+	GetContract(ctx context.Context, arg GetContractParams) (*Contract, error)
+	// ERC1155 보유 수량 (없으면 no rows)
+	GetERC1155Amount(ctx context.Context, arg GetERC1155AmountParams) (string, error)
+	// ERC1155 Balance At Block
+	GetERC1155BalanceAt(ctx context.Context, arg GetERC1155BalanceAtParams) (string, error)
+	// ERC20 Balance At Block
+	GetERC20BalanceAt(ctx context.Context, arg GetERC20BalanceAtParams) (string, error)
+	// ERC721 Owner At Block
+	GetERC721OwnerAt(ctx context.Context, arg GetERC721OwnerAtParams) ([]byte, error)
+	// ERC721 Token Owner
+	GetERC721TokenOwner(ctx context.Context, arg GetERC721TokenOwnerParams) (*GetERC721TokenOwnerRow, error)
+	// Native Balance At Block
+	GetNativeBalanceAt(ctx context.Context, arg GetNativeBalanceAtParams) (string, error)
+	GetPartitionPolicy(ctx context.Context, chainID int64) (*PartitionPolicy, error)
+	// block_number (월 파티션은 unix 초) 가 들어가는 파티션 범위
+	GetPartitionRange(ctx context.Context, arg GetPartitionRangeParams) (*PartitionRange, error)
+	// Transaction By Hash
+	GetTransactionByHash(ctx context.Context, arg GetTransactionByHashParams) (*Transaction, error)
+	// Transaction Input (보관 정책으로 지워졌으면 no rows)
+	GetTransactionInput(ctx context.Context, arg GetTransactionInputParams) ([]byte, error)
+	// Wallet
+	GetWallet(ctx context.Context, arg GetWalletParams) (*Wallet, error)
+	GetWatchlistByName(ctx context.Context, name string) (*Watchlist, error)
+	InsertAlertRuleEvent(ctx context.Context, arg InsertAlertRuleEventParams) error
+	// Anomaly Insert
+	InsertAnomaly(ctx context.Context, arg InsertAnomalyParams) error
+	// Balance Change Insert
+	InsertBalanceChange(ctx context.Context, arg InsertBalanceChangeParams) error
+	// Balance Drift Insert
+	InsertBalanceDrift(ctx context.Context, arg InsertBalanceDriftParams) error
+	// Block Insert (이미 반영된 블록이면 0 rows)
+	InsertBlock(ctx context.Context, arg InsertBlockParams) (int64, error)
 	// Coin Log Insert
 	InsertCoinLog(ctx context.Context, arg InsertCoinLogParams) error
 	// Contract Insert
@@ -29,17 +111,168 @@ type Querier interface {
 	InsertLog(ctx context.Context, arg InsertLogParams) error
 	// Transaction Insert
 	InsertTransaction(ctx context.Context, arg InsertTransactionParams) error
+	// Transaction Input Insert (보관 정책으로 지울 수 있게 따로 저장)
+	InsertTransactionInput(ctx context.Context, arg InsertTransactionInputParams) error
 	// Wallet Insert
 	InsertWallet(ctx context.Context, arg InsertWalletParams) error
+	InsertWatchAlert(ctx context.Context, arg InsertWatchAlertParams) error
+	InsertWebhookDelivery(ctx context.Context, arg InsertWebhookDeliveryParams) error
+	// 블록을 저장할 때 필터를 맞춰 볼 구독
+	ListActiveWebhookSubscriptions(ctx context.Context, chainID sql.NullInt64) ([]*WebhookSubscription, error)
+	// Address ERC1155 Holdings
+	ListAddressERC1155Holdings(ctx context.Context, arg ListAddressERC1155HoldingsParams) ([]*ListAddressERC1155HoldingsRow, error)
+	// Address ERC1155 Transfers
+	ListAddressERC1155Transfers(ctx context.Context, arg ListAddressERC1155TransfersParams) ([]*Erc1155Log, error)
+	// Address ERC20 Holdings
+	ListAddressERC20Holdings(ctx context.Context, arg ListAddressERC20HoldingsParams) ([]*ListAddressERC20HoldingsRow, error)
+	// Address ERC20 Transfers
+	ListAddressERC20Transfers(ctx context.Context, arg ListAddressERC20TransfersParams) ([]*Erc20Log, error)
+	// Address ERC721 Holdings
+	ListAddressERC721Holdings(ctx context.Context, arg ListAddressERC721HoldingsParams) ([]*ListAddressERC721HoldingsRow, error)
+	// Address ERC721 Transfers
+	ListAddressERC721Transfers(ctx context.Context, arg ListAddressERC721TransfersParams) ([]*Erc721Log, error)
+	// Address Logs
+	ListAddressLogs(ctx context.Context, arg ListAddressLogsParams) ([]*Log, error)
+	// Address Logs By topic0
+	ListAddressTopicLogs(ctx context.Context, arg ListAddressTopicLogsParams) ([]*Log, error)
+	// Address Transactions (from / to 가 각각 인덱스를 타도록 UNION, id 내림차순 keyset)
+	ListAddressTransactions(ctx context.Context, arg ListAddressTransactionsParams) ([]*Transaction, error)
+	// rule_name 이 null 이면 전체 (최신순)
+	ListAlertRuleEvents(ctx context.Context, arg ListAlertRuleEventsParams) ([]*AlertRuleEvent, error)
+	ListAlertRules(ctx context.Context) ([]*AlertRule, error)
+	// Anomaly List (최신순)
+	ListAnomalies(ctx context.Context, arg ListAnomaliesParams) ([]*Anomaly, error)
+	// 고정한 높이 이후에 바뀐 잔액. 페이지의 잔액을 그 높이의 값으로 되돌린다 (seeded 면 그 높이에는 없던 잔액)
+	ListBalanceChangesAfter(ctx context.Context, arg ListBalanceChangesAfterParams) ([]*ListBalanceChangesAfterRow, error)
+	// Block Transactions
+	ListBlockTransactions(ctx context.Context, arg ListBlockTransactionsParams) ([]*Transaction, error)
+	ListBlocksByNumber(ctx context.Context, arg ListBlocksByNumberParams) ([]*Block, error)
+	// 스트림 재전송 (재접속한 구독자가 놓친 블록)
+	ListBlocksFrom(ctx context.Context, arg ListBlocksFromParams) ([]*Block, error)
+	// 구간에서 주인이 바뀐 erc721 토큰
+	ListChangedERC721Tokens(ctx context.Context, arg ListChangedERC721TokensParams) ([]*ListChangedERC721TokensRow, error)
+	// timestamp 로 파티션을 좁힌다
+	ListCoinLogsByTransactions(ctx context.Context, arg ListCoinLogsByTransactionsParams) ([]*CoinLog, error)
+	// Contract ERC1155 Transfers
+	ListContractERC1155Transfers(ctx context.Context, arg ListContractERC1155TransfersParams) ([]*Erc1155Log, error)
+	// Contract ERC20 Transfers
+	ListContractERC20Transfers(ctx context.Context, arg ListContractERC20TransfersParams) ([]*Erc20Log, error)
+	// Contract ERC721 Transfers
+	ListContractERC721Transfers(ctx context.Context, arg ListContractERC721TransfersParams) ([]*Erc721Log, error)
+	// GraphQL dataloader 용 일괄 조회 (한 요청에서 모인 키를 체인별로 한 번에 읽는다)
+	ListContractsByHash(ctx context.Context, arg ListContractsByHashParams) ([]*Contract, error)
+	// ERC1155 Balance Page
+	ListERC1155Balances(ctx context.Context, arg ListERC1155BalancesParams) ([]*Erc1155Balance, error)
+	// ERC1155 Holders
+	ListERC1155Holders(ctx context.Context, arg ListERC1155HoldersParams) ([]*ListERC1155HoldersRow, error)
+	ListERC1155LogsByTransactions(ctx context.Context, arg ListERC1155LogsByTransactionsParams) ([]*Erc1155Log, error)
+	// ERC1155 Token Holders
+	ListERC1155TokenHolders(ctx context.Context, arg ListERC1155TokenHoldersParams) ([]*ListERC1155TokenHoldersRow, error)
+	ListERC1155TokensByID(ctx context.Context, arg ListERC1155TokensByIDParams) ([]*Erc1155, error)
+	// ERC20 Balance Page
+	ListERC20Balances(ctx context.Context, arg ListERC20BalancesParams) ([]*Erc20Balance, error)
+	// ERC20 Holders (잔액 내림차순, (balance, id) keyset)
+	ListERC20Holders(ctx context.Context, arg ListERC20HoldersParams) ([]*ListERC20HoldersRow, error)
+	// timestamp 로 파티션을 좁힌다
+	ListERC20LogsByTransactions(ctx context.Context, arg ListERC20LogsByTransactionsParams) ([]*Erc20Log, error)
+	// ERC721 Balance Page
+	ListERC721Balances(ctx context.Context, arg ListERC721BalancesParams) ([]*Erc721Balance, error)
+	// ERC721 Holders
+	ListERC721Holders(ctx context.Context, arg ListERC721HoldersParams) ([]*ListERC721HoldersRow, error)
+	ListERC721LogsByTransactions(ctx context.Context, arg ListERC721LogsByTransactionsParams) ([]*Erc721Log, error)
+	// ERC721 Owner At Block (여러 토큰). 높이까지 마지막으로 받은 주소
+	ListERC721OwnersAt(ctx context.Context, arg ListERC721OwnersAtParams) ([]*ListERC721OwnersAtRow, error)
+	// NFT 메타데이터 (url, image_url)
+	ListERC721TokensByID(ctx context.Context, arg ListERC721TokensByIDParams) ([]*Erc721, error)
+	// Existing ERC1155 Balances
+	ListExistingERC1155Balances(ctx context.Context, arg ListExistingERC1155BalancesParams) ([]*ListExistingERC1155BalancesRow, error)
+	// Existing ERC20 Balances
+	ListExistingERC20Balances(ctx context.Context, arg ListExistingERC20BalancesParams) ([]*ListExistingERC20BalancesRow, error)
+	// Existing ERC721 Tokens
+	ListExistingERC721Tokens(ctx context.Context, arg ListExistingERC721TokensParams) ([]*ListExistingERC721TokensRow, error)
+	// Existing Wallets
+	ListExistingWallets(ctx context.Context, arg ListExistingWalletsParams) ([][]byte, error)
+	// Address Holdings At Block
+	ListHoldingsAt(ctx context.Context, arg ListHoldingsAtParams) ([]*ListHoldingsAtRow, error)
+	ListLogsByTransactions(ctx context.Context, arg ListLogsByTransactionsParams) ([]*Log, error)
+	ListPartitionPolicies(ctx context.Context) ([]*PartitionPolicy, error)
+	ListPartitionRanges(ctx context.Context, chainID int64) ([]*PartitionRange, error)
+	// 아직 보내지 않은 알림 (메일 주소가 있는 watchlist 만)
+	ListPendingWatchAlerts(ctx context.Context, rowLimit int32) ([]*ListPendingWatchAlertsRow, error)
+	// Token Holders At Block
+	ListTokenHoldersAt(ctx context.Context, arg ListTokenHoldersAtParams) ([]*ListTokenHoldersAtRow, error)
+	ListTransactionERC1155Transfers(ctx context.Context, arg ListTransactionERC1155TransfersParams) ([]*Erc1155Log, error)
+	// Transaction ERC20 Transfers (timestamp 로 파티션을 좁힌다)
+	ListTransactionERC20Transfers(ctx context.Context, arg ListTransactionERC20TransfersParams) ([]*Erc20Log, error)
+	ListTransactionERC721Transfers(ctx context.Context, arg ListTransactionERC721TransfersParams) ([]*Erc721Log, error)
+	// block_number 로 파티션을 좁힌다
+	ListTransactionInputs(ctx context.Context, arg ListTransactionInputsParams) ([]*TransactionInput, error)
+	// Transaction Logs (block_number 로 파티션을 좁힌다)
+	ListTransactionLogs(ctx context.Context, arg ListTransactionLogsParams) ([]*Log, error)
+	// ERC721 컬렉션 중 보유 기록도, 생성 기록도 없는 컬렉션 (추적 시작 전에 배포됨)
+	ListUnseededCollections(ctx context.Context, arg ListUnseededCollectionsParams) ([][]byte, error)
+	// Wallet Page
+	ListWallets(ctx context.Context, arg ListWalletsParams) ([]*Wallet, error)
+	// watchlistID 가 null 이면 전체 (최신순)
+	ListWatchAlerts(ctx context.Context, arg ListWatchAlertsParams) ([]*WatchAlert, error)
+	ListWatchlistAddresses(ctx context.Context, watchlistID int64) ([]*WatchlistAddress, error)
+	// 주소 수를 같이 돌려준다
+	ListWatchlists(ctx context.Context) ([]*ListWatchlistsRow, error)
+	// subscription_id, status 가 null 이면 전체 (최신순)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]*ListWebhookDeliveriesRow, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]*WebhookSubscription, error)
+	MarkWatchAlertsDelivered(ctx context.Context, arg MarkWatchAlertsDeliveredParams) error
+	MarkWebhookDelivered(ctx context.Context, arg MarkWebhookDeliveredParams) error
+	// status 가 dead 면 더 보내지 않는다 (수동 재전송만)
+	MarkWebhookFailed(ctx context.Context, arg MarkWebhookFailedParams) error
+	// 블록에 등장한 주소 중 감시 중인 주소
+	MatchWatchlistAddresses(ctx context.Context, arg MatchWatchlistAddressesParams) ([]*MatchWatchlistAddressesRow, error)
+	// 보관 기간이 지난 log / transaction_input 파티션 삭제, 지운 파티션 수
+	PruneRawData(ctx context.Context, arg PruneRawDataParams) (int32, error)
+	RemoveWatchlistAddress(ctx context.Context, arg RemoveWatchlistAddressParams) (int64, error)
+	// ERC721 Owner Repair (스냅샷 이후 소유자가 바뀌지 않은 경우만)
+	RepairERC721Owner(ctx context.Context, arg RepairERC721OwnerParams) (int64, error)
+	// 구독의 dead 건을 모두 다시 보낸다
+	ReplayDeadWebhookDeliveries(ctx context.Context, arg ReplayDeadWebhookDeliveriesParams) (int64, error)
+	// 수동 재전송. 시도 횟수를 초기화해서 처음부터 재시도한다
+	ReplayWebhookDelivery(ctx context.Context, arg ReplayWebhookDeliveryParams) (int64, error)
+	// Anomaly Resolve
+	ResolveAnomaly(ctx context.Context, arg ResolveAnomalyParams) (int64, error)
+	RevertERC1155Balances(ctx context.Context, arg RevertERC1155BalancesParams) (int64, error)
+	RevertERC20Balances(ctx context.Context, arg RevertERC20BalancesParams) (int64, error)
+	// 잔액은 원장의 델타를 빼서 되돌린다. 시드/repair 는 구간과 상관없는 값이라 남긴다
+	RevertWalletBalances(ctx context.Context, arg RevertWalletBalancesParams) (int64, error)
+	// ERC1155 Balance Sample
+	SampleERC1155Balances(ctx context.Context, arg SampleERC1155BalancesParams) ([]*Erc1155Balance, error)
+	// ERC20 Balance Sample
+	SampleERC20Balances(ctx context.Context, arg SampleERC20BalancesParams) ([]*Erc20Balance, error)
+	// ERC721 Balance Sample
+	SampleERC721Balances(ctx context.Context, arg SampleERC721BalancesParams) ([]*Erc721Balance, error)
+	// Wallet Sample
+	SampleWallets(ctx context.Context, arg SampleWalletsParams) ([]*Wallet, error)
+	// ERC1155 Balance Seed
+	SeedERC1155Balance(ctx context.Context, arg SeedERC1155BalanceParams) (int64, error)
+	// ERC20 Balance Seed
+	SeedERC20Balance(ctx context.Context, arg SeedERC20BalanceParams) (int64, error)
+	// ERC721 Balance Seed
+	SeedERC721Balance(ctx context.Context, arg SeedERC721BalanceParams) (int64, error)
+	// Wallet Seed
+	SeedWallet(ctx context.Context, arg SeedWalletParams) (int64, error)
+	SetAlertRuleEnabled(ctx context.Context, arg SetAlertRuleEnabledParams) (int64, error)
+	SetWebhookSubscriptionActive(ctx context.Context, arg SetWebhookSubscriptionActiveParams) (int64, error)
 	// ERC1155 Balance DELETE (소유권 이전 시)
-	SubtractERC1155Balance(ctx context.Context, arg SubtractERC1155BalanceParams) error
+	SubtractERC1155Balance(ctx context.Context, arg SubtractERC1155BalanceParams) (int64, error)
+	// erc721 주인은 원장에서 마지막으로 받은 주소로 맞춘다
+	SyncERC721Owners(ctx context.Context, arg SyncERC721OwnersParams) (int64, error)
 	UpdateContractType(ctx context.Context, arg UpdateContractTypeParams) error
+	UpdateWatchlistEmail(ctx context.Context, arg UpdateWatchlistEmailParams) (int64, error)
 	// ERC1155 Balance UPSERT
 	UpsertERC1155Balance_Add(ctx context.Context, arg UpsertERC1155Balance_AddParams) error
 	// ERC20 Balance UPSERT
-	UpsertERC20Balance(ctx context.Context, arg UpsertERC20BalanceParams) error
+	UpsertERC20Balance(ctx context.Context, arg UpsertERC20BalanceParams) (string, error)
 	// -- ERC721 Balance INSERT
 	UpsertERC721Balance(ctx context.Context, arg UpsertERC721BalanceParams) error
+	UpsertPartitionPolicy(ctx context.Context, arg UpsertPartitionPolicyParams) error
 	// Wallet Update Balance
 	UpsertWalletBalance(ctx context.Context, arg UpsertWalletBalanceParams) error
 }
@@ -21,8 +21,8 @@ DO NOTHING
 `
 
 type CreateErc1155Params struct {
-	ChainID string `json:"chain_id"`
-	Hash    string `json:"hash"`
+	ChainID int64  `json:"chain_id"`
+	Hash    []byte `json:"hash"`
 	TokenID string `json:"token_id"`
 }
 
@@ -39,8 +39,8 @@ DO NOTHING
 `
 
 type CreateErc721Params struct {
-	ChainID string `json:"chain_id"`
-	Hash    string `json:"hash"`
+	ChainID int64  `json:"chain_id"`
+	Hash    []byte `json:"hash"`
 	TokenID string `json:"token_id"`
 }
 
@@ -50,49 +50,45 @@ func (q *Queries) CreateErc721(ctx context.Context, arg CreateErc721Params) erro
 }
 
 const getBlockHeight = `-- name: GetBlockHeight :one
-SELECT number_int from block
+SELECT number from block
 where chain_id = $1
-order by number_int desc
+order by number desc
 limit 1
 `
 
 // Block Height
-func (q *Queries) GetBlockHeight(ctx context.Context, chainID string) (string, error) {
+func (q *Queries) GetBlockHeight(ctx context.Context, chainID int64) (int64, error) {
 	row := q.queryRow(ctx, q.getBlockHeightStmt, getBlockHeight, chainID)
-	var number_int string
-	err := row.Scan(&number_int)
-	return number_int, err
+	var number int64
+	err := row.Scan(&number)
+	return number, err
 }
 
-const insertBlock = `-- name: InsertBlock :exec
+const insertBlock = `-- name: InsertBlock :execrows
 INSERT INTO block (chain_id, difficulty, hash, gas_limit, gas_used, miner, number,
-                   number_int, parent_hash, timestamp, timestamp_int, created_at,
-                   total_difficulty, transactions_root)
+                   parent_hash, timestamp, total_difficulty, transactions_root)
 VALUES ($1, $2, $3, $4, $5, $6, $7,
-        $8, $9, $10, $11, $12,
-        $13, $14)
+        $8, $9, $10, $11)
+ON CONFLICT (hash, chain_id) DO NOTHING
 `
 
 type InsertBlockParams struct {
-	ChainID          string         `json:"chain_id"`
+	ChainID          int64          `json:"chain_id"`
 	Difficulty       sql.NullString `json:"difficulty"`
-	Hash             string         `json:"hash"`
-	GasLimit         sql.NullString `json:"gas_limit"`
-	GasUsed          sql.NullString `json:"gas_used"`
-	Miner            sql.NullString `json:"miner"`
-	Number           string         `json:"number"`
-	NumberInt        string         `json:"number_int"`
-	ParentHash       sql.NullString `json:"parent_hash"`
-	Timestamp        string         `json:"timestamp"`
-	TimestampInt     string         `json:"timestamp_int"`
-	CreatedAt        time.Time      `json:"created_at"`
+	Hash             []byte         `json:"hash"`
+	GasLimit         sql.NullInt64  `json:"gas_limit"`
+	GasUsed          sql.NullInt64  `json:"gas_used"`
+	Miner            []byte         `json:"miner"`
+	Number           int64          `json:"number"`
+	ParentHash       []byte         `json:"parent_hash"`
+	Timestamp        time.Time      `json:"timestamp"`
 	TotalDifficulty  sql.NullString `json:"total_difficulty"`
-	TransactionsRoot sql.NullString `json:"transactions_root"`
+	TransactionsRoot []byte         `json:"transactions_root"`
 }
 
-// Block Insert
-func (q *Queries) InsertBlock(ctx context.Context, arg InsertBlockParams) error {
-	_, err := q.exec(ctx, q.insertBlockStmt, insertBlock,
+// Block Insert (이미 반영된 블록이면 0 rows)
+func (q *Queries) InsertBlock(ctx context.Context, arg InsertBlockParams) (int64, error) {
+	result, err := q.exec(ctx, q.insertBlockStmt, insertBlock,
 		arg.ChainID,
 		arg.Difficulty,
 		arg.Hash,
@@ -100,41 +96,35 @@ func (q *Queries) InsertBlock(ctx context.Context, arg InsertBlockParams) error
 		arg.GasUsed,
 		arg.Miner,
 		arg.Number,
-		arg.NumberInt,
 		arg.ParentHash,
 		arg.Timestamp,
-		arg.TimestampInt,
-		arg.CreatedAt,
 		arg.TotalDifficulty,
 		arg.TransactionsRoot,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const insertCoinLog = `-- name: InsertCoinLog :exec
-INSERT INTO coin_log (chain_id, timestamp, timestamp_int, created_at, transaction_hash,
-                      "from", "to", amount, gas, gas_int, gas_price, gas_price_int,
-                      gas_used, gas_used_int)
-VALUES ($1, $2, $3, $4, $5,
-        $6, $7, $8, $9, $10, $11, $12,
-        $13, $14)
+INSERT INTO coin_log (chain_id, timestamp, transaction_hash,
+                      "from", "to", amount, gas, gas_price, gas_used)
+VALUES ($1, $2, $3,
+        $4, $5, $6, $7, $8, $9)
+ON CONFLICT (chain_id, timestamp, transaction_hash) DO NOTHING
 `
 
 type InsertCoinLogParams struct {
-	ChainID         string         `json:"chain_id"`
-	Timestamp       string         `json:"timestamp"`
-	TimestampInt    string         `json:"timestamp_int"`
-	CreatedAt       time.Time      `json:"created_at"`
-	TransactionHash sql.NullString `json:"transaction_hash"`
-	From            sql.NullString `json:"from"`
-	To              sql.NullString `json:"to"`
+	ChainID         int64          `json:"chain_id"`
+	Timestamp       time.Time      `json:"timestamp"`
+	TransactionHash []byte         `json:"transaction_hash"`
+	From            []byte         `json:"from"`
+	To              []byte         `json:"to"`
 	Amount          sql.NullString `json:"amount"`
-	Gas             sql.NullString `json:"gas"`
-	GasInt          sql.NullString `json:"gas_int"`
+	Gas             sql.NullInt64  `json:"gas"`
 	GasPrice        sql.NullString `json:"gas_price"`
-	GasPriceInt     sql.NullString `json:"gas_price_int"`
-	GasUsed         sql.NullString `json:"gas_used"`
-	GasUsedInt      sql.NullString `json:"gas_used_int"`
+	GasUsed         sql.NullInt64  `json:"gas_used"`
 }
 
 // Coin Log Insert
@@ -142,18 +132,13 @@ func (q *Queries) InsertCoinLog(ctx context.Context, arg InsertCoinLogParams) er
 	_, err := q.exec(ctx, q.insertCoinLogStmt, insertCoinLog,
 		arg.ChainID,
 		arg.Timestamp,
-		arg.TimestampInt,
-		arg.CreatedAt,
 		arg.TransactionHash,
 		arg.From,
 		arg.To,
 		arg.Amount,
 		arg.Gas,
-		arg.GasInt,
 		arg.GasPrice,
-		arg.GasPriceInt,
 		arg.GasUsed,
-		arg.GasUsedInt,
 	)
 	return err
 }
@@ -170,14 +155,14 @@ SET name = EXCLUDED.name,
 `
 
 type InsertContractParams struct {
-	ChainID     string         `json:"chain_id"`
-	Hash        string         `json:"hash"`
+	ChainID     int64          `json:"chain_id"`
+	Hash        []byte         `json:"hash"`
 	Name        sql.NullString `json:"name"`
 	Symbol      sql.NullString `json:"symbol"`
 	Decimals    sql.NullInt32  `json:"decimals"`
 	TotalSupply sql.NullString `json:"total_supply"`
 	Type        sql.NullString `json:"type"`
-	Creator     sql.NullString `json:"creator"`
+	Creator     []byte         `json:"creator"`
 }
 
 // Contract Insert
@@ -196,26 +181,29 @@ func (q *Queries) InsertContract(ctx context.Context, arg InsertContractParams)
 }
 
 const insertERC1155Log = `-- name: InsertERC1155Log :exec
-INSERT INTO erc1155_log (chain_id, timestamp, timestamp_int, created_at, transaction_hash,
-                         contract_address, "from", "to", token_id, amount, function, name, symbol)
-VALUES ($1, $2, $3, $4, $5,
-        $6, $7, $8, $9, $10, $11, $12, $13)
+INSERT INTO erc1155_log (chain_id, timestamp, transaction_hash,
+                         contract_address, "from", "to", token_id, amount, function, name, symbol,
+                         log_index, batch_index)
+VALUES ($1, $2, $3,
+        $4, $5, $6, $7, $8, $9, $10, $11,
+        $12, $13)
+ON CONFLICT (chain_id, timestamp, transaction_hash, log_index, batch_index) DO NOTHING
 `
 
 type InsertERC1155LogParams struct {
-	ChainID         string         `json:"chain_id"`
-	Timestamp       string         `json:"timestamp"`
-	TimestampInt    string         `json:"timestamp_int"`
-	CreatedAt       time.Time      `json:"created_at"`
-	TransactionHash string         `json:"transaction_hash"`
-	ContractAddress sql.NullString `json:"contract_address"`
-	From            sql.NullString `json:"from"`
-	To              sql.NullString `json:"to"`
+	ChainID         int64          `json:"chain_id"`
+	Timestamp       time.Time      `json:"timestamp"`
+	TransactionHash []byte         `json:"transaction_hash"`
+	ContractAddress []byte         `json:"contract_address"`
+	From            []byte         `json:"from"`
+	To              []byte         `json:"to"`
 	TokenID         sql.NullString `json:"token_id"`
 	Amount          sql.NullString `json:"amount"`
 	Function        sql.NullString `json:"function"`
 	Name            sql.NullString `json:"name"`
 	Symbol          sql.NullString `json:"symbol"`
+	LogIndex        int64          `json:"log_index"`
+	BatchIndex      int32          `json:"batch_index"`
 }
 
 // ERC1155 Log Insert
@@ -223,8 +211,6 @@ func (q *Queries) InsertERC1155Log(ctx context.Context, arg InsertERC1155LogPara
 	_, err := q.exec(ctx, q.insertERC1155LogStmt, insertERC1155Log,
 		arg.ChainID,
 		arg.Timestamp,
-		arg.TimestampInt,
-		arg.CreatedAt,
 		arg.TransactionHash,
 		arg.ContractAddress,
 		arg.From,
@@ -234,30 +220,32 @@ func (q *Queries) InsertERC1155Log(ctx context.Context, arg InsertERC1155LogPara
 		arg.Function,
 		arg.Name,
 		arg.Symbol,
+		arg.LogIndex,
+		arg.BatchIndex,
 	)
 	return err
 }
 
 const insertERC20Log = `-- name: InsertERC20Log :exec
-INSERT INTO erc20_log (chain_id, timestamp, timestamp_int, created_at, transaction_hash,
-                       contract_address, "from", "to", amount, function, name, symbol)
-VALUES ($1, $2, $3, $4, $5,
-        $6, $7, $8, $9, $10, $11, $12)
+INSERT INTO erc20_log (chain_id, timestamp, transaction_hash,
+                       contract_address, "from", "to", amount, function, name, symbol, log_index)
+VALUES ($1, $2, $3,
+        $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT (chain_id, timestamp, transaction_hash, log_index) DO NOTHING
 `
 
 type InsertERC20LogParams struct {
-	ChainID         string         `json:"chain_id"`
-	Timestamp       string         `json:"timestamp"`
-	TimestampInt    string         `json:"timestamp_int"`
-	CreatedAt       time.Time      `json:"created_at"`
-	TransactionHash string         `json:"transaction_hash"`
-	ContractAddress string         `json:"contract_address"`
-	From            string         `json:"from"`
-	To              string         `json:"to"`
+	ChainID         int64          `json:"chain_id"`
+	Timestamp       time.Time      `json:"timestamp"`
+	TransactionHash []byte         `json:"transaction_hash"`
+	ContractAddress []byte         `json:"contract_address"`
+	From            []byte         `json:"from"`
+	To              []byte         `json:"to"`
 	Amount          string         `json:"amount"`
 	Function        string         `json:"function"`
 	Name            sql.NullString `json:"name"`
 	Symbol          sql.NullString `json:"symbol"`
+	LogIndex        int64          `json:"log_index"`
 }
 
 // ERC20 Log Insert
@@ -265,8 +253,6 @@ func (q *Queries) InsertERC20Log(ctx context.Context, arg InsertERC20LogParams)
 	_, err := q.exec(ctx, q.insertERC20LogStmt, insertERC20Log,
 		arg.ChainID,
 		arg.Timestamp,
-		arg.TimestampInt,
-		arg.CreatedAt,
 		arg.TransactionHash,
 		arg.ContractAddress,
 		arg.From,
@@ -275,30 +261,31 @@ func (q *Queries) InsertERC20Log(ctx context.Context, arg InsertERC20LogParams)
 		arg.Function,
 		arg.Name,
 		arg.Symbol,
+		arg.LogIndex,
 	)
 	return err
 }
 
 const insertERC721Log = `-- name: InsertERC721Log :exec
-INSERT INTO erc721_log (chain_id, timestamp, timestamp_int, created_at, transaction_hash,
-                        contract_address, "from", "to", token_id, function, name, symbol)
-VALUES ($1, $2, $3, $4, $5,
-        $6, $7, $8, $9, $10, $11, $12)
+INSERT INTO erc721_log (chain_id, timestamp, transaction_hash,
+                        contract_address, "from", "to", token_id, function, name, symbol, log_index)
+VALUES ($1, $2, $3,
+        $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT (chain_id, timestamp, transaction_hash, log_index) DO NOTHING
 `
 
 type InsertERC721LogParams struct {
-	ChainID         string         `json:"chain_id"`
-	Timestamp       string         `json:"timestamp"`
-	TimestampInt    string         `json:"timestamp_int"`
-	CreatedAt       time.Time      `json:"created_at"`
-	TransactionHash string         `json:"transaction_hash"`
-	ContractAddress string         `json:"contract_address"`
-	From            string         `json:"from"`
-	To              string         `json:"to"`
+	ChainID         int64          `json:"chain_id"`
+	Timestamp       time.Time      `json:"timestamp"`
+	TransactionHash []byte         `json:"transaction_hash"`
+	ContractAddress []byte         `json:"contract_address"`
+	From            []byte         `json:"from"`
+	To              []byte         `json:"to"`
 	TokenID         string         `json:"token_id"`
 	Function        sql.NullString `json:"function"`
 	Name            sql.NullString `json:"name"`
 	Symbol          sql.NullString `json:"symbol"`
+	LogIndex        int64          `json:"log_index"`
 }
 
 // ERC721 Log Insert
@@ -306,8 +293,6 @@ func (q *Queries) InsertERC721Log(ctx context.Context, arg InsertERC721LogParams
 	_, err := q.exec(ctx, q.insertERC721LogStmt, insertERC721Log,
 		arg.ChainID,
 		arg.Timestamp,
-		arg.TimestampInt,
-		arg.CreatedAt,
 		arg.TransactionHash,
 		arg.ContractAddress,
 		arg.From,
@@ -316,36 +301,35 @@ func (q *Queries) InsertERC721Log(ctx context.Context, arg InsertERC721LogParams
 		arg.Function,
 		arg.Name,
 		arg.Symbol,
+		arg.LogIndex,
 	)
 	return err
 }
 
 const insertLog = `-- name: InsertLog :exec
-INSERT INTO log (chain_id, address, block_hash, block_number, block_number_int, data,
+INSERT INTO log (chain_id, address, block_hash, block_number, data,
                  log_index, removed, topics, transaction_hash, transaction_index,
-                 "from", "to", timestamp, timestamp_int, created_at)
-VALUES ($1, $2, $3, $4, $5, $6,
-        $7, $8, $9, $10, $11,
-        $12, $13, $14, $15, $16)
+                 "from", "to", timestamp)
+VALUES ($1, $2, $3, $4, $5,
+        $6, $7, $8, $9, $10,
+        $11, $12, $13)
+ON CONFLICT (chain_id, block_number, transaction_hash, log_index) DO NOTHING
 `
 
 type InsertLogParams struct {
-	ChainID          string         `json:"chain_id"`
-	Address          sql.NullString `json:"address"`
-	BlockHash        string         `json:"block_hash"`
-	BlockNumber      string         `json:"block_number"`
-	BlockNumberInt   sql.NullString `json:"block_number_int"`
-	Data             sql.NullString `json:"data"`
-	LogIndex         sql.NullString `json:"log_index"`
-	Removed          bool           `json:"removed"`
-	Topics           []string       `json:"topics"`
-	TransactionHash  string         `json:"transaction_hash"`
-	TransactionIndex string         `json:"transaction_index"`
-	From             sql.NullString `json:"from"`
-	To               sql.NullString `json:"to"`
-	Timestamp        string         `json:"timestamp"`
-	TimestampInt     string         `json:"timestamp_int"`
-	CreatedAt        time.Time      `json:"created_at"`
+	ChainID          int64     `json:"chain_id"`
+	Address          []byte    `json:"address"`
+	BlockHash        []byte    `json:"block_hash"`
+	BlockNumber      int64     `json:"block_number"`
+	Data             []byte    `json:"data"`
+	LogIndex         int64     `json:"log_index"`
+	Removed          bool      `json:"removed"`
+	Topics           [][]byte  `json:"topics"`
+	TransactionHash  []byte    `json:"transaction_hash"`
+	TransactionIndex int64     `json:"transaction_index"`
+	From             []byte    `json:"from"`
+	To               []byte    `json:"to"`
+	Timestamp        time.Time `json:"timestamp"`
 }
 
 // Log Insert
@@ -355,7 +339,6 @@ func (q *Queries) InsertLog(ctx context.Context, arg InsertLogParams) error {
 		arg.Address,
 		arg.BlockHash,
 		arg.BlockNumber,
-		arg.BlockNumberInt,
 		arg.Data,
 		arg.LogIndex,
 		arg.Removed,
@@ -365,56 +348,47 @@ func (q *Queries) InsertLog(ctx context.Context, arg InsertLogParams) error {
 		arg.From,
 		arg.To,
 		arg.Timestamp,
-		arg.TimestampInt,
-		arg.CreatedAt,
 	)
 	return err
 }
 
 const insertTransaction = `-- name: InsertTransaction :exec
-INSERT INTO transaction (chain_id, block_hash, block_number, block_number_int, "from", "to",
-                         gas, gas_int, gas_price, gas_price_int, hash, r, s, v, transaction_index,
-                         value, value_int, nonce, input, contract_address, gas_used, gas_used_int,
-                         status, type, timestamp, timestamp_int, created_at, coin_count, nft_count, erc20_count, erc721_count, erc1155_count)
-VALUES ($1, $2, $3, $4, $5, $6,
-        $7, $8, $9, $10, $11, $12, $13, $14, $15,
-        $16, $17, $18, $19, $20, $21, $22,
-        $23, $24, $25, $26, $27, $28, $29, $30, $31, $32)
+INSERT INTO transaction (chain_id, block_hash, block_number, "from", "to",
+                         gas, gas_price, hash, r, s, v, transaction_index,
+                         value, nonce, contract_address, gas_used,
+                         status, type, timestamp, coin_count, nft_count, erc20_count, erc721_count, erc1155_count)
+VALUES ($1, $2, $3, $4, $5,
+        $6, $7, $8, $9, $10, $11, $12,
+        $13, $14, $15, $16,
+        $17, $18, $19, $20, $21, $22, $23, $24)
+ON CONFLICT (chain_id, block_number, hash) DO NOTHING
 `
 
 type InsertTransactionParams struct {
-	ChainID          string         `json:"chain_id"`
-	BlockHash        string         `json:"block_hash"`
-	BlockNumber      string         `json:"block_number"`
-	BlockNumberInt   string         `json:"block_number_int"`
-	From             sql.NullString `json:"from"`
-	To               sql.NullString `json:"to"`
-	Gas              sql.NullString `json:"gas"`
-	GasInt           sql.NullString `json:"gas_int"`
+	ChainID          int64          `json:"chain_id"`
+	BlockHash        []byte         `json:"block_hash"`
+	BlockNumber      int64          `json:"block_number"`
+	From             []byte         `json:"from"`
+	To               []byte         `json:"to"`
+	Gas              sql.NullInt64  `json:"gas"`
 	GasPrice         sql.NullString `json:"gas_price"`
-	GasPriceInt      sql.NullString `json:"gas_price_int"`
-	Hash             string         `json:"hash"`
-	R                sql.NullString `json:"r"`
-	S                sql.NullString `json:"s"`
-	V                sql.NullString `json:"v"`
-	TransactionIndex sql.NullString `json:"transaction_index"`
+	Hash             []byte         `json:"hash"`
+	R                []byte         `json:"r"`
+	S                []byte         `json:"s"`
+	V                []byte         `json:"v"`
+	TransactionIndex sql.NullInt64  `json:"transaction_index"`
 	Value            sql.NullString `json:"value"`
-	ValueInt         sql.NullString `json:"value_int"`
-	Nonce            sql.NullString `json:"nonce"`
-	Input            sql.NullString `json:"input"`
-	ContractAddress  sql.NullString `json:"contract_address"`
-	GasUsed          sql.NullString `json:"gas_used"`
-	GasUsedInt       sql.NullString `json:"gas_used_int"`
-	Status           sql.NullString `json:"status"`
-	Type             sql.NullString `json:"type"`
-	Timestamp        string         `json:"timestamp"`
-	TimestampInt     string         `json:"timestamp_int"`
-	CreatedAt        time.Time      `json:"created_at"`
-	CoinCount        string         `json:"coin_count"`
-	NftCount         string         `json:"nft_count"`
-	Erc20Count       string         `json:"erc20_count"`
-	Erc721Count      string         `json:"erc721_count"`
-	Erc1155Count     string         `json:"erc1155_count"`
+	Nonce            sql.NullInt64  `json:"nonce"`
+	ContractAddress  []byte         `json:"contract_address"`
+	GasUsed          sql.NullInt64  `json:"gas_used"`
+	Status           sql.NullInt16  `json:"status"`
+	Type             sql.NullInt16  `json:"type"`
+	Timestamp        time.Time      `json:"timestamp"`
+	CoinCount        int32          `json:"coin_count"`
+	NftCount         int32          `json:"nft_count"`
+	Erc20Count       int32          `json:"erc20_count"`
+	Erc721Count      int32          `json:"erc721_count"`
+	Erc1155Count     int32          `json:"erc1155_count"`
 }
 
 // Transaction Insert
@@ -423,30 +397,22 @@ func (q *Queries) InsertTransaction(ctx context.Context, arg InsertTransactionPa
 		arg.ChainID,
 		arg.BlockHash,
 		arg.BlockNumber,
-		arg.BlockNumberInt,
 		arg.From,
 		arg.To,
 		arg.Gas,
-		arg.GasInt,
 		arg.GasPrice,
-		arg.GasPriceInt,
 		arg.Hash,
 		arg.R,
 		arg.S,
 		arg.V,
 		arg.TransactionIndex,
 		arg.Value,
-		arg.ValueInt,
 		arg.Nonce,
-		arg.Input,
 		arg.ContractAddress,
 		arg.GasUsed,
-		arg.GasUsedInt,
 		arg.Status,
 		arg.Type,
 		arg.Timestamp,
-		arg.TimestampInt,
-		arg.CreatedAt,
 		arg.CoinCount,
 		arg.NftCount,
 		arg.Erc20Count,
@@ -456,14 +422,38 @@ func (q *Queries) InsertTransaction(ctx context.Context, arg InsertTransactionPa
 	return err
 }
 
+const insertTransactionInput = `-- name: InsertTransactionInput :exec
+INSERT INTO transaction_input (chain_id, block_number, hash, input)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (chain_id, block_number, hash) DO NOTHING
+`
+
+type InsertTransactionInputParams struct {
+	ChainID     int64  `json:"chain_id"`
+	BlockNumber int64  `json:"block_number"`
+	Hash        []byte `json:"hash"`
+	Input       []byte `json:"input"`
+}
+
+// Transaction Input Insert (보관 정책으로 지울 수 있게 따로 저장)
+func (q *Queries) InsertTransactionInput(ctx context.Context, arg InsertTransactionInputParams) error {
+	_, err := q.exec(ctx, q.insertTransactionInputStmt, insertTransactionInput,
+		arg.ChainID,
+		arg.BlockNumber,
+		arg.Hash,
+		arg.Input,
+	)
+	return err
+}
+
 const insertWallet = `-- name: InsertWallet :exec
 INSERT INTO wallet (chain_id, address)
 VALUES ($1, $2) ON CONFLICT (chain_id, address) DO NOTHING
 `
 
 type InsertWalletParams struct {
-	ChainID string `json:"chain_id"`
-	Address string `json:"address"`
+	ChainID int64  `json:"chain_id"`
+	Address []byte `json:"address"`
 }
 
 // Wallet Insert
@@ -472,7 +462,7 @@ func (q *Queries) InsertWallet(ctx context.Context, arg InsertWalletParams) erro
 	return err
 }
 
-const subtractERC1155Balance = `-- name: SubtractERC1155Balance :exec
+const subtractERC1155Balance = `-- name: SubtractERC1155Balance :execrows
 UPDATE erc1155_balance
 SET amount = amount - $5
 WHERE chain_id = $1 AND hash = $2 AND token_id = $3 AND address = $4
@@ -480,23 +470,26 @@ AND amount >= $5
 `
 
 type SubtractERC1155BalanceParams struct {
-	ChainID string `json:"chain_id"`
-	Hash    string `json:"hash"`
+	ChainID int64  `json:"chain_id"`
+	Hash    []byte `json:"hash"`
 	TokenID string `json:"token_id"`
-	Address string `json:"address"`
+	Address []byte `json:"address"`
 	Amount  string `json:"amount"`
 }
 
 // ERC1155 Balance DELETE (소유권 이전 시)
-func (q *Queries) SubtractERC1155Balance(ctx context.Context, arg SubtractERC1155BalanceParams) error {
-	_, err := q.exec(ctx, q.subtractERC1155BalanceStmt, subtractERC1155Balance,
+func (q *Queries) SubtractERC1155Balance(ctx context.Context, arg SubtractERC1155BalanceParams) (int64, error) {
+	result, err := q.exec(ctx, q.subtractERC1155BalanceStmt, subtractERC1155Balance,
 		arg.ChainID,
 		arg.Hash,
 		arg.TokenID,
 		arg.Address,
 		arg.Amount,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const updateContractType = `-- name: UpdateContractType :exec
@@ -507,8 +500,8 @@ WHERE chain_id = $2 AND hash = $3
 
 type UpdateContractTypeParams struct {
 	Type    sql.NullString `json:"type"`
-	ChainID string         `json:"chain_id"`
-	Hash    string         `json:"hash"`
+	ChainID int64          `json:"chain_id"`
+	Hash    []byte         `json:"hash"`
 }
 
 func (q *Queries) UpdateContractType(ctx context.Context, arg UpdateContractTypeParams) error {
@@ -524,10 +517,10 @@ DO UPDATE SET amount = erc1155_balance.amount + EXCLUDED.amount
 `
 
 type UpsertERC1155Balance_AddParams struct {
-	ChainID string `json:"chain_id"`
-	Hash    string `json:"hash"`
+	ChainID int64  `json:"chain_id"`
+	Hash    []byte `json:"hash"`
 	TokenID string `json:"token_id"`
-	Address string `json:"address"`
+	Address []byte `json:"address"`
 	Amount  string `json:"amount"`
 }
 
@@ -543,29 +536,32 @@ func (q *Queries) UpsertERC1155Balance_Add(ctx context.Context, arg UpsertERC115
 	return err
 }
 
-const upsertERC20Balance = `-- name: UpsertERC20Balance :exec
+const upsertERC20Balance = `-- name: UpsertERC20Balance :one
 INSERT INTO erc20_balance (chain_id, balance, hash, address)
 VALUES ($1, $2, $3, $4)
 ON CONFLICT (hash, chain_id, address) DO UPDATE
 SET balance = erc20_balance.balance + EXCLUDED.balance
+RETURNING balance
 `
 
 type UpsertERC20BalanceParams struct {
-	ChainID string `json:"chain_id"`
+	ChainID int64  `json:"chain_id"`
 	Balance string `json:"balance"`
-	Hash    string `json:"hash"`
-	Address string `json:"address"`
+	Hash    []byte `json:"hash"`
+	Address []byte `json:"address"`
 }
 
 // ERC20 Balance UPSERT
-func (q *Queries) UpsertERC20Balance(ctx context.Context, arg UpsertERC20BalanceParams) error {
-	_, err := q.exec(ctx, q.upsertERC20BalanceStmt, upsertERC20Balance,
+func (q *Queries) UpsertERC20Balance(ctx context.Context, arg UpsertERC20BalanceParams) (string, error) {
+	row := q.queryRow(ctx, q.upsertERC20BalanceStmt, upsertERC20Balance,
 		arg.ChainID,
 		arg.Balance,
 		arg.Hash,
 		arg.Address,
 	)
-	return err
+	var balance string
+	err := row.Scan(&balance)
+	return balance, err
 }
 
 const upsertERC721Balance = `-- name: UpsertERC721Balance :exec
@@ -576,10 +572,10 @@ DO UPDATE SET address = EXCLUDED.address
 `
 
 type UpsertERC721BalanceParams struct {
-	ChainID string `json:"chain_id"`
-	Hash    string `json:"hash"`
+	ChainID int64  `json:"chain_id"`
+	Hash    []byte `json:"hash"`
 	TokenID string `json:"token_id"`
-	Address string `json:"address"`
+	Address []byte `json:"address"`
 }
 
 // -- ERC721 Balance INSERT
@@ -600,8 +596,8 @@ SET balance = wallet.balance + EXCLUDED.balance
 `
 
 type UpsertWalletBalanceParams struct {
-	ChainID string `json:"chain_id"`
-	Address string `json:"address"`
+	ChainID int64  `json:"chain_id"`
+	Address []byte `json:"address"`
 	Balance string `json:"balance"`
 }
 
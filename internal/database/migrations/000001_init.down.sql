@@ -0,0 +1,18 @@
+drop table if exists anomaly;
+drop table if exists balance_drift;
+drop table if exists reconcile_run;
+drop table if exists balance_change;
+drop table if exists erc1155;
+drop table if exists erc721;
+drop table if exists erc1155_balance;
+drop table if exists erc721_balance;
+drop table if exists erc20_balance;
+drop table if exists wallet;
+drop table if exists erc1155_log;
+drop table if exists erc721_log;
+drop table if exists erc20_log;
+drop table if exists coin_log;
+drop table if exists contract;
+drop table if exists log;
+drop table if exists transaction;
+drop table if exists block;
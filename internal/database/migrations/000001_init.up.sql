@@ -1,5 +1,3 @@
--- command + alt + l
-
 create table block
 (
     id                serial primary key,
@@ -17,7 +15,8 @@ create table block
     created_at        timestamp    not null,
     total_difficulty  varchar(255),
     transactions_root varchar(255),
-    unique (hash, chain_id)
+    unique (hash, chain_id),
+    unique (chain_id, number_int)
 );
 
 create table transaction
@@ -76,7 +75,8 @@ create table log
     "to"              varchar(42),
     timestamp         varchar(255) not null,
     timestamp_int     numeric      not null,
-    created_at        timestamp    not null
+    created_at        timestamp    not null,
+    unique (chain_id, transaction_hash, log_index)
 );
 
 create table contract
@@ -111,7 +111,8 @@ create table coin_log
     gas_price        varchar(255),
     gas_price_int    numeric,
     gas_used         varchar(255),
-    gas_used_int     numeric
+    gas_used_int     numeric,
+    unique (chain_id, transaction_hash)
 );
 
 create table erc20_log
@@ -128,7 +129,9 @@ create table erc20_log
     amount           numeric      not null,
     function         varchar(255) not null,
     name             text,
-    symbol           text
+    symbol           text,
+    log_index        numeric      not null,
+    unique (chain_id, transaction_hash, log_index)
 );
 
 create table erc721_log
@@ -145,7 +148,9 @@ create table erc721_log
     token_id         numeric      not null,
     function         varchar(255),
     name             text,
-    symbol           text
+    symbol           text,
+    log_index        numeric      not null,
+    unique (chain_id, transaction_hash, log_index)
 );
 
 create table erc1155_log
@@ -163,7 +168,10 @@ create table erc1155_log
     amount           numeric,
     function         varchar(255),
     name             text,
-    symbol           text
+    symbol           text,
+    log_index        numeric           not null,
+    batch_index      integer default 0 not null, -- TransferBatch 안에서의 순서
+    unique (chain_id, transaction_hash, log_index, batch_index)
 );
 
 create table wallet
@@ -228,13 +236,73 @@ create table erc1155
     unique (chain_id, hash, token_id)
 );
 
-INSERT INTO wallet (chain_id, address, balance)
-VALUES (8989, '0xc90cf9b42cbd122810d3b4661c3e1083d24bb97b', 456719261665419348619673600),
-       (8989, '0xca59d509d074d28c3fa89f976aaf7dc3302b327c', 457796877875121402945740800),
-       (8989, '0xaee6ea43376868951542a32b7629b83bd07f03ae', 1469146820925806344693760000),
-       (8989, '0xab63b99c7bbbaa50d5e172e57242c0a3a0cae3fc', 568172893402942721024000000),
-       (8989, '0x602928e12cf7c77c61f50ae9437b0a082725c1b3', 935000000000000000000000000),
-       (8989, '0xbb16fae5808ff3dd77557448280a900f72b6991a', 192000000000000000000000);
+-- 잔액 변경 원장 (append only)
+create table balance_change
+(
+    id               serial primary key,
+    chain_id         numeric     not null,
+    kind             varchar(16) not null, -- coin / erc20 / erc721 / erc1155
+    hash             varchar(255),         -- coin 이면 null
+    token_id         numeric,
+    address          varchar(42) not null,
+    delta            numeric     not null, -- erc721 은 +1 / -1
+    block_number     numeric     not null,
+    transaction_hash varchar(255),
+    reason           varchar(32) not null, -- transfer / mint / burn / repair / seed
+    created_at       timestamp   not null
+);
+
+create index balance_change_address_idx on balance_change (chain_id, address, block_number);
+create index balance_change_hash_idx on balance_change (chain_id, hash, block_number);
+
+create table reconcile_run
+(
+    id           serial primary key,
+    chain_id     numeric                 not null,
+    mode         varchar(32)             not null, -- full / sample
+    repair       boolean   default false not null,
+    checked      numeric   default 0     not null,
+    mismatched   numeric   default 0     not null,
+    started_at   timestamp               not null,
+    finished_at  timestamp
+);
+
+create table balance_drift
+(
+    id              serial primary key,
+    run_id          integer                 not null references reconcile_run (id),
+    chain_id        numeric                 not null,
+    kind            varchar(16)             not null, -- coin / erc20 / erc721 / erc1155
+    hash            varchar(255),                     -- coin 이면 null
+    token_id        numeric,
+    address         varchar(42)             not null,
+    onchain_address varchar(42),                      -- erc721 실제 소유자
+    block_number    numeric                 not null,
+    stored          numeric                 not null,
+    onchain         numeric                 not null,
+    difference      numeric                 not null,
+    repaired        boolean   default false not null,
+    created_at      timestamp               not null
+);
+
+-- 데이터 이상 징후 (잔액 부족 차감, 음수 잔액, 깨진 로그)
+create table anomaly
+(
+    id               serial primary key,
+    chain_id         numeric                 not null,
+    kind             varchar(64)             not null, -- malformed_log / erc20_negative_balance / erc1155_insufficient_balance
+    block_number     numeric                 not null,
+    transaction_hash varchar(255),
+    log_index        numeric,
+    contract         varchar(42),
+    address          varchar(42),
+    expected         numeric,                          -- 차감하려던 수량
+    actual           numeric,                          -- 차감 전 보유 수량
+    detail           text,
+    resolved         boolean   default false not null,
+    resolved_at      timestamp,
+    resolution       text,
+    created_at       timestamp               not null
+);
 
-INSERT INTO wallet (chain_id, address, balance)
-VALUES (898989, '0xbb16fae5808ff3dd77557448280a900f72b6991a', 192000000000000000000000);
+create index anomaly_unresolved_idx on anomaly (chain_id, resolved, created_at);
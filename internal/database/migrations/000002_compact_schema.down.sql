@@ -0,0 +1,55 @@
+drop trigger if exists block_compact_sync on block;
+drop trigger if exists transaction_compact_sync on transaction;
+drop trigger if exists log_compact_sync on log;
+drop trigger if exists coin_log_compact_sync on coin_log;
+drop trigger if exists erc20_log_compact_sync on erc20_log;
+drop trigger if exists erc721_log_compact_sync on erc721_log;
+drop trigger if exists erc1155_log_compact_sync on erc1155_log;
+drop trigger if exists erc721_compact_sync on erc721;
+drop trigger if exists erc1155_compact_sync on erc1155;
+drop trigger if exists balance_change_compact_sync on balance_change;
+
+drop table if exists compact_progress;
+
+drop function if exists compact_sync();
+drop function if exists compact_block(bigint, bigint);
+drop function if exists compact_transaction(bigint, bigint);
+drop function if exists compact_log(bigint, bigint);
+drop function if exists compact_contract(bigint, bigint);
+drop function if exists compact_coin_log(bigint, bigint);
+drop function if exists compact_erc20_log(bigint, bigint);
+drop function if exists compact_erc721_log(bigint, bigint);
+drop function if exists compact_erc1155_log(bigint, bigint);
+drop function if exists compact_wallet(bigint, bigint);
+drop function if exists compact_erc20_balance(bigint, bigint);
+drop function if exists compact_erc721_balance(bigint, bigint);
+drop function if exists compact_erc1155_balance(bigint, bigint);
+drop function if exists compact_erc721(bigint, bigint);
+drop function if exists compact_erc1155(bigint, bigint);
+drop function if exists compact_balance_change(bigint, bigint);
+drop function if exists compact_reconcile_run(bigint, bigint);
+drop function if exists compact_balance_drift(bigint, bigint);
+drop function if exists compact_anomaly(bigint, bigint);
+
+drop table if exists anomaly_v2;
+drop table if exists balance_drift_v2;
+drop table if exists reconcile_run_v2;
+drop table if exists balance_change_v2;
+drop table if exists erc1155_v2;
+drop table if exists erc721_v2;
+drop table if exists erc1155_balance_v2;
+drop table if exists erc721_balance_v2;
+drop table if exists erc20_balance_v2;
+drop table if exists wallet_v2;
+drop table if exists erc1155_log_v2;
+drop table if exists erc721_log_v2;
+drop table if exists erc20_log_v2;
+drop table if exists coin_log_v2;
+drop table if exists contract_v2;
+drop table if exists log_v2;
+drop table if exists transaction_v2;
+drop table if exists block_v2;
+
+drop function if exists compact_numeric(text);
+drop function if exists compact_uint(text);
+drop function if exists compact_hex(text);
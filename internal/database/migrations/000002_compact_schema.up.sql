@@ -0,0 +1,568 @@
+-- compact 스키마 (1/2): 새 타입의 *_v2 테이블을 만들고 기존 데이터를 옮길 준비를 한다.
+--   hash / address / calldata : bytea
+--   블록 번호, 인덱스, gas      : bigint
+--   uint256 (수량, 잔액)        : numeric(78, 0)
+--   시간                        : timestamptz
+-- 이 마이그레이션 이후 기존 바이너리는 그대로 돌려도 되고, 추가만 되는 테이블은 트리거로 *_v2 에도 같이 들어간다.
+-- 기존 행은 `compact backfill` 로 옮기고, 000003 에서 테이블을 교체한다.
+
+-- 0x hex -> bytea (홀수 자리는 앞에 0 을 붙인다)
+create function compact_hex(value text) returns bytea
+    language sql
+    immutable as
+$$
+select case
+           when value is null or value = '' then null
+           when length(value) % 2 = 1 then decode('0' || substr(value, 3), 'hex')
+           else decode(substr(value, 3), 'hex')
+           end
+$$;
+
+-- 0x quantity -> bigint
+create function compact_uint(value text) returns bigint
+    language sql
+    immutable as
+$$
+select case
+           when value is null or length(value) <= 2 then null
+           else ('x' || lpad(substr(value, 3), 16, '0'))::bit(64)::bigint
+           end
+$$;
+
+-- 0x quantity -> numeric (uint256)
+create function compact_numeric(value text) returns numeric
+    language plpgsql
+    immutable as
+$$
+declare
+    result numeric := 0;
+    digit  text;
+begin
+    if value is null or length(value) <= 2 then
+        return null;
+    end if;
+    foreach digit in array regexp_split_to_array(lower(substr(value, 3)), '')
+        loop
+            result := result * 16 + position(digit in '0123456789abcdef') - 1;
+        end loop;
+    return result;
+end
+$$;
+
+create table block_v2
+(
+    id                bigint generated by default as identity primary key,
+    chain_id          bigint      not null,
+    difficulty        numeric(78, 0),
+    hash              bytea       not null,
+    gas_limit         bigint,
+    gas_used          bigint,
+    miner             bytea,
+    number            bigint      not null,
+    parent_hash       bytea,
+    timestamp         timestamptz not null,
+    total_difficulty  numeric(78, 0),
+    transactions_root bytea,
+    unique (hash, chain_id),
+    unique (chain_id, number)
+);
+
+create table transaction_v2
+(
+    id                bigint generated by default as identity primary key,
+    chain_id          bigint            not null,
+    block_hash        bytea             not null,
+    block_number      bigint            not null,
+    "from"            bytea,
+    "to"              bytea,
+    gas               bigint,
+    gas_price         numeric(78, 0),
+    hash              bytea             not null,
+    r                 bytea,
+    s                 bytea,
+    v                 bytea,
+    transaction_index bigint,
+    value             numeric(78, 0),
+    nonce             bigint,
+    input             bytea,
+    contract_address  bytea,
+    gas_used          bigint,
+    status            smallint,
+    type              smallint,
+    timestamp         timestamptz       not null,
+    coin_count        integer default 0 not null,
+    nft_count         integer default 0 not null,
+    erc20_count       integer default 0 not null,
+    erc721_count      integer default 0 not null,
+    erc1155_count     integer default 0 not null,
+    unique (hash, chain_id)
+);
+
+create table log_v2
+(
+    id                bigint generated by default as identity primary key,
+    chain_id          bigint      not null,
+    address           bytea,
+    block_hash        bytea       not null,
+    block_number      bigint      not null,
+    data              bytea,
+    log_index         bigint      not null,
+    removed           boolean     not null,
+    topics            bytea[],
+    transaction_hash  bytea       not null,
+    transaction_index bigint      not null,
+    "from"            bytea,
+    "to"              bytea,
+    timestamp         timestamptz not null,
+    unique (chain_id, transaction_hash, log_index)
+);
+
+create table contract_v2
+(
+    id             bigint generated by default as identity primary key,
+    chain_id       bigint not null,
+    hash           bytea  not null,
+    name           text,
+    symbol         text,
+    decimals       integer,
+    total_supply   numeric(78, 0),
+    type           varchar(255), -- erc20: 20 erc721: 721 erc1155: 1155
+    creator        bytea,
+    logo_url       text,
+    background_url text,
+    unique (hash, chain_id)
+);
+
+create table coin_log_v2
+(
+    id               bigint generated by default as identity primary key,
+    chain_id         bigint      not null,
+    timestamp        timestamptz not null,
+    transaction_hash bytea       not null,
+    "from"           bytea,
+    "to"             bytea,
+    amount           numeric(78, 0),
+    gas              bigint,
+    gas_price        numeric(78, 0),
+    gas_used         bigint,
+    unique (chain_id, transaction_hash)
+);
+
+create table erc20_log_v2
+(
+    id               bigint generated by default as identity primary key,
+    chain_id         bigint         not null,
+    timestamp        timestamptz    not null,
+    transaction_hash bytea          not null,
+    contract_address bytea          not null,
+    "from"           bytea          not null,
+    "to"             bytea          not null,
+    amount           numeric(78, 0) not null,
+    function         varchar(255)   not null,
+    name             text,
+    symbol           text,
+    log_index        bigint         not null,
+    unique (chain_id, transaction_hash, log_index)
+);
+
+create table erc721_log_v2
+(
+    id               bigint generated by default as identity primary key,
+    chain_id         bigint         not null,
+    timestamp        timestamptz    not null,
+    transaction_hash bytea          not null,
+    contract_address bytea          not null,
+    "from"           bytea          not null,
+    "to"             bytea          not null,
+    token_id         numeric(78, 0) not null,
+    function         varchar(255),
+    name             text,
+    symbol           text,
+    log_index        bigint         not null,
+    unique (chain_id, transaction_hash, log_index)
+);
+
+create table erc1155_log_v2
+(
+    id               bigint generated by default as identity primary key,
+    chain_id         bigint            not null,
+    timestamp        timestamptz       not null,
+    transaction_hash bytea             not null,
+    contract_address bytea,
+    "from"           bytea,
+    "to"             bytea,
+    token_id         numeric(78, 0),
+    amount           numeric(78, 0),
+    function         varchar(255),
+    name             text,
+    symbol           text,
+    log_index        bigint            not null,
+    batch_index      integer default 0 not null, -- TransferBatch 안에서의 순서
+    unique (chain_id, transaction_hash, log_index, batch_index)
+);
+
+create table wallet_v2
+(
+    id       bigint generated by default as identity primary key,
+    chain_id bigint                   not null,
+    address  bytea                    not null,
+    balance  numeric(78, 0) default 0 not null,
+    unique (chain_id, address)
+);
+
+create table erc20_balance_v2
+(
+    id       bigint generated by default as identity primary key,
+    chain_id bigint                   not null,
+    balance  numeric(78, 0) default 0 not null,
+    hash     bytea                    not null,
+    address  bytea                    not null,
+    unique (hash, chain_id, address)
+);
+
+create table erc721_balance_v2
+(
+    id       bigint generated by default as identity primary key,
+    chain_id bigint         not null,
+    hash     bytea          not null,
+    token_id numeric(78, 0) not null,
+    address  bytea          not null,
+    unique (hash, token_id, chain_id)
+);
+
+create table erc1155_balance_v2
+(
+    id       bigint generated by default as identity primary key,
+    chain_id bigint         not null,
+    hash     bytea          not null,
+    token_id numeric(78, 0) not null,
+    address  bytea          not null,
+    amount   numeric(78, 0) not null,
+    unique (hash, token_id, address, chain_id)
+);
+
+create table erc721_v2
+(
+    id        bigint generated by default as identity primary key,
+    chain_id  bigint         not null,
+    hash      bytea          not null,
+    token_id  numeric(78, 0) not null,
+    url       text,
+    image_url text,
+    unique (chain_id, hash, token_id)
+);
+
+create table erc1155_v2
+(
+    id        bigint generated by default as identity primary key,
+    chain_id  bigint         not null,
+    hash      bytea          not null,
+    token_id  numeric(78, 0) not null,
+    url       text,
+    image_url text,
+    unique (chain_id, hash, token_id)
+);
+
+create table balance_change_v2
+(
+    id               bigint generated by default as identity primary key,
+    chain_id         bigint         not null,
+    kind             varchar(16)    not null, -- coin / erc20 / erc721 / erc1155
+    hash             bytea,                   -- coin 이면 null
+    token_id         numeric(78, 0),
+    address          bytea          not null,
+    delta            numeric(78, 0) not null, -- erc721 은 +1 / -1
+    block_number     bigint         not null,
+    transaction_hash bytea,
+    reason           varchar(32)    not null, -- transfer / mint / burn / repair / seed
+    created_at       timestamptz    not null
+);
+
+create index balance_change_v2_address_idx on balance_change_v2 (chain_id, address, block_number);
+create index balance_change_v2_hash_idx on balance_change_v2 (chain_id, hash, block_number);
+
+create table reconcile_run_v2
+(
+    id          bigint generated by default as identity primary key,
+    chain_id    bigint                not null,
+    mode        varchar(32)           not null, -- full / sample
+    repair      boolean default false not null,
+    checked     bigint  default 0     not null,
+    mismatched  bigint  default 0     not null,
+    started_at  timestamptz           not null,
+    finished_at timestamptz
+);
+
+-- run_id 외래키는 000003 에서 교체 후에 건다
+create table balance_drift_v2
+(
+    id              bigint generated by default as identity primary key,
+    run_id          bigint                not null,
+    chain_id        bigint                not null,
+    kind            varchar(16)           not null, -- coin / erc20 / erc721 / erc1155
+    hash            bytea,                          -- coin 이면 null
+    token_id        numeric(78, 0),
+    address         bytea                 not null,
+    onchain_address bytea,                          -- erc721 실제 소유자
+    block_number    bigint                not null,
+    stored          numeric(78, 0)        not null,
+    onchain         numeric(78, 0)        not null,
+    difference      numeric(78, 0)        not null,
+    repaired        boolean default false not null,
+    created_at      timestamptz           not null
+);
+
+create table anomaly_v2
+(
+    id               bigint generated by default as identity primary key,
+    chain_id         bigint                not null,
+    kind             varchar(64)           not null, -- malformed_log / erc20_negative_balance / erc1155_insufficient_balance
+    block_number     bigint                not null,
+    transaction_hash bytea,
+    log_index        bigint,
+    contract         bytea,
+    address          bytea,
+    expected         numeric(78, 0),                 -- 차감하려던 수량
+    actual           numeric(78, 0),                 -- 차감 전 보유 수량
+    detail           text,
+    resolved         boolean default false not null,
+    resolved_at      timestamptz,
+    resolution       text,
+    created_at       timestamptz           not null
+);
+
+create index anomaly_v2_unresolved_idx on anomaly_v2 (chain_id, resolved, created_at);
+
+-- 기존 행 -> *_v2 행 변환 (id 는 그대로 유지)
+create function compact_block(from_id bigint, to_id bigint) returns setof block_v2
+    language sql
+    stable as
+$$
+select id, chain_id::bigint, compact_numeric(difficulty), compact_hex(hash), compact_uint(gas_limit), compact_uint(gas_used),
+       compact_hex(miner), number_int::bigint, compact_hex(parent_hash), to_timestamp(timestamp_int::double precision),
+       compact_numeric(total_difficulty), compact_hex(transactions_root)
+from block
+where id > from_id and id <= to_id
+$$;
+
+create function compact_transaction(from_id bigint, to_id bigint) returns setof transaction_v2
+    language sql
+    stable as
+$$
+select id, chain_id::bigint, compact_hex(block_hash), block_number_int::bigint, compact_hex("from"), compact_hex("to"),
+       gas_int::bigint, gas_price_int, compact_hex(hash), compact_hex(r), compact_hex(s), compact_hex(v),
+       compact_uint(transaction_index), value_int, compact_uint(nonce), compact_hex(input), compact_hex(contract_address),
+       gas_used_int::bigint, compact_uint(status)::smallint, compact_uint(type)::smallint,
+       to_timestamp(timestamp_int::double precision), coin_count::integer, nft_count::integer, erc20_count::integer,
+       erc721_count::integer, erc1155_count::integer
+from transaction
+where id > from_id and id <= to_id
+$$;
+
+create function compact_log(from_id bigint, to_id bigint) returns setof log_v2
+    language sql
+    stable as
+$$
+select id, chain_id::bigint, compact_hex(address), compact_hex(block_hash),
+       coalesce(block_number_int::bigint, compact_uint(block_number)), compact_hex(data),
+       coalesce(compact_uint(log_index), 0), removed,
+       (select array_agg(compact_hex(topic) order by ord) from unnest(topics) with ordinality as t(topic, ord)),
+       compact_hex(transaction_hash), coalesce(compact_uint(transaction_index), 0),
+       nullif(compact_hex("from"), '\x0000000000000000000000000000000000000000'::bytea),
+       nullif(compact_hex("to"), '\x0000000000000000000000000000000000000000'::bytea),
+       to_timestamp(timestamp_int::double precision)
+from log
+where id > from_id and id <= to_id
+$$;
+
+create function compact_contract(from_id bigint, to_id bigint) returns setof contract_v2
+    language sql
+    stable as
+$$
+select id, chain_id::bigint, compact_hex(hash), name, symbol, decimals, total_supply, type, compact_hex(creator),
+       logo_url, background_url
+from contract
+where id > from_id and id <= to_id
+$$;
+
+create function compact_coin_log(from_id bigint, to_id bigint) returns setof coin_log_v2
+    language sql
+    stable as
+$$
+select id, chain_id::bigint, to_timestamp(timestamp_int::double precision), compact_hex(transaction_hash),
+       compact_hex("from"), compact_hex("to"), amount, gas_int::bigint, gas_price_int, gas_used_int::bigint
+from coin_log
+where id > from_id and id <= to_id
+$$;
+
+create function compact_erc20_log(from_id bigint, to_id bigint) returns setof erc20_log_v2
+    language sql
+    stable as
+$$
+select id, chain_id::bigint, to_timestamp(timestamp_int::double precision), compact_hex(transaction_hash),
+       compact_hex(contract_address), compact_hex("from"), compact_hex("to"), amount, function, name, symbol,
+       log_index::bigint
+from erc20_log
+where id > from_id and id <= to_id
+$$;
+
+create function compact_erc721_log(from_id bigint, to_id bigint) returns setof erc721_log_v2
+    language sql
+    stable as
+$$
+select id, chain_id::bigint, to_timestamp(timestamp_int::double precision), compact_hex(transaction_hash),
+       compact_hex(contract_address), compact_hex("from"), compact_hex("to"), token_id, function, name, symbol,
+       log_index::bigint
+from erc721_log
+where id > from_id and id <= to_id
+$$;
+
+create function compact_erc1155_log(from_id bigint, to_id bigint) returns setof erc1155_log_v2
+    language sql
+    stable as
+$$
+select id, chain_id::bigint, to_timestamp(timestamp_int::double precision), compact_hex(transaction_hash),
+       compact_hex(contract_address), compact_hex("from"), compact_hex("to"), token_id, amount, function, name, symbol,
+       log_index::bigint, batch_index
+from erc1155_log
+where id > from_id and id <= to_id
+$$;
+
+create function compact_wallet(from_id bigint, to_id bigint) returns setof wallet_v2
+    language sql
+    stable as
+$$
+select id, chain_id::bigint, compact_hex(address), balance
+from wallet
+where id > from_id and id <= to_id
+$$;
+
+create function compact_erc20_balance(from_id bigint, to_id bigint) returns setof erc20_balance_v2
+    language sql
+    stable as
+$$
+select id, chain_id::bigint, balance, compact_hex(hash), compact_hex(address)
+from erc20_balance
+where id > from_id and id <= to_id
+$$;
+
+create function compact_erc721_balance(from_id bigint, to_id bigint) returns setof erc721_balance_v2
+    language sql
+    stable as
+$$
+select id, chain_id::bigint, compact_hex(hash), token_id, compact_hex(address)
+from erc721_balance
+where id > from_id and id <= to_id
+$$;
+
+create function compact_erc1155_balance(from_id bigint, to_id bigint) returns setof erc1155_balance_v2
+    language sql
+    stable as
+$$
+select id, chain_id::bigint, compact_hex(hash), token_id, compact_hex(address), amount
+from erc1155_balance
+where id > from_id and id <= to_id
+$$;
+
+create function compact_erc721(from_id bigint, to_id bigint) returns setof erc721_v2
+    language sql
+    stable as
+$$
+select id, chain_id::bigint, compact_hex(hash), token_id, url, image_url
+from erc721
+where id > from_id and id <= to_id
+$$;
+
+create function compact_erc1155(from_id bigint, to_id bigint) returns setof erc1155_v2
+    language sql
+    stable as
+$$
+select id, chain_id::bigint, compact_hex(hash), token_id, url, image_url
+from erc1155
+where id > from_id and id <= to_id
+$$;
+
+create function compact_balance_change(from_id bigint, to_id bigint) returns setof balance_change_v2
+    language sql
+    stable as
+$$
+select id, chain_id::bigint, kind, compact_hex(hash), token_id, compact_hex(address), delta, block_number::bigint,
+       compact_hex(transaction_hash), reason, created_at at time zone 'UTC'
+from balance_change
+where id > from_id and id <= to_id
+$$;
+
+create function compact_reconcile_run(from_id bigint, to_id bigint) returns setof reconcile_run_v2
+    language sql
+    stable as
+$$
+select id, chain_id::bigint, mode, repair, checked::bigint, mismatched::bigint, started_at at time zone 'UTC',
+       finished_at at time zone 'UTC'
+from reconcile_run
+where id > from_id and id <= to_id
+$$;
+
+create function compact_balance_drift(from_id bigint, to_id bigint) returns setof balance_drift_v2
+    language sql
+    stable as
+$$
+select id, run_id, chain_id::bigint, kind, compact_hex(hash), token_id, compact_hex(address),
+       compact_hex(onchain_address), block_number::bigint, stored, onchain, difference, repaired,
+       created_at at time zone 'UTC'
+from balance_drift
+where id > from_id and id <= to_id
+$$;
+
+create function compact_anomaly(from_id bigint, to_id bigint) returns setof anomaly_v2
+    language sql
+    stable as
+$$
+select id, chain_id::bigint, kind, block_number::bigint, compact_hex(transaction_hash), log_index::bigint,
+       compact_hex(contract), compact_hex(address), expected, actual, detail, resolved, resolved_at at time zone 'UTC',
+       resolution, created_at at time zone 'UTC'
+from anomaly
+where id > from_id and id <= to_id
+$$;
+
+-- 추가만 되는 테이블은 새로 들어오는 행을 바로 *_v2 에도 넣는다.
+-- 잔액처럼 갱신되는 테이블은 000003 에서 한 번에 옮긴다.
+create function compact_sync() returns trigger
+    language plpgsql as
+$$
+begin
+    execute format('insert into %I select * from %I($1 - 1, $1) on conflict do nothing',
+                   tg_table_name || '_v2', 'compact_' || tg_table_name) using new.id::bigint;
+    return null;
+end
+$$;
+
+create trigger block_compact_sync after insert on block for each row execute function compact_sync();
+create trigger transaction_compact_sync after insert on transaction for each row execute function compact_sync();
+create trigger log_compact_sync after insert on log for each row execute function compact_sync();
+create trigger coin_log_compact_sync after insert on coin_log for each row execute function compact_sync();
+create trigger erc20_log_compact_sync after insert on erc20_log for each row execute function compact_sync();
+create trigger erc721_log_compact_sync after insert on erc721_log for each row execute function compact_sync();
+create trigger erc1155_log_compact_sync after insert on erc1155_log for each row execute function compact_sync();
+create trigger erc721_compact_sync after insert on erc721 for each row execute function compact_sync();
+create trigger erc1155_compact_sync after insert on erc1155 for each row execute function compact_sync();
+create trigger balance_change_compact_sync after insert on balance_change for each row execute function compact_sync();
+
+-- 트리거 생성 시점까지의 행은 backfill 대상 (target_id 까지 옮기면 끝)
+create table compact_progress
+(
+    table_name varchar(64) primary key,
+    last_id    bigint default 0 not null,
+    target_id  bigint           not null
+);
+
+insert into compact_progress (table_name, target_id)
+select 'block', coalesce(max(id), 0) from block
+union all select 'transaction', coalesce(max(id), 0) from transaction
+union all select 'log', coalesce(max(id), 0) from log
+union all select 'coin_log', coalesce(max(id), 0) from coin_log
+union all select 'erc20_log', coalesce(max(id), 0) from erc20_log
+union all select 'erc721_log', coalesce(max(id), 0) from erc721_log
+union all select 'erc1155_log', coalesce(max(id), 0) from erc1155_log
+union all select 'erc721', coalesce(max(id), 0) from erc721
+union all select 'erc1155', coalesce(max(id), 0) from erc1155
+union all select 'balance_change', coalesce(max(id), 0) from balance_change;
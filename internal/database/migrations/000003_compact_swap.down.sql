@@ -0,0 +1,6 @@
+do
+$$
+    begin
+        raise exception 'compact_swap cannot be reverted, restore the database from the backup taken before the swap';
+    end
+$$;
@@ -0,0 +1,107 @@
+-- compact 스키마 (2/2): *_v2 테이블로 교체한다.
+-- tracker 를 멈추고 `compact backfill` 이 끝난 뒤에 실행한다. 되돌릴 수 없으니 백업을 먼저 받는다.
+
+do
+$$
+    declare
+        pending text;
+    begin
+        select string_agg(table_name, ', ' order by table_name)
+        into pending
+        from compact_progress
+        where last_id < target_id;
+
+        if pending is not null then
+            raise exception 'compact backfill is not finished (%), run `compact backfill` first', pending;
+        end if;
+    end
+$$;
+
+-- 갱신되는 테이블은 여기서 한 번에 옮긴다
+insert into contract_v2 select * from compact_contract(0, 9223372036854775807);
+insert into wallet_v2 select * from compact_wallet(0, 9223372036854775807);
+insert into erc20_balance_v2 select * from compact_erc20_balance(0, 9223372036854775807);
+insert into erc721_balance_v2 select * from compact_erc721_balance(0, 9223372036854775807);
+insert into erc1155_balance_v2 select * from compact_erc1155_balance(0, 9223372036854775807);
+insert into reconcile_run_v2 select * from compact_reconcile_run(0, 9223372036854775807);
+insert into balance_drift_v2 select * from compact_balance_drift(0, 9223372036854775807);
+insert into anomaly_v2 select * from compact_anomaly(0, 9223372036854775807);
+
+drop function compact_sync() cascade;
+drop function compact_block(bigint, bigint);
+drop function compact_transaction(bigint, bigint);
+drop function compact_log(bigint, bigint);
+drop function compact_contract(bigint, bigint);
+drop function compact_coin_log(bigint, bigint);
+drop function compact_erc20_log(bigint, bigint);
+drop function compact_erc721_log(bigint, bigint);
+drop function compact_erc1155_log(bigint, bigint);
+drop function compact_wallet(bigint, bigint);
+drop function compact_erc20_balance(bigint, bigint);
+drop function compact_erc721_balance(bigint, bigint);
+drop function compact_erc1155_balance(bigint, bigint);
+drop function compact_erc721(bigint, bigint);
+drop function compact_erc1155(bigint, bigint);
+drop function compact_balance_change(bigint, bigint);
+drop function compact_reconcile_run(bigint, bigint);
+drop function compact_balance_drift(bigint, bigint);
+drop function compact_anomaly(bigint, bigint);
+drop function compact_numeric(text);
+drop function compact_uint(text);
+drop function compact_hex(text);
+drop table compact_progress;
+
+drop table anomaly, balance_drift, reconcile_run, balance_change, erc1155, erc721, erc1155_balance, erc721_balance,
+    erc20_balance, wallet, erc1155_log, erc721_log, erc20_log, coin_log, contract, log, transaction, block;
+
+-- 이름에서 _v2 를 뗀다
+alter table block_v2 rename to block;
+alter table transaction_v2 rename to transaction;
+alter table log_v2 rename to log;
+alter table contract_v2 rename to contract;
+alter table coin_log_v2 rename to coin_log;
+alter table erc20_log_v2 rename to erc20_log;
+alter table erc721_log_v2 rename to erc721_log;
+alter table erc1155_log_v2 rename to erc1155_log;
+alter table wallet_v2 rename to wallet;
+alter table erc20_balance_v2 rename to erc20_balance;
+alter table erc721_balance_v2 rename to erc721_balance;
+alter table erc1155_balance_v2 rename to erc1155_balance;
+alter table erc721_v2 rename to erc721;
+alter table erc1155_v2 rename to erc1155;
+alter table balance_change_v2 rename to balance_change;
+alter table reconcile_run_v2 rename to reconcile_run;
+alter table balance_drift_v2 rename to balance_drift;
+alter table anomaly_v2 rename to anomaly;
+
+-- 제약조건 / id 시퀀스 이름도 맞추고, 시퀀스는 현재 최대값 다음으로 옮긴다
+do
+$$
+    declare
+        t   text;
+        c   record;
+        seq text;
+    begin
+        foreach t in array array ['block', 'transaction', 'log', 'contract', 'coin_log', 'erc20_log', 'erc721_log',
+            'erc1155_log', 'wallet', 'erc20_balance', 'erc721_balance', 'erc1155_balance', 'erc721', 'erc1155',
+            'balance_change', 'reconcile_run', 'balance_drift', 'anomaly']
+            loop
+                for c in select conname from pg_constraint where conrelid = t::regclass and starts_with(conname, t || '_v2_')
+                    loop
+                        execute format('alter table %I rename constraint %I to %I', t, c.conname,
+                                       replace(c.conname, t || '_v2_', t || '_'));
+                    end loop;
+
+                seq := pg_get_serial_sequence(t, 'id');
+                execute format('alter sequence %s rename to %I', seq, t || '_id_seq');
+                execute format('select setval(%L, coalesce((select max(id) from %I), 0) + 1, false)', t || '_id_seq', t);
+            end loop;
+    end
+$$;
+
+alter index balance_change_v2_address_idx rename to balance_change_address_idx;
+alter index balance_change_v2_hash_idx rename to balance_change_hash_idx;
+alter index anomaly_v2_unresolved_idx rename to anomaly_unresolved_idx;
+
+alter table balance_drift
+    add foreign key (run_id) references reconcile_run (id);
@@ -0,0 +1,8 @@
+drop index if exists transaction_from_idx, transaction_to_idx,
+    log_address_idx, log_address_topic0_idx,
+    erc20_log_from_idx, erc20_log_to_idx, erc20_log_contract_idx,
+    erc721_log_from_idx, erc721_log_to_idx, erc721_log_contract_idx,
+    erc1155_log_from_idx, erc1155_log_to_idx, erc1155_log_contract_idx,
+    erc20_balance_address_idx, erc20_balance_holder_idx,
+    erc721_balance_address_idx, erc721_balance_hash_idx,
+    erc1155_balance_address_idx, erc1155_balance_hash_idx;
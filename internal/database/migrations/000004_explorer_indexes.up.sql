@@ -0,0 +1,29 @@
+-- 주소/컨트랙트 기준 조회용 인덱스 (explorer.sql)
+-- 목록은 모두 id 내림차순 keyset 페이지라 마지막 컬럼을 id 로 둔다
+
+create index if not exists transaction_from_idx on transaction (chain_id, "from", id);
+create index if not exists transaction_to_idx on transaction (chain_id, "to", id);
+
+create index if not exists log_address_idx on log (chain_id, address, id);
+create index if not exists log_address_topic0_idx on log (chain_id, address, (topics[1]), id);
+
+create index if not exists erc20_log_from_idx on erc20_log (chain_id, "from", id);
+create index if not exists erc20_log_to_idx on erc20_log (chain_id, "to", id);
+create index if not exists erc20_log_contract_idx on erc20_log (chain_id, contract_address, id);
+
+create index if not exists erc721_log_from_idx on erc721_log (chain_id, "from", id);
+create index if not exists erc721_log_to_idx on erc721_log (chain_id, "to", id);
+create index if not exists erc721_log_contract_idx on erc721_log (chain_id, contract_address, id);
+
+create index if not exists erc1155_log_from_idx on erc1155_log (chain_id, "from", id);
+create index if not exists erc1155_log_to_idx on erc1155_log (chain_id, "to", id);
+create index if not exists erc1155_log_contract_idx on erc1155_log (chain_id, contract_address, id);
+
+create index if not exists erc20_balance_address_idx on erc20_balance (chain_id, address, id);
+create index if not exists erc20_balance_holder_idx on erc20_balance (chain_id, hash, balance, id);
+
+create index if not exists erc721_balance_address_idx on erc721_balance (chain_id, address, id);
+create index if not exists erc721_balance_hash_idx on erc721_balance (chain_id, hash, id);
+
+create index if not exists erc1155_balance_address_idx on erc1155_balance (chain_id, address, id);
+create index if not exists erc1155_balance_hash_idx on erc1155_balance (chain_id, hash, id);
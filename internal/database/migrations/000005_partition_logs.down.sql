@@ -0,0 +1,6 @@
+do
+$$
+    begin
+        raise exception 'partition_logs cannot be reverted (pruned partitions are gone), restore the database from the backup taken before the migration';
+    end
+$$;
@@ -0,0 +1,445 @@
+-- 로그/트랜잭션 테이블을 체인별로 나누고, 다시 블록 범위(transaction, transaction_input, log) 또는 월(coin_log, erc*_log) 로 나눈다.
+-- 하위 파티션은 트래커가 블록을 저장하기 전에 ensure_*_partition 으로 만든다.
+-- 보관 정책(partition_policy.retain_blocks)이 있는 체인은 오래된 log / transaction_input 파티션을 통째로 지운다.
+-- 기존 데이터를 새 테이블로 복사하므로 트래커를 멈추고 실행한다.
+
+create table partition_policy
+(
+    chain_id      bigint primary key,
+    block_range   bigint default 1000000 not null, -- 새로 만드는 블록 범위 파티션 크기
+    retain_blocks bigint,                          -- null 이면 지우지 않는다
+    pruned_below  bigint default 0       not null  -- 이 블록 아래의 원본 로그/input 은 지워졌다
+);
+
+-- 만들어진 하위 파티션. 월 파티션의 범위는 unix 초
+create table partition_range
+(
+    parent      varchar(64)                            not null,
+    chain_id    bigint                                 not null,
+    name        varchar(128)                           not null,
+    lower_bound bigint                                 not null,
+    upper_bound bigint                                 not null,
+    pruned      boolean                  default false not null,
+    created_at  timestamptz              default now() not null,
+    primary key (parent, chain_id, lower_bound)
+);
+
+create function ensure_chain_partition(parent_table text, chain bigint, partition_key text) returns text
+    language plpgsql as
+$$
+declare
+    chained text := parent_table || '_c' || chain;
+begin
+    execute format('create table if not exists %I partition of %I for values in (%s) partition by range (%I)',
+                   chained, parent_table, chain, partition_key);
+    return chained;
+end
+$$;
+
+-- block 이 들어갈 블록 범위 파티션을 만든다. 정책의 block_range 가 바뀌어도 기존 범위와 겹치지 않게 빈 구간만 채운다.
+-- 보관 정책으로 지운 구간을 다시 인덱싱하면 같은 범위로 다시 만든다
+create function ensure_block_partition(parent_table text, chain bigint, block bigint) returns void
+    language plpgsql as
+$$
+declare
+    chained  text;
+    size     bigint;
+    lo       bigint;
+    hi       bigint;
+    covering partition_range;
+begin
+    if exists(select 1
+              from partition_range r
+              where r.parent = parent_table
+                and r.chain_id = chain
+                and r.lower_bound <= block
+                and r.upper_bound > block
+                and not r.pruned) then
+        return;
+    end if;
+
+    perform pg_advisory_xact_lock(hashtext(parent_table || '_c' || chain));
+    chained := ensure_chain_partition(parent_table, chain, 'block_number');
+
+    select *
+    into covering
+    from partition_range r
+    where r.parent = parent_table
+      and r.chain_id = chain
+      and r.lower_bound <= block
+      and r.upper_bound > block;
+    if found then
+        if covering.pruned then
+            execute format('create table if not exists %I partition of %I for values from (%s) to (%s)',
+                           covering.name, chained, covering.lower_bound, covering.upper_bound);
+            update partition_range r
+            set pruned = false
+            where r.parent = parent_table
+              and r.chain_id = chain
+              and r.lower_bound = covering.lower_bound;
+        end if;
+        return;
+    end if;
+
+    select coalesce((select p.block_range from partition_policy p where p.chain_id = chain), 1000000) into size;
+    lo := block - block % size;
+    hi := lo + size;
+    select greatest(lo, coalesce(max(r.upper_bound), lo))
+    into lo
+    from partition_range r
+    where r.parent = parent_table
+      and r.chain_id = chain
+      and r.upper_bound <= block;
+    select least(hi, coalesce(min(r.lower_bound), hi))
+    into hi
+    from partition_range r
+    where r.parent = parent_table
+      and r.chain_id = chain
+      and r.lower_bound > block;
+
+    execute format('create table %I partition of %I for values from (%s) to (%s)',
+                   chained || '_' || lo, chained, lo, hi);
+
+    insert into partition_range (parent, chain_id, name, lower_bound, upper_bound)
+    values (parent_table, chain, chained || '_' || lo, lo, hi);
+end
+$$;
+
+-- ts 가 들어갈 월 파티션을 만든다 (UTC 기준)
+create function ensure_month_partition(parent_table text, chain bigint, ts timestamptz) returns void
+    language plpgsql as
+$$
+declare
+    chained        text;
+    partition_name text;
+    lo             timestamptz := date_trunc('month', ts at time zone 'UTC') at time zone 'UTC';
+    hi             timestamptz := lo + interval '1 month';
+begin
+    if exists(select 1
+              from partition_range r
+              where r.parent = parent_table
+                and r.chain_id = chain
+                and r.lower_bound = extract(epoch from lo)::bigint) then
+        return;
+    end if;
+
+    perform pg_advisory_xact_lock(hashtext(parent_table || '_c' || chain));
+
+    chained := ensure_chain_partition(parent_table, chain, 'timestamp');
+    partition_name := chained || '_' || to_char(lo at time zone 'UTC', 'YYYYMM');
+    execute format('create table if not exists %I partition of %I for values from (%L) to (%L)', partition_name, chained, lo, hi);
+
+    insert into partition_range (parent, chain_id, name, lower_bound, upper_bound)
+    values (parent_table, chain, partition_name, extract(epoch from lo)::bigint, extract(epoch from hi)::bigint)
+    on conflict do nothing;
+end
+$$;
+
+-- 보관 기간이 지난 log / transaction_input 파티션을 지운다. 지운 파티션 수를 돌려준다
+create function prune_raw_data(chain bigint, height bigint) returns integer
+    language plpgsql as
+$$
+declare
+    retain  bigint;
+    cutoff  bigint;
+    r       record;
+    dropped integer := 0;
+begin
+    select p.retain_blocks into retain from partition_policy p where p.chain_id = chain;
+    if retain is null then
+        return 0;
+    end if;
+    cutoff := height - retain;
+
+    for r in select *
+             from partition_range pr
+             where pr.chain_id = chain
+               and pr.parent in ('log', 'transaction_input')
+               and not pr.pruned
+               and pr.upper_bound <= cutoff
+             order by pr.lower_bound
+        loop
+            execute format('drop table if exists %I', r.name);
+            update partition_range
+            set pruned = true
+            where parent = r.parent
+              and chain_id = chain
+              and lower_bound = r.lower_bound;
+            dropped := dropped + 1;
+        end loop;
+
+    update partition_policy p
+    set pruned_below = greatest(p.pruned_below, coalesce((select max(pr.upper_bound)
+                                                          from partition_range pr
+                                                          where pr.chain_id = chain
+                                                            and pr.parent = 'log'
+                                                            and pr.pruned), 0))
+    where p.chain_id = chain;
+
+    return dropped;
+end
+$$;
+
+alter table transaction rename to transaction_old;
+alter table log rename to log_old;
+alter table coin_log rename to coin_log_old;
+alter table erc20_log rename to erc20_log_old;
+alter table erc721_log rename to erc721_log_old;
+alter table erc1155_log rename to erc1155_log_old;
+
+-- 기존 테이블의 제약조건/인덱스/시퀀스 이름을 새 테이블이 쓸 수 있게 비운다
+do
+$$
+    declare
+        t   text;
+        c   record;
+    begin
+        foreach t in array array ['transaction', 'log', 'coin_log', 'erc20_log', 'erc721_log', 'erc1155_log']
+            loop
+                for c in select conname from pg_constraint where conrelid = (t || '_old')::regclass and contype in ('p', 'u')
+                    loop
+                        execute format('alter table %I drop constraint %I', t || '_old', c.conname);
+                    end loop;
+                for c in select indexrelid::regclass::text as name from pg_index where indrelid = (t || '_old')::regclass
+                    loop
+                        execute format('drop index %s', c.name);
+                    end loop;
+                execute format('alter sequence %I rename to %I', t || '_id_seq', t || '_old_id_seq');
+                execute format('create sequence %I', t || '_id_seq');
+            end loop;
+    end
+$$;
+
+create table transaction
+(
+    id                bigint  default nextval('transaction_id_seq') not null,
+    chain_id          bigint                                       not null,
+    block_hash        bytea                                        not null,
+    block_number      bigint                                       not null,
+    "from"            bytea,
+    "to"              bytea,
+    gas               bigint,
+    gas_price         numeric(78, 0),
+    hash              bytea                                        not null,
+    r                 bytea,
+    s                 bytea,
+    v                 bytea,
+    transaction_index bigint,
+    value             numeric(78, 0),
+    nonce             bigint,
+    contract_address  bytea,
+    gas_used          bigint,
+    status            smallint,
+    type              smallint,
+    timestamp         timestamptz                                  not null,
+    coin_count        integer default 0                            not null,
+    nft_count         integer default 0                            not null,
+    erc20_count       integer default 0                            not null,
+    erc721_count      integer default 0                            not null,
+    erc1155_count     integer default 0                            not null,
+    primary key (id, chain_id, block_number),
+    unique (chain_id, block_number, hash)
+) partition by list (chain_id);
+
+-- calldata 는 보관 정책으로 지울 수 있게 따로 둔다
+create table transaction_input
+(
+    chain_id     bigint not null,
+    block_number bigint not null,
+    hash         bytea  not null,
+    input        bytea  not null,
+    primary key (chain_id, block_number, hash)
+) partition by list (chain_id);
+
+create table log
+(
+    id                bigint default nextval('log_id_seq') not null,
+    chain_id          bigint                               not null,
+    address           bytea,
+    block_hash        bytea                                not null,
+    block_number      bigint                               not null,
+    data              bytea,
+    log_index         bigint                               not null,
+    removed           boolean                              not null,
+    topics            bytea[],
+    transaction_hash  bytea                                not null,
+    transaction_index bigint                               not null,
+    "from"            bytea,
+    "to"              bytea,
+    timestamp         timestamptz                          not null,
+    primary key (id, chain_id, block_number),
+    unique (chain_id, block_number, transaction_hash, log_index)
+) partition by list (chain_id);
+
+create table coin_log
+(
+    id               bigint default nextval('coin_log_id_seq') not null,
+    chain_id         bigint                                    not null,
+    timestamp        timestamptz                               not null,
+    transaction_hash bytea                                     not null,
+    "from"           bytea,
+    "to"             bytea,
+    amount           numeric(78, 0),
+    gas              bigint,
+    gas_price        numeric(78, 0),
+    gas_used         bigint,
+    primary key (id, chain_id, timestamp),
+    unique (chain_id, timestamp, transaction_hash)
+) partition by list (chain_id);
+
+create table erc20_log
+(
+    id               bigint default nextval('erc20_log_id_seq') not null,
+    chain_id         bigint                                     not null,
+    timestamp        timestamptz                                not null,
+    transaction_hash bytea                                      not null,
+    contract_address bytea                                      not null,
+    "from"           bytea                                      not null,
+    "to"             bytea                                      not null,
+    amount           numeric(78, 0)                             not null,
+    function         varchar(255)                               not null,
+    name             text,
+    symbol           text,
+    log_index        bigint                                     not null,
+    primary key (id, chain_id, timestamp),
+    unique (chain_id, timestamp, transaction_hash, log_index)
+) partition by list (chain_id);
+
+create table erc721_log
+(
+    id               bigint default nextval('erc721_log_id_seq') not null,
+    chain_id         bigint                                      not null,
+    timestamp        timestamptz                                 not null,
+    transaction_hash bytea                                       not null,
+    contract_address bytea                                       not null,
+    "from"           bytea                                       not null,
+    "to"             bytea                                       not null,
+    token_id         numeric(78, 0)                              not null,
+    function         varchar(255),
+    name             text,
+    symbol           text,
+    log_index        bigint                                      not null,
+    primary key (id, chain_id, timestamp),
+    unique (chain_id, timestamp, transaction_hash, log_index)
+) partition by list (chain_id);
+
+create table erc1155_log
+(
+    id               bigint            default nextval('erc1155_log_id_seq') not null,
+    chain_id         bigint                                                  not null,
+    timestamp        timestamptz                                             not null,
+    transaction_hash bytea                                                   not null,
+    contract_address bytea,
+    "from"           bytea,
+    "to"             bytea,
+    token_id         numeric(78, 0),
+    amount           numeric(78, 0),
+    function         varchar(255),
+    name             text,
+    symbol           text,
+    log_index        bigint                                                  not null,
+    batch_index      integer default 0                                       not null, -- TransferBatch 안에서의 순서
+    primary key (id, chain_id, timestamp),
+    unique (chain_id, timestamp, transaction_hash, log_index, batch_index)
+) partition by list (chain_id);
+
+alter sequence transaction_id_seq owned by transaction.id;
+alter sequence log_id_seq owned by log.id;
+alter sequence coin_log_id_seq owned by coin_log.id;
+alter sequence erc20_log_id_seq owned by erc20_log.id;
+alter sequence erc721_log_id_seq owned by erc721_log.id;
+alter sequence erc1155_log_id_seq owned by erc1155_log.id;
+
+-- 000004 인덱스 (분할 테이블에 만들면 하위 파티션에도 만들어진다)
+create index transaction_from_idx on transaction (chain_id, "from", id);
+create index transaction_to_idx on transaction (chain_id, "to", id);
+create index log_address_idx on log (chain_id, address, id);
+create index log_address_topic0_idx on log (chain_id, address, (topics[1]), id);
+create index erc20_log_from_idx on erc20_log (chain_id, "from", id);
+create index erc20_log_to_idx on erc20_log (chain_id, "to", id);
+create index erc20_log_contract_idx on erc20_log (chain_id, contract_address, id);
+create index erc721_log_from_idx on erc721_log (chain_id, "from", id);
+create index erc721_log_to_idx on erc721_log (chain_id, "to", id);
+create index erc721_log_contract_idx on erc721_log (chain_id, contract_address, id);
+create index erc1155_log_from_idx on erc1155_log (chain_id, "from", id);
+create index erc1155_log_to_idx on erc1155_log (chain_id, "to", id);
+create index erc1155_log_contract_idx on erc1155_log (chain_id, contract_address, id);
+
+-- 기존 데이터가 들어갈 파티션
+do
+$$
+    declare
+        r record;
+        b bigint;
+        t text;
+    begin
+        for r in select chain_id, min(block_number) as lo, max(block_number) as hi from transaction_old group by chain_id
+            loop
+                b := r.lo;
+                while b <= r.hi
+                    loop
+                        perform ensure_block_partition('transaction', r.chain_id, b);
+                        perform ensure_block_partition('transaction_input', r.chain_id, b);
+                        b := (select upper_bound
+                              from partition_range
+                              where parent = 'transaction'
+                                and chain_id = r.chain_id
+                                and lower_bound <= b
+                                and upper_bound > b);
+                    end loop;
+            end loop;
+
+        for r in select chain_id, min(block_number) as lo, max(block_number) as hi from log_old group by chain_id
+            loop
+                b := r.lo;
+                while b <= r.hi
+                    loop
+                        perform ensure_block_partition('log', r.chain_id, b);
+                        b := (select upper_bound
+                              from partition_range
+                              where parent = 'log'
+                                and chain_id = r.chain_id
+                                and lower_bound <= b
+                                and upper_bound > b);
+                    end loop;
+            end loop;
+
+        foreach t in array array ['coin_log', 'erc20_log', 'erc721_log', 'erc1155_log']
+            loop
+                for r in execute format('select distinct chain_id, date_trunc(''month'', timestamp at time zone ''UTC'') at time zone ''UTC'' as month from %I',
+                                        t || '_old')
+                    loop
+                        perform ensure_month_partition(t, r.chain_id, r.month);
+                    end loop;
+            end loop;
+    end
+$$;
+
+insert into transaction (id, chain_id, block_hash, block_number, "from", "to", gas, gas_price, hash, r, s, v,
+                         transaction_index, value, nonce, contract_address, gas_used, status, type, timestamp,
+                         coin_count, nft_count, erc20_count, erc721_count, erc1155_count)
+select id, chain_id, block_hash, block_number, "from", "to", gas, gas_price, hash, r, s, v,
+       transaction_index, value, nonce, contract_address, gas_used, status, type, timestamp,
+       coin_count, nft_count, erc20_count, erc721_count, erc1155_count
+from transaction_old;
+
+insert into transaction_input (chain_id, block_number, hash, input)
+select chain_id, block_number, hash, input
+from transaction_old
+where input is not null
+  and length(input) > 0;
+
+insert into log select * from log_old;
+insert into coin_log select * from coin_log_old;
+insert into erc20_log select * from erc20_log_old;
+insert into erc721_log select * from erc721_log_old;
+insert into erc1155_log select * from erc1155_log_old;
+
+select setval('transaction_id_seq', coalesce((select max(id) from transaction_old), 0) + 1, false);
+select setval('log_id_seq', coalesce((select max(id) from log_old), 0) + 1, false);
+select setval('coin_log_id_seq', coalesce((select max(id) from coin_log_old), 0) + 1, false);
+select setval('erc20_log_id_seq', coalesce((select max(id) from erc20_log_old), 0) + 1, false);
+select setval('erc721_log_id_seq', coalesce((select max(id) from erc721_log_old), 0) + 1, false);
+select setval('erc1155_log_id_seq', coalesce((select max(id) from erc1155_log_old), 0) + 1, false);
+
+drop table transaction_old, log_old, coin_log_old, erc20_log_old, erc721_log_old, erc1155_log_old;
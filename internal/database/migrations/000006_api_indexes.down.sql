@@ -0,0 +1 @@
+drop index if exists transaction_hash_idx;
@@ -0,0 +1,2 @@
+-- 해시로 트랜잭션 조회 (REST API). 로그/전송 내역은 기존 unique 인덱스로 찾는다
+create index if not exists transaction_hash_idx on transaction (chain_id, hash);
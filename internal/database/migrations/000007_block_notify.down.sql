@@ -0,0 +1,3 @@
+drop trigger if exists block_removed_notify on block;
+drop trigger if exists block_committed_notify on block;
+drop function if exists notify_block();
@@ -0,0 +1,32 @@
+-- 블록 저장/삭제를 LISTEN 중인 API 서버에 알린다. 알림은 트랜잭션이 커밋될 때 전달된다.
+-- payload: {"chain_id": 1, "number": 123, "hash": "0x..."}
+create function notify_block() returns trigger
+    language plpgsql as
+$$
+declare
+    b       block;
+    channel text;
+begin
+    if tg_op = 'DELETE' then
+        b := old;
+        channel := 'block_removed';
+    else
+        b := new;
+        channel := 'block_committed';
+    end if;
+
+    perform pg_notify(channel, json_build_object('chain_id', b.chain_id, 'number', b.number,
+                                                 'hash', '0x' || encode(b.hash, 'hex'))::text);
+    return null;
+end
+$$;
+
+create trigger block_committed_notify
+    after insert on block
+    for each row
+execute function notify_block();
+
+create trigger block_removed_notify
+    after delete on block
+    for each row
+execute function notify_block();
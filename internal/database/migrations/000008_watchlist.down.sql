@@ -0,0 +1,3 @@
+drop table watch_alert;
+drop table watchlist_address;
+drop table watchlist;
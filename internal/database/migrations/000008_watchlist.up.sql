@@ -0,0 +1,47 @@
+-- 주소 묶음(watchlist)과 감시 주소. 같은 주소를 여러 watchlist 에 넣을 수 있다
+create table watchlist
+(
+    id         bigint generated by default as identity primary key,
+    name       varchar(128) not null unique,
+    email      varchar(255),               -- 알림 받을 주소 (없으면 알림만 쌓인다)
+    created_at timestamptz  not null
+);
+
+create table watchlist_address
+(
+    watchlist_id bigint      not null references watchlist (id) on delete cascade,
+    chain_id     bigint      not null,
+    address      bytea       not null,
+    label        varchar(128),
+    created_at   timestamptz not null,
+    primary key (watchlist_id, chain_id, address)
+);
+
+create index watchlist_address_lookup_idx on watchlist_address (chain_id, address);
+
+-- 감시 주소가 보내거나 받은 전송 한 건. 같은 블록을 다시 반영해도 중복되지 않는다
+create table watch_alert
+(
+    id               bigint generated by default as identity primary key,
+    watchlist_id     bigint         not null references watchlist (id) on delete cascade,
+    chain_id         bigint         not null,
+    block_number     bigint         not null,
+    transaction_hash bytea          not null,
+    log_index        bigint         not null, -- coin 이면 -1
+    batch_index      integer        not null, -- erc1155 TransferBatch 안에서의 순서, 나머지는 0
+    kind             varchar(16)    not null, -- coin / erc20 / erc721 / erc1155
+    contract         bytea,                   -- coin 이면 null
+    token_id         numeric(78, 0),
+    address          bytea          not null, -- 감시 주소
+    direction        varchar(3)     not null, -- in / out
+    counterparty     bytea,                   -- 컨트랙트 생성이면 null
+    amount           numeric(78, 0) not null, -- 원본 수량
+    value            numeric        not null, -- decimals 로 나눈 수량 (nft 는 원본 수량)
+    symbol           text,
+    created_at       timestamptz    not null,
+    delivered_at     timestamptz,
+    unique (watchlist_id, chain_id, transaction_hash, kind, log_index, batch_index, address, direction)
+);
+
+create index watch_alert_pending_idx on watch_alert (id) where delivered_at is null;
+create index watch_alert_watchlist_idx on watch_alert (watchlist_id, id);
@@ -0,0 +1,33 @@
+-- 웹훅 구독. 필터는 모두 만족해야 하고 (AND), 목록 안에서는 하나만 맞으면 된다 (OR). 빈 목록은 전체
+create table webhook_subscription
+(
+    id         bigint generated by default as identity primary key,
+    url        text                 not null,
+    secret     text                 not null,               -- HMAC-SHA256 서명 키
+    chain_id   bigint,                                      -- null 이면 전체 체인
+    kinds      varchar(16)[]        not null default '{}',  -- coin / erc20 / erc721 / erc1155
+    addresses  bytea[]              not null default '{}',  -- from 또는 to
+    contracts  bytea[]              not null default '{}',
+    active     boolean default true not null,
+    created_at timestamptz          not null
+);
+
+-- 블록과 같은 트랜잭션에서 쓰는 전송 대기열 (transactional outbox). 커밋된 블록의 이벤트는 최소 한 번 전달된다
+create table webhook_delivery
+(
+    id              bigint generated by default as identity primary key,
+    subscription_id bigint                          not null references webhook_subscription (id) on delete cascade,
+    event_key       text                            not null, -- 같은 블록을 다시 반영해도 중복으로 쌓이지 않게
+    payload         json                            not null, -- 서명하는 본문 그대로
+    status          varchar(16) default 'pending'   not null, -- pending / delivered / dead
+    attempts        integer     default 0           not null,
+    next_attempt_at timestamptz                     not null, -- 보내는 중이면 lease 만료 시각
+    last_status     integer,                                  -- 마지막 응답 코드
+    last_error      text,
+    created_at      timestamptz                     not null,
+    delivered_at    timestamptz,
+    unique (subscription_id, event_key)
+);
+
+create index webhook_delivery_due_idx on webhook_delivery (next_attempt_at) where status = 'pending';
+create index webhook_delivery_subscription_idx on webhook_delivery (subscription_id, status, id);
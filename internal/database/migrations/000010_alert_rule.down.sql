@@ -0,0 +1,2 @@
+drop table alert_rule_event;
+drop table alert_rule;
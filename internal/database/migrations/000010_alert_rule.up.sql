@@ -0,0 +1,28 @@
+-- 블록 활동 알림 규칙. RULES_FILE 의 규칙과 합쳐서 쓴다 (이름이 같으면 DB 규칙)
+create table alert_rule
+(
+    id               bigint generated by default as identity primary key,
+    name             varchar(128)                not null unique,
+    chain_id         bigint,                                   -- null 이면 전체 체인
+    type             varchar(32)                 not null,     -- large_transfer / large_mint / large_burn / contract_deployed / transfer_rate / gas_price_spike
+    params           json                        not null,
+    severity         varchar(16) default 'warning' not null,
+    cooldown_seconds integer     default 300     not null,
+    enabled          boolean     default true    not null,
+    created_at       timestamptz                 not null
+);
+
+-- 규칙이 울린 기록. 파일 규칙도 남기므로 이름으로 남긴다
+create table alert_rule_event
+(
+    id           bigint generated by default as identity primary key,
+    rule_name    varchar(128) not null,
+    chain_id     bigint       not null,
+    block_number bigint       not null,
+    subject      text         not null, -- 컨트랙트/주소 등 cooldown 단위
+    title        text         not null,
+    body         text         not null,
+    created_at   timestamptz  not null
+);
+
+create index alert_rule_event_rule_idx on alert_rule_event (rule_name, id);
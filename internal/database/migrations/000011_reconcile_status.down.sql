@@ -0,0 +1,4 @@
+alter table reconcile_run
+    drop column error,
+    drop column status,
+    drop column skipped;
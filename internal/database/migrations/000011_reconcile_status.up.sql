@@ -0,0 +1,9 @@
+-- 실패한 reconcile 도 끝난 것으로 남기고, 조회에 실패해서 건너뛴 잔액 수를 센다
+alter table reconcile_run
+    add column skipped bigint      default 0         not null,
+    add column status  varchar(16) default 'running' not null, -- running / finished / failed
+    add column error   text;
+
+update reconcile_run
+set status = 'finished'
+where finished_at is not null;
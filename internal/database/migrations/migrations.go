@@ -0,0 +1,9 @@
+package migrations
+
+import "embed"
+
+// FS 버전별 스키마 변경 파일 (<version>_<name>.up.sql / <version>_<name>.down.sql)
+// sqlc 는 이 디렉터리를 schema 로 읽으며 .down.sql 은 무시한다.
+//
+//go:embed *.sql
+var FS embed.FS
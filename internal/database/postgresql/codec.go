@@ -0,0 +1,40 @@
+package postgresql
+
+import (
+	"encoding/hex"
+	"strings"
+)
+
+// HexToBytes 0x hex 문자열 (해시, 주소, calldata) 을 bytea 값으로 바꾼다. 빈 문자열이나 잘못된 값은 NULL
+func HexToBytes(value string) []byte {
+	if value == "" {
+		return nil
+	}
+
+	value = strings.TrimPrefix(value, "0x")
+	if len(value)%2 == 1 {
+		value = "0" + value
+	}
+
+	b, err := hex.DecodeString(value)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+func HexListToBytes(values []string) [][]byte {
+	list := make([][]byte, len(values))
+	for i, value := range values {
+		list[i] = HexToBytes(value)
+	}
+	return list
+}
+
+// BytesToHex bytea 값을 소문자 0x hex 로 바꾼다. NULL 이면 빈 문자열
+func BytesToHex(b []byte) string {
+	if b == nil {
+		return ""
+	}
+	return "0x" + hex.EncodeToString(b)
+}
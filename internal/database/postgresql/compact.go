@@ -0,0 +1,104 @@
+package postgresql
+
+import (
+	"blockchain-tracking/internal/logger"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// compact_progress 는 000002 ~ 000003 사이에만 있는 테이블이라 sqlc 를 쓰지 않는다
+
+type CompactProgress struct {
+	Table    string
+	LastID   int64
+	TargetID int64
+}
+
+// CompactStatus 테이블별 backfill 진행 상황. compact 마이그레이션 구간이 아니면 nil
+func (m *Migrator) CompactStatus(ctx context.Context) ([]CompactProgress, error) {
+	var exists bool
+	err := m.db.QueryRowContext(ctx, "SELECT to_regclass('compact_progress') IS NOT NULL").Scan(&exists)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	rows, err := m.db.QueryContext(ctx, "SELECT table_name, last_id, target_id FROM compact_progress ORDER BY table_name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var progress []CompactProgress
+	for rows.Next() {
+		var p CompactProgress
+		if err = rows.Scan(&p.Table, &p.LastID, &p.TargetID); err != nil {
+			return nil, err
+		}
+		progress = append(progress, p)
+	}
+
+	return progress, rows.Err()
+}
+
+// CompactBackfill 트리거 생성 전의 행을 batch 개씩 *_v2 로 옮긴다. 중간에 멈춰도 이어서 실행할 수 있다
+func (m *Migrator) CompactBackfill(ctx context.Context, batch int64) error {
+	progress, err := m.CompactStatus(ctx)
+	if err != nil {
+		return err
+	}
+	if progress == nil {
+		return fmt.Errorf("compact migration is not in progress, run `migrate up -steps 1` on schema version 1 first")
+	}
+
+	for _, p := range progress {
+		start := time.Now()
+		copied := int64(0)
+
+		for p.LastID < p.TargetID {
+			to := p.LastID + batch
+			if to > p.TargetID {
+				to = p.TargetID
+			}
+
+			n, err := m.compactBatch(ctx, p.Table, p.LastID, to)
+			if err != nil {
+				return fmt.Errorf("compact %s (%d, %d]: %w", p.Table, p.LastID, to, err)
+			}
+			copied += n
+			p.LastID = to
+		}
+
+		m.l.Info("compact backfill done", logger.Field{Key: "table", Value: p.Table}, logger.Field{Key: "copied", Value: copied}, logger.Field{Key: "elapsed", Value: time.Since(start).String()})
+	}
+
+	return nil
+}
+
+func (m *Migrator) compactBatch(ctx context.Context, table string, from, to int64) (int64, error) {
+	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf("INSERT INTO %s SELECT * FROM %s($1, $2) ON CONFLICT DO NOTHING",
+		pq.QuoteIdentifier(table+"_v2"), pq.QuoteIdentifier("compact_"+table))
+	result, err := tx.ExecContext(ctx, query, from, to)
+	if err != nil {
+		return 0, err
+	}
+	copied, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = tx.ExecContext(ctx, "UPDATE compact_progress SET last_id = $2 WHERE table_name = $1", table, to); err != nil {
+		return 0, err
+	}
+
+	return copied, tx.Commit()
+}
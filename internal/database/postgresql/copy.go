@@ -0,0 +1,67 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// CopyIn rows 를 COPY 로 table 에 넣는다. 트랜잭션 안에서만 사용할 수 있다.
+func (d *Database) CopyIn(ctx context.Context, table string, columns []string, rows [][]interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	tx, ok := d.Querier.(*sql.Tx)
+	if !ok {
+		return fmt.Errorf("copy into %s requires a transaction", table)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn(table, columns...))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, row := range rows {
+		if _, err = stmt.ExecContext(ctx, row...); err != nil {
+			return fmt.Errorf("copy into %s: %w", table, err)
+		}
+	}
+
+	// 인자 없이 한 번 더 호출해야 버퍼가 flush 된다
+	if _, err = stmt.ExecContext(ctx); err != nil {
+		return fmt.Errorf("copy into %s: %w", table, err)
+	}
+
+	return nil
+}
+
+// CopyToTemp table 과 같은 컬럼 타입의 임시 테이블을 만들고 rows 를 COPY 한다.
+// 임시 테이블은 커밋 시 삭제되며, 반환된 이름으로 INSERT ... SELECT 하면 된다.
+func (d *Database) CopyToTemp(ctx context.Context, table string, columns []string, rows [][]interface{}) (string, error) {
+	temp := "tmp_" + table
+
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = pq.QuoteIdentifier(column)
+	}
+
+	_, err := d.ExecContext(ctx, fmt.Sprintf(
+		"CREATE TEMP TABLE IF NOT EXISTS %s ON COMMIT DROP AS SELECT %s FROM %s WITH NO DATA",
+		pq.QuoteIdentifier(temp), strings.Join(quoted, ", "), pq.QuoteIdentifier(table),
+	))
+	if err != nil {
+		return "", err
+	}
+
+	// 같은 트랜잭션에서 다시 쓰는 경우를 위해 비운다
+	if _, err = d.ExecContext(ctx, "TRUNCATE "+pq.QuoteIdentifier(temp)); err != nil {
+		return "", err
+	}
+
+	return temp, d.CopyIn(ctx, temp, columns, rows)
+}
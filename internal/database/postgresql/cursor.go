@@ -4,61 +4,82 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/binary"
 	"fmt"
 	"io"
 )
 
+// Cursor 페이지 위치를 클라이언트가 읽거나 바꿀 수 없는 문자열로 만든다 (AES-GCM).
+// 비밀 키는 길이에 상관없이 sha256 으로 32바이트 키를 만든다
 type Cursor struct {
 	secretKey []byte
 }
 
 func NewCursor(secretKey []byte) *Cursor {
+	key := sha256.Sum256(secretKey)
+	return &Cursor{secretKey: key[:]}
+}
+
+func (c *Cursor) Encrypt(id int64) (string, error) {
+	plaintext := make([]byte, 8)
+	binary.BigEndian.PutUint64(plaintext, uint64(id))
 
-	return &Cursor{secretKey: secretKey}
+	return c.Seal(plaintext)
 }
 
-func (c *Cursor) Encrypt(id int) (string, error) {
-	block, err := aes.NewCipher(c.secretKey)
+func (c *Cursor) Decrypt(encodedCursor string) (int64, error) {
+	plaintext, err := c.Open(encodedCursor)
 	if err != nil {
-		return "", err
+		return 0, err
+	}
+	if len(plaintext) != 8 {
+		return 0, fmt.Errorf("invalid cursor")
 	}
 
-	plaintext := make([]byte, 4)
-	binary.BigEndian.PutUint32(plaintext, uint32(id))
+	return int64(binary.BigEndian.Uint64(plaintext)), nil
+}
 
-	ciphertext := make([]byte, aes.BlockSize+len(plaintext))
-	iv := ciphertext[:aes.BlockSize]
-	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+// Seal 임의의 값(예: json)을 cursor 문자열로 만든다
+func (c *Cursor) Seal(plaintext []byte) (string, error) {
+	gcm, err := c.gcm()
+	if err != nil {
 		return "", err
 	}
 
-	stream := cipher.NewCFBEncrypter(block, iv)
-	stream.XORKeyStream(ciphertext[aes.BlockSize:], plaintext)
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
 
-	return base64.URLEncoding.EncodeToString(ciphertext), nil
+	return base64.RawURLEncoding.EncodeToString(gcm.Seal(nonce, nonce, plaintext, nil)), nil
 }
 
-func (c *Cursor) Decrypt(encodedCursor string) (int, error) {
-	ciphertext, err := base64.URLEncoding.DecodeString(encodedCursor)
+// Open 다른 키로 만들었거나 변조된 cursor 는 에러
+func (c *Cursor) Open(encodedCursor string) ([]byte, error) {
+	ciphertext, err := base64.RawURLEncoding.DecodeString(encodedCursor)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	block, err := aes.NewCipher(c.secretKey)
+	gcm, err := c.gcm()
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	if len(ciphertext) < aes.BlockSize {
-		return 0, fmt.Errorf("ciphertext too short")
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
 	}
-	iv := ciphertext[:aes.BlockSize]
-	ciphertext = ciphertext[aes.BlockSize:]
+	nonce := ciphertext[:gcm.NonceSize()]
 
-	stream := cipher.NewCFBDecrypter(block, iv)
-	stream.XORKeyStream(ciphertext, ciphertext)
+	return gcm.Open(nil, nonce, ciphertext[gcm.NonceSize():], nil)
+}
 
-	return int(binary.BigEndian.Uint32(ciphertext)), nil
+func (c *Cursor) gcm() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(c.secretKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
 }
@@ -0,0 +1,79 @@
+package postgresql
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	c := NewCursor([]byte("secret"))
+
+	for _, id := range []int64{0, 1, 1 << 40, -1} {
+		encoded, err := c.Encrypt(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := c.Decrypt(encoded)
+		if err != nil {
+			t.Fatalf("decrypt %d: %v", id, err)
+		}
+		if got != id {
+			t.Errorf("decrypt = %d, want %d", got, id)
+		}
+	}
+
+	sealed, err := c.Seal([]byte(`{"id":42}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	opened, err := c.Open(sealed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(opened) != `{"id":42}` {
+		t.Errorf("open = %s", opened)
+	}
+
+	// nonce 가 매번 달라서 같은 값이어도 cursor 는 다르다
+	first, _ := c.Encrypt(7)
+	second, _ := c.Encrypt(7)
+	if first == second {
+		t.Errorf("same cursor for the same id: %s", first)
+	}
+}
+
+func TestCursorRejectsTampering(t *testing.T) {
+	c := NewCursor([]byte("secret"))
+	encoded, err := c.Encrypt(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flipped := append([]byte{}, raw...)
+	flipped[len(flipped)-1] ^= 0x01
+	sealedJSON, _ := c.Seal([]byte(`{"id":42}`))
+
+	tests := map[string]struct {
+		cursor *Cursor
+		value  string
+	}{
+		"flipped bit":        {c, base64.RawURLEncoding.EncodeToString(flipped)},
+		"truncated":          {c, base64.RawURLEncoding.EncodeToString(raw[:len(raw)-4])},
+		"shorter than nonce": {c, base64.RawURLEncoding.EncodeToString(raw[:8])},
+		"not base64":         {c, "!!!"},
+		"other key":          {NewCursor([]byte("other")), encoded},
+		"empty key":          {NewCursor(nil), encoded},
+		"not an id":          {c, sealedJSON},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if id, err := tt.cursor.Decrypt(tt.value); err == nil {
+				t.Errorf("decrypt accepted %q as %d", tt.value, id)
+			}
+		})
+	}
+}
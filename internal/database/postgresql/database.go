@@ -26,6 +26,7 @@ type Database struct {
 	Querier
 	Queries *gen.Queries //sqlc로 생성된 쿼리
 	cursor  *Cursor
+	dsn     string
 }
 
 type Querier interface {
@@ -56,12 +57,10 @@ func NewDB(config *config.Config, l logger.Logger) (*Database, error) {
 		db.SetMaxOpenConns(MaxOpenConn)
 		if err == nil {
 			err = db.Ping()
-			//:FIXME add your secret key
-			cursorSecret := []byte("secret") // 설정에서 비밀 키 가져오기
-			cursorInstance := NewCursor(cursorSecret)
+			cursorInstance := NewCursor([]byte(config.CursorSecret))
 
 			if err == nil {
-				return &Database{db, gen.New(db), cursorInstance}, nil
+				return &Database{db, gen.New(db), cursorInstance, dsn}, nil
 			}
 		}
 		l.Warn("Failed to connect to the database. Retrying...", logger.NewField("error", err), logger.NewField("backoff", backoff))
@@ -88,10 +87,14 @@ func (d *Database) GetQueryRowerFromContext(ctx context.Context) *Database {
 	return d
 }
 
-func (d *Database) EncryptCursor(id int) (string, error) {
+func (d *Database) EncryptCursor(id int64) (string, error) {
 	return d.cursor.Encrypt(id)
 }
 
-func (d *Database) DecryptCursor(encodedCursor string) (int, error) {
+func (d *Database) DecryptCursor(encodedCursor string) (int64, error) {
 	return d.cursor.Decrypt(encodedCursor)
 }
+
+func (d *Database) Cursor() *Cursor {
+	return d.cursor
+}
@@ -0,0 +1,25 @@
+package postgresql
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	listenMinReconnect = 1 * time.Second
+	listenMaxReconnect = time.Minute
+)
+
+// Listen LISTEN 전용 연결을 따로 연다 (커넥션 풀과 별개).
+// 연결이 끊기면 pq 가 다시 연결하고 Notify 로 nil 을 보낸다. 끊긴 동안의 알림은 전달되지 않는다
+func (d *Database) Listen(channels ...string) (*pq.Listener, error) {
+	listener := pq.NewListener(d.dsn, listenMinReconnect, listenMaxReconnect, nil)
+	for _, channel := range channels {
+		if err := listener.Listen(channel); err != nil {
+			_ = listener.Close()
+			return nil, err
+		}
+	}
+	return listener, nil
+}
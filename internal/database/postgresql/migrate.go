@@ -0,0 +1,349 @@
+package postgresql
+
+import (
+	"blockchain-tracking/internal/logger"
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// 동시에 여러 프로세스가 마이그레이션하지 않도록 거는 advisory lock 키
+const migrationLockKey = 8_329_114_001
+
+var migrationFileRegex = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	l          logger.Logger
+}
+
+func NewMigrator(db *Database, fsys fs.FS, l logger.Logger) (*Migrator, error) {
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db.Querier.(*sql.DB), migrations: migrations, l: l}, nil
+}
+
+// LoadMigrations fsys 최상위의 마이그레이션 파일을 버전 순으로 읽는다
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := migrationFileRegex.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s, %s", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Latest 이 바이너리가 알고 있는 마지막 스키마 버전
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version 적용된 마지막 버전. schema_migrations 가 없으면 0
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	return currentVersion(ctx, m.db)
+}
+
+// Check 실행 전 스키마 버전이 바이너리와 같은지 확인한다
+func (m *Migrator) Check(ctx context.Context) error {
+	version, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	if version == 0 {
+		var legacy bool
+		err = m.db.QueryRowContext(ctx, "SELECT to_regclass('block') IS NOT NULL").Scan(&legacy)
+		if err != nil {
+			return err
+		}
+		if legacy {
+			return fmt.Errorf("database was created from init.sql without schema_migrations, run `migrate force -version 1` then `migrate up`")
+		}
+	}
+
+	if version != m.Latest() {
+		return fmt.Errorf("schema version %d, expected %d, run `migrate up` (or deploy the matching binary)", version, m.Latest())
+	}
+
+	return nil
+}
+
+// Up 아직 적용하지 않은 마이그레이션을 steps 개 적용한다 (0 이면 전부)
+func (m *Migrator) Up(ctx context.Context, steps int) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		version, err := currentVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if version > m.Latest() {
+			return fmt.Errorf("schema version %d is newer than this binary (%d)", version, m.Latest())
+		}
+
+		applied := 0
+		for _, migration := range m.migrations {
+			if migration.Version <= version {
+				continue
+			}
+			if steps > 0 && applied == steps {
+				break
+			}
+
+			err = m.apply(ctx, conn, migration.Version, migration.Name, migration.Up,
+				"INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, now())", migration.Version, migration.Name)
+			if err != nil {
+				return err
+			}
+			applied++
+		}
+
+		if applied == 0 {
+			m.l.Info("schema is up to date", logger.Field{Key: "version", Value: version})
+		}
+		return nil
+	})
+}
+
+// Down 마지막으로 적용한 마이그레이션부터 steps 개 되돌린다
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		for i := 0; i < steps; i++ {
+			version, err := currentVersion(ctx, conn)
+			if err != nil {
+				return err
+			}
+			if version == 0 {
+				return nil
+			}
+
+			migration, ok := m.find(version)
+			if !ok {
+				return fmt.Errorf("migration %d is applied but unknown to this binary", version)
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s has no down file", migration.Version, migration.Name)
+			}
+
+			err = m.apply(ctx, conn, migration.Version, migration.Name, migration.Down,
+				"DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Force 스키마는 건드리지 않고 version 까지 적용된 것으로 기록한다 (init.sql 로 만든 기존 DB 용)
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	if _, ok := m.find(version); !ok && version != 0 {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		if _, err = tx.ExecContext(ctx, "DELETE FROM schema_migrations"); err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+			_, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, now())",
+				migration.Version, migration.Name)
+			if err != nil {
+				return err
+			}
+		}
+
+		m.l.Info("schema version forced", logger.Field{Key: "version", Value: version})
+		return tx.Commit()
+	})
+}
+
+// Status 마이그레이션별 적용 시각 (적용 전이면 nil)
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	appliedAt := make(map[int64]time.Time)
+
+	exists, err := migrationTableExists(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		rows, err := m.db.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var version int64
+			var at time.Time
+			if err = rows.Scan(&version, &at); err != nil {
+				return nil, err
+			}
+			appliedAt[version] = at
+		}
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	status := make([]MigrationStatus, len(m.migrations))
+	for i, migration := range m.migrations {
+		status[i] = MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if at, ok := appliedAt[migration.Version]; ok {
+			status[i].AppliedAt = &at
+		}
+	}
+
+	return status, nil
+}
+
+// apply 마이그레이션 본문과 schema_migrations 기록을 한 트랜잭션에서 실행한다
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, version int64, name, body, record string, args ...interface{}) error {
+	start := time.Now()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, body); err != nil {
+		return fmt.Errorf("migration %d_%s: %w", version, name, err)
+	}
+	if _, err = tx.ExecContext(ctx, record, args...); err != nil {
+		return fmt.Errorf("migration %d_%s: %w", version, name, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	m.l.Info("migration applied", logger.Field{Key: "version", Value: version}, logger.Field{Key: "name", Value: name}, logger.Field{Key: "elapsed", Value: time.Since(start).String()})
+	return nil
+}
+
+func (m *Migrator) find(version int64) (Migration, bool) {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockKey)
+
+	_, err = conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations
+(
+    version    bigint primary key,
+    name       varchar(255) not null,
+    applied_at timestamp    not null
+)`)
+	if err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func migrationTableExists(ctx context.Context, q queryRower) (bool, error) {
+	var exists bool
+	err := q.QueryRowContext(ctx, "SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists)
+	return exists, err
+}
+
+func currentVersion(ctx context.Context, q queryRower) (int64, error) {
+	exists, err := migrationTableExists(ctx, q)
+	if err != nil || !exists {
+		return 0, err
+	}
+
+	var version int64
+	err = q.QueryRowContext(ctx, "SELECT coalesce(max(version), 0) FROM schema_migrations").Scan(&version)
+	return version, err
+}
@@ -12,7 +12,7 @@ type transactionKeyType string
 const transactionKey transactionKeyType = "dbTx"
 
 func NewContextWithTransaction(ctx context.Context, tx *sql.Tx, cursorInstance *Cursor) context.Context {
-	dbTx := &Database{tx, gen.New(tx), cursorInstance}
+	dbTx := &Database{tx, gen.New(tx), cursorInstance, ""}
 	return context.WithValue(ctx, transactionKey, dbTx)
 }
 
@@ -0,0 +1,35 @@
+-- Anomaly Insert
+-- name: InsertAnomaly :exec
+INSERT INTO anomaly (chain_id, kind, block_number, transaction_hash, log_index, contract,
+                     address, expected, actual, detail, created_at)
+VALUES ($1, $2, $3, $4, $5, $6,
+        $7, $8, $9, $10, $11);
+
+-- Anomaly List (최신순)
+-- name: ListAnomalies :many
+SELECT id, chain_id, kind, block_number, transaction_hash, log_index, contract, address, expected, actual,
+       detail, resolved, resolved_at, resolution, created_at
+FROM anomaly
+WHERE (sqlc.narg(chain_id)::bigint IS NULL OR chain_id = sqlc.narg(chain_id))
+  AND (sqlc.narg(kind)::varchar IS NULL OR kind = sqlc.narg(kind))
+  AND resolved = sqlc.arg(resolved)
+ORDER BY id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- Anomaly Resolve
+-- name: ResolveAnomaly :execrows
+UPDATE anomaly
+SET resolved    = true,
+    resolved_at = $2,
+    resolution  = $3
+WHERE id = $1 AND resolved = false;
+
+-- 최근 구간 이상 징후 수 (알림용)
+-- name: CountAnomaliesSince :one
+SELECT count(*) FROM anomaly
+WHERE chain_id = $1 AND created_at >= $2;
+
+-- ERC1155 보유 수량 (없으면 no rows)
+-- name: GetERC1155Amount :one
+SELECT amount FROM erc1155_balance
+WHERE chain_id = $1 AND hash = $2 AND token_id = $3 AND address = $4;
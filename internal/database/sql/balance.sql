@@ -0,0 +1,60 @@
+-- Balance Change Insert
+-- name: InsertBalanceChange :exec
+INSERT INTO balance_change (chain_id, kind, hash, token_id, address, delta,
+                            block_number, transaction_hash, reason, created_at)
+VALUES ($1, $2, $3, $4, $5, $6,
+        $7, $8, $9, $10);
+
+-- Native Balance At Block
+-- name: GetNativeBalanceAt :one
+SELECT coalesce(sum(delta), 0)::numeric AS balance
+FROM balance_change
+WHERE chain_id = $1 AND kind = 'coin' AND address = $2 AND block_number <= $3;
+
+-- ERC20 Balance At Block
+-- name: GetERC20BalanceAt :one
+SELECT coalesce(sum(delta), 0)::numeric AS balance
+FROM balance_change
+WHERE chain_id = $1 AND kind = 'erc20' AND hash = $2 AND address = $3 AND block_number <= $4;
+
+-- ERC1155 Balance At Block
+-- name: GetERC1155BalanceAt :one
+SELECT coalesce(sum(delta), 0)::numeric AS balance
+FROM balance_change
+WHERE chain_id = $1 AND kind = 'erc1155' AND hash = $2 AND token_id = $3 AND address = $4 AND block_number <= $5;
+
+-- ERC721 Owner At Block
+-- name: GetERC721OwnerAt :one
+SELECT address
+FROM balance_change
+WHERE chain_id = $1 AND kind = 'erc721' AND hash = $2 AND token_id = $3 AND block_number <= $4
+GROUP BY address
+HAVING sum(delta) > 0
+ORDER BY max(id) DESC
+LIMIT 1;
+
+-- Address Holdings At Block
+-- name: ListHoldingsAt :many
+SELECT kind, hash, token_id, sum(delta)::numeric AS balance
+FROM balance_change
+WHERE chain_id = $1 AND address = $2 AND block_number <= $3
+GROUP BY kind, hash, token_id
+HAVING sum(delta) <> 0
+ORDER BY kind, hash, token_id;
+
+-- Token Holders At Block
+-- name: ListTokenHoldersAt :many
+SELECT address, token_id, sum(delta)::numeric AS balance
+FROM balance_change
+WHERE chain_id = $1 AND hash = $2 AND block_number <= $3
+GROUP BY address, token_id
+HAVING sum(delta) > 0
+ORDER BY balance DESC, address
+LIMIT $4 OFFSET $5;
+
+-- Block Number At Timestamp
+-- name: GetBlockNumberAtTimestamp :one
+SELECT number FROM block
+WHERE chain_id = $1 AND timestamp <= $2
+ORDER BY number DESC
+LIMIT 1;
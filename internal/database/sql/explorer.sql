@@ -0,0 +1,231 @@
+-- Address Transactions (from / to 가 각각 인덱스를 타도록 UNION, id 내림차순 keyset)
+-- name: ListAddressTransactions :many
+(SELECT id, chain_id, block_hash, block_number, "from", "to", gas, gas_price, hash, r, s, v, transaction_index, value, nonce, contract_address, gas_used, status, type, timestamp, coin_count, nft_count, erc20_count, erc721_count, erc1155_count
+ FROM transaction
+ WHERE chain_id = sqlc.arg(chain_id) AND "from" = sqlc.arg(address) AND id < sqlc.arg(id)
+ ORDER BY id DESC
+ LIMIT sqlc.arg('limit'))
+UNION
+(SELECT id, chain_id, block_hash, block_number, "from", "to", gas, gas_price, hash, r, s, v, transaction_index, value, nonce, contract_address, gas_used, status, type, timestamp, coin_count, nft_count, erc20_count, erc721_count, erc1155_count
+ FROM transaction
+ WHERE chain_id = sqlc.arg(chain_id) AND "to" = sqlc.arg(address) AND id < sqlc.arg(id)
+ ORDER BY id DESC
+ LIMIT sqlc.arg('limit'))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- Transaction Input (보관 정책으로 지워졌으면 no rows)
+-- name: GetTransactionInput :one
+SELECT input FROM transaction_input
+WHERE chain_id = $1 AND block_number = $2 AND hash = $3;
+
+-- Address ERC20 Transfers
+-- name: ListAddressERC20Transfers :many
+(SELECT id, chain_id, timestamp, transaction_hash, contract_address, "from", "to", amount, function, name, symbol, log_index
+ FROM erc20_log
+ WHERE chain_id = sqlc.arg(chain_id) AND "from" = sqlc.arg(address) AND id < sqlc.arg(id)
+ ORDER BY id DESC
+ LIMIT sqlc.arg('limit'))
+UNION
+(SELECT id, chain_id, timestamp, transaction_hash, contract_address, "from", "to", amount, function, name, symbol, log_index
+ FROM erc20_log
+ WHERE chain_id = sqlc.arg(chain_id) AND "to" = sqlc.arg(address) AND id < sqlc.arg(id)
+ ORDER BY id DESC
+ LIMIT sqlc.arg('limit'))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- Contract ERC20 Transfers
+-- name: ListContractERC20Transfers :many
+SELECT id, chain_id, timestamp, transaction_hash, contract_address, "from", "to", amount, function, name, symbol, log_index
+FROM erc20_log
+WHERE chain_id = $1 AND contract_address = $2 AND id < $3
+ORDER BY id DESC
+LIMIT $4;
+
+-- Address ERC721 Transfers
+-- name: ListAddressERC721Transfers :many
+(SELECT id, chain_id, timestamp, transaction_hash, contract_address, "from", "to", token_id, function, name, symbol, log_index
+ FROM erc721_log
+ WHERE chain_id = sqlc.arg(chain_id) AND "from" = sqlc.arg(address) AND id < sqlc.arg(id)
+ ORDER BY id DESC
+ LIMIT sqlc.arg('limit'))
+UNION
+(SELECT id, chain_id, timestamp, transaction_hash, contract_address, "from", "to", token_id, function, name, symbol, log_index
+ FROM erc721_log
+ WHERE chain_id = sqlc.arg(chain_id) AND "to" = sqlc.arg(address) AND id < sqlc.arg(id)
+ ORDER BY id DESC
+ LIMIT sqlc.arg('limit'))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- Contract ERC721 Transfers
+-- name: ListContractERC721Transfers :many
+SELECT id, chain_id, timestamp, transaction_hash, contract_address, "from", "to", token_id, function, name, symbol, log_index
+FROM erc721_log
+WHERE chain_id = $1 AND contract_address = $2 AND id < $3
+ORDER BY id DESC
+LIMIT $4;
+
+-- Address ERC1155 Transfers
+-- name: ListAddressERC1155Transfers :many
+(SELECT id, chain_id, timestamp, transaction_hash, contract_address, "from", "to", token_id, amount, function, name, symbol, log_index, batch_index
+ FROM erc1155_log
+ WHERE chain_id = sqlc.arg(chain_id) AND "from" = sqlc.arg(address) AND id < sqlc.arg(id)
+ ORDER BY id DESC
+ LIMIT sqlc.arg('limit'))
+UNION
+(SELECT id, chain_id, timestamp, transaction_hash, contract_address, "from", "to", token_id, amount, function, name, symbol, log_index, batch_index
+ FROM erc1155_log
+ WHERE chain_id = sqlc.arg(chain_id) AND "to" = sqlc.arg(address) AND id < sqlc.arg(id)
+ ORDER BY id DESC
+ LIMIT sqlc.arg('limit'))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- Contract ERC1155 Transfers
+-- name: ListContractERC1155Transfers :many
+SELECT id, chain_id, timestamp, transaction_hash, contract_address, "from", "to", token_id, amount, function, name, symbol, log_index, batch_index
+FROM erc1155_log
+WHERE chain_id = $1 AND contract_address = $2 AND id < $3
+ORDER BY id DESC
+LIMIT $4;
+
+-- Address Logs
+-- name: ListAddressLogs :many
+SELECT id, chain_id, address, block_hash, block_number, data, log_index, removed, topics, transaction_hash, transaction_index, "from", "to", timestamp
+FROM log
+WHERE chain_id = $1 AND address = $2 AND id < $3
+ORDER BY id DESC
+LIMIT $4;
+
+-- Address Logs By topic0
+-- name: ListAddressTopicLogs :many
+SELECT id, chain_id, address, block_hash, block_number, data, log_index, removed, topics, transaction_hash, transaction_index, "from", "to", timestamp
+FROM log
+WHERE chain_id = sqlc.arg(chain_id)
+  AND address = sqlc.arg(address)
+  AND topics[1] = sqlc.arg(topic0)::bytea
+  AND id < sqlc.arg(id)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- Wallet
+-- name: GetWallet :one
+SELECT id, chain_id, address, balance FROM wallet
+WHERE chain_id = $1 AND address = $2;
+
+-- Address ERC20 Holdings
+-- name: ListAddressERC20Holdings :many
+SELECT b.id, b.hash, b.balance, c.name, c.symbol, c.decimals
+FROM erc20_balance b
+         LEFT JOIN contract c ON c.chain_id = b.chain_id AND c.hash = b.hash
+WHERE b.chain_id = $1 AND b.address = $2 AND b.balance <> 0 AND b.id < $3
+ORDER BY b.id DESC
+LIMIT $4;
+
+-- Address ERC721 Holdings
+-- name: ListAddressERC721Holdings :many
+SELECT b.id, b.hash, b.token_id, c.name, c.symbol
+FROM erc721_balance b
+         LEFT JOIN contract c ON c.chain_id = b.chain_id AND c.hash = b.hash
+WHERE b.chain_id = $1 AND b.address = $2 AND b.id < $3
+ORDER BY b.id DESC
+LIMIT $4;
+
+-- Address ERC1155 Holdings
+-- name: ListAddressERC1155Holdings :many
+SELECT b.id, b.hash, b.token_id, b.amount, c.name, c.symbol
+FROM erc1155_balance b
+         LEFT JOIN contract c ON c.chain_id = b.chain_id AND c.hash = b.hash
+WHERE b.chain_id = $1 AND b.address = $2 AND b.amount <> 0 AND b.id < $3
+ORDER BY b.id DESC
+LIMIT $4;
+
+-- ERC20 Holders (잔액 내림차순, (balance, id) keyset)
+-- name: ListERC20Holders :many
+SELECT id, address, balance FROM erc20_balance
+WHERE chain_id = sqlc.arg(chain_id)
+  AND hash = sqlc.arg(hash)
+  AND balance > 0
+  AND (balance, id) < (sqlc.arg(balance)::numeric, sqlc.arg(id)::bigint)
+ORDER BY balance DESC, id DESC
+LIMIT sqlc.arg('limit');
+
+-- ERC721 Holders
+-- name: ListERC721Holders :many
+SELECT id, token_id, address FROM erc721_balance
+WHERE chain_id = $1 AND hash = $2 AND id < $3
+ORDER BY id DESC
+LIMIT $4;
+
+-- ERC1155 Holders
+-- name: ListERC1155Holders :many
+SELECT id, token_id, address, amount FROM erc1155_balance
+WHERE chain_id = $1 AND hash = $2 AND amount > 0 AND id < $3
+ORDER BY id DESC
+LIMIT $4;
+
+-- Block By Number
+-- name: GetBlockByNumber :one
+SELECT * FROM block
+WHERE chain_id = $1 AND number = $2;
+
+-- Block By Hash
+-- name: GetBlockByHash :one
+SELECT * FROM block
+WHERE chain_id = $1 AND hash = $2;
+
+-- Block Transactions
+-- name: ListBlockTransactions :many
+SELECT id, chain_id, block_hash, block_number, "from", "to", gas, gas_price, hash, r, s, v, transaction_index, value, nonce, contract_address, gas_used, status, type, timestamp, coin_count, nft_count, erc20_count, erc721_count, erc1155_count
+FROM transaction
+WHERE chain_id = $1 AND block_number = $2
+ORDER BY transaction_index;
+
+-- Transaction By Hash
+-- name: GetTransactionByHash :one
+SELECT id, chain_id, block_hash, block_number, "from", "to", gas, gas_price, hash, r, s, v, transaction_index, value, nonce, contract_address, gas_used, status, type, timestamp, coin_count, nft_count, erc20_count, erc721_count, erc1155_count
+FROM transaction
+WHERE chain_id = $1 AND hash = $2
+ORDER BY block_number DESC
+LIMIT 1;
+
+-- Transaction Logs (block_number 로 파티션을 좁힌다)
+-- name: ListTransactionLogs :many
+SELECT * FROM log
+WHERE chain_id = $1 AND block_number = $2 AND transaction_hash = $3
+ORDER BY log_index;
+
+-- Transaction ERC20 Transfers (timestamp 로 파티션을 좁힌다)
+-- name: ListTransactionERC20Transfers :many
+SELECT * FROM erc20_log
+WHERE chain_id = $1 AND timestamp = $2 AND transaction_hash = $3
+ORDER BY log_index;
+
+-- name: ListTransactionERC721Transfers :many
+SELECT * FROM erc721_log
+WHERE chain_id = $1 AND timestamp = $2 AND transaction_hash = $3
+ORDER BY log_index;
+
+-- name: ListTransactionERC1155Transfers :many
+SELECT * FROM erc1155_log
+WHERE chain_id = $1 AND timestamp = $2 AND transaction_hash = $3
+ORDER BY log_index, batch_index;
+
+-- Token
+-- name: GetContract :one
+SELECT * FROM contract
+WHERE chain_id = $1 AND hash = $2;
+
+-- ERC721 Token Owner
+-- name: GetERC721TokenOwner :one
+SELECT id, token_id, address FROM erc721_balance
+WHERE chain_id = $1 AND hash = $2 AND token_id = $3;
+
+-- ERC1155 Token Holders
+-- name: ListERC1155TokenHolders :many
+SELECT id, token_id, address, amount FROM erc1155_balance
+WHERE chain_id = $1 AND hash = $2 AND token_id = $3 AND amount > 0 AND id < $4
+ORDER BY id DESC
+LIMIT $5;
@@ -0,0 +1,55 @@
+-- GraphQL dataloader 용 일괄 조회 (한 요청에서 모인 키를 체인별로 한 번에 읽는다)
+-- name: ListContractsByHash :many
+SELECT * FROM contract
+WHERE chain_id = sqlc.arg(chain_id) AND hash = ANY(sqlc.arg(hashes)::bytea[]);
+
+-- name: ListBlocksByNumber :many
+SELECT * FROM block
+WHERE chain_id = sqlc.arg(chain_id) AND number = ANY(sqlc.arg(numbers)::bigint[]);
+
+-- block_number 로 파티션을 좁힌다
+-- name: ListTransactionInputs :many
+SELECT * FROM transaction_input
+WHERE chain_id = sqlc.arg(chain_id)
+  AND block_number = ANY(sqlc.arg(block_numbers)::bigint[])
+  AND hash = ANY(sqlc.arg(hashes)::bytea[]);
+
+-- name: ListLogsByTransactions :many
+SELECT * FROM log
+WHERE chain_id = sqlc.arg(chain_id)
+  AND block_number = ANY(sqlc.arg(block_numbers)::bigint[])
+  AND transaction_hash = ANY(sqlc.arg(hashes)::bytea[])
+ORDER BY log_index;
+
+-- timestamp 로 파티션을 좁힌다
+-- name: ListERC20LogsByTransactions :many
+SELECT * FROM erc20_log
+WHERE chain_id = sqlc.arg(chain_id)
+  AND timestamp = ANY(sqlc.arg(timestamps)::timestamptz[])
+  AND transaction_hash = ANY(sqlc.arg(hashes)::bytea[])
+ORDER BY log_index;
+
+-- name: ListERC721LogsByTransactions :many
+SELECT * FROM erc721_log
+WHERE chain_id = sqlc.arg(chain_id)
+  AND timestamp = ANY(sqlc.arg(timestamps)::timestamptz[])
+  AND transaction_hash = ANY(sqlc.arg(hashes)::bytea[])
+ORDER BY log_index;
+
+-- name: ListERC1155LogsByTransactions :many
+SELECT * FROM erc1155_log
+WHERE chain_id = sqlc.arg(chain_id)
+  AND timestamp = ANY(sqlc.arg(timestamps)::timestamptz[])
+  AND transaction_hash = ANY(sqlc.arg(hashes)::bytea[])
+ORDER BY log_index, batch_index;
+
+-- NFT 메타데이터 (url, image_url)
+-- name: ListERC721TokensByID :many
+SELECT * FROM erc721
+WHERE chain_id = sqlc.arg(chain_id)
+  AND (hash, token_id) IN (SELECT unnest(sqlc.arg(hashes)::bytea[]), unnest(sqlc.arg(token_ids)::numeric[]));
+
+-- name: ListERC1155TokensByID :many
+SELECT * FROM erc1155
+WHERE chain_id = sqlc.arg(chain_id)
+  AND (hash, token_id) IN (SELECT unnest(sqlc.arg(hashes)::bytea[]), unnest(sqlc.arg(token_ids)::numeric[]));
@@ -0,0 +1,36 @@
+-- 블록 범위 파티션 생성 (transaction, transaction_input, log)
+-- name: EnsureBlockPartition :exec
+SELECT ensure_block_partition(sqlc.arg(parent)::text, sqlc.arg(chain_id)::bigint, sqlc.arg(block_number)::bigint);
+
+-- 월 파티션 생성 (coin_log, erc20_log, erc721_log, erc1155_log)
+-- name: EnsureMonthPartition :exec
+SELECT ensure_month_partition(sqlc.arg(parent)::text, sqlc.arg(chain_id)::bigint, sqlc.arg(timestamp)::timestamptz);
+
+-- block_number (월 파티션은 unix 초) 가 들어가는 파티션 범위
+-- name: GetPartitionRange :one
+SELECT * FROM partition_range
+WHERE parent = sqlc.arg(parent) AND chain_id = sqlc.arg(chain_id)
+  AND lower_bound <= sqlc.arg(block_number)::bigint AND upper_bound > sqlc.arg(block_number)::bigint;
+
+-- name: ListPartitionRanges :many
+SELECT * FROM partition_range
+WHERE chain_id = $1
+ORDER BY parent, lower_bound;
+
+-- name: UpsertPartitionPolicy :exec
+INSERT INTO partition_policy (chain_id, block_range, retain_blocks)
+VALUES ($1, $2, $3) ON CONFLICT (chain_id) DO UPDATE
+SET block_range = EXCLUDED.block_range,
+    retain_blocks = EXCLUDED.retain_blocks;
+
+-- name: GetPartitionPolicy :one
+SELECT * FROM partition_policy
+WHERE chain_id = $1;
+
+-- name: ListPartitionPolicies :many
+SELECT * FROM partition_policy
+ORDER BY chain_id;
+
+-- 보관 기간이 지난 log / transaction_input 파티션 삭제, 지운 파티션 수
+-- name: PruneRawData :one
+SELECT prune_raw_data(sqlc.arg(chain_id)::bigint, sqlc.arg(height)::bigint)::integer AS pruned;
@@ -1,38 +1,45 @@
 -- Block Height
 -- name: GetBlockHeight :one
-SELECT number_int from block
+SELECT number from block
 where chain_id = $1
-order by number_int desc
+order by number desc
 limit 1;
 
--- Block Insert
--- name: InsertBlock :exec
+-- Block Insert (이미 반영된 블록이면 0 rows)
+-- name: InsertBlock :execrows
 INSERT INTO block (chain_id, difficulty, hash, gas_limit, gas_used, miner, number,
-                   number_int, parent_hash, timestamp, timestamp_int, created_at,
-                   total_difficulty, transactions_root)
+                   parent_hash, timestamp, total_difficulty, transactions_root)
 VALUES ($1, $2, $3, $4, $5, $6, $7,
-        $8, $9, $10, $11, $12,
-        $13, $14);
+        $8, $9, $10, $11)
+ON CONFLICT (hash, chain_id) DO NOTHING;
 
 -- Transaction Insert
 -- name: InsertTransaction :exec
-INSERT INTO transaction (chain_id, block_hash, block_number, block_number_int, "from", "to",
-                         gas, gas_int, gas_price, gas_price_int, hash, r, s, v, transaction_index,
-                         value, value_int, nonce, input, contract_address, gas_used, gas_used_int,
-                         status, type, timestamp, timestamp_int, created_at, coin_count, nft_count, erc20_count, erc721_count, erc1155_count)
-VALUES ($1, $2, $3, $4, $5, $6,
-        $7, $8, $9, $10, $11, $12, $13, $14, $15,
-        $16, $17, $18, $19, $20, $21, $22,
-        $23, $24, $25, $26, $27, $28, $29, $30, $31, $32);
+INSERT INTO transaction (chain_id, block_hash, block_number, "from", "to",
+                         gas, gas_price, hash, r, s, v, transaction_index,
+                         value, nonce, contract_address, gas_used,
+                         status, type, timestamp, coin_count, nft_count, erc20_count, erc721_count, erc1155_count)
+VALUES ($1, $2, $3, $4, $5,
+        $6, $7, $8, $9, $10, $11, $12,
+        $13, $14, $15, $16,
+        $17, $18, $19, $20, $21, $22, $23, $24)
+ON CONFLICT (chain_id, block_number, hash) DO NOTHING;
+
+-- Transaction Input Insert (보관 정책으로 지울 수 있게 따로 저장)
+-- name: InsertTransactionInput :exec
+INSERT INTO transaction_input (chain_id, block_number, hash, input)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (chain_id, block_number, hash) DO NOTHING;
 
 -- Log Insert
 -- name: InsertLog :exec
-INSERT INTO log (chain_id, address, block_hash, block_number, block_number_int, data,
+INSERT INTO log (chain_id, address, block_hash, block_number, data,
                  log_index, removed, topics, transaction_hash, transaction_index,
-                 "from", "to", timestamp, timestamp_int, created_at)
-VALUES ($1, $2, $3, $4, $5, $6,
-        $7, $8, $9, $10, $11,
-        $12, $13, $14, $15, $16);
+                 "from", "to", timestamp)
+VALUES ($1, $2, $3, $4, $5,
+        $6, $7, $8, $9, $10,
+        $11, $12, $13)
+ON CONFLICT (chain_id, block_number, transaction_hash, log_index) DO NOTHING;
 
 -- Contract Insert
 -- name: InsertContract :exec
@@ -47,33 +54,37 @@ SET name = EXCLUDED.name,
 
 -- Coin Log Insert
 -- name: InsertCoinLog :exec
-INSERT INTO coin_log (chain_id, timestamp, timestamp_int, created_at, transaction_hash,
-                      "from", "to", amount, gas, gas_int, gas_price, gas_price_int,
-                      gas_used, gas_used_int)
-VALUES ($1, $2, $3, $4, $5,
-        $6, $7, $8, $9, $10, $11, $12,
-        $13, $14);
+INSERT INTO coin_log (chain_id, timestamp, transaction_hash,
+                      "from", "to", amount, gas, gas_price, gas_used)
+VALUES ($1, $2, $3,
+        $4, $5, $6, $7, $8, $9)
+ON CONFLICT (chain_id, timestamp, transaction_hash) DO NOTHING;
 
 -- ERC20 Log Insert
 -- name: InsertERC20Log :exec
-INSERT INTO erc20_log (chain_id, timestamp, timestamp_int, created_at, transaction_hash,
-                       contract_address, "from", "to", amount, function, name, symbol)
-VALUES ($1, $2, $3, $4, $5,
-        $6, $7, $8, $9, $10, $11, $12);
+INSERT INTO erc20_log (chain_id, timestamp, transaction_hash,
+                       contract_address, "from", "to", amount, function, name, symbol, log_index)
+VALUES ($1, $2, $3,
+        $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT (chain_id, timestamp, transaction_hash, log_index) DO NOTHING;
 
 -- ERC721 Log Insert
 -- name: InsertERC721Log :exec
-INSERT INTO erc721_log (chain_id, timestamp, timestamp_int, created_at, transaction_hash,
-                        contract_address, "from", "to", token_id, function, name, symbol)
-VALUES ($1, $2, $3, $4, $5,
-        $6, $7, $8, $9, $10, $11, $12);
+INSERT INTO erc721_log (chain_id, timestamp, transaction_hash,
+                        contract_address, "from", "to", token_id, function, name, symbol, log_index)
+VALUES ($1, $2, $3,
+        $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT (chain_id, timestamp, transaction_hash, log_index) DO NOTHING;
 
 -- ERC1155 Log Insert
 -- name: InsertERC1155Log :exec
-INSERT INTO erc1155_log (chain_id, timestamp, timestamp_int, created_at, transaction_hash,
-                         contract_address, "from", "to", token_id, amount, function, name, symbol)
-VALUES ($1, $2, $3, $4, $5,
-        $6, $7, $8, $9, $10, $11, $12, $13);
+INSERT INTO erc1155_log (chain_id, timestamp, transaction_hash,
+                         contract_address, "from", "to", token_id, amount, function, name, symbol,
+                         log_index, batch_index)
+VALUES ($1, $2, $3,
+        $4, $5, $6, $7, $8, $9, $10, $11,
+        $12, $13)
+ON CONFLICT (chain_id, timestamp, transaction_hash, log_index, batch_index) DO NOTHING;
 
 -- Wallet Insert
 -- name: InsertWallet :exec
@@ -87,11 +98,12 @@ VALUES ($1, $2, $3) ON CONFLICT (chain_id, address) DO UPDATE
 SET balance = wallet.balance + EXCLUDED.balance;
 
 -- ERC20 Balance UPSERT
--- name: UpsertERC20Balance :exec
+-- name: UpsertERC20Balance :one
 INSERT INTO erc20_balance (chain_id, balance, hash, address)
 VALUES ($1, $2, $3, $4)
 ON CONFLICT (hash, chain_id, address) DO UPDATE
-SET balance = erc20_balance.balance + EXCLUDED.balance;
+SET balance = erc20_balance.balance + EXCLUDED.balance
+RETURNING balance;
 
 -- -- ERC721 Balance INSERT
 -- name: UpsertERC721Balance :exec
@@ -101,7 +113,7 @@ ON CONFLICT (hash, token_id, chain_id)
 DO UPDATE SET address = EXCLUDED.address;
 
 -- ERC1155 Balance DELETE (소유권 이전 시)
--- name: SubtractERC1155Balance :exec
+-- name: SubtractERC1155Balance :execrows
 UPDATE erc1155_balance
 SET amount = amount - $5
 WHERE chain_id = $1 AND hash = $2 AND token_id = $3 AND address = $4
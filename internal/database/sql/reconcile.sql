@@ -0,0 +1,113 @@
+-- Wallet Page
+-- name: ListWallets :many
+SELECT id, chain_id, address, balance FROM wallet
+WHERE chain_id = $1 AND id > $2
+ORDER BY id
+LIMIT $3;
+
+-- Wallet Sample
+-- name: SampleWallets :many
+SELECT id, chain_id, address, balance FROM wallet
+WHERE chain_id = $1
+ORDER BY random()
+LIMIT $2;
+
+-- ERC20 Balance Page
+-- name: ListERC20Balances :many
+SELECT id, chain_id, balance, hash, address FROM erc20_balance
+WHERE chain_id = $1 AND id > $2
+ORDER BY id
+LIMIT $3;
+
+-- ERC20 Balance Sample
+-- name: SampleERC20Balances :many
+SELECT id, chain_id, balance, hash, address FROM erc20_balance
+WHERE chain_id = $1
+ORDER BY random()
+LIMIT $2;
+
+-- ERC721 Balance Page
+-- name: ListERC721Balances :many
+SELECT id, chain_id, hash, token_id, address FROM erc721_balance
+WHERE chain_id = $1 AND id > $2
+ORDER BY id
+LIMIT $3;
+
+-- ERC721 Balance Sample
+-- name: SampleERC721Balances :many
+SELECT id, chain_id, hash, token_id, address FROM erc721_balance
+WHERE chain_id = $1
+ORDER BY random()
+LIMIT $2;
+
+-- ERC1155 Balance Page
+-- name: ListERC1155Balances :many
+SELECT id, chain_id, hash, token_id, address, amount FROM erc1155_balance
+WHERE chain_id = $1 AND id > $2
+ORDER BY id
+LIMIT $3;
+
+-- ERC1155 Balance Sample
+-- name: SampleERC1155Balances :many
+SELECT id, chain_id, hash, token_id, address, amount FROM erc1155_balance
+WHERE chain_id = $1
+ORDER BY random()
+LIMIT $2;
+
+-- Reconcile Run Insert
+-- name: CreateReconcileRun :one
+INSERT INTO reconcile_run (chain_id, mode, repair, started_at)
+VALUES ($1, $2, $3, $4)
+RETURNING id;
+
+-- Reconcile Run Finish (status 는 finished / failed)
+-- name: FinishReconcileRun :exec
+UPDATE reconcile_run
+SET checked = $2,
+    mismatched = $3,
+    skipped = $4,
+    status = $5,
+    error = $6,
+    finished_at = $7
+WHERE id = $1;
+
+-- Balance Drift Insert
+-- name: InsertBalanceDrift :exec
+INSERT INTO balance_drift (run_id, chain_id, kind, hash, token_id, address, onchain_address,
+                           block_number, stored, onchain, difference, repaired, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7,
+        $8, $9, $10, $11, $12, $13);
+
+-- Balance Drift Summary (토큰별)
+-- name: GetBalanceDriftSummary :many
+SELECT kind, hash, count(*) AS mismatches, sum(difference)::numeric AS total_difference
+FROM balance_drift
+WHERE run_id = $1
+GROUP BY kind, hash
+ORDER BY mismatches DESC;
+
+-- ERC721 Owner Repair (스냅샷 이후 소유자가 바뀌지 않은 경우만)
+-- name: RepairERC721Owner :execrows
+UPDATE erc721_balance
+SET address = sqlc.arg(new_address)
+WHERE chain_id = sqlc.arg(chain_id) AND hash = sqlc.arg(hash)
+  AND token_id = sqlc.arg(token_id) AND address = sqlc.arg(old_address);
+
+-- 고정한 높이 이후에 바뀐 잔액. 페이지의 잔액을 그 높이의 값으로 되돌린다 (seeded 면 그 높이에는 없던 잔액)
+-- name: ListBalanceChangesAfter :many
+SELECT hash, token_id::text AS token_id, address, sum(delta)::text AS delta, bool_or(reason = 'seed')::boolean AS seeded
+FROM balance_change
+WHERE chain_id = sqlc.arg(chain_id) AND kind = sqlc.arg(kind) AND block_number > sqlc.arg(block_number)
+  AND address = ANY(sqlc.arg(addresses)::bytea[])
+GROUP BY hash, token_id, address;
+
+-- ERC721 Owner At Block (여러 토큰). 높이까지 마지막으로 받은 주소
+-- name: ListERC721OwnersAt :many
+SELECT c.hash, c.token_id::text AS token_id, c.address
+FROM balance_change c
+WHERE c.chain_id = sqlc.arg(chain_id) AND c.kind = 'erc721' AND c.delta > 0 AND c.block_number <= sqlc.arg(block_number)
+  AND (c.hash, c.token_id) IN (SELECT unnest(sqlc.arg(hashes)::bytea[]), unnest(sqlc.arg(token_ids)::numeric[]))
+  AND NOT EXISTS (SELECT 1 FROM balance_change n
+                  WHERE n.chain_id = c.chain_id AND n.kind = 'erc721' AND n.hash = c.hash AND n.token_id = c.token_id
+                    AND n.delta > 0 AND n.block_number <= sqlc.arg(block_number)
+                    AND (n.block_number, n.id) > (c.block_number, c.id));
@@ -0,0 +1,134 @@
+-- 재인덱싱 구간의 블록 timestamp 범위 (로그 파티션을 좁힌다)
+-- name: GetBlockRangeTimestamps :one
+SELECT coalesce(min(timestamp), 'epoch')::timestamptz AS from_time, coalesce(max(timestamp), 'epoch')::timestamptz AS to_time
+FROM block
+WHERE chain_id = sqlc.arg(chain_id) AND number >= sqlc.arg(from_block) AND number <= sqlc.arg(to_block);
+
+-- 잔액은 원장의 델타를 빼서 되돌린다. 시드/repair 는 구간과 상관없는 값이라 남긴다
+-- name: RevertWalletBalances :execrows
+UPDATE wallet w
+SET balance = w.balance - d.delta
+FROM (SELECT address, sum(delta) AS delta
+      FROM balance_change
+      WHERE chain_id = sqlc.arg(chain_id) AND kind = 'coin'
+        AND block_number >= sqlc.arg(from_block) AND block_number <= sqlc.arg(to_block)
+        AND reason NOT IN ('seed', 'repair')
+      GROUP BY address) d
+WHERE w.chain_id = sqlc.arg(chain_id) AND w.address = d.address;
+
+-- name: RevertERC20Balances :execrows
+UPDATE erc20_balance b
+SET balance = b.balance - d.delta
+FROM (SELECT hash, address, sum(delta) AS delta
+      FROM balance_change
+      WHERE chain_id = sqlc.arg(chain_id) AND kind = 'erc20'
+        AND block_number >= sqlc.arg(from_block) AND block_number <= sqlc.arg(to_block)
+        AND reason NOT IN ('seed', 'repair')
+      GROUP BY hash, address) d
+WHERE b.chain_id = sqlc.arg(chain_id) AND b.hash = d.hash AND b.address = d.address;
+
+-- name: RevertERC1155Balances :execrows
+UPDATE erc1155_balance b
+SET amount = b.amount - d.delta
+FROM (SELECT hash, token_id, address, sum(delta) AS delta
+      FROM balance_change
+      WHERE chain_id = sqlc.arg(chain_id) AND kind = 'erc1155'
+        AND block_number >= sqlc.arg(from_block) AND block_number <= sqlc.arg(to_block)
+        AND reason NOT IN ('seed', 'repair')
+      GROUP BY hash, token_id, address) d
+WHERE b.chain_id = sqlc.arg(chain_id) AND b.hash = d.hash AND b.token_id = d.token_id AND b.address = d.address;
+
+-- 구간에서 주인이 바뀐 erc721 토큰
+-- name: ListChangedERC721Tokens :many
+SELECT DISTINCT hash, token_id::text AS token_id
+FROM balance_change
+WHERE chain_id = sqlc.arg(chain_id) AND kind = 'erc721'
+  AND block_number >= sqlc.arg(from_block) AND block_number <= sqlc.arg(to_block);
+
+-- erc721 주인은 원장에서 마지막으로 받은 주소로 맞춘다
+-- name: SyncERC721Owners :execrows
+UPDATE erc721_balance b
+SET address = o.address
+FROM (SELECT DISTINCT ON (c.hash, c.token_id) c.hash, c.token_id, c.address
+      FROM balance_change c
+      WHERE c.chain_id = sqlc.arg(chain_id) AND c.kind = 'erc721' AND c.delta > 0
+        AND (c.hash, c.token_id) IN (SELECT unnest(sqlc.arg(hashes)::bytea[]), unnest(sqlc.arg(token_ids)::numeric[]))
+      ORDER BY c.hash, c.token_id, c.block_number DESC, c.id DESC) o
+WHERE b.chain_id = sqlc.arg(chain_id) AND b.hash = o.hash AND b.token_id = o.token_id;
+
+-- 원장에 받은 기록이 하나도 남지 않은 토큰은 구간 안에서 처음 나온 토큰이다
+-- name: DeleteUnownedERC721Balances :execrows
+DELETE FROM erc721_balance b
+WHERE b.chain_id = sqlc.arg(chain_id)
+  AND (b.hash, b.token_id) IN (SELECT unnest(sqlc.arg(hashes)::bytea[]), unnest(sqlc.arg(token_ids)::numeric[]))
+  AND NOT EXISTS (SELECT 1 FROM balance_change c
+                  WHERE c.chain_id = b.chain_id AND c.kind = 'erc721' AND c.hash = b.hash AND c.token_id = b.token_id AND c.delta > 0);
+
+-- name: DeleteBalanceChanges :execrows
+DELETE FROM balance_change
+WHERE chain_id = sqlc.arg(chain_id)
+  AND block_number >= sqlc.arg(from_block) AND block_number <= sqlc.arg(to_block)
+  AND reason NOT IN ('seed', 'repair');
+
+-- name: DeleteCoinLogs :execrows
+DELETE FROM coin_log
+WHERE chain_id = sqlc.arg(chain_id)
+  AND timestamp >= sqlc.arg(from_time) AND timestamp <= sqlc.arg(to_time)
+  AND transaction_hash IN (SELECT hash FROM transaction t
+                           WHERE t.chain_id = sqlc.arg(chain_id) AND t.block_number >= sqlc.arg(from_block) AND t.block_number <= sqlc.arg(to_block));
+
+-- name: DeleteERC20Logs :execrows
+DELETE FROM erc20_log
+WHERE chain_id = sqlc.arg(chain_id)
+  AND timestamp >= sqlc.arg(from_time) AND timestamp <= sqlc.arg(to_time)
+  AND transaction_hash IN (SELECT hash FROM transaction t
+                           WHERE t.chain_id = sqlc.arg(chain_id) AND t.block_number >= sqlc.arg(from_block) AND t.block_number <= sqlc.arg(to_block));
+
+-- name: DeleteERC721Logs :execrows
+DELETE FROM erc721_log
+WHERE chain_id = sqlc.arg(chain_id)
+  AND timestamp >= sqlc.arg(from_time) AND timestamp <= sqlc.arg(to_time)
+  AND transaction_hash IN (SELECT hash FROM transaction t
+                           WHERE t.chain_id = sqlc.arg(chain_id) AND t.block_number >= sqlc.arg(from_block) AND t.block_number <= sqlc.arg(to_block));
+
+-- name: DeleteERC1155Logs :execrows
+DELETE FROM erc1155_log
+WHERE chain_id = sqlc.arg(chain_id)
+  AND timestamp >= sqlc.arg(from_time) AND timestamp <= sqlc.arg(to_time)
+  AND transaction_hash IN (SELECT hash FROM transaction t
+                           WHERE t.chain_id = sqlc.arg(chain_id) AND t.block_number >= sqlc.arg(from_block) AND t.block_number <= sqlc.arg(to_block));
+
+-- name: DeleteLogs :execrows
+DELETE FROM log
+WHERE chain_id = sqlc.arg(chain_id) AND block_number >= sqlc.arg(from_block) AND block_number <= sqlc.arg(to_block);
+
+-- name: DeleteTransactionInputs :execrows
+DELETE FROM transaction_input
+WHERE chain_id = sqlc.arg(chain_id) AND block_number >= sqlc.arg(from_block) AND block_number <= sqlc.arg(to_block);
+
+-- name: DeleteTransactions :execrows
+DELETE FROM transaction
+WHERE chain_id = sqlc.arg(chain_id) AND block_number >= sqlc.arg(from_block) AND block_number <= sqlc.arg(to_block);
+
+-- 블록 저장 중에 남긴 이상 징후는 다시 받을 때 새로 남는다
+-- name: DeleteAnomalies :execrows
+DELETE FROM anomaly
+WHERE chain_id = sqlc.arg(chain_id) AND block_number >= sqlc.arg(from_block) AND block_number <= sqlc.arg(to_block);
+
+-- 이미 보낸 감시 주소 알림은 남긴다 (다시 받아도 unique 로 중복되지 않는다)
+-- name: DeletePendingWatchAlerts :execrows
+DELETE FROM watch_alert
+WHERE chain_id = sqlc.arg(chain_id) AND block_number >= sqlc.arg(from_block) AND block_number <= sqlc.arg(to_block)
+  AND delivered_at IS NULL;
+
+-- name: DeletePendingWebhookDeliveries :execrows
+DELETE FROM webhook_delivery
+WHERE status = 'pending'
+  AND (payload ->> 'chainId')::bigint = sqlc.arg(chain_id)::bigint
+  AND (payload ->> 'blockNumber')::bigint >= sqlc.arg(from_block)::bigint
+  AND (payload ->> 'blockNumber')::bigint <= sqlc.arg(to_block)::bigint;
+
+-- block_removed 알림이 블록마다 나간다 (000007_block_notify)
+-- name: DeleteBlocks :execrows
+DELETE FROM block
+WHERE chain_id = sqlc.arg(chain_id) AND number >= sqlc.arg(from_block) AND number <= sqlc.arg(to_block);
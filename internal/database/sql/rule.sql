@@ -0,0 +1,28 @@
+-- name: CreateAlertRule :one
+INSERT INTO alert_rule (name, chain_id, type, params, severity, cooldown_seconds, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id;
+
+-- name: ListAlertRules :many
+SELECT * FROM alert_rule
+ORDER BY id;
+
+-- name: SetAlertRuleEnabled :execrows
+UPDATE alert_rule
+SET enabled = $2
+WHERE name = $1;
+
+-- name: DeleteAlertRule :execrows
+DELETE FROM alert_rule
+WHERE name = $1;
+
+-- name: InsertAlertRuleEvent :exec
+INSERT INTO alert_rule_event (rule_name, chain_id, block_number, subject, title, body, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- rule_name 이 null 이면 전체 (최신순)
+-- name: ListAlertRuleEvents :many
+SELECT * FROM alert_rule_event
+WHERE (sqlc.narg(rule_name)::varchar IS NULL OR rule_name = sqlc.narg(rule_name))
+ORDER BY id DESC
+LIMIT sqlc.arg(row_limit);
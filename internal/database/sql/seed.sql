@@ -0,0 +1,51 @@
+-- Existing Wallets
+-- name: ListExistingWallets :many
+SELECT address FROM wallet
+WHERE chain_id = sqlc.arg(chain_id) AND address = ANY(sqlc.arg(addresses)::bytea[]);
+
+-- Existing ERC20 Balances
+-- name: ListExistingERC20Balances :many
+SELECT hash, address FROM erc20_balance
+WHERE chain_id = sqlc.arg(chain_id)
+  AND (hash, address) IN (SELECT unnest(sqlc.arg(hashes)::bytea[]), unnest(sqlc.arg(addresses)::bytea[]));
+
+-- Existing ERC721 Tokens
+-- name: ListExistingERC721Tokens :many
+SELECT hash, token_id FROM erc721_balance
+WHERE chain_id = sqlc.arg(chain_id)
+  AND (hash, token_id) IN (SELECT unnest(sqlc.arg(hashes)::bytea[]), unnest(sqlc.arg(token_ids)::numeric[]));
+
+-- Existing ERC1155 Balances
+-- name: ListExistingERC1155Balances :many
+SELECT hash, token_id, address FROM erc1155_balance
+WHERE chain_id = sqlc.arg(chain_id)
+  AND (hash, token_id, address) IN (SELECT unnest(sqlc.arg(hashes)::bytea[]),
+                                           unnest(sqlc.arg(token_ids)::numeric[]),
+                                           unnest(sqlc.arg(addresses)::bytea[]));
+
+-- ERC721 컬렉션 중 보유 기록도, 생성 기록도 없는 컬렉션 (추적 시작 전에 배포됨)
+-- name: ListUnseededCollections :many
+SELECT h::bytea AS hash
+FROM unnest(sqlc.arg(hashes)::bytea[]) AS h
+WHERE NOT EXISTS (SELECT 1 FROM erc721_balance b WHERE b.chain_id = sqlc.arg(chain_id) AND b.hash = h)
+  AND NOT EXISTS (SELECT 1 FROM contract c WHERE c.chain_id = sqlc.arg(chain_id) AND c.hash = h);
+
+-- Wallet Seed
+-- name: SeedWallet :execrows
+INSERT INTO wallet (chain_id, address, balance)
+VALUES ($1, $2, $3) ON CONFLICT (chain_id, address) DO NOTHING;
+
+-- ERC20 Balance Seed
+-- name: SeedERC20Balance :execrows
+INSERT INTO erc20_balance (chain_id, balance, hash, address)
+VALUES ($1, $2, $3, $4) ON CONFLICT (hash, chain_id, address) DO NOTHING;
+
+-- ERC721 Balance Seed
+-- name: SeedERC721Balance :execrows
+INSERT INTO erc721_balance (chain_id, hash, token_id, address)
+VALUES ($1, $2, $3, $4) ON CONFLICT (hash, token_id, chain_id) DO NOTHING;
+
+-- ERC1155 Balance Seed
+-- name: SeedERC1155Balance :execrows
+INSERT INTO erc1155_balance (chain_id, hash, token_id, address, amount)
+VALUES ($1, $2, $3, $4, $5) ON CONFLICT (hash, token_id, address, chain_id) DO NOTHING;
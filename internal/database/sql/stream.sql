@@ -0,0 +1,14 @@
+-- 스트림 재전송 (재접속한 구독자가 놓친 블록)
+-- name: ListBlocksFrom :many
+SELECT * FROM block
+WHERE chain_id = sqlc.arg(chain_id) AND number >= sqlc.arg(number)
+ORDER BY number
+LIMIT sqlc.arg(row_limit);
+
+-- timestamp 로 파티션을 좁힌다
+-- name: ListCoinLogsByTransactions :many
+SELECT * FROM coin_log
+WHERE chain_id = sqlc.arg(chain_id)
+  AND timestamp = ANY(sqlc.arg(timestamps)::timestamptz[])
+  AND transaction_hash = ANY(sqlc.arg(hashes)::bytea[])
+ORDER BY id;
@@ -0,0 +1,74 @@
+-- name: CreateWatchlist :one
+INSERT INTO watchlist (name, email, created_at)
+VALUES ($1, $2, $3)
+RETURNING id;
+
+-- name: GetWatchlistByName :one
+SELECT * FROM watchlist
+WHERE name = $1;
+
+-- 주소 수를 같이 돌려준다
+-- name: ListWatchlists :many
+SELECT w.id, w.name, w.email, w.created_at, count(a.address) AS addresses
+FROM watchlist w
+         LEFT JOIN watchlist_address a ON a.watchlist_id = w.id
+GROUP BY w.id
+ORDER BY w.id;
+
+-- name: UpdateWatchlistEmail :execrows
+UPDATE watchlist
+SET email = $2
+WHERE id = $1;
+
+-- 주소와 알림도 같이 지워진다 (on delete cascade)
+-- name: DeleteWatchlist :execrows
+DELETE FROM watchlist
+WHERE id = $1;
+
+-- 이미 있으면 label 만 바꾼다
+-- name: AddWatchlistAddress :exec
+INSERT INTO watchlist_address (watchlist_id, chain_id, address, label, created_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (watchlist_id, chain_id, address) DO UPDATE SET label = excluded.label;
+
+-- name: RemoveWatchlistAddress :execrows
+DELETE FROM watchlist_address
+WHERE watchlist_id = $1 AND chain_id = $2 AND address = $3;
+
+-- name: ListWatchlistAddresses :many
+SELECT * FROM watchlist_address
+WHERE watchlist_id = $1
+ORDER BY chain_id, created_at;
+
+-- 블록에 등장한 주소 중 감시 중인 주소
+-- name: MatchWatchlistAddresses :many
+SELECT watchlist_id, address FROM watchlist_address
+WHERE chain_id = sqlc.arg(chain_id) AND address = ANY(sqlc.arg(addresses)::bytea[]);
+
+-- name: InsertWatchAlert :exec
+INSERT INTO watch_alert (watchlist_id, chain_id, block_number, transaction_hash, log_index, batch_index, kind,
+                         contract, token_id, address, direction, counterparty, amount, value, symbol, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7,
+        $8, $9, $10, $11, $12, $13, $14, $15, $16)
+ON CONFLICT DO NOTHING;
+
+-- watchlistID 가 null 이면 전체 (최신순)
+-- name: ListWatchAlerts :many
+SELECT * FROM watch_alert
+WHERE (sqlc.narg(watchlist_id)::bigint IS NULL OR watchlist_id = sqlc.narg(watchlist_id))
+ORDER BY id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- 아직 보내지 않은 알림 (메일 주소가 있는 watchlist 만)
+-- name: ListPendingWatchAlerts :many
+SELECT a.*, w.name AS watchlist_name, w.email
+FROM watch_alert a
+         JOIN watchlist w ON w.id = a.watchlist_id
+WHERE a.delivered_at IS NULL AND w.email IS NOT NULL
+ORDER BY a.id
+LIMIT sqlc.arg(row_limit);
+
+-- name: MarkWatchAlertsDelivered :exec
+UPDATE watch_alert
+SET delivered_at = sqlc.arg(delivered_at)
+WHERE id = ANY(sqlc.arg(ids)::bigint[]);
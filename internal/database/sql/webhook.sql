@@ -0,0 +1,88 @@
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscription (url, secret, chain_id, kinds, addresses, contracts, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id;
+
+-- name: ListWebhookSubscriptions :many
+SELECT * FROM webhook_subscription
+ORDER BY id;
+
+-- 블록을 저장할 때 필터를 맞춰 볼 구독
+-- name: ListActiveWebhookSubscriptions :many
+SELECT * FROM webhook_subscription
+WHERE active AND (chain_id IS NULL OR chain_id = $1);
+
+-- name: SetWebhookSubscriptionActive :execrows
+UPDATE webhook_subscription
+SET active = $2
+WHERE id = $1;
+
+-- 쌓인 전송 이력도 같이 지워진다 (on delete cascade)
+-- name: DeleteWebhookSubscription :execrows
+DELETE FROM webhook_subscription
+WHERE id = $1;
+
+-- name: InsertWebhookDelivery :exec
+INSERT INTO webhook_delivery (subscription_id, event_key, payload, next_attempt_at, created_at)
+VALUES ($1, $2, $3, $4, $4)
+ON CONFLICT (subscription_id, event_key) DO NOTHING;
+
+-- 보낼 차례가 된 건을 lease 시각까지 가져간다. 보내다 프로세스가 죽으면 lease 가 끝난 뒤 다시 보낸다.
+-- 멈춘 구독의 건은 가져가지 않고 pending 으로 남겨 두었다가 다시 켜면 보낸다
+-- name: ClaimWebhookDeliveries :many
+UPDATE webhook_delivery d
+SET next_attempt_at = sqlc.arg(lease_until)
+FROM webhook_subscription s
+WHERE s.id = d.subscription_id
+  AND s.active
+  AND d.id IN (SELECT pd.id FROM webhook_delivery pd
+               JOIN webhook_subscription ps ON ps.id = pd.subscription_id
+               WHERE pd.status = 'pending' AND pd.next_attempt_at <= sqlc.arg(now) AND ps.active
+               ORDER BY pd.next_attempt_at
+               LIMIT sqlc.arg(row_limit) FOR UPDATE OF pd SKIP LOCKED)
+RETURNING d.id, d.subscription_id, d.event_key, d.payload, d.attempts, s.url, s.secret;
+
+-- name: MarkWebhookDelivered :exec
+UPDATE webhook_delivery
+SET status       = 'delivered',
+    attempts     = attempts + 1,
+    last_status  = $2,
+    last_error   = NULL,
+    delivered_at = $3
+WHERE id = $1;
+
+-- status 가 dead 면 더 보내지 않는다 (수동 재전송만)
+-- name: MarkWebhookFailed :exec
+UPDATE webhook_delivery
+SET status          = $2,
+    attempts        = attempts + 1,
+    next_attempt_at = $3,
+    last_status     = $4,
+    last_error      = $5
+WHERE id = $1;
+
+-- subscription_id, status 가 null 이면 전체 (최신순)
+-- name: ListWebhookDeliveries :many
+SELECT id, subscription_id, event_key, status, attempts, next_attempt_at, last_status, last_error, created_at, delivered_at
+FROM webhook_delivery
+WHERE (sqlc.narg(subscription_id)::bigint IS NULL OR subscription_id = sqlc.narg(subscription_id))
+  AND (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status))
+ORDER BY id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- 수동 재전송. 시도 횟수를 초기화해서 처음부터 재시도한다
+-- name: ReplayWebhookDelivery :execrows
+UPDATE webhook_delivery
+SET status          = 'pending',
+    attempts        = 0,
+    next_attempt_at = $2,
+    delivered_at    = NULL
+WHERE id = $1;
+
+-- 구독의 dead 건을 모두 다시 보낸다
+-- name: ReplayDeadWebhookDeliveries :execrows
+UPDATE webhook_delivery
+SET status          = 'pending',
+    attempts        = 0,
+    next_attempt_at = $2
+WHERE subscription_id = $1 AND status = 'dead';
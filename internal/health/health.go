@@ -0,0 +1,324 @@
+package health
+
+import (
+	"blockchain-tracking/internal/logger"
+	"blockchain-tracking/internal/notify"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// 체인 상태
+const (
+	StateStarting   = "starting" // 아직 한 번도 추적에 성공하지 않음
+	StateRunning    = "running"
+	StateStalled    = "stalled"    // RPC 최신 높이가 stallAfter 동안 그대로 (체인이 멈춤)
+	StateRestarting = "restarting" // 트래커가 끝나서 재시작을 기다림
+	StateFailed     = "failed"     // 재시작 허용 횟수를 넘김 (성공할 때까지)
+	StatePaused     = "paused"     // 운영 API 로 멈춤
+	StateReindexing = "reindexing" // 운영 API 로 구간을 다시 받는 중
+)
+
+type Registry struct {
+	stallAfter time.Duration
+	notifier   notify.Notifier
+	l          logger.Logger
+
+	mu     sync.Mutex
+	chains []*Chain
+}
+
+func NewRegistry(stallAfter time.Duration, notifier notify.Notifier, l logger.Logger) *Registry {
+	return &Registry{
+		stallAfter: stallAfter,
+		notifier:   notifier,
+		l:          l,
+	}
+}
+
+// Chain 체인 하나를 등록한다. 트래커가 이 값으로 진행 상황을 남긴다 (이미 있으면 그 값을 돌려준다)
+func (r *Registry) Chain(name string) *Chain {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, c := range r.chains {
+		if c.name == name {
+			return c
+		}
+	}
+
+	c := &Chain{name: name, started: time.Now()}
+	r.chains = append(r.chains, c)
+	return c
+}
+
+// Chain 한 체인의 추적 상황. 여러 goroutine 에서 부른다
+type Chain struct {
+	name    string
+	started time.Time
+
+	mu            sync.Mutex
+	head          int64
+	headChangedAt time.Time
+	indexed       int64
+	indexedAt     time.Time // 저장된 마지막 블록의 timestamp
+	lastBatch     time.Time
+	succeeded     bool
+	failCount     int
+	lastError     string
+	restarts      int
+	nextRestart   time.Time
+	restarting    bool
+	failed        bool
+	stalled       bool   // Monitor 가 마지막으로 본 값
+	suspended     string // StatePaused / StateReindexing, 비어 있으면 트래커가 돈다
+}
+
+// Head RPC 가 준 최신 높이. 높이가 바뀐 시각으로 체인이 멈췄는지 본다
+func (c *Chain) Head(number int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if number != c.head {
+		c.head = number
+		c.headChangedAt = time.Now()
+	}
+}
+
+// Indexed 저장한 블록
+func (c *Chain) Indexed(number int64, timestamp time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.indexed = number
+	c.indexedAt = timestamp
+}
+
+// Batch 배치 하나를 저장함
+func (c *Chain) Batch() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lastBatch = time.Now()
+}
+
+// Round 추적 한 번의 결과 (StartTrack 주기마다)
+func (c *Chain) Round(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err != nil {
+		c.failCount++
+		c.lastError = err.Error()
+		return
+	}
+	c.failCount = 0
+	c.succeeded = true
+	c.failed = false
+}
+
+// Start 트래커를 (다시) 띄움
+func (c *Chain) Start() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.restarting = false
+	c.nextRestart = time.Time{}
+	c.failCount = 0
+}
+
+// Restarting 트래커가 끝나서 at 에 다시 띄운다. failed 는 재시작 허용 횟수를 넘겼는지
+func (c *Chain) Restarting(err error, at time.Time, failed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.restarts++
+	c.restarting = true
+	c.nextRestart = at
+	c.failed = failed
+	c.lastError = err.Error()
+}
+
+// Suspend 트래커를 멈춘 이유. Release 전까지 다른 상태보다 먼저 보인다
+func (c *Chain) Suspend(state string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.suspended = state
+	c.restarting = false
+	c.nextRestart = time.Time{}
+}
+
+func (c *Chain) Release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.suspended = ""
+}
+
+type Status struct {
+	Name        string     `json:"name"`
+	State       string     `json:"state"`
+	Head        int64      `json:"head"`
+	Indexed     int64      `json:"indexed"`
+	LagBlocks   int64      `json:"lagBlocks"`
+	LagSeconds  int64      `json:"lagSeconds"`     // 지금 - 저장된 마지막 블록 timestamp
+	HeadAge     int64      `json:"headAgeSeconds"` // RPC 최신 높이가 바뀐 뒤 지난 시간
+	LastBatch   *time.Time `json:"lastBatch,omitempty"`
+	FailCount   int        `json:"failCount"`
+	LastError   string     `json:"lastError,omitempty"`
+	Restarts    int        `json:"restarts"`
+	NextRestart *time.Time `json:"nextRestart,omitempty"`
+	Started     time.Time  `json:"started"`
+}
+
+func (c *Chain) status(now time.Time, stallAfter time.Duration) Status {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := Status{
+		Name:      c.name,
+		Head:      c.head,
+		Indexed:   c.indexed,
+		LagBlocks: max(c.head-c.indexed, 0),
+		FailCount: c.failCount,
+		LastError: c.lastError,
+		Restarts:  c.restarts,
+		Started:   c.started,
+	}
+	if !c.indexedAt.IsZero() {
+		s.LagSeconds = int64(now.Sub(c.indexedAt).Seconds())
+	}
+	if !c.headChangedAt.IsZero() {
+		s.HeadAge = int64(now.Sub(c.headChangedAt).Seconds())
+	}
+	if !c.lastBatch.IsZero() {
+		lastBatch := c.lastBatch
+		s.LastBatch = &lastBatch
+	}
+	if c.restarting {
+		nextRestart := c.nextRestart
+		s.NextRestart = &nextRestart
+	}
+
+	switch {
+	case c.suspended != "":
+		s.State = c.suspended
+	case c.failed:
+		s.State = StateFailed
+	case c.restarting:
+		s.State = StateRestarting
+	case !c.headChangedAt.IsZero() && now.Sub(c.headChangedAt) >= stallAfter:
+		s.State = StateStalled
+	case !c.succeeded:
+		s.State = StateStarting
+	default:
+		s.State = StateRunning
+	}
+	return s
+}
+
+func (r *Registry) Statuses() []Status {
+	r.mu.Lock()
+	chains := append([]*Chain(nil), r.chains...)
+	r.mu.Unlock()
+
+	now := time.Now()
+	statuses := make([]Status, len(chains))
+	for i, c := range chains {
+		statuses[i] = c.status(now, r.stallAfter)
+	}
+	return statuses
+}
+
+// StatusOf 등록된 체인 하나의 상태
+func (r *Registry) StatusOf(name string) (Status, bool) {
+	for _, s := range r.Statuses() {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Status{}, false
+}
+
+// Monitor interval 마다 체인이 멈췄는지 보고, 멈추거나 다시 움직이면 알린다. ctx 가 끝나면 돌아온다
+func (r *Registry) Monitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		r.mu.Lock()
+		chains := append([]*Chain(nil), r.chains...)
+		r.mu.Unlock()
+
+		now := time.Now()
+		for _, c := range chains {
+			s := c.status(now, r.stallAfter)
+			stalled := s.State == StateStalled
+
+			c.mu.Lock()
+			changed := c.stalled != stalled
+			c.stalled = stalled
+			c.mu.Unlock()
+			if !changed {
+				continue
+			}
+
+			if !stalled {
+				r.l.Info(fmt.Sprintf("%s chain head is advancing again", s.Name), logger.Field{Key: "head", Value: s.Head})
+				continue
+			}
+
+			r.l.Warn(fmt.Sprintf("%s chain head has not advanced", s.Name), logger.Field{Key: "head", Value: s.Head}, logger.Field{Key: "seconds", Value: s.HeadAge})
+			_ = r.notifier.Notify(ctx, notify.Message{
+				Severity: notify.SeverityWarning,
+				Key:      "chain_stalled:" + s.Name,
+				Title:    fmt.Sprintf("%s chain head stuck at %d", s.Name, s.Head),
+				Body:     fmt.Sprintf("RPC head has not advanced for %s. The chain may have halted or the RPC node is out of sync.", time.Duration(s.HeadAge)*time.Second),
+			})
+		}
+	}
+}
+
+// Status 체인별 상태 (항상 200)
+func (r *Registry) Status(w http.ResponseWriter, _ *http.Request) {
+	r.write(w, func(Status) bool { return true })
+}
+
+// Healthz 재시작 허용 횟수를 넘긴(failed) 체인이 있으면 503 (프로세스를 다시 띄운다)
+func (r *Registry) Healthz(w http.ResponseWriter, _ *http.Request) {
+	r.write(w, func(s Status) bool { return s.State != StateFailed })
+}
+
+// Readyz 모든 체인이 running 일 때만 200 (운영 API 로 멈춘 체인은 뺀다)
+func (r *Registry) Readyz(w http.ResponseWriter, _ *http.Request) {
+	r.write(w, func(s Status) bool { return s.State == StateRunning || s.State == StatePaused })
+}
+
+func (r *Registry) write(w http.ResponseWriter, ok func(Status) bool) {
+	statuses := r.Statuses()
+
+	code, status := http.StatusOK, "ok"
+	for _, s := range statuses {
+		if !ok(s) {
+			code, status = http.StatusServiceUnavailable, "unavailable"
+			break
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(struct {
+		Status string   `json:"status"`
+		Chains []Status `json:"chains"`
+	}{status, statuses})
+}
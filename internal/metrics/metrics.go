@@ -0,0 +1,141 @@
+package metrics
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// 체인 라벨은 chains.yaml 의 name (Ethereum ...)
+var (
+	headBlock = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tracker_head_block",
+		Help: "Latest block number reported by the chain RPC.",
+	}, []string{"chain"})
+	indexedBlock = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tracker_indexed_block",
+		Help: "Latest block number stored in the database.",
+	}, []string{"chain"})
+	lagBlocks = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tracker_lag_blocks",
+		Help: "Head block minus indexed block.",
+	}, []string{"chain"})
+
+	indexedBlocks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tracker_indexed_blocks_total",
+		Help: "Blocks stored.",
+	}, []string{"chain"})
+	indexedTransactions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tracker_indexed_transactions_total",
+		Help: "Transactions stored.",
+	}, []string{"chain"})
+	indexedLogs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tracker_indexed_logs_total",
+		Help: "Logs stored.",
+	}, []string{"chain"})
+
+	batchDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "tracker_scan_batch_duration_seconds",
+		Help:    "Time to fetch, decode and store one BlockScanner batch.",
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+	}, []string{"chain"})
+
+	trackRestarts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "tracker_restarts_total",
+		Help: "Chain tracker restarts by the supervisor.",
+	}, []string{"chain"})
+	trackFailures = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tracker_consecutive_failures",
+		Help: "Consecutive failed tracking rounds in StartTrack.",
+	}, []string{"chain"})
+
+	rpcDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "rpc_request_duration_seconds",
+		Help:    "JSON-RPC request latency.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "endpoint"})
+	rpcErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rpc_request_errors_total",
+		Help: "JSON-RPC requests that failed or returned an error object.",
+	}, []string{"method", "endpoint"})
+
+	dbDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_transaction_duration_seconds",
+		Help:    "Block store transaction duration.",
+		Buckets: []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"operation"})
+	dbFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_transaction_failures_total",
+		Help: "Block store transactions that were rolled back.",
+	}, []string{"operation"})
+
+	panics = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "panics_recovered_total",
+		Help: "Goroutine panics caught by recover.",
+	}, []string{"component"})
+)
+
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Heights head 와 저장된 높이, 그 차이
+func Heights(chain string, head, indexed int64) {
+	headBlock.WithLabelValues(chain).Set(float64(head))
+	indexedBlock.WithLabelValues(chain).Set(float64(indexed))
+	lagBlocks.WithLabelValues(chain).Set(float64(max(head-indexed, 0)))
+}
+
+// Indexed 저장한 블록 하나. 초당 값은 rate() 로 본다
+func Indexed(chain string, blockNumber uint64, transactions, logs int) {
+	indexedBlock.WithLabelValues(chain).Set(float64(blockNumber))
+	indexedBlocks.WithLabelValues(chain).Inc()
+	indexedTransactions.WithLabelValues(chain).Add(float64(transactions))
+	indexedLogs.WithLabelValues(chain).Add(float64(logs))
+}
+
+func BatchDuration(chain string, d time.Duration) {
+	batchDuration.WithLabelValues(chain).Observe(d.Seconds())
+}
+
+func Restart(chain string) {
+	trackRestarts.WithLabelValues(chain).Inc()
+}
+
+func TrackFailures(chain string, count int) {
+	trackFailures.WithLabelValues(chain).Set(float64(count))
+}
+
+// RPCRequest 여러 요청을 묶은 batch 는 method 를 "batch" 로 넘긴다
+func RPCRequest(method, rpcUrl string, d time.Duration, err error) {
+	endpoint := Endpoint(rpcUrl)
+	rpcDuration.WithLabelValues(method, endpoint).Observe(d.Seconds())
+	if err != nil {
+		rpcErrors.WithLabelValues(method, endpoint).Inc()
+	}
+}
+
+// DBTransaction operation 은 create / create_batch / unindex
+func DBTransaction(operation string, d time.Duration, err error) {
+	dbDuration.WithLabelValues(operation).Observe(d.Seconds())
+	if err != nil {
+		dbFailures.WithLabelValues(operation).Inc()
+	}
+}
+
+func Panic(component string) {
+	panics.WithLabelValues(component).Inc()
+}
+
+// Endpoint RPC URL 의 host 만 라벨로 쓴다 (경로나 query 에 API 키가 들어 있는 경우가 많다)
+func Endpoint(rpcUrl string) string {
+	u, err := url.Parse(rpcUrl)
+	if err != nil || u.Host == "" {
+		return "unknown"
+	}
+	return u.Host
+}
@@ -0,0 +1,99 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// FormatSlack Slack incoming webhook {"text": ...}
+	FormatSlack = "slack"
+	// FormatDiscord Discord webhook {"content": ...}
+	FormatDiscord = "discord"
+	// FormatJSON 메시지를 그대로 JSON 으로 ({"severity", "key", "title", "body", "timestamp"})
+	FormatJSON = "json"
+)
+
+// HTTP incoming webhook 채널 (Slack, Discord, 그 밖의 HTTP 수신기)
+type HTTP struct {
+	name   string
+	url    string
+	format string
+	client *http.Client
+}
+
+var _ Channel = (*HTTP)(nil)
+
+func NewHTTP(name, url, format string) (*HTTP, error) {
+	switch format {
+	case FormatSlack, FormatDiscord, FormatJSON:
+	default:
+		return nil, fmt.Errorf("unknown notification format %s", format)
+	}
+	if url == "" {
+		return nil, fmt.Errorf("%s webhook url is required", name)
+	}
+
+	return &HTTP{
+		name:   name,
+		url:    url,
+		format: format,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (h *HTTP) Name() string {
+	return h.name
+}
+
+func (h *HTTP) Send(ctx context.Context, msg Message) error {
+	text := fmt.Sprintf("[%s] %s\n%s", msg.Severity, msg.Title, msg.Body)
+
+	var payload interface{}
+	switch h.format {
+	case FormatSlack:
+		payload = map[string]string{"text": text}
+	case FormatDiscord:
+		// Discord 는 content 2000자 제한
+		if runes := []rune(text); len(runes) > 2000 {
+			text = string(runes[:1997]) + "..."
+		}
+		payload = map[string]string{"content": text}
+	default:
+		payload = map[string]interface{}{
+			"severity":  msg.Severity.String(),
+			"key":       msg.Key,
+			"title":     msg.Title,
+			"body":      msg.Body,
+			"timestamp": time.Now().UTC(),
+		}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s webhook returned %s", h.name, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityCritical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityCritical:
+		return "critical"
+	}
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+func ParseSeverity(value string) (Severity, error) {
+	switch strings.ToLower(value) {
+	case "info":
+		return SeverityInfo, nil
+	case "warning", "warn":
+		return SeverityWarning, nil
+	case "critical":
+		return SeverityCritical, nil
+	}
+	return 0, fmt.Errorf("unknown severity %s, expected info, warning or critical", value)
+}
+
+// Message 운영 알림 한 건. Key 가 같은 알림은 중복 제거 구간 동안 한 번만 보낸다 (비어 있으면 Title)
+type Message struct {
+	Severity Severity
+	Key      string
+	Title    string
+	Body     string
+}
+
+// Notifier 운영 알림을 보낸다 (Router 가 채널을 골라 보낸다)
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// Channel 알림을 받는 곳 하나 (메일, Slack, Discord, HTTP)
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// Mailer 수신자를 지정해 메일을 보낸다 (watchlist 알림처럼 받는 사람이 정해진 경우)
+type Mailer interface {
+	SendEmail(to, subject, body string) error
+}
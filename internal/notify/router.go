@@ -0,0 +1,136 @@
+package notify
+
+import (
+	"blockchain-tracking/internal/logger"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RouterOptions Dedup 동안 같은 Key 는 한 번만, 채널마다 RateWindow 동안 RateLimit 건까지만 보낸다 (0 이면 제한 없음)
+type RouterOptions struct {
+	Dedup      time.Duration
+	RateLimit  int
+	RateWindow time.Duration
+}
+
+type route struct {
+	channel     Channel
+	minSeverity Severity
+
+	sent       []time.Time // RateWindow 안에서 보낸 시각
+	suppressed int         // 제한에 걸려 버린 수. 다음에 보내는 알림에 붙인다
+}
+
+// Router 심각도에 맞는 채널로 보낸다. 흔들리는 체인이 같은 알림을 계속 보내도 받는 쪽이 넘치지 않게 걸러 낸다
+type Router struct {
+	opts   RouterOptions
+	routes []*route
+	l      logger.Logger
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+var _ Notifier = (*Router)(nil)
+
+func NewRouter(opts RouterOptions, l logger.Logger) *Router {
+	return &Router{
+		opts: opts,
+		l:    l,
+		seen: make(map[string]time.Time),
+	}
+}
+
+// Add minSeverity 이상인 알림을 channel 로 보낸다
+func (r *Router) Add(channel Channel, minSeverity Severity) {
+	r.routes = append(r.routes, &route{channel: channel, minSeverity: minSeverity})
+}
+
+func (r *Router) Notify(ctx context.Context, msg Message) error {
+	if len(r.routes) == 0 {
+		r.l.Warn("no notification channel is configured", logger.Field{Key: "title", Value: msg.Title})
+		return nil
+	}
+
+	key := msg.Key
+	if key == "" {
+		key = msg.Title
+	}
+
+	type delivery struct {
+		channel Channel
+		msg     Message
+	}
+	var deliveries []delivery
+
+	now := time.Now()
+	r.mu.Lock()
+	if last, ok := r.seen[key]; ok && now.Sub(last) < r.opts.Dedup {
+		r.mu.Unlock()
+		r.l.Debug("duplicate notification suppressed", logger.Field{Key: "key", Value: key})
+		return nil
+	}
+	r.seen[key] = now
+	r.forget(now)
+
+	for _, rt := range r.routes {
+		if msg.Severity < rt.minSeverity {
+			continue
+		}
+		if !rt.allow(now, r.opts) {
+			rt.suppressed++
+			r.l.Warn("notification rate limited", logger.Field{Key: "channel", Value: rt.channel.Name()}, logger.Field{Key: "key", Value: key})
+			continue
+		}
+
+		out := msg
+		if rt.suppressed > 0 {
+			out.Body += fmt.Sprintf("\n\n(%d notifications were suppressed by the rate limit)", rt.suppressed)
+			rt.suppressed = 0
+		}
+		deliveries = append(deliveries, delivery{channel: rt.channel, msg: out})
+	}
+	r.mu.Unlock()
+
+	var errs []error
+	for _, d := range deliveries {
+		if err := d.channel.Send(ctx, d.msg); err != nil {
+			r.l.Error("send notification", logger.Field{Key: "error", Value: err.Error()}, logger.Field{Key: "channel", Value: d.channel.Name()}, logger.Field{Key: "key", Value: key})
+			errs = append(errs, fmt.Errorf("%s: %w", d.channel.Name(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// forget 중복 제거 구간이 지난 키를 지운다
+func (r *Router) forget(now time.Time) {
+	for key, last := range r.seen {
+		if now.Sub(last) >= r.opts.Dedup {
+			delete(r.seen, key)
+		}
+	}
+}
+
+func (rt *route) allow(now time.Time, opts RouterOptions) bool {
+	if opts.RateLimit <= 0 {
+		return true
+	}
+
+	recent := rt.sent[:0]
+	for _, sent := range rt.sent {
+		if now.Sub(sent) < opts.RateWindow {
+			recent = append(recent, sent)
+		}
+	}
+	rt.sent = recent
+
+	if len(rt.sent) >= opts.RateLimit {
+		return false
+	}
+	rt.sent = append(rt.sent, now)
+	return true
+}
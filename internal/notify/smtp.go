@@ -0,0 +1,154 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"html"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// TLSStartTLS 평문으로 붙은 뒤 STARTTLS (587)
+	TLSStartTLS = "starttls"
+	// TLSImplicit 처음부터 TLS (465)
+	TLSImplicit = "tls"
+	// TLSNone 암호화 없음. 로컬 릴레이용
+	TLSNone = "none"
+)
+
+type SMTPOptions struct {
+	Host     string
+	Port     int
+	TLS      string
+	Username string
+	Password string
+	From     string   // 비어 있으면 Username
+	To       []string // 운영 알림 수신자
+}
+
+// SMTP 메일 채널. Mailer 로 쓰면 수신자를 직접 정한다
+type SMTP struct {
+	opts SMTPOptions
+}
+
+var (
+	_ Channel = (*SMTP)(nil)
+	_ Mailer  = (*SMTP)(nil)
+)
+
+func NewSMTP(opts SMTPOptions) (*SMTP, error) {
+	if opts.Host == "" {
+		return nil, fmt.Errorf("smtp host is required")
+	}
+	switch opts.TLS {
+	case "":
+		opts.TLS = TLSStartTLS
+	case TLSStartTLS, TLSImplicit, TLSNone:
+	default:
+		return nil, fmt.Errorf("unknown smtp tls mode %s, expected starttls, tls or none", opts.TLS)
+	}
+	if opts.From == "" {
+		opts.From = opts.Username
+	}
+	if opts.From == "" {
+		return nil, fmt.Errorf("smtp from address is required")
+	}
+
+	return &SMTP{opts: opts}, nil
+}
+
+func (s *SMTP) Name() string {
+	return "email"
+}
+
+func (s *SMTP) Send(_ context.Context, msg Message) error {
+	if len(s.opts.To) == 0 {
+		return fmt.Errorf("no alert email recipients")
+	}
+
+	subject := fmt.Sprintf("[%s] %s", msg.Severity, msg.Title)
+	return s.send(s.opts.To, subject, strings.ReplaceAll(html.EscapeString(msg.Body), "\n", "<br>"))
+}
+
+// SendEmail to 는 쉼표로 여러 명을 넣을 수 있다. body 는 HTML
+func (s *SMTP) SendEmail(to, subject, body string) error {
+	var recipients []string
+	for _, address := range strings.Split(to, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			recipients = append(recipients, address)
+		}
+	}
+	if len(recipients) == 0 {
+		return fmt.Errorf("no email recipients")
+	}
+
+	return s.send(recipients, subject, body)
+}
+
+func (s *SMTP) send(to []string, subject, body string) error {
+	addr := net.JoinHostPort(s.opts.Host, strconv.Itoa(s.opts.Port))
+	tlsConfig := &tls.Config{ServerName: s.opts.Host}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	var conn net.Conn
+	var err error
+	if s.opts.TLS == TLSImplicit {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return err
+	}
+	_ = conn.SetDeadline(time.Now().Add(30 * time.Second))
+
+	client, err := smtp.NewClient(conn, s.opts.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if s.opts.TLS == TLSStartTLS {
+		if err = client.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+	if s.opts.Username != "" {
+		if err = client.Auth(smtp.PlainAuth("", s.opts.Username, s.opts.Password, s.opts.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err = client.Mail(s.opts.From); err != nil {
+		return err
+	}
+	for _, address := range to {
+		if err = client.Rcpt(address); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	msg := "From: " + s.opts.From + "\r\n" +
+		"To: " + strings.Join(to, ", ") + "\r\n" +
+		"Subject: " + subject + "\r\n" +
+		"MIME-version: 1.0\r\nContent-Type: text/html; charset=\"UTF-8\"\r\n\r\n" +
+		body + "\r\n"
+	if _, err = w.Write([]byte(msg)); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
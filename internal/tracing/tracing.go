@@ -0,0 +1,95 @@
+package tracing
+
+import (
+	"blockchain-tracking/internal/logger"
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "blockchain-tracking"
+
+// Exporter 종류. none 이면 span 을 만들지 않는다 (noop)
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"   // OTLP/HTTP, 주소는 OTEL_EXPORTER_OTLP_ENDPOINT (기본 localhost:4318)
+	ExporterStdout = "stdout" // 로컬 확인용
+)
+
+// Setup 전역 TracerProvider 를 만든다. 돌려준 shutdown 으로 남은 span 을 내보내고 닫는다
+func Setup(ctx context.Context, exporter, serviceName string) (func(context.Context) error, error) {
+	var spanExporter sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		spanExporter, err = otlptracehttp.New(ctx)
+	case ExporterStdout:
+		spanExporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %s", exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// OTEL_SERVICE_NAME, OTEL_RESOURCE_ATTRIBUTES 가 있으면 그 값을 쓴다
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(serviceName)),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// 샘플링은 OTEL_TRACES_SAMPLER / OTEL_TRACES_SAMPLER_ARG 로 바꾼다 (기본 전부)
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return provider.Shutdown, nil
+}
+
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End err 가 있으면 span 을 실패로 표시하고 닫는다
+func End(span trace.Span, err error) {
+	Fail(span, err)
+	span.End()
+}
+
+// Fail 돌려주는 에러와 별개로 span 만 실패로 표시한다 (err 가 nil 이면 그대로)
+func Fail(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// Logger ctx 에 span 이 있으면 trace_id, span_id 를 붙인 logger
+func Logger(ctx context.Context, l logger.Logger) logger.Logger {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return l
+	}
+	return l.With(
+		logger.Field{Key: "trace_id", Value: sc.TraceID().String()},
+		logger.Field{Key: "span_id", Value: sc.SpanID().String()},
+	)
+}